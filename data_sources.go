@@ -32,23 +32,58 @@ func initDS() (*dataSources, error) {
 		return nil, fmt.Errorf("error opening db: %w", err)
 	}
 
+	// Accounts created before emails were verified keep their access
+	verifyExisting := !db.Migrator().HasColumn(&model.User{}, "EmailVerified")
+
 	// Migrate models and setup join tables
 	if err := db.AutoMigrate(
 		&model.User{},
+		&model.APIToken{},
 		&model.Guild{},
 		&model.Member{},
 		&model.Channel{},
 		&model.DMMember{},
 		&model.Message{},
 		&model.Attachment{},
+		&model.MessageRevision{},
+		&model.Reaction{},
+		&model.Mention{},
+		&model.ReadState{},
+		&model.Role{},
+		&model.PermissionOverwrite{},
+		&model.AuditLogEntry{},
+		&model.Webhook{},
+		&model.WebhookDelivery{},
+		&model.IncomingWebhook{},
+		&model.Command{},
 	); err != nil {
 		return nil, fmt.Errorf("error migrating models: %w", err)
 	}
 
+	if verifyExisting {
+		if err := db.Model(&model.User{}).Where("1 = 1").Update("email_verified", true).Error; err != nil {
+			return nil, fmt.Errorf("error verifying existing users: %w", err)
+		}
+	}
+
 	if err := db.SetupJoinTable(&model.Guild{}, "Members", &model.Member{}); err != nil {
 		return nil, fmt.Errorf("error creating join table: %w", err)
 	}
 
+	// Setup the full-text search column for messages
+	if err := db.Exec(`
+		ALTER TABLE messages ADD COLUMN IF NOT EXISTS text_search tsvector
+		GENERATED ALWAYS AS (to_tsvector('english', coalesce(text, ''))) STORED
+	`).Error; err != nil {
+		return nil, fmt.Errorf("error creating search column: %w", err)
+	}
+
+	if err := db.Exec(
+		"CREATE INDEX IF NOT EXISTS idx_messages_text_search ON messages USING GIN (text_search)",
+	).Error; err != nil {
+		return nil, fmt.Errorf("error creating search index: %w", err)
+	}
+
 	// Initialize redis connection
 	redisURL := os.Getenv("REDIS_URL")
 	opt, err := redis.ParseURL(redisURL)
@@ -66,24 +101,35 @@ func initDS() (*dataSources, error) {
 		return nil, fmt.Errorf("error connecting to redis: %w", err)
 	}
 
-	// Initialize S3 Session
-	accessKey := os.Getenv("AWS_ACCESS_KEY")
-	secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
-	region := os.Getenv("AWS_S3_REGION")
+	// The S3 session is only needed if the files are not stored locally
+	var sess *session.Session
+
+	if getEnvOrDefault("STORAGE_DRIVER", storageDriverS3) == storageDriverS3 {
+		log.Printf("Initializing S3 Session\n")
+		accessKey := os.Getenv("AWS_ACCESS_KEY")
+		secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+		region := os.Getenv("AWS_S3_REGION")
 
-	sess, err := session.NewSession(
-		&aws.Config{
+		config := &aws.Config{
 			Credentials: credentials.NewStaticCredentials(
 				accessKey,
 				secretKey,
 				"",
 			),
 			Region: aws.String(region),
-		},
-	)
+			// MinIO and most other S3 compatible storages require path-style addressing
+			S3ForcePathStyle: aws.Bool(os.Getenv("AWS_S3_FORCE_PATH_STYLE") == "true"),
+		}
 
-	if err != nil {
-		return nil, fmt.Errorf("error creating s3 session: %w", err)
+		if endpoint := os.Getenv("AWS_S3_ENDPOINT"); endpoint != "" {
+			config.Endpoint = aws.String(endpoint)
+		}
+
+		sess, err = session.NewSession(config)
+
+		if err != nil {
+			return nil, fmt.Errorf("error creating s3 session: %w", err)
+		}
 	}
 
 	return &dataSources{
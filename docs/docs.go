@@ -101,6 +101,86 @@ var doc = `{
                 }
             }
         },
+        "/account/bots": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get User's Bots",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Create Bot",
+                "parameters": [
+                    {
+                        "description": "Create Bot",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateBotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/account/change-password": {
             "put": {
                 "consumes": [
@@ -337,34 +417,31 @@ var doc = `{
                 }
             }
         },
-        "/account/register": {
+        "/account/mfa/disable": {
             "post": {
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Account"
                 ],
-                "summary": "Create an Account",
+                "summary": "Disable Two-Factor Authentication",
                 "parameters": [
                     {
-                        "description": "Create account",
-                        "name": "account",
+                        "description": "TOTP or recovery code",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/RegisterRequest"
+                            "$ref": "#/definitions/MfaCodeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/User"
+                            "$ref": "#/definitions/SuccessResponse"
                         }
                     },
                     "400": {
@@ -379,6 +456,12 @@ var doc = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -388,26 +471,23 @@ var doc = `{
                 }
             }
         },
-        "/account/reset-password": {
+        "/account/mfa/enable": {
             "post": {
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Account"
                 ],
-                "summary": "Reset Password",
+                "summary": "Enable Two-Factor Authentication",
                 "parameters": [
                     {
-                        "description": "Reset Password",
+                        "description": "TOTP code",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ResetPasswordRequest"
+                            "$ref": "#/definitions/MfaCodeRequest"
                         }
                     }
                 ],
@@ -415,7 +495,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/User"
+                            "$ref": "#/definitions/RecoveryCodes"
                         }
                     },
                     "400": {
@@ -424,6 +504,18 @@ var doc = `{
                             "$ref": "#/definitions/ErrorsResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -433,77 +525,104 @@ var doc = `{
                 }
             }
         },
-        "/account/{memberId}/friend": {
+        "/account/mfa/login": {
             "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Friends"
+                    "Account"
                 ],
-                "summary": "Send Friend Request",
+                "summary": "Two-Factor Login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "memberId",
-                        "in": "path",
-                        "required": true
+                        "description": "Ticket and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MfaLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/User"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/account/mfa/recovery-codes": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Friends"
+                    "Account"
                 ],
-                "summary": "Remove Friend",
+                "summary": "Regenerate Recovery Codes",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "memberId",
-                        "in": "path",
-                        "required": true
+                        "description": "TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MfaCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/RecoveryCodes"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -511,29 +630,20 @@ var doc = `{
                 }
             }
         },
-        "/account/{memberId}/friend/accept": {
+        "/account/mfa/setup": {
             "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Friends"
-                ],
-                "summary": "Accept Friend's Request",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "memberId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Account"
                 ],
+                "summary": "Set up Two-Factor Authentication",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/MfaSetup"
                         }
                     },
                     "400": {
@@ -542,8 +652,20 @@ var doc = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -551,39 +673,50 @@ var doc = `{
                 }
             }
         },
-        "/account/{memberId}/friend/cancel": {
+        "/account/register": {
             "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Friends"
+                    "Account"
                 ],
-                "summary": "Cancel Friend's Request",
+                "summary": "Create an Account",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "memberId",
-                        "in": "path",
-                        "required": true
+                        "description": "Create account",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/User"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -591,33 +724,44 @@ var doc = `{
                 }
             }
         },
-        "/channels/me/dm": {
-            "get": {
+        "/account/reset-password": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Channels"
+                    "Account"
                 ],
-                "summary": "Get User's DMs",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/DirectMessage"
-                            }
+                "summary": "Reset Password",
+                "parameters": [
+                    {
+                        "description": "Reset Password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/User"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/ErrorsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -625,44 +769,23 @@ var doc = `{
                 }
             }
         },
-        "/channels/{channelId}": {
-            "put": {
+        "/account/sessions": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Channels"
-                ],
-                "summary": "Edit Channel",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Channel ID",
-                        "name": "channelId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Edit Channel",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/ChannelRequest"
-                        }
-                    }
+                    "Account"
                 ],
+                "summary": "Get Current User's Sessions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorsResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Session"
+                            }
                         }
                     },
                     "401": {
@@ -671,8 +794,8 @@ var doc = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -684,41 +807,30 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/channels/{channelId}/dm": {
-            "post": {
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Channels"
-                ],
-                "summary": "Get or Create DM",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Member ID",
-                        "name": "channelId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Account"
                 ],
+                "summary": "Revoke Other Sessions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/DirectMessage"
+                            "$ref": "#/definitions/SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -732,20 +844,20 @@ var doc = `{
                 }
             }
         },
-        "/channels/{channelId}/members": {
-            "get": {
+        "/account/sessions/{sessionId}": {
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Channels"
+                    "Account"
                 ],
-                "summary": "Get Members of the given Channel",
+                "summary": "Revoke Session",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Channel ID",
-                        "name": "channelId",
+                        "description": "Session ID",
+                        "name": "sessionId",
                         "in": "path",
                         "required": true
                     }
@@ -754,10 +866,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/SuccessResponse"
                         }
                     },
                     "401": {
@@ -766,40 +875,43 @@ var doc = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/channels/{guildId}": {
+        "/account/tokens": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Channels"
-                ],
-                "summary": "Get Guild Channels",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Account"
                 ],
+                "summary": "Get User's Tokens",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Channel"
+                                "$ref": "#/definitions/APIToken"
                             }
                         }
                     },
@@ -809,8 +921,14 @@ var doc = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -822,26 +940,25 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Channels"
+                    "Account"
                 ],
-                "summary": "Create Channel",
+                "summary": "Create Token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
-                        "in": "path",
-                        "required": true
+                        "description": "Create Token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Channel"
-                            }
+                            "$ref": "#/definitions/CreatedToken"
                         }
                     },
                     "400": {
@@ -856,6 +973,12 @@ var doc = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -871,20 +994,20 @@ var doc = `{
                 }
             }
         },
-        "/channels/{id}": {
+        "/account/tokens/{tokenId}": {
             "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Channels"
+                    "Account"
                 ],
-                "summary": "Delete Channel",
+                "summary": "Revoke Token",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Channel ID",
-                        "name": "id",
+                        "description": "Token ID",
+                        "name": "tokenId",
                         "in": "path",
                         "required": true
                     }
@@ -896,14 +1019,14 @@ var doc = `{
                             "$ref": "#/definitions/SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -923,33 +1046,44 @@ var doc = `{
                 }
             }
         },
-        "/channels/{id}/dm": {
-            "delete": {
+        "/account/verify-email": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Channels"
+                    "Account"
                 ],
-                "summary": "Close DM",
+                "summary": "Verify Email",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "DM Channel ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Verification token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/VerifyEmailRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/User"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -957,27 +1091,42 @@ var doc = `{
                 }
             }
         },
-        "/guilds": {
-            "get": {
+        "/account/verify-email/resend": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Guilds"
+                    "Account"
                 ],
-                "summary": "Get Current User's Guilds",
+                "summary": "Resend Verification Mail",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/GuildResponse"
-                            }
+                            "$ref": "#/definitions/SuccessResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -985,40 +1134,35 @@ var doc = `{
                 }
             }
         },
-        "/guilds/create": {
+        "/account/{memberId}/friend": {
             "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Guilds"
+                    "Friends"
                 ],
-                "summary": "Create Guild",
+                "summary": "Send Friend Request",
                 "parameters": [
                     {
-                        "description": "Create Guild",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/CreateGuildRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/GuildResponse"
-                            }
+                            "$ref": "#/definitions/SuccessResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorsResponse"
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "404": {
@@ -1026,41 +1170,31 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
                     }
                 }
-            }
-        },
-        "/guilds/join": {
-            "post": {
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Guilds"
+                    "Friends"
                 ],
-                "summary": "Join Guild",
+                "summary": "Remove Friend",
                 "parameters": [
                     {
-                        "description": "Join Guild",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/JoinRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/GuildResponse"
+                            "$ref": "#/definitions/SuccessResponse"
                         }
                     },
                     "400": {
@@ -1069,8 +1203,8 @@ var doc = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1078,29 +1212,20 @@ var doc = `{
                 }
             }
         },
-        "/guilds/{guildId}": {
-            "put": {
+        "/account/{memberId}/friend/accept": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Guilds"
+                    "Friends"
                 ],
-                "summary": "Edit Guild",
+                "summary": "Accept Friend's Request",
                 "parameters": [
-                    {
-                        "description": "Edit Guild",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/EditGuildRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
+                        "description": "User ID",
+                        "name": "memberId",
                         "in": "path",
                         "required": true
                     }
@@ -1114,12 +1239,6 @@ var doc = `{
                     },
                     "400": {
                         "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorsResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1129,28 +1248,24 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/account/{memberId}/friend/cancel": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Guilds"
+                    "Friends"
                 ],
-                "summary": "Leave Guild",
+                "summary": "Cancel Friend's Request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
+                        "description": "User ID",
+                        "name": "memberId",
                         "in": "path",
                         "required": true
                     }
@@ -1162,8 +1277,8 @@ var doc = `{
                             "$ref": "#/definitions/SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1173,41 +1288,26 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/guilds/{guildId}/bans": {
+        "/channels/me/dm": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
-                ],
-                "summary": "Get Guild Ban list",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Channels"
                 ],
+                "summary": "Get User's DMs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/BanResponse"
+                                "$ref": "#/definitions/DirectMessage"
                             }
                         }
                     },
@@ -1222,38 +1322,34 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/channels/{channelId}": {
+            "put": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Channels"
                 ],
-                "summary": "Ban Member",
+                "summary": "Edit Channel",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
+                        "description": "Channel ID",
+                        "name": "channelId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Member ID",
+                        "description": "Edit Channel",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/MemberRequest"
+                            "$ref": "#/definitions/ChannelRequest"
                         }
                     }
                 ],
@@ -1261,16 +1357,13 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/SuccessResponse"
-                            }
+                            "$ref": "#/definitions/SuccessResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/ErrorsResponse"
                         }
                     },
                     "401": {
@@ -1292,41 +1385,31 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/channels/{channelId}/dm": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Channels"
                 ],
-                "summary": "Unban Member",
+                "summary": "Get or Create DM",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
+                        "description": "Member ID",
+                        "name": "channelId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Member ID",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/MemberRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/SuccessResponse"
-                            }
+                            "$ref": "#/definitions/DirectMessage"
                         }
                     },
                     "400": {
@@ -1335,12 +1418,6 @@ var doc = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1356,20 +1433,20 @@ var doc = `{
                 }
             }
         },
-        "/guilds/{guildId}/delete": {
-            "delete": {
+        "/channels/{channelId}/members": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Guilds"
+                    "Channels"
                 ],
-                "summary": "Delete Guild",
+                "summary": "Get Members of the given Channel",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
+                        "description": "Channel ID",
+                        "name": "channelId",
                         "in": "path",
                         "required": true
                     }
@@ -1378,7 +1455,10 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -1392,25 +1472,19 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/guilds/{guildId}/invite": {
+        "/channels/{guildId}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Guilds"
+                    "Channels"
                 ],
-                "summary": "Get Guild Invite",
+                "summary": "Get Guild Channels",
                 "parameters": [
                     {
                         "type": "string",
@@ -1418,25 +1492,16 @@ var doc = `{
                         "name": "guildId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Is Permanent",
-                        "name": "isPermanent",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Channel"
+                            }
                         }
                     },
                     "401": {
@@ -1450,23 +1515,17 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Guilds"
+                    "Channels"
                 ],
-                "summary": "Delete all permanent invite links",
+                "summary": "Create Channel",
                 "parameters": [
                     {
                         "type": "string",
@@ -1480,7 +1539,16 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Channel"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
                         }
                     },
                     "401": {
@@ -1504,41 +1572,29 @@ var doc = `{
                 }
             }
         },
-        "/guilds/{guildId}/kick": {
-            "post": {
+        "/channels/{id}": {
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Channels"
                 ],
-                "summary": "Kick Member",
+                "summary": "Delete Channel",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
+                        "description": "Channel ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Member ID",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/MemberRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/SuccessResponse"
-                            }
+                            "$ref": "#/definitions/SuccessResponse"
                         }
                     },
                     "400": {
@@ -1568,29 +1624,50 @@ var doc = `{
                 }
             }
         },
-        "/guilds/{guildId}/member": {
-            "get": {
+        "/channels/{id}/ack": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Channels"
                 ],
-                "summary": "Get Member Settings",
+                "summary": "Acknowledge Channel",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
+                        "description": "Channel ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Last read message",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AckRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/MemberSettings"
+                            "$ref": "#/definitions/ReadState"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "404": {
@@ -1598,31 +1675,30 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/channels/{id}/dm": {
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Channels"
                 ],
-                "summary": "Edit Member Settings",
+                "summary": "Close DM",
                 "parameters": [
-                    {
-                        "description": "Edit Member",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/MemberSettingsRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
+                        "description": "DM Channel ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1634,43 +1710,67 @@ var doc = `{
                             "$ref": "#/definitions/SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorsResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/guilds/{guildId}/members": {
+        "/channels/{id}/messages/search": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Guilds"
+                    "Messages"
                 ],
-                "summary": "Get Guild Members",
+                "summary": "Search Channel Messages",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Guild ID",
-                        "name": "guildId",
+                        "description": "Channel ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term. Maximum 200 characters",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Author ID",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Use attachment to only return messages with an attachment",
+                        "name": "has",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the mentioned user",
+                        "name": "mentions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 date. Use the createdAt field for pagination",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 date",
+                        "name": "after",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1679,10 +1779,16 @@ var doc = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Member"
+                                "$ref": "#/definitions/Message"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -1694,32 +1800,32 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/messages/{channelId}": {
+        "/channels/{id}/overwrites": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Messages"
+                    "Channels"
                 ],
-                "summary": "Get Channel Messages",
+                "summary": "Get Channel Permission Overwrites",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Channel ID",
-                        "name": "channelId",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Cursor Pagination using the createdAt field",
-                        "name": "cursor",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1728,7 +1834,7 @@ var doc = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Message"
+                                "$ref": "#/definitions/PermissionOverwrite"
                             }
                         }
                     },
@@ -1745,36 +1851,45 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/channels/{id}/overwrites/{targetId}": {
+            "put": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Messages"
+                    "Channels"
                 ],
-                "summary": "Create Messages",
+                "summary": "Set Channel Permission Overwrite",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Channel ID",
-                        "name": "channelId",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Create Message",
+                        "type": "string",
+                        "description": "Role or Member ID",
+                        "name": "targetId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Set Overwrite",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/MessageRequest"
+                            "$ref": "#/definitions/OverwriteRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/SuccessResponse"
                         }
@@ -1804,33 +1919,29 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/messages/{messageId}": {
-            "put": {
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Messages"
+                    "Channels"
                 ],
-                "summary": "Edit Messages",
+                "summary": "Delete Channel Permission Overwrite",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Message ID",
-                        "name": "messageId",
+                        "description": "Channel ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Edit Message",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/MessageRequest"
-                        }
+                        "type": "string",
+                        "description": "Role or Member ID",
+                        "name": "targetId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1840,12 +1951,6 @@ var doc = `{
                             "$ref": "#/definitions/SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorsResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -1865,20 +1970,22 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/channels/{id}/pins": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Messages"
+                    "Channels"
                 ],
-                "summary": "Delete Messages",
+                "summary": "Get Channel Pins",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Message ID",
-                        "name": "messageId",
+                        "description": "Channel ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1887,7 +1994,10 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Message"
+                            }
                         }
                     },
                     "401": {
@@ -1901,347 +2011,3808 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/channels/{id}/threads": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Channels"
+                ],
+                "summary": "Get Channel Threads",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Channel"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     }
                 }
-            }
-        }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Channels"
+                ],
+                "summary": "Create Thread",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Thread",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ThreadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Channel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/channels/{id}/webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Channel Webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/IncomingWebhook"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create Channel Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ChannelWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/CreatedIncomingWebhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/channels/{id}/webhooks/{webhookId}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete Channel Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{path}": {
+            "get": {
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Get File",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File path",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature of the path",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guilds"
+                ],
+                "summary": "Get Current User's Guilds",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GuildResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/create": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guilds"
+                ],
+                "summary": "Create Guild",
+                "parameters": [
+                    {
+                        "description": "Create Guild",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateGuildRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GuildResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/join": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guilds"
+                ],
+                "summary": "Join Guild",
+                "parameters": [
+                    {
+                        "description": "Join Guild",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/JoinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/GuildResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guilds"
+                ],
+                "summary": "Edit Guild",
+                "parameters": [
+                    {
+                        "description": "Edit Guild",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EditGuildRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guilds"
+                ],
+                "summary": "Leave Guild",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/audit-logs": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guilds"
+                ],
+                "summary": "Get Guild Audit Log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action type",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user that performed the action",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entry ID. Use the id field for pagination",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Amount of entries. 1 to 100, defaults to 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/AuditLogEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/bans": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "Get Guild Ban list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/BanResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "Ban Member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MemberRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason for the audit log. Maximum 512 characters",
+                        "name": "X-Audit-Log-Reason",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SuccessResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "Unban Member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SuccessResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/commands": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Commands"
+                ],
+                "summary": "Get Guild Commands",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Command"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Commands"
+                ],
+                "summary": "Register Guild Command",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Register Command",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CommandRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Command"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/commands/{commandId}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Commands"
+                ],
+                "summary": "Delete Guild Command",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Command ID",
+                        "name": "commandId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/delete": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guilds"
+                ],
+                "summary": "Delete Guild",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "TOTP or recovery code. Required if two-factor authentication is enabled.",
+                        "name": "X-MFA-Code",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/invite": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guilds"
+                ],
+                "summary": "Get Guild Invite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Is Permanent",
+                        "name": "isPermanent",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guilds"
+                ],
+                "summary": "Delete all permanent invite links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/kick": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "Kick Member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MemberRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason for the audit log. Maximum 512 characters",
+                        "name": "X-Audit-Log-Reason",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SuccessResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/member": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "Get Member Settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/MemberSettings"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "Edit Member Settings",
+                "parameters": [
+                    {
+                        "description": "Edit Member",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MemberSettingsRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/members": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guilds"
+                ],
+                "summary": "Get Guild Members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Member"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/messages/search": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Search Guild Messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term. Maximum 200 characters",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Author ID",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Use attachment to only return messages with an attachment",
+                        "name": "has",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the mentioned user",
+                        "name": "mentions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 date. Use the createdAt field for pagination",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 date",
+                        "name": "after",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Message"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/roles": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get Guild Roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Role"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Create Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Role"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/roles/{roleId}": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Edit Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Edit Role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Delete Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/roles/{roleId}/members": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Add Role to Member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Remove Role from Member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Guild Webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Webhook"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/webhooks/{webhookId}": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Edit Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Edit Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guilds/{guildId}/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhook Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guild ID",
+                        "name": "guildId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Amount of deliveries. 1 to 100, defaults to 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/interactions/{interactionId}/respond": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Commands"
+                ],
+                "summary": "Respond to Interaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Interaction ID",
+                        "name": "interactionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Response",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/InteractionResponseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/EphemeralMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{channelId}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Get Channel Messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor Pagination using the createdAt field",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Message"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Create Messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Message",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{messageId}": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Edit Messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Edit Message",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Delete Messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{messageId}/history": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Get Message History",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/MessageHistory"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{messageId}/pin": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Pin Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Unpin Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{messageId}/reactions/{emoji}": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Add Reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Emoji",
+                        "name": "emoji",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Remove Reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Emoji",
+                        "name": "emoji",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/{token}": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Execute Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook Token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ExecuteWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
     },
     "definitions": {
+        "APIToken": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "AckRequest": {
+            "type": "object",
+            "properties": {
+                "messageId": {
+                    "description": "ID of the last read message",
+                    "type": "string"
+                }
+            }
+        },
         "Attachment": {
             "type": "object",
             "properties": {
-                "filename": {
+                "filename": {
+                    "type": "string"
+                },
+                "filetype": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "AuditLogChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "AuditLogEntry": {
+            "type": "object",
+            "properties": {
+                "actionType": {
+                    "type": "string"
+                },
+                "actorId": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AuditLogChange"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "guildId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "targetId": {
+                    "type": "string"
+                }
+            }
+        },
+        "BanResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "ChangePasswordRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "TOTP or recovery code. Required if two-factor authentication is enabled.",
+                    "type": "string"
+                },
+                "confirmNewPassword": {
+                    "description": "Must be the same as the newPassword value.",
+                    "type": "string"
+                },
+                "currentPassword": {
+                    "type": "string"
+                },
+                "newPassword": {
+                    "description": "Min 6, max 150 characters.",
+                    "type": "string"
+                }
+            }
+        },
+        "Channel": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "hasNotification": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isPublic": {
+                    "type": "boolean"
+                },
+                "mentionCount": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "type": "string"
+                },
+                "unreadCount": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "ChannelRequest": {
+            "type": "object",
+            "properties": {
+                "isPublic": {
+                    "description": "Default is true",
+                    "type": "boolean"
+                },
+                "members": {
+                    "description": "Array of memberIds",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Channel Name. 3 to 30 character",
+                    "type": "string"
+                }
+            }
+        },
+        "ChannelWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Min 1, max 30 characters. Used as the default author name.",
+                    "type": "string"
+                }
+            }
+        },
+        "Command": {
+            "type": "object",
+            "properties": {
+                "botId": {
+                    "type": "string"
+                },
+                "callbackUrl": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "guildId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CommandOption"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "CommandOption": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "integer",
+                        "boolean",
+                        "user",
+                        "channel"
+                    ]
+                }
+            }
+        },
+        "CommandRequest": {
+            "type": "object",
+            "properties": {
+                "callbackUrl": {
+                    "description": "http or https URL the interactions get POSTed to. Interactions are sent to\nthe bot's websocket room if omitted.",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Min 1, max 100 characters",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Lowercase letters, numbers, - and _. Max 32 characters.",
+                    "type": "string"
+                },
+                "options": {
+                    "description": "Max 10 options. Required options must come before optional ones.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CommandOption"
+                    }
+                },
+                "secret": {
+                    "description": "Used to sign the callbacks. 16 to 128 characters. Required if callbackUrl is set.",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateBotRequest": {
+            "type": "object",
+            "properties": {
+                "username": {
+                    "description": "Min 3, max 30 characters.",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateGuildRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Guild Name. 3 to 30 characters",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateTokenRequest": {
+            "type": "object",
+            "properties": {
+                "botId": {
+                    "description": "ID of one of the user's bots. The token is created for the user if omitted.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Min 1, max 64 characters.",
+                    "type": "string"
+                }
+            }
+        },
+        "CreatedIncomingWebhook": {
+            "type": "object",
+            "properties": {
+                "channelId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "string"
+                },
+                "guildId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreatedToken": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "DMUser": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "isFriend": {
+                    "type": "boolean"
+                },
+                "isOnline": {
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "DirectMessage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "unreadCount": {
+                    "type": "integer"
+                },
+                "user": {
+                    "$ref": "#/definitions/DMUser"
+                }
+            }
+        },
+        "EditGuildRequest": {
+            "type": "object",
+            "properties": {
+                "icon": {
+                    "description": "The old guild icon url if no new image is selected. Set to null to reset the guild icon",
+                    "type": "string"
+                },
+                "image": {
+                    "description": "image/png or image/jpeg",
+                    "type": "string",
+                    "format": "binary"
+                },
+                "name": {
+                    "description": "Guild Name. 3 to 30 characters",
+                    "type": "string"
+                }
+            }
+        },
+        "EditUser": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Must be unique",
+                    "type": "string"
+                },
+                "image": {
+                    "description": "image/png or image/jpeg",
+                    "type": "string",
+                    "format": "binary"
+                },
+                "locale": {
+                    "description": "The language of emails. Leave empty to keep the current one.",
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "de"
+                    ]
+                },
+                "username": {
+                    "description": "Min 3, max 30 characters.",
+                    "type": "string"
+                }
+            }
+        },
+        "EphemeralMessage": {
+            "type": "object",
+            "properties": {
+                "channelId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interactionId": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/Member"
+                }
+            }
+        },
+        "ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/HttpError"
+                }
+            }
+        },
+        "ErrorsResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/FieldError"
+                    }
+                }
+            }
+        },
+        "ExecuteWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "avatarUrl": {
+                    "description": "Overrides the webhook's avatar for this message. Must be a http or https URL.",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "Maximum 2000 characters",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "Overrides the webhook's name for this message. Min 1, max 30 characters.",
+                    "type": "string"
+                }
+            }
+        },
+        "FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "The property containing the error",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "The specific error message",
+                    "type": "string"
+                }
+            }
+        },
+        "ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "Friend": {
+            "type": "object",
+            "properties": {
+                "customStatus": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "isOnline": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "online",
+                        "idle",
+                        "dnd",
+                        "offline"
+                    ]
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "FriendRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "1: Incoming, 0: Outgoing",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "GuildResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
-                "filetype": {
+                "default_channel_id": {
                     "type": "string"
                 },
-                "url": {
+                "hasNotification": {
+                    "type": "boolean"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mentionCount": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ownerId": {
+                    "type": "string"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "BanResponse": {
+        "HttpError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "The specific error message",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "The Http Response as a string",
+                    "type": "string"
+                }
+            }
+        },
+        "IncomingWebhook": {
             "type": "object",
             "properties": {
+                "channelId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "string"
+                },
+                "guildId": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "image": {
+                "name": {
                     "type": "string"
                 },
-                "username": {
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
                     "type": "string"
                 }
             }
         },
-        "ChangePasswordRequest": {
+        "InteractionResponseRequest": {
             "type": "object",
             "properties": {
-                "confirmNewPassword": {
-                    "description": "Must be the same as the newPassword value.",
+                "text": {
+                    "description": "Maximum 2000 characters",
                     "type": "string"
-                },
-                "currentPassword": {
+                }
+            }
+        },
+        "JoinRequest": {
+            "type": "object",
+            "properties": {
+                "link": {
+                    "type": "string"
+                }
+            }
+        },
+        "LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Must be unique",
                     "type": "string"
                 },
-                "newPassword": {
+                "password": {
                     "description": "Min 6, max 150 characters.",
                     "type": "string"
                 }
             }
         },
-        "Channel": {
+        "Member": {
             "type": "object",
             "properties": {
+                "color": {
+                    "type": "string"
+                },
                 "createdAt": {
                     "type": "string"
                 },
-                "hasNotification": {
-                    "type": "boolean"
+                "customStatus": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "isPublic": {
+                "image": {
+                    "type": "string"
+                },
+                "isBot": {
                     "type": "boolean"
                 },
-                "name": {
+                "isFriend": {
+                    "type": "boolean"
+                },
+                "isOnline": {
+                    "type": "boolean"
+                },
+                "nickname": {
                     "type": "string"
                 },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "online",
+                        "idle",
+                        "dnd",
+                        "invisible",
+                        "offline"
+                    ]
+                },
                 "updatedAt": {
                     "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "ChannelRequest": {
+        "MemberRequest": {
             "type": "object",
             "properties": {
-                "isPublic": {
-                    "description": "Default is true",
+                "memberId": {
+                    "type": "string"
+                }
+            }
+        },
+        "MemberSettings": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                }
+            }
+        },
+        "MemberSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                }
+            }
+        },
+        "Message": {
+            "type": "object",
+            "properties": {
+                "attachment": {
+                    "$ref": "#/definitions/Attachment"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deleted": {
                     "type": "boolean"
                 },
-                "members": {
-                    "description": "Array of memberIds",
+                "id": {
+                    "type": "string"
+                },
+                "pinnedAt": {
+                    "type": "string"
+                },
+                "reactions": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/Reaction"
                     }
                 },
-                "name": {
-                    "description": "Channel Name. 3 to 30 character",
+                "replyTo": {
+                    "$ref": "#/definitions/MessageReference"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "threadId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/Member"
+                },
+                "webhookId": {
                     "type": "string"
                 }
             }
         },
-        "CreateGuildRequest": {
+        "MessageHistory": {
             "type": "object",
             "properties": {
-                "name": {
-                    "description": "Guild Name. 3 to 30 characters",
+                "channelId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MessageRevision"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
                     "type": "string"
                 }
             }
         },
-        "DMUser": {
+        "MessageReference": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "string"
                 },
-                "image": {
+                "text": {
                     "type": "string"
                 },
-                "isFriend": {
-                    "type": "boolean"
-                },
-                "isOnline": {
-                    "type": "boolean"
+                "userId": {
+                    "type": "string"
                 },
                 "username": {
                     "type": "string"
                 }
             }
         },
-        "DirectMessage": {
+        "MessageRequest": {
+            "type": "object",
+            "properties": {
+                "file": {
+                    "description": "Image, audio, video, pdf, text or archive file.\nThe size limit depends on the type.",
+                    "type": "string",
+                    "format": "binary"
+                },
+                "replyTo": {
+                    "description": "ID of the message in the same channel this message replies to",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "Maximum 2000 characters",
+                    "type": "string"
+                }
+            }
+        },
+        "MessageRevision": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "MfaCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "6 digit TOTP code or a recovery code if allowed",
+                    "type": "string"
+                }
+            }
+        },
+        "MfaLoginRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "6 digit TOTP code or a recovery code",
+                    "type": "string"
+                },
+                "ticket": {
+                    "description": "The ticket returned by the login",
+                    "type": "string"
+                }
+            }
+        },
+        "MfaSetup": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "OverwriteRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
+                "allow": {
+                    "description": "Bitfield of the allowed channel permissions",
+                    "type": "integer"
                 },
-                "user": {
-                    "$ref": "#/definitions/DMUser"
+                "deny": {
+                    "description": "Bitfield of the denied channel permissions",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Either role or member. Use the guild ID as the role to target everyone",
+                    "type": "string"
                 }
             }
         },
-        "EditGuildRequest": {
+        "PermissionOverwrite": {
             "type": "object",
             "properties": {
-                "icon": {
-                    "description": "The old guild icon url if no new image is selected. Set to null to reset the guild icon",
-                    "type": "string"
+                "allow": {
+                    "type": "integer"
                 },
-                "image": {
-                    "description": "image/png or image/jpeg",
-                    "type": "string",
-                    "format": "binary"
+                "deny": {
+                    "type": "integer"
                 },
-                "name": {
-                    "description": "Guild Name. 3 to 30 characters",
+                "targetId": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "EditUser": {
+        "Reaction": {
             "type": "object",
             "properties": {
-                "email": {
-                    "description": "Must be unique",
-                    "type": "string"
-                },
-                "image": {
-                    "description": "image/png or image/jpeg",
-                    "type": "string",
-                    "format": "binary"
+                "count": {
+                    "type": "integer"
                 },
-                "username": {
-                    "description": "Min 3, max 30 characters.",
+                "emoji": {
                     "type": "string"
+                },
+                "me": {
+                    "type": "boolean"
                 }
             }
         },
-        "ErrorResponse": {
+        "ReadState": {
             "type": "object",
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/HttpError"
+                "channelId": {
+                    "type": "string"
+                },
+                "lastMessageId": {
+                    "type": "string"
+                },
+                "lastReadAt": {
+                    "type": "string"
                 }
             }
         },
-        "ErrorsResponse": {
+        "RecoveryCodes": {
             "type": "object",
             "properties": {
-                "errors": {
+                "codes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/FieldError"
+                        "type": "string"
                     }
                 }
             }
         },
-        "FieldError": {
+        "RegisterRequest": {
             "type": "object",
             "properties": {
-                "field": {
-                    "description": "The property containing the error",
+                "email": {
+                    "description": "Must be unique",
                     "type": "string"
                 },
-                "message": {
-                    "description": "The specific error message",
+                "password": {
+                    "description": "Min 6, max 150 characters.",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "Min 3, max 30 characters.",
                     "type": "string"
                 }
             }
         },
-        "ForgotPasswordRequest": {
+        "ResetPasswordRequest": {
             "type": "object",
             "properties": {
-                "email": {
+                "confirmNewPassword": {
+                    "description": "Must be the same as the password value.",
+                    "type": "string"
+                },
+                "newPassword": {
+                    "description": "Min 6, max 150 characters.",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "The token the user got from the email.",
                     "type": "string"
                 }
             }
         },
-        "Friend": {
+        "Role": {
             "type": "object",
             "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "image": {
+                "name": {
                     "type": "string"
                 },
-                "isOnline": {
-                    "type": "boolean"
+                "permissions": {
+                    "type": "integer"
                 },
-                "username": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "FriendRequest": {
+        "RoleRequest": {
             "type": "object",
             "properties": {
-                "id": {
+                "color": {
+                    "description": "Hex color code of the role",
                     "type": "string"
                 },
-                "image": {
+                "name": {
+                    "description": "Role Name. 3 to 30 characters",
                     "type": "string"
                 },
-                "type": {
-                    "description": "1: Incoming, 0: Outgoing",
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
-                },
-                "username": {
-                    "type": "string"
+                "permissions": {
+                    "description": "Bitfield of the role's permissions",
+                    "type": "integer"
                 }
             }
         },
-        "GuildResponse": {
+        "Session": {
             "type": "object",
             "properties": {
                 "createdAt": {
                     "type": "string"
                 },
-                "default_channel_id": {
-                    "type": "string"
-                },
-                "hasNotification": {
+                "current": {
                     "type": "boolean"
                 },
-                "icon": {
+                "device": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "ownerId": {
+                "ip": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
-                }
-            }
-        },
-        "HttpError": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "description": "The specific error message",
+                "lastUsed": {
                     "type": "string"
                 },
-                "type": {
-                    "description": "The Http Response as a string",
+                "userAgent": {
                     "type": "string"
                 }
             }
         },
-        "JoinRequest": {
+        "SuccessResponse": {
             "type": "object",
             "properties": {
-                "link": {
-                    "type": "string"
+                "success": {
+                    "description": "Only returns true, not a json object",
+                    "type": "boolean"
                 }
             }
         },
-        "LoginRequest": {
+        "ThreadRequest": {
             "type": "object",
             "properties": {
-                "email": {
-                    "description": "Must be unique",
+                "messageId": {
+                    "description": "ID of the message that starts the thread",
                     "type": "string"
                 },
-                "password": {
-                    "description": "Min 6, max 150 characters.",
+                "name": {
+                    "description": "Thread Name. 3 to 30 character",
                     "type": "string"
                 }
             }
         },
-        "Member": {
+        "User": {
             "type": "object",
             "properties": {
-                "color": {
+                "createdAt": {
                     "type": "string"
                 },
-                "createdAt": {
+                "email": {
                     "type": "string"
                 },
+                "emailVerified": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
                 "image": {
                     "type": "string"
                 },
-                "isFriend": {
+                "isBot": {
                     "type": "boolean"
                 },
                 "isOnline": {
                     "type": "boolean"
                 },
-                "nickname": {
+                "locale": {
                     "type": "string"
                 },
+                "mfaEnabled": {
+                    "type": "boolean"
+                },
                 "updatedAt": {
                     "type": "string"
                 },
@@ -2250,138 +5821,102 @@ var doc = `{
                 }
             }
         },
-        "MemberRequest": {
+        "VerifyEmailRequest": {
             "type": "object",
             "properties": {
-                "memberId": {
+                "token": {
+                    "description": "The token the user got from the email.",
                     "type": "string"
                 }
             }
         },
-        "MemberSettings": {
+        "Webhook": {
             "type": "object",
             "properties": {
-                "color": {
+                "createdAt": {
                     "type": "string"
                 },
-                "nickname": {
-                    "type": "string"
-                }
-            }
-        },
-        "MemberSettingsRequest": {
-            "type": "object",
-            "properties": {
-                "color": {
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
                 },
-                "nickname": {
-                    "type": "string"
-                }
-            }
-        },
-        "Message": {
-            "type": "object",
-            "properties": {
-                "attachment": {
-                    "$ref": "#/definitions/Attachment"
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "createdAt": {
-                    "type": "string"
+                "failureCount": {
+                    "type": "integer"
                 },
-                "id": {
+                "guildId": {
                     "type": "string"
                 },
-                "text": {
+                "id": {
                     "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/Member"
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "MessageRequest": {
+        "WebhookDelivery": {
             "type": "object",
             "properties": {
-                "file": {
-                    "description": "image/* or audio/*",
-                    "type": "string",
-                    "format": "binary"
+                "attempts": {
+                    "type": "integer"
                 },
-                "text": {
-                    "description": "Maximum 2000 characters",
+                "createdAt": {
                     "type": "string"
-                }
-            }
-        },
-        "RegisterRequest": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "description": "Must be unique",
+                },
+                "event": {
                     "type": "string"
                 },
-                "password": {
-                    "description": "Min 6, max 150 characters.",
+                "id": {
                     "type": "string"
                 },
-                "username": {
-                    "description": "Min 3, max 30 characters.",
+                "lastError": {
                     "type": "string"
-                }
-            }
-        },
-        "ResetPasswordRequest": {
-            "type": "object",
-            "properties": {
-                "confirmNewPassword": {
-                    "description": "Must be the same as the password value.",
+                },
+                "nextAttemptAt": {
                     "type": "string"
                 },
-                "newPassword": {
-                    "description": "Min 6, max 150 characters.",
+                "responseStatus": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 },
-                "token": {
-                    "description": "The token the user got from the email.",
+                "updatedAt": {
+                    "type": "string"
+                },
+                "webhookId": {
                     "type": "string"
                 }
             }
         },
-        "SuccessResponse": {
+        "WebhookRequest": {
             "type": "object",
             "properties": {
-                "success": {
-                    "description": "Only returns true, not a json object",
+                "enabled": {
+                    "description": "Re-enables a disabled webhook. Only used when editing.",
                     "type": "boolean"
-                }
-            }
-        },
-        "User": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
                 },
-                "image": {
-                    "type": "string"
-                },
-                "isOnline": {
-                    "type": "boolean"
+                "events": {
+                    "description": "Events the webhook receives. Receives all events if empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "updatedAt": {
+                "secret": {
+                    "description": "Used to sign the deliveries. 16 to 128 characters. Keeps the current secret if omitted when editing.",
                     "type": "string"
                 },
-                "username": {
+                "url": {
+                    "description": "http or https URL the events get POSTed to",
                     "type": "string"
                 }
             }
@@ -2405,7 +5940,7 @@ var SwaggerInfo = swaggerInfo{
 	BasePath:    "/api",
 	Schemes:     []string{},
 	Title:       "Valkyrie API",
-	Description: "Valkyrie REST API Specs. This service uses sessions for authentication",
+	Description: "Valkyrie REST API Specs. This service uses sessions or \"Bot\"/\"Bearer\" tokens in the Authorization header for authentication",
 }
 
 type s struct{}
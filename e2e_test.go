@@ -73,6 +73,7 @@ func TestMain_AccountE2E(t *testing.T) {
 
 				assert.Contains(t, recorder.Header(), "Set-Cookie")
 				authUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 			},
 		},
 		{
@@ -334,6 +335,7 @@ func TestMain_FriendsE2E(t *testing.T) {
 
 				assert.Contains(t, recorder.Header(), "Set-Cookie")
 				authUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 				cookie = recorder.Header().Get("Set-Cookie")
 			},
 		},
@@ -370,6 +372,7 @@ func TestMain_FriendsE2E(t *testing.T) {
 				assert.NotNil(t, respBody.UpdatedAt)
 
 				mockUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 				mockUserCookie = recorder.Header().Get("Set-Cookie")
 			},
 		},
@@ -664,6 +667,7 @@ func TestMain_GuildsE2E(t *testing.T) {
 
 				assert.Contains(t, recorder.Header(), "Set-Cookie")
 				authUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 				cookie = recorder.Header().Get("Set-Cookie")
 			},
 		},
@@ -700,6 +704,7 @@ func TestMain_GuildsE2E(t *testing.T) {
 				assert.NotNil(t, respBody.UpdatedAt)
 
 				mockUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 				mockUserCookie = recorder.Header().Get("Set-Cookie")
 			},
 		},
@@ -1067,6 +1072,7 @@ func TestMain_MembersE2E(t *testing.T) {
 
 				assert.Contains(t, recorder.Header(), "Set-Cookie")
 				authUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 				cookie = recorder.Header().Get("Set-Cookie")
 			},
 		},
@@ -1104,6 +1110,7 @@ func TestMain_MembersE2E(t *testing.T) {
 				assert.NotNil(t, respBody.UpdatedAt)
 
 				mockUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 				mockUserCookie = recorder.Header().Get("Set-Cookie")
 			},
 		},
@@ -1576,6 +1583,7 @@ func TestMain_ChannelsE2E(t *testing.T) {
 
 				assert.Contains(t, recorder.Header(), "Set-Cookie")
 				authUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 				cookie = recorder.Header().Get("Set-Cookie")
 			},
 		},
@@ -1879,6 +1887,7 @@ func TestMain_DMsE2E(t *testing.T) {
 
 				assert.Contains(t, recorder.Header(), "Set-Cookie")
 				authUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 				cookie = recorder.Header().Get("Set-Cookie")
 			},
 		},
@@ -1916,6 +1925,7 @@ func TestMain_DMsE2E(t *testing.T) {
 				assert.NotNil(t, respBody.UpdatedAt)
 
 				mockUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 			},
 		},
 		{
@@ -2119,6 +2129,7 @@ func TestMain_MessagesE2E(t *testing.T) {
 
 				assert.Contains(t, recorder.Header(), "Set-Cookie")
 				authUser.ID = respBody.ID
+				verifyEmail(t, ds, respBody.ID)
 				cookie = recorder.Header().Get("Set-Cookie")
 			},
 		},
@@ -2377,3 +2388,10 @@ func TestMain_MessagesE2E(t *testing.T) {
 		})
 	}
 }
+
+// verifyEmail marks the email of the registered user as verified,
+// as the verification token is only sent by email
+func verifyEmail(t *testing.T, ds *dataSources, id string) {
+	err := ds.DB.Model(&model.User{}).Where("id = ?", id).Update("email_verified", true).Error
+	assert.NoError(t, err)
+}
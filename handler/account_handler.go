@@ -48,6 +48,8 @@ type editReq struct {
 	Username string `form:"username"`
 	// Must be unique
 	Email string `form:"email"`
+	// The language of emails. Leave empty to keep the current one.
+	Locale string `form:"locale" enums:"en,de"`
 	// image/png or image/jpeg
 	Image *multipart.FileHeader `form:"image" swaggertype:"string" format:"binary"`
 } //@name EditUser
@@ -56,6 +58,7 @@ func (r editReq) validate() error {
 	return validation.ValidateStruct(&r,
 		validation.Field(&r.Email, validation.Required, is.EmailFormat),
 		validation.Field(&r.Username, validation.Required, validation.Length(3, 30)),
+		validation.Field(&r.Locale, isSupportedLocale),
 	)
 }
 
@@ -102,8 +105,13 @@ func (h *Handler) Edit(c *gin.Context) {
 
 	authUser.Username = req.Username
 
+	if req.Locale != "" {
+		authUser.Locale = req.Locale
+	}
+
 	// New email, check if it's unique
-	if authUser.Email != req.Email {
+	emailChanged := authUser.Email != req.Email
+	if emailChanged {
 		inUse := h.userService.IsEmailAlreadyInUse(req.Email)
 
 		if inUse {
@@ -111,6 +119,7 @@ func (h *Handler) Edit(c *gin.Context) {
 			return
 		}
 		authUser.Email = req.Email
+		authUser.EmailVerified = false
 	}
 
 	if req.Image != nil {
@@ -149,6 +158,13 @@ func (h *Handler) Edit(c *gin.Context) {
 		return
 	}
 
+	// The new email has to be verified again
+	if emailChanged {
+		if err = h.userService.SendVerificationMail(c.Request.Context(), authUser); err != nil {
+			log.Printf("Failed to send the verification mail: %v\n", err.Error())
+		}
+	}
+
 	c.JSON(http.StatusOK, authUser)
 }
 
@@ -158,6 +174,8 @@ type changeRequest struct {
 	NewPassword string `json:"newPassword"`
 	// Must be the same as the newPassword value.
 	ConfirmNewPassword string `json:"confirmNewPassword"`
+	// TOTP or recovery code. Required if two-factor authentication is enabled.
+	Code string `json:"code"`
 } //@name ChangePasswordRequest
 
 func (r changeRequest) validate() error {
@@ -172,9 +190,10 @@ func (r *changeRequest) sanitize() {
 	r.CurrentPassword = strings.TrimSpace(r.CurrentPassword)
 	r.NewPassword = strings.TrimSpace(r.NewPassword)
 	r.ConfirmNewPassword = strings.TrimSpace(r.ConfirmNewPassword)
+	r.Code = strings.TrimSpace(r.Code)
 }
 
-// ChangePassword handler changes the user's password
+// ChangePassword handler changes the user's password and revokes all other sessions
 // ChangePassword godoc
 // @Tags Account
 // @Summary Change Current User's Password
@@ -213,6 +232,13 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 		return
 	}
 
+	if err = h.userService.VerifyMfa(authUser, req.Code); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
 	err = h.userService.ChangePassword(req.CurrentPassword, req.NewPassword, authUser)
 
 	if err != nil {
@@ -222,5 +248,17 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 		return
 	}
 
+	// Log out all other devices as the old password might have been compromised
+	revoked, err := h.sessionService.RevokeSessions(c.Request.Context(), userId, c.GetString("sessionId"))
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	h.socketService.CloseSessions(revoked)
+
 	c.JSON(http.StatusOK, true)
 }
@@ -202,6 +202,109 @@ func TestHandler_EditAccount(t *testing.T) {
 		mockUserService.AssertCalled(t, "UpdateAccount", UpdateAccountArgs...)
 	})
 
+	t.Run("Changing the email requires a new verification", func(t *testing.T) {
+		router := getAuthenticatedTestRouter(uid)
+
+		authUser := fixture.GetMockUser()
+		authUser.ID = uid
+		newEmail := fixture.Email()
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(authUser, nil)
+		mockUserService.On("IsEmailAlreadyInUse", newEmail).Return(false)
+		mockUserService.On("UpdateAccount", authUser).Return(nil)
+		mockUserService.On("SendVerificationMail", mock.Anything, authUser).Return(nil)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  mockUserService,
+			MaxBodyBytes: 4 * 1024 * 1024,
+		})
+
+		rr := httptest.NewRecorder()
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		_ = writer.WriteField("username", authUser.Username)
+		_ = writer.WriteField("email", newEmail)
+
+		_ = writer.Close()
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account", body)
+		request.Header.Set("Content-Type", writer.FormDataContentType())
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, newEmail, authUser.Email)
+		assert.False(t, authUser.EmailVerified)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Changes the mail locale", func(t *testing.T) {
+		router := getAuthenticatedTestRouter(uid)
+
+		authUser := fixture.GetMockUser()
+		authUser.ID = uid
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(authUser, nil)
+		mockUserService.On("UpdateAccount", authUser).Return(nil)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  mockUserService,
+			MaxBodyBytes: 4 * 1024 * 1024,
+		})
+
+		rr := httptest.NewRecorder()
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		_ = writer.WriteField("username", authUser.Username)
+		_ = writer.WriteField("email", authUser.Email)
+		_ = writer.WriteField("locale", "de")
+
+		_ = writer.Close()
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account", body)
+		request.Header.Set("Content-Type", writer.FormDataContentType())
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "de", authUser.Locale)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Unsupported locale", func(t *testing.T) {
+		router := getAuthenticatedTestRouter(uid)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(mockUser, nil)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  mockUserService,
+			MaxBodyBytes: 4 * 1024 * 1024,
+		})
+
+		rr := httptest.NewRecorder()
+
+		form := url.Values{}
+		form.Add("username", mockUser.Username)
+		form.Add("email", mockUser.Email)
+		form.Add("locale", "xx")
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account", strings.NewReader(form.Encode()))
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "UpdateAccount", mock.Anything)
+	})
+
 	t.Run("UpdateAccount Failure", func(t *testing.T) {
 		router := getAuthenticatedTestRouter(uid)
 
@@ -344,6 +447,7 @@ func TestHandler_ChangePassword(t *testing.T) {
 
 		mockUserService := new(mocks.UserService)
 		mockUserService.On("Get", uid).Return(mockUser, nil)
+		mockUserService.On("VerifyMfa", mockUser, "").Return(nil)
 
 		currentPassword := mockUser.Password
 		newPassword := "password!"
@@ -358,10 +462,19 @@ func TestHandler_ChangePassword(t *testing.T) {
 			On("ChangePassword", ChangePasswordArgs...).
 			Return(nil)
 
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.On("TouchSession", mock.Anything, uid, testSessionId).Return(nil)
+		mockSessionService.On("RevokeSessions", mock.Anything, uid, testSessionId).Return([]string{"other-session"}, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("CloseSessions", []string{"other-session"}).Return()
+
 		NewHandler(&Config{
-			R:            router,
-			UserService:  mockUserService,
-			MaxBodyBytes: 4 * 1024 * 1024,
+			R:              router,
+			UserService:    mockUserService,
+			SessionService: mockSessionService,
+			SocketService:  mockSocketService,
+			MaxBodyBytes:   4 * 1024 * 1024,
 		})
 
 		rr := httptest.NewRecorder()
@@ -383,6 +496,8 @@ func TestHandler_ChangePassword(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 		assert.Equal(t, respBody, rr.Body.Bytes())
 		mockUserService.AssertCalled(t, "ChangePassword", ChangePasswordArgs...)
+		mockSessionService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
 	})
 
 	t.Run("ChangePassword Failure", func(t *testing.T) {
@@ -390,6 +505,7 @@ func TestHandler_ChangePassword(t *testing.T) {
 
 		mockUserService := new(mocks.UserService)
 		mockUserService.On("Get", uid).Return(mockUser, nil)
+		mockUserService.On("VerifyMfa", mockUser, "").Return(nil)
 
 		currentPassword := mockUser.Password
 		newPassword := "password!"
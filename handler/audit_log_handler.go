@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+ * AuditLogHandler contains all routes related to the audit log (/api/guilds/:guildId/audit-logs)
+ */
+
+// auditLogReq specifies the query parameters of the audit log
+type auditLogReq struct {
+	// Only entries of the given action type
+	ActionType string `form:"action"`
+	// Only entries of the given user
+	ActorId string `form:"actor"`
+	// Only entries older than the entry with the given ID
+	Before string `form:"before"`
+	// Amount of entries. 1 to 100, defaults to 50
+	Limit int `form:"limit"`
+} //@name AuditLogRequest
+
+func (r auditLogReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ActionType, validation.In(
+			model.AuditGuildUpdate,
+			model.AuditInvitesDelete,
+			model.AuditChannelUpdate,
+			model.AuditChannelDelete,
+			model.AuditMemberKick,
+			model.AuditMemberBan,
+			model.AuditMemberUnban,
+			model.AuditMemberUpdate,
+		)),
+		validation.Field(&r.Limit, validation.Min(0), validation.Max(model.MaximumAuditLogEntries)),
+	)
+}
+
+// GetAuditLogs returns the most recent audit log entries of the given guild
+// GetAuditLogs godoc
+// @Tags Guilds
+// @Summary Get Guild Audit Log
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param action query string false "Action type"
+// @Param actor query string false "ID of the user that performed the action"
+// @Param before query string false "Entry ID. Use the id field for pagination"
+// @Param limit query int false "Amount of entries. 1 to 100, defaults to 50"
+// @Success 200 {array} model.AuditLogEntry
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/audit-logs [get]
+func (h *Handler) GetAuditLogs(c *gin.Context) {
+	var req auditLogReq
+
+	// Bind incoming query to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	guildId := c.Param("guildId")
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("guild", guildId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	userId := c.MustGet("userId").(string)
+
+	if !h.permissionService.HasPermission(userId, guild, model.ManageGuild) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = model.DefaultAuditLogEntries
+	}
+
+	entries, err := h.guildService.GetAuditLogs(&model.AuditLogQuery{
+		GuildId:    guild.ID,
+		ActionType: req.ActionType,
+		ActorId:    req.ActorId,
+		Before:     req.Before,
+		Limit:      limit,
+	})
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// If the guild does not have any entries, return an empty array
+	if len(*entries) == 0 {
+		empty := make([]model.AuditLogEntry, 0)
+		c.JSON(http.StatusOK, empty)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// getAuditLogReason returns the optional reason of the X-Audit-Log-Reason header.
+// The header may be URL encoded. If the reason is too long it writes the error
+// to the response and returns false.
+func getAuditLogReason(c *gin.Context) (*string, bool) {
+	reason := c.GetHeader("X-Audit-Log-Reason")
+
+	if decoded, err := url.PathUnescape(reason); err == nil {
+		reason = decoded
+	}
+
+	reason = strings.TrimSpace(reason)
+
+	if reason == "" {
+		return nil, true
+	}
+
+	if utf8.RuneCountInString(reason) > model.MaximumReasonLen {
+		e := apperrors.NewBadRequest(apperrors.AuditLogReasonError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	return &reason, true
+}
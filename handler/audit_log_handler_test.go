@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_GetAuditLogs(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully fetched filtered entries", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		actorId := fixture.RandID()
+		before := fixture.RandID()
+		targetId := fixture.RandID()
+
+		mockEntries := []model.AuditLogEntry{
+			{
+				ID:         fixture.RandID(),
+				GuildId:    mockGuild.ID,
+				ActorId:    actorId,
+				ActionType: model.AuditMemberKick,
+				TargetId:   &targetId,
+			},
+		}
+
+		query := &model.AuditLogQuery{
+			GuildId:    mockGuild.ID,
+			ActionType: model.AuditMemberKick,
+			ActorId:    actorId,
+			Before:     before,
+			Limit:      10,
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+		mockGuildService.On("GetAuditLogs", query).Return(&mockEntries, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(true)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/audit-logs?action=%s&actor=%s&before=%s&limit=10",
+			mockGuild.ID, model.AuditMemberKick, actorId, before)
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockEntries)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockGuildService.AssertExpectations(t)
+		mockPermissionService.AssertExpectations(t)
+	})
+
+	t.Run("Uses the default limit", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+
+		query := &model.AuditLogQuery{
+			GuildId: mockGuild.ID,
+			Limit:   model.DefaultAuditLogEntries,
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+		mockGuildService.On("GetAuditLogs", query).Return(&[]model.AuditLogEntry{}, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(true)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/guilds/%s/audit-logs", mockGuild.ID), nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(make([]model.AuditLogEntry, 0))
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockGuildService.AssertExpectations(t)
+	})
+
+	t.Run("Missing permissions", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(false)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/guilds/%s/audit-logs", mockGuild.ID), nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockGuildService.AssertNotCalled(t, "GetAuditLogs", mock.Anything)
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		mockGuildService := new(mocks.GuildService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			GuildService: mockGuildService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/audit-logs?action=unknown&limit=500", fixture.RandID())
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		mockGuildService.AssertNotCalled(t, "GetGuild", mock.Anything)
+		mockGuildService.AssertNotCalled(t, "GetAuditLogs", mock.Anything)
+	})
+}
+
+func TestHandler_KickMember_AuditLogReason(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Passes the decoded reason", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockMember := fixture.GetMockUser()
+		reason := "Spamming links"
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+		mockGuildService.On("GetUser", mockMember.ID).Return(mockMember, nil)
+		mockGuildService.On("KickMember", mockMember.ID, mockGuild.ID, authUser.ID, &reason).Return(nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.KickMembers).Return(true)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+		mockPermissionService.On("GetMemberPermissions", mockMember.ID, mockGuild).Return(model.DefaultPermissions)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitRemoveMember", mockGuild.ID, mockMember.ID)
+		mockSocketService.On("EmitRemoveFromGuild", mockMember.ID, mockGuild.ID)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+			SocketService:     mockSocketService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"memberId": mockMember.ID,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/guilds/%s/kick", mockGuild.ID), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-Audit-Log-Reason", "Spamming%20links")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockGuildService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Reason too long", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockMember := fixture.GetMockUser()
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+		mockGuildService.On("GetUser", mockMember.ID).Return(mockMember, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.KickMembers).Return(true)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+		mockPermissionService.On("GetMemberPermissions", mockMember.ID, mockGuild).Return(model.DefaultPermissions)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+			SocketService:     mockSocketService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"memberId": mockMember.ID,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/guilds/%s/kick", mockGuild.ID), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-Audit-Log-Reason", fixture.RandStr(model.MaximumReasonLen+1))
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.AuditLogReasonError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockGuildService.AssertNotCalled(t, "KickMember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitRemoveMember", mock.Anything, mock.Anything)
+	})
+}
@@ -66,6 +66,7 @@ func (h *Handler) Register(c *gin.Context) {
 		Email:    req.Email,
 		Username: req.Username,
 		Password: req.Password,
+		Locale:   requestLocale(c),
 	}
 
 	user, err := h.userService.Register(initial)
@@ -81,7 +82,17 @@ func (h *Handler) Register(c *gin.Context) {
 		return
 	}
 
-	setUserSession(c, user.ID)
+	if err := h.setUserSession(c, user.ID); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// The account can be used without the email, so a failed mail can be resent later
+	if err := h.userService.SendVerificationMail(c.Request.Context(), user); err != nil {
+		log.Printf("Failed to send the verification mail: %v\n", err.Error())
+	}
 
 	c.JSON(http.StatusCreated, user)
 }
@@ -106,7 +117,8 @@ func (r *loginReq) sanitize() {
 	r.Password = strings.TrimSpace(r.Password)
 }
 
-// Login used to authenticate existent user
+// Login used to authenticate existent user.
+// Returns a model.MfaChallenge instead of creating a session if the user enabled two-factor authentication.
 // Login godoc
 // @Tags Account
 // @Summary User Login
@@ -136,12 +148,10 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	setUserSession(c, user.ID)
-
-	c.JSON(http.StatusOK, user)
+	h.startLogin(c, user)
 }
 
-// Logout handler removes the current session
+// Logout handler removes and revokes the current session
 // Logout godoc
 // @Tags Account
 // @Summary User Logout
@@ -154,15 +164,17 @@ func (h *Handler) Logout(c *gin.Context) {
 	c.Set("user", nil)
 
 	session := sessions.Default(c)
-	session.Set("userId", "")
-	session.Clear()
-	session.Options(sessions.Options{Path: "/", MaxAge: -1})
-	err := session.Save()
+	userId, _ := session.Get("userId").(string)
+	sessionId, _ := session.Get("sessionId").(string)
 
-	if err != nil {
-		log.Printf("error clearing session: %v\n", err.Error())
+	if userId != "" && sessionId != "" {
+		if err := h.sessionService.RevokeSession(c.Request.Context(), userId, sessionId); err == nil {
+			h.socketService.CloseSessions([]string{sessionId})
+		}
 	}
 
+	clearUserSession(c)
+
 	c.JSON(http.StatusOK, true)
 }
 
@@ -253,7 +265,8 @@ func (r *resetRequest) sanitize() {
 	r.ConfirmPassword = strings.TrimSpace(r.ConfirmPassword)
 }
 
-// ResetPassword resets the user's password with the provided token
+// ResetPassword resets the user's password with the provided token.
+// Returns a model.MfaChallenge instead of creating a session if the user enabled two-factor authentication.
 // ResetPassword godoc
 // @Tags Account
 // @Summary Reset Password
@@ -293,7 +306,17 @@ func (h *Handler) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	setUserSession(c, user.ID)
+	// Log out all devices as the old password might have been compromised
+	revoked, err := h.sessionService.RevokeSessions(ctx, user.ID, "")
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	h.socketService.CloseSessions(revoked)
 
-	c.JSON(http.StatusOK, user)
+	h.startLogin(c, user)
 }
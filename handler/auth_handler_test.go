@@ -212,6 +212,7 @@ func TestHandler_Register(t *testing.T) {
 			Email:    reqUser.Email,
 			Username: reqUser.Username,
 			Password: reqUser.Password,
+			Locale:   model.DefaultLocale,
 		}
 
 		mockUserService := new(mocks.UserService)
@@ -253,13 +254,22 @@ func TestHandler_Register(t *testing.T) {
 			Email:    reqUser.Email,
 			Username: reqUser.Username,
 			Password: reqUser.Password,
+			Locale:   "de",
 		}
 
 		mockUserService := new(mocks.UserService)
 
 		mockUserService.
 			On("Register", u).
-			Return(reqUser, nil)
+			Return(u, nil)
+		mockUserService.
+			On("SendVerificationMail", mock.Anything, u).
+			Return(nil)
+
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.
+			On("CreateSession", mock.Anything, reqUser.ID, mock.Anything, mock.Anything).
+			Return(&model.Session{Id: "session-id"}, nil)
 
 		// a response recorder for getting written http response
 		rr := httptest.NewRecorder()
@@ -267,8 +277,9 @@ func TestHandler_Register(t *testing.T) {
 		router := getTestRouter()
 
 		NewHandler(&Config{
-			R:           router,
-			UserService: mockUserService,
+			R:              router,
+			UserService:    mockUserService,
+			SessionService: mockSessionService,
 		})
 
 		// create a request body with empty email and password
@@ -284,6 +295,7 @@ func TestHandler_Register(t *testing.T) {
 		assert.NoError(t, err)
 
 		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Accept-Language", "de-CH,de;q=0.9,en;q=0.8")
 
 		router.ServeHTTP(rr, request)
 
@@ -293,7 +305,10 @@ func TestHandler_Register(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, rr.Code)
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
+		assert.NotEmpty(t, rr.Header().Get("Set-Cookie"))
+
 		mockUserService.AssertExpectations(t)
+		mockSessionService.AssertExpectations(t)
 	})
 }
 
@@ -303,12 +318,14 @@ func TestHandler_Login(t *testing.T) {
 
 	// setup mock services, gin engine/router, handler layer
 	mockUserService := new(mocks.UserService)
+	mockSessionService := new(mocks.SessionService)
 
 	router := getTestRouter()
 
 	NewHandler(&Config{
-		R:           router,
-		UserService: mockUserService,
+		R:              router,
+		UserService:    mockUserService,
+		SessionService: mockSessionService,
 	})
 
 	t.Run("Bad request data", func(t *testing.T) {
@@ -374,6 +391,9 @@ func TestHandler_Login(t *testing.T) {
 		}
 
 		mockUserService.On("Login", mockUSArgs...).Return(user, nil)
+		mockSessionService.
+			On("CreateSession", mock.Anything, user.ID, mock.Anything, mock.Anything).
+			Return(&model.Session{Id: "session-id"}, nil)
 
 		// a response recorder for getting written http response
 		rr := httptest.NewRecorder()
@@ -398,6 +418,7 @@ func TestHandler_Login(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockUserService.AssertCalled(t, "Login", mockUSArgs...)
+		mockSessionService.AssertCalled(t, "CreateSession", mock.Anything, user.ID, mock.Anything, mock.Anything)
 	})
 }
 
@@ -412,8 +433,16 @@ func TestHandler_Logout(t *testing.T) {
 		// creates a test context for setting a user
 		router := getAuthenticatedTestRouter(uid)
 
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.On("RevokeSession", mock.Anything, uid, testSessionId).Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("CloseSessions", []string{testSessionId}).Return()
+
 		NewHandler(&Config{
-			R: router,
+			R:              router,
+			SessionService: mockSessionService,
+			SocketService:  mockSocketService,
 		})
 
 		request, _ := http.NewRequest(http.MethodPost, "/api/account/logout", nil)
@@ -423,6 +452,8 @@ func TestHandler_Logout(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockSessionService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
 
 		router.Use(func(c *gin.Context) {
 			contextUserId, exists := c.Get("userId")
@@ -637,7 +668,7 @@ func TestHandler_ResetPassword(t *testing.T) {
 		router := getTestRouter()
 
 		ResetPasswordArgs := mock.Arguments{
-			mock.AnythingOfType("*context.emptyCtx"),
+			mock.Anything,
 			mockUser.Password,
 			token,
 		}
@@ -646,10 +677,21 @@ func TestHandler_ResetPassword(t *testing.T) {
 			On("ResetPassword", ResetPasswordArgs...).
 			Return(mockUser, nil)
 
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.On("RevokeSessions", mock.Anything, mockUser.ID, "").Return([]string{"old-session"}, nil)
+		mockSessionService.
+			On("CreateSession", mock.Anything, mockUser.ID, mock.Anything, mock.Anything).
+			Return(&model.Session{Id: "session-id"}, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("CloseSessions", []string{"old-session"}).Return()
+
 		NewHandler(&Config{
-			R:            router,
-			UserService:  mockUserService,
-			MaxBodyBytes: 4 * 1024 * 1024,
+			R:              router,
+			UserService:    mockUserService,
+			SessionService: mockSessionService,
+			SocketService:  mockSocketService,
+			MaxBodyBytes:   4 * 1024 * 1024,
 		})
 
 		rr := httptest.NewRecorder()
@@ -671,6 +713,9 @@ func TestHandler_ResetPassword(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 		assert.Equal(t, respBody, rr.Body.Bytes())
 		mockUserService.AssertCalled(t, "ResetPassword", ResetPasswordArgs...)
+		mockSessionService.AssertCalled(t, "RevokeSessions", mock.Anything, mockUser.ID, "")
+		mockSessionService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
 	})
 
 	t.Run("ResetPassword Failure", func(t *testing.T) {
@@ -679,7 +724,7 @@ func TestHandler_ResetPassword(t *testing.T) {
 		router := getTestRouter()
 
 		ResetPasswordArgs := mock.Arguments{
-			mock.AnythingOfType("*context.emptyCtx"),
+			mock.Anything,
 			mockUser.Password,
 			token,
 		}
@@ -124,8 +124,8 @@ func (h *Handler) CreateChannel(c *gin.Context) {
 		return
 	}
 
-	if guild.OwnerId != userId {
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+	if !h.permissionService.HasPermission(userId, guild, model.ManageChannels) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 
 		c.JSON(e.Status(), gin.H{
 			"error": e,
@@ -250,8 +250,8 @@ func (h *Handler) PrivateChannelMembers(c *gin.Context) {
 		return
 	}
 
-	if guild.OwnerId != userId {
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+	if !h.permissionService.HasPermission(userId, guild, model.ManageChannels) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		c.JSON(e.Status(), gin.H{
 			"error": e,
 		})
@@ -370,6 +370,21 @@ func (h *Handler) GetOrCreateDM(c *gin.Context) {
 		return
 	}
 
+	// Only verified users can start new conversations
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if ok := requireVerifiedEmail(c, authUser); !ok {
+		return
+	}
+
 	// Create the dm channel between the current user and the member
 	id := fmt.Sprintf("%s-%s", userId, memberId)
 	channelParams := model.Channel{
@@ -467,8 +482,8 @@ func (h *Handler) EditChannel(c *gin.Context) {
 		return
 	}
 
-	if guild.OwnerId != userId {
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+	if !h.permissionService.HasPermission(userId, guild, model.ManageChannels) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		c.JSON(e.Status(), gin.H{
 			"error": e,
 		})
@@ -526,7 +541,7 @@ func (h *Handler) EditChannel(c *gin.Context) {
 		}
 	}
 
-	if err = h.channelService.UpdateChannel(channel); err != nil {
+	if err = h.channelService.EditChannel(channel, userId); err != nil {
 		c.JSON(apperrors.Status(err), gin.H{
 			"error": err,
 		})
@@ -593,16 +608,16 @@ func (h *Handler) DeleteChannel(c *gin.Context) {
 		return
 	}
 
-	if guild.OwnerId != userId {
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+	if !h.permissionService.HasPermission(userId, guild, model.ManageChannels) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		c.JSON(e.Status(), gin.H{
 			"error": e,
 		})
 		return
 	}
 
-	// Check if the guild has the minimum amount of channels
-	if len(guild.Channels) <= model.MinimumChannels {
+	// Check if the guild has the minimum amount of channels. Threads do not count
+	if channel.ParentId == nil && len(guild.Channels) <= model.MinimumChannels {
 		e := apperrors.NewBadRequest(apperrors.OneChannelRequired)
 
 		c.JSON(e.Status(), gin.H{
@@ -611,7 +626,7 @@ func (h *Handler) DeleteChannel(c *gin.Context) {
 		return
 	}
 
-	if err = h.channelService.DeleteChannel(channel); err != nil {
+	if err = h.channelService.DeleteChannel(channel, userId); err != nil {
 		c.JSON(apperrors.Status(err), gin.H{
 			"error": err,
 		})
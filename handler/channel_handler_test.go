@@ -222,6 +222,9 @@ func TestHandler_CreateChannel(t *testing.T) {
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 		mockGuildService.On("UpdateGuild", mockGuild).Return(nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 
 		channelParams := &model.Channel{
@@ -238,10 +241,11 @@ func TestHandler_CreateChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -331,16 +335,20 @@ func TestHandler_CreateChannel(t *testing.T) {
 
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockSocketService := new(mocks.SocketService)
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -372,23 +380,27 @@ func TestHandler_CreateChannel(t *testing.T) {
 		mockSocketService.AssertNotCalled(t, "EmitNewChannel")
 	})
 
-	t.Run("Not the guild owner", func(t *testing.T) {
+	t.Run("Missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 		mockChannel := fixture.GetMockChannel(mockGuild.ID)
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(false)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockSocketService := new(mocks.SocketService)
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -405,7 +417,7 @@ func TestHandler_CreateChannel(t *testing.T) {
 
 		request.Header.Set("Content-Type", "application/json")
 
-		mockError := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		respBody, _ := json.Marshal(gin.H{
 			"error": mockError,
 		})
@@ -475,6 +487,9 @@ func TestHandler_CreateChannel(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 
 		channelParams := &model.Channel{
@@ -490,10 +505,11 @@ func TestHandler_CreateChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -537,6 +553,9 @@ func TestHandler_CreateChannel(t *testing.T) {
 		mockGuildService.On("UpdateGuild", mockGuild).Return(nil)
 		mockGuildService.On("FindUsersByIds", reqMembers, mockGuild.ID).Return(&members, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 
 		channelParams := &model.Channel{
@@ -555,10 +574,11 @@ func TestHandler_CreateChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -664,6 +684,9 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
@@ -681,9 +704,10 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			PermissionService: mockPermissionService,
 		})
 
 		url := fmt.Sprintf("/api/channels/%s/members", mockChannel.ID)
@@ -777,7 +801,7 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 		mockChannelService.AssertNotCalled(t, "GetPrivateChannelMembers")
 	})
 
-	t.Run("Not the guild owner", func(t *testing.T) {
+	t.Run("Missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 		mockChannel := fixture.GetMockChannel(mockGuild.ID)
 		mockChannel.IsPublic = false
@@ -785,6 +809,9 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(false)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
@@ -793,16 +820,17 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			PermissionService: mockPermissionService,
 		})
 
 		url := fmt.Sprintf("/api/channels/%s/members", mockChannel.ID)
 		request, err := http.NewRequest(http.MethodGet, url, nil)
 		assert.NoError(t, err)
 
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		respBody, err := json.Marshal(gin.H{
 			"error": e,
 		})
@@ -863,6 +891,9 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
@@ -875,9 +906,10 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			PermissionService: mockPermissionService,
 		})
 
 		url := fmt.Sprintf("/api/channels/%s/members", mockChannel.ID)
@@ -1066,10 +1098,14 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 		}
 		mockChannelService.On("AddDMChannelMembers", mockArgs...).Return(nil)
 
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    mockUserService,
 			FriendService:  mockFriendService,
 			ChannelService: mockChannelService,
 		})
@@ -1093,6 +1129,46 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 		mockChannelService.AssertExpectations(t)
 	})
 
+	t.Run("Unverified users cannot start new DMs", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		unverifiedUser := fixture.GetMockUser()
+		unverifiedUser.EmailVerified = false
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", unverifiedUser.ID).Return(unverifiedUser, nil)
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", mockUser.ID).Return(mockUser, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetDirectMessageChannel", unverifiedUser.ID, mockUser.ID).Return(nil, nil)
+
+		router := getAuthenticatedTestRouter(unverifiedUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    mockUserService,
+			FriendService:  mockFriendService,
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/dm", mockUser.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": apperrors.NewAuthorization(apperrors.EmailNotVerified),
+		})
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockChannelService.AssertNotCalled(t, "CreateChannel", mock.Anything)
+	})
+
 	t.Run("Member not found", func(t *testing.T) {
 		id := fixture.RandID()
 		mockError := apperrors.NewNotFound("member", id)
@@ -1230,10 +1306,14 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 		mockError := apperrors.NewInternal()
 		mockChannelService.On("AddDMChannelMembers", mockArgs...).Return(mockError)
 
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    mockUserService,
 			FriendService:  mockFriendService,
 			ChannelService: mockChannelService,
 		})
@@ -1271,10 +1351,13 @@ func TestHandler_EditChannel(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
-		mockChannelService.On("UpdateChannel", mockChannel).Return(nil)
+		mockChannelService.On("EditChannel", mockChannel, authUser.ID).Return(nil)
 
 		mockSocketService := new(mocks.SocketService)
 		response := mockChannel.SerializeChannel()
@@ -1283,10 +1366,11 @@ func TestHandler_EditChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -1360,7 +1444,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
 		mockChannelService.AssertCalled(t, "Get", mockChannel.ID)
-		mockChannelService.AssertNotCalled(t, "UpdateChannel")
+		mockChannelService.AssertNotCalled(t, "EditChannel")
 		mockSocketService.AssertNotCalled(t, "EmitEditChannel")
 	})
 
@@ -1408,17 +1492,20 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		mockChannelService.AssertCalled(t, "Get", id)
 		mockGuildService.AssertNotCalled(t, "GetGuild")
-		mockChannelService.AssertNotCalled(t, "UpdateChannel")
+		mockChannelService.AssertNotCalled(t, "EditChannel")
 		mockSocketService.AssertNotCalled(t, "EmitEditChannel")
 	})
 
-	t.Run("Not the guild owner", func(t *testing.T) {
+	t.Run("Missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 		mockChannel := fixture.GetMockChannel(mockGuild.ID)
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(false)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
@@ -1427,10 +1514,11 @@ func TestHandler_EditChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -1447,7 +1535,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		request.Header.Set("Content-Type", "application/json")
 
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		respBody, _ := json.Marshal(gin.H{
 			"error": e,
 		})
@@ -1458,7 +1546,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
 		mockChannelService.AssertCalled(t, "Get", mockChannel.ID)
-		mockChannelService.AssertNotCalled(t, "UpdateChannel")
+		mockChannelService.AssertNotCalled(t, "EditChannel")
 		mockSocketService.AssertNotCalled(t, "EmitEditChannel")
 	})
 
@@ -1507,7 +1595,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		mockChannelService.AssertNotCalled(t, "Get")
 		mockGuildService.AssertNotCalled(t, "GetGuild")
-		mockChannelService.AssertNotCalled(t, "UpdateChannel")
+		mockChannelService.AssertNotCalled(t, "EditChannel")
 		mockSocketService.AssertNotCalled(t, "EmitEditChannel")
 	})
 
@@ -1518,11 +1606,14 @@ func TestHandler_EditChannel(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
 		mockError := apperrors.NewInternal()
-		mockChannelService.On("UpdateChannel", mockChannel).Return(mockError)
+		mockChannelService.On("EditChannel", mockChannel, authUser.ID).Return(mockError)
 
 		mockSocketService := new(mocks.SocketService)
 		response := mockChannel.SerializeChannel()
@@ -1531,10 +1622,11 @@ func TestHandler_EditChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -1572,13 +1664,16 @@ func TestHandler_EditChannel(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
 		response := mockChannel.SerializeChannel()
 
 		mockChannelService.On("CleanPCMembers", mockChannel.ID).Return(nil)
-		mockChannelService.On("UpdateChannel", mockChannel).
+		mockChannelService.On("EditChannel", mockChannel, authUser.ID).
 			Run(func(args mock.Arguments) {
 				mockChannel.IsPublic = true
 				response = mockChannel.SerializeChannel()
@@ -1591,10 +1686,11 @@ func TestHandler_EditChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -1630,13 +1726,16 @@ func TestHandler_EditChannel(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 		mockChannelService.On("AddPrivateChannelMembers", []string{authUser.ID}, mockChannel.ID).Return(nil)
 		mockChannelService.On("RemovePrivateChannelMembers", []string(nil), mockChannel.ID).Return(nil)
 
 		response := mockChannel.SerializeChannel()
-		mockChannelService.On("UpdateChannel", mockChannel).
+		mockChannelService.On("EditChannel", mockChannel, authUser.ID).
 			Run(func(args mock.Arguments) {
 				mockChannel.IsPublic = false
 				response = mockChannel.SerializeChannel()
@@ -1649,10 +1748,11 @@ func TestHandler_EditChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -1758,9 +1858,12 @@ func TestHandler_DeleteChannel(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
-		mockChannelService.On("DeleteChannel", mockChannel).Return(nil)
+		mockChannelService.On("DeleteChannel", mockChannel, authUser.ID).Return(nil)
 
 		mockSocketService := new(mocks.SocketService)
 		mockSocketService.On("EmitDeleteChannel", mockChannel)
@@ -1768,10 +1871,11 @@ func TestHandler_DeleteChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -1881,13 +1985,16 @@ func TestHandler_DeleteChannel(t *testing.T) {
 		mockSocketService.AssertNotCalled(t, "EmitDeleteChannel")
 	})
 
-	t.Run("Not the guild owner", func(t *testing.T) {
+	t.Run("Missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 		mockChannel := fixture.GetMockChannel(mockGuild.ID)
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", *mockChannel.GuildID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(false)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
@@ -1896,10 +2003,11 @@ func TestHandler_DeleteChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -1911,7 +2019,7 @@ func TestHandler_DeleteChannel(t *testing.T) {
 
 		request.Header.Set("Content-Type", "application/json")
 
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		respBody, _ := json.Marshal(gin.H{
 			"error": e,
 		})
@@ -1934,6 +2042,9 @@ func TestHandler_DeleteChannel(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", *mockChannel.GuildID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
@@ -1942,10 +2053,11 @@ func TestHandler_DeleteChannel(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
@@ -2023,21 +2135,25 @@ func TestHandler_DeleteChannel(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
 		mockError := apperrors.NewInternal()
-		mockChannelService.On("DeleteChannel", mockChannel).Return(mockError)
+		mockChannelService.On("DeleteChannel", mockChannel, authUser.ID).Return(mockError)
 
 		mockSocketService := new(mocks.SocketService)
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		rr := httptest.NewRecorder()
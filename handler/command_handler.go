@@ -0,0 +1,406 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	gonanoid "github.com/matoous/go-nanoid"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+/*
+ * CommandHandler contains all routes related to slash commands
+ * (/api/guilds/:guildId/commands, /api/interactions)
+ */
+
+var commandNameRegex = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// commandReq specifies the input form for registering a command
+type commandReq struct {
+	// Lowercase letters, numbers, - and _. Max 32 characters.
+	Name string `json:"name"`
+	// Min 1, max 100 characters
+	Description string `json:"description"`
+	// Max 10 options. Required options must come before optional ones.
+	Options []model.CommandOption `json:"options"`
+	// http or https URL the interactions get POSTed to. Interactions are sent to
+	// the bot's websocket room if omitted.
+	CallbackUrl *string `json:"callbackUrl"`
+	// Used to sign the callbacks. 16 to 128 characters. Required if callbackUrl is set.
+	Secret *string `json:"secret"`
+} //@name CommandRequest
+
+func (r commandReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 32), validation.Match(commandNameRegex)),
+		validation.Field(&r.Description, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Options, validation.Length(0, model.MaximumCommandOptions), validation.By(validateCommandOptions)),
+		validation.Field(&r.CallbackUrl, validation.NilOrNotEmpty, is.URL, validation.Match(webhookUrlRegex), validation.By(validatePublicHost)),
+		validation.Field(&r.Secret, validation.When(r.CallbackUrl != nil, validation.Required), validation.Length(16, 128)),
+	)
+}
+
+func (r *commandReq) sanitize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	if r.Options == nil {
+		r.Options = make([]model.CommandOption, 0)
+	}
+	// The secret is only used for callbacks
+	if r.CallbackUrl == nil {
+		r.Secret = nil
+	}
+}
+
+// validateCommandOptions checks the name, description and type of every option
+func validateCommandOptions(value interface{}) error {
+	options, _ := value.([]model.CommandOption)
+	names := make(map[string]bool)
+	optional := false
+
+	for _, option := range options {
+		if len(option.Name) == 0 || len(option.Name) > 32 || !commandNameRegex.MatchString(option.Name) {
+			return errors.New("contains an invalid option name")
+		}
+
+		if names[option.Name] {
+			return errors.New("contains duplicate option names")
+		}
+		names[option.Name] = true
+
+		if l := len(strings.TrimSpace(option.Description)); l == 0 || l > 100 {
+			return errors.New("option descriptions must be between 1 and 100 characters")
+		}
+
+		if err := validation.Validate(option.Type, validation.In(model.CommandOptionTypes...)); err != nil || option.Type == "" {
+			return errors.New("contains an unknown option type")
+		}
+
+		if option.Required && optional {
+			return errors.New("required options must come before optional ones")
+		}
+		optional = optional || !option.Required
+	}
+
+	return nil
+}
+
+// GetCommands returns the commands registered in the given guild
+// GetCommands godoc
+// @Tags Commands
+// @Summary Get Guild Commands
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Success 200 {array} model.Command
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/commands [get]
+func (h *Handler) GetCommands(c *gin.Context) {
+	guildId := c.Param("guildId")
+	userId := c.MustGet("userId").(string)
+
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil || !isMember(guild, userId) {
+		e := apperrors.NewNotFound("guild", guildId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	commands, err := h.commandService.GetCommands(guild.ID)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// If the guild does not have any commands, return an empty array
+	if len(*commands) == 0 {
+		empty := make([]model.Command, 0)
+		c.JSON(http.StatusOK, empty)
+		return
+	}
+
+	c.JSON(http.StatusOK, commands)
+}
+
+// RegisterCommand registers a command for the authenticated bot.
+// Registering a command with the name of one of the bot's commands replaces it.
+// RegisterCommand godoc
+// @Tags Commands
+// @Summary Register Guild Command
+// @Accepts json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param request body commandReq true "Register Command"
+// @Success 201 {object} model.Command
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/commands [post]
+func (h *Handler) RegisterCommand(c *gin.Context) {
+	var req commandReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	guildId := c.Param("guildId")
+	userId := c.MustGet("userId").(string)
+
+	bot, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("user", userId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if !bot.IsBot {
+		e := apperrors.NewAuthorization(apperrors.BotCommandError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil || !isMember(guild, userId) {
+		e := apperrors.NewNotFound("guild", guildId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	command, err := h.commandService.RegisterCommand(&model.Command{
+		GuildId:     guild.ID,
+		BotId:       bot.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		Options:     req.Options,
+		CallbackUrl: req.CallbackUrl,
+		Secret:      req.Secret,
+	})
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, command)
+}
+
+// DeleteCommand deletes the given command.
+// Only the bot of the command or members with the manage guild permission can delete it.
+// DeleteCommand godoc
+// @Tags Commands
+// @Summary Delete Guild Command
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param commandId path string true "Command ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/commands/{commandId} [delete]
+func (h *Handler) DeleteCommand(c *gin.Context) {
+	guildId := c.Param("guildId")
+	commandId := c.Param("commandId")
+	userId := c.MustGet("userId").(string)
+
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil || !isMember(guild, userId) {
+		e := apperrors.NewNotFound("guild", guildId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	command, err := h.commandService.GetCommand(commandId)
+
+	if err != nil || command.GuildId != guild.ID {
+		e := apperrors.NewNotFound("command", commandId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if command.BotId != userId && !h.permissionService.HasPermission(userId, guild, model.ManageGuild) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err = h.commandService.DeleteCommand(command); err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// interactionResponseReq specifies the input form for responding to an interaction
+type interactionResponseReq struct {
+	// Maximum 2000 characters
+	Text string `json:"text"`
+} //@name InteractionResponseRequest
+
+func (r interactionResponseReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Text, validation.Required, validation.Length(1, 2000)),
+	)
+}
+
+func (r *interactionResponseReq) sanitize() {
+	r.Text = strings.TrimSpace(r.Text)
+}
+
+// RespondToInteraction sends an ephemeral message to the user that invoked the command.
+// Public responses are posted as regular messages.
+// RespondToInteraction godoc
+// @Tags Commands
+// @Summary Respond to Interaction
+// @Accepts json
+// @Produce  json
+// @Param interactionId path string true "Interaction ID"
+// @Param request body interactionResponseReq true "Response"
+// @Success 201 {object} model.EphemeralMessage
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /interactions/{interactionId}/respond [post]
+func (h *Handler) RespondToInteraction(c *gin.Context) {
+	var req interactionResponseReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+	interactionId := c.Param("interactionId")
+
+	interaction, err := h.commandService.GetInteraction(c.Request.Context(), interactionId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if interaction.BotId != userId {
+		e := apperrors.NewAuthorization(apperrors.InteractionError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	bot, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("user", userId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	message := newEphemeralMessage(interaction, bot, req.Text)
+	h.socketService.EmitEphemeralMessage(interaction.UserId, message)
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// invokeCommand creates the interaction and dispatches it to the bot
+// instead of posting the text as a message
+func (h *Handler) invokeCommand(c *gin.Context, command *model.Command, channel *model.Channel, userId string, options map[string]interface{}) {
+	interaction, err := h.commandService.CreateInteraction(c.Request.Context(), command, channel.ID, userId, options)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if command.CallbackUrl == nil {
+		h.socketService.EmitInteraction(command.BotId, interaction)
+	} else {
+		go func() {
+			if err := h.commandService.DispatchInteraction(command, interaction); err != nil {
+				log.Printf("could not dispatch interaction %s: %v\n", interaction.Id, err)
+
+				// Let the user know that the bot did not receive the command
+				if bot, err := h.userService.Get(command.BotId); err == nil {
+					text := fmt.Sprintf("/%s did not respond", command.Name)
+					h.socketService.EmitEphemeralMessage(userId, newEphemeralMessage(interaction, bot, text))
+				}
+			}
+		}()
+	}
+
+	c.JSON(http.StatusAccepted, interaction)
+}
+
+// newEphemeralMessage creates an ephemeral response of the bot to the interaction
+func newEphemeralMessage(interaction *model.Interaction, bot *model.User, text string) *model.EphemeralMessage {
+	id, _ := gonanoid.Nanoid(20)
+
+	return &model.EphemeralMessage{
+		Id:            id,
+		InteractionId: interaction.Id,
+		ChannelId:     interaction.ChannelId,
+		Text:          text,
+		User: model.MemberResponse{
+			Id:        bot.ID,
+			Username:  bot.Username,
+			Image:     bot.Image,
+			IsOnline:  bot.IsOnline,
+			IsBot:     bot.IsBot,
+			CreatedAt: bot.CreatedAt,
+			UpdatedAt: bot.UpdatedAt,
+		},
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,392 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestHandler_RegisterCommand(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+	authUser.IsBot = true
+
+	options := []model.CommandOption{
+		{Name: "minutes", Description: "Delay", Type: model.IntegerOption, Required: true},
+		{Name: "text", Description: "Reminder text", Type: model.StringOption},
+	}
+
+	t.Run("Successfully registered", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(fixture.RandID())
+		mockGuild.Members = append(mockGuild.Members, *authUser)
+
+		mockCommand := &model.Command{
+			BaseModel:   model.BaseModel{ID: fixture.RandID()},
+			GuildId:     mockGuild.ID,
+			BotId:       authUser.ID,
+			Name:        "remind",
+			Description: "Sets a reminder",
+			Options:     options,
+		}
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("RegisterCommand", &model.Command{
+			GuildId:     mockGuild.ID,
+			BotId:       authUser.ID,
+			Name:        "remind",
+			Description: "Sets a reminder",
+			Options:     options,
+		}).Return(mockCommand, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    mockUserService,
+			GuildService:   mockGuildService,
+			CommandService: mockCommandService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":        "remind",
+			"description": "Sets a reminder",
+			"options":     options,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/guilds/%s/commands", mockGuild.ID), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockCommand)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockCommandService.AssertExpectations(t)
+	})
+
+	t.Run("Users cannot register commands", func(t *testing.T) {
+		user := fixture.GetMockUser()
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", user.ID).Return(user, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockCommandService := new(mocks.CommandService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(user.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    mockUserService,
+			GuildService:   mockGuildService,
+			CommandService: mockCommandService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":        "remind",
+			"description": "Sets a reminder",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/guilds/%s/commands", fixture.RandID()), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		e := apperrors.NewAuthorization(apperrors.BotCommandError)
+		respBody, err := json.Marshal(gin.H{
+			"error": e,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, e.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockGuildService.AssertNotCalled(t, "GetGuild", mock.Anything)
+		mockCommandService.AssertNotCalled(t, "RegisterCommand", mock.Anything)
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		testCases := []gin.H{
+			{"name": "Remind", "description": "Sets a reminder"},
+			{"name": "remind"},
+			{"name": "remind", "description": "Sets a reminder", "callbackUrl": "https://bot.example.com"},
+			{"name": "remind", "description": "Sets a reminder", "options": []gin.H{{"name": "minutes", "description": "Delay", "type": "float"}}},
+			{"name": "remind", "description": "Sets a reminder", "options": []gin.H{
+				{"name": "text", "description": "Reminder text", "type": "string"},
+				{"name": "minutes", "description": "Delay", "type": "integer", "required": true},
+			}},
+		}
+
+		for _, body := range testCases {
+			mockCommandService := new(mocks.CommandService)
+
+			rr := httptest.NewRecorder()
+
+			router := getAuthenticatedTestRouter(authUser.ID)
+
+			NewHandler(&Config{
+				R:              router,
+				CommandService: mockCommandService,
+			})
+
+			reqBody, err := json.Marshal(body)
+			assert.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/guilds/%s/commands", fixture.RandID()), strings.NewReader(string(reqBody)))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(rr, request)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockCommandService.AssertNotCalled(t, "RegisterCommand", mock.Anything)
+		}
+	})
+}
+
+func TestHandler_CreateMessage_InvokeCommand(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Dispatches the interaction to the bot", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		text := "/remind 15 deploy"
+
+		mockCommand := &model.Command{
+			BaseModel: model.BaseModel{ID: fixture.RandID()},
+			GuildId:   mockGuild.ID,
+			BotId:     fixture.RandID(),
+			Name:      "remind",
+		}
+		options := map[string]interface{}{"minutes": int64(15), "text": "deploy"}
+
+		mockInteraction := &model.Interaction{
+			Id:          fixture.RandID(),
+			CommandId:   mockCommand.ID,
+			CommandName: mockCommand.Name,
+			GuildId:     mockGuild.ID,
+			ChannelId:   mockChannel.ID,
+			UserId:      authUser.ID,
+			BotId:       mockCommand.BotId,
+			Options:     options,
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("ParseCommand", mockGuild.ID, text).Return(mockCommand, options, nil)
+		mockCommandService.On("CreateInteraction", mock.Anything, mockCommand, mockChannel.ID, authUser.ID, options).Return(mockInteraction, nil)
+
+		mockMessageService := new(mocks.MessageService)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitInteraction", mockCommand.BotId, mockInteraction).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+			CommandService: mockCommandService,
+		})
+
+		form := url.Values{}
+		form.Add("text", text)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockInteraction)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockCommandService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitNewMessage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		text := "/remind soon"
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockError := apperrors.NewBadRequest("Option minutes must be an integer")
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("ParseCommand", mockGuild.ID, text).Return(nil, nil, mockError)
+
+		mockMessageService := new(mocks.MessageService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			CommandService: mockCommandService,
+		})
+
+		form := url.Values{}
+		form.Add("text", text)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockCommandService.AssertNotCalled(t, "CreateInteraction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+	})
+}
+
+func TestHandler_RespondToInteraction(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+	authUser.IsBot = true
+
+	t.Run("Sends an ephemeral message to the invoking user", func(t *testing.T) {
+		mockInteraction := &model.Interaction{
+			Id:        fixture.RandID(),
+			ChannelId: fixture.RandID(),
+			UserId:    fixture.RandID(),
+			BotId:     authUser.ID,
+		}
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("GetInteraction", mock.Anything, mockInteraction.Id).Return(mockInteraction, nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitEphemeralMessage", mockInteraction.UserId, mock.MatchedBy(func(m *model.EphemeralMessage) bool {
+			return m.Text == "Reminder set" && m.InteractionId == mockInteraction.Id &&
+				m.ChannelId == mockInteraction.ChannelId && m.User.Id == authUser.ID && m.User.IsBot
+		})).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    mockUserService,
+			SocketService:  mockSocketService,
+			CommandService: mockCommandService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": " Reminder set ",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/interactions/%s/respond", mockInteraction.Id), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Interaction of another bot", func(t *testing.T) {
+		mockInteraction := &model.Interaction{
+			Id:     fixture.RandID(),
+			UserId: fixture.RandID(),
+			BotId:  fixture.RandID(),
+		}
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("GetInteraction", mock.Anything, mockInteraction.Id).Return(mockInteraction, nil)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			SocketService:  mockSocketService,
+			CommandService: mockCommandService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": "Reminder set",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/interactions/%s/respond", mockInteraction.Id), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		e := apperrors.NewAuthorization(apperrors.InteractionError)
+		respBody, err := json.Marshal(gin.H{
+			"error": e,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, e.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockSocketService.AssertNotCalled(t, "EmitEphemeralMessage", mock.Anything, mock.Anything)
+	})
+}
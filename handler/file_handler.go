@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+)
+
+/*
+ * FileHandler contains all routes related to serving locally stored files
+ */
+
+// GetFile serves a file uploaded to the local storage.
+// Only available if STORAGE_DRIVER is set to local.
+// GetFile godoc
+// @Tags Files
+// @Summary Get File
+// @Produce  octet-stream
+// @Param path path string true "File path"
+// @Param signature query string true "Signature of the path"
+// @Success 200 {file} binary
+// @Failure 404 {object} model.ErrorResponse
+// @Router /files/{path} [get]
+func (h *Handler) GetFile(c *gin.Context) {
+	key := "files" + c.Param("path")
+
+	path, err := h.fileStore.ResolveFile(key, c.Query("signature"))
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Uploaded files must never run as part of the API's origin
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Security-Policy", "default-src 'none'; img-src 'self'; media-src 'self'; style-src 'unsafe-inline'; sandbox")
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.File(path)
+}
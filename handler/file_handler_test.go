@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHandler_GetFile(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	key := "files/channels/1/abcde-notes.txt"
+
+	t.Run("Serves the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notes.txt")
+		err := os.WriteFile(path, []byte("hello"), 0644)
+		assert.NoError(t, err)
+
+		mockFileStore := new(mocks.FileStore)
+		mockFileStore.On("ResolveFile", key, "valid").Return(path, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:         router,
+			FileStore: mockFileStore,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/"+key+"?signature=valid", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "hello", rr.Body.String())
+		assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+		mockFileStore.AssertExpectations(t)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		mockError := apperrors.NewNotFound("file", key)
+
+		mockFileStore := new(mocks.FileStore)
+		mockFileStore.On("ResolveFile", key, "forged").Return("", mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:         router,
+			FileStore: mockFileStore,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/"+key+"?signature=forged", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+	})
+
+	t.Run("Not served without local storage", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R: router,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/"+key+"?signature=valid", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -148,6 +148,10 @@ func (h *Handler) CreateGuild(c *gin.Context) {
 		return
 	}
 
+	if ok := requireVerifiedEmail(c, authUser); !ok {
+		return
+	}
+
 	// Check if the user is already in 100 guilds
 	if len(authUser.Guilds) >= model.MaximumGuilds {
 		e := apperrors.NewBadRequest(apperrors.GuildLimitReached)
@@ -256,8 +260,8 @@ func (h *Handler) EditGuild(c *gin.Context) {
 		return
 	}
 
-	if guild.OwnerId != userId {
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+	if !h.permissionService.HasPermission(userId, guild, model.ManageGuild) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		c.JSON(e.Status(), gin.H{
 			"error": e,
 		})
@@ -299,7 +303,7 @@ func (h *Handler) EditGuild(c *gin.Context) {
 		guild.Icon = nil
 	}
 
-	if err = h.guildService.UpdateGuild(guild); err != nil {
+	if err = h.guildService.EditGuild(guild, userId); err != nil {
 		log.Printf("Failed to update guild: %v\n", err.Error())
 		c.JSON(apperrors.Status(err), gin.H{
 			"error": err,
@@ -414,8 +418,8 @@ func (h *Handler) DeleteGuildInvites(c *gin.Context) {
 		return
 	}
 
-	if guild.OwnerId != userId {
-		e := apperrors.NewAuthorization(apperrors.InvalidateInvitesError)
+	if !h.permissionService.HasPermission(userId, guild, model.ManageInvites) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		c.JSON(e.Status(), gin.H{
 			"error": e,
 		})
@@ -423,7 +427,7 @@ func (h *Handler) DeleteGuildInvites(c *gin.Context) {
 	}
 
 	ctx := context.Background()
-	h.guildService.InvalidateInvites(ctx, guild)
+	h.guildService.InvalidateInvites(ctx, guild, userId)
 	guild.InviteLinks = make(pq.StringArray, 0)
 
 	if err = h.guildService.UpdateGuild(guild); err != nil {
@@ -612,6 +616,7 @@ func (h *Handler) LeaveGuild(c *gin.Context) {
 // @Summary Delete Guild
 // @Produce  json
 // @Param guildId path string true "Guild ID"
+// @Param X-MFA-Code header string false "TOTP or recovery code. Required if two-factor authentication is enabled."
 // @Success 200 {object} model.Success
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
@@ -640,6 +645,24 @@ func (h *Handler) DeleteGuild(c *gin.Context) {
 		return
 	}
 
+	owner, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Deleting a guild requires a two-factor code if the owner enabled it
+	if err = h.userService.VerifyMfa(owner, c.GetHeader(MfaCodeHeader)); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
 	// Get the ID of all members to emit the deletion to
 	members := make([]string, 0)
 	for _, member := range guild.Members {
@@ -378,6 +378,42 @@ func TestHandler_CreateGuild(t *testing.T) {
 		mockChannelService.AssertCalled(t, "CreateChannel", channelParams)
 	})
 
+	t.Run("Unverified users cannot create guilds", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+
+		router := getAuthenticatedTestRouter(mockUser.ID)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetUser", mockUser.ID).Return(mockUser, nil)
+
+		NewHandler(&Config{
+			R:            router,
+			GuildService: mockGuildService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name": fixture.Username(),
+		})
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodPost, "/api/guilds/create", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": apperrors.NewAuthorization(apperrors.EmailNotVerified),
+		})
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockGuildService.AssertNotCalled(t, "CreateGuild", mock.Anything)
+	})
+
 	t.Run("Error Returned from GuildService.CreateGuild", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild(authUser.ID)
 
@@ -590,11 +626,14 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(true)
+
 		name := fixture.RandStringRunes(8)
 		form := url.Values{}
 		form.Add("name", name)
 
-		mockGuildService.On("UpdateGuild", mockGuild).
+		mockGuildService.On("EditGuild", mockGuild, authUser.ID).
 			Run(func(args mock.Arguments) {
 				mockGuild.Name = name
 			}).
@@ -604,9 +643,10 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		mockSocketService.On("EmitEditGuild", mockGuild).Return()
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		// a response recorder for getting written http response
@@ -621,7 +661,7 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
-		mockGuildService.AssertCalled(t, "UpdateGuild", mockGuild)
+		mockGuildService.AssertCalled(t, "EditGuild", mockGuild, authUser.ID)
 		mockSocketService.AssertCalled(t, "EmitEditGuild", mockGuild)
 	})
 
@@ -633,20 +673,24 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(true)
+
 		name := fixture.RandStringRunes(8)
 		form := url.Values{}
 		form.Add("name", name)
 
 		mockError := apperrors.NewInternal()
-		mockGuildService.On("UpdateGuild", mockGuild).Return(mockError)
+		mockGuildService.On("EditGuild", mockGuild, authUser.ID).Return(mockError)
 
 		mockSocketService := new(mocks.SocketService)
 		mockSocketService.On("EmitEditGuild", mockGuild).Return()
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		// a response recorder for getting written http response
@@ -665,11 +709,11 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
-		mockGuildService.AssertCalled(t, "UpdateGuild", mockGuild)
+		mockGuildService.AssertCalled(t, "EditGuild", mockGuild, authUser.ID)
 		mockSocketService.AssertNotCalled(t, "EmitEditGuild", mockGuild)
 	})
 
-	t.Run("Not the owner of the guild", func(t *testing.T) {
+	t.Run("Missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 
 		router := getAuthenticatedTestRouter(authUser.ID)
@@ -677,6 +721,9 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(false)
+
 		name := fixture.RandStringRunes(8)
 		form := url.Values{}
 		form.Add("name", name)
@@ -684,9 +731,10 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		mockSocketService := new(mocks.SocketService)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		// a response recorder for getting written http response
@@ -696,7 +744,7 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		assert.NoError(t, err)
 		request.Form = form
 
-		mockError := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		respBody, _ := json.Marshal(gin.H{
 			"error": mockError,
 		})
@@ -706,7 +754,7 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
-		mockGuildService.AssertNotCalled(t, "UpdateGuild", mockGuild)
+		mockGuildService.AssertNotCalled(t, "EditGuild", mockGuild, authUser.ID)
 		mockSocketService.AssertNotCalled(t, "EmitEditGuild", mockGuild)
 	})
 
@@ -746,7 +794,7 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockGuildService.AssertNotCalled(t, "GetGuild", mockGuild.ID)
-		mockGuildService.AssertNotCalled(t, "UpdateGuild", mockGuild)
+		mockGuildService.AssertNotCalled(t, "EditGuild", mockGuild, authUser.ID)
 		mockSocketService.AssertNotCalled(t, "EmitEditGuild", mockGuild)
 	})
 
@@ -786,7 +834,7 @@ func TestHandler_UpdateGuild(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockGuildService.AssertCalled(t, "GetGuild", id)
-		mockGuildService.AssertNotCalled(t, "UpdateGuild", mock.AnythingOfType("*model.Guild"))
+		mockGuildService.AssertNotCalled(t, "EditGuild", mock.AnythingOfType("*model.Guild"), authUser.ID)
 		mockSocketService.AssertNotCalled(t, "EmitEditGuild", mock.AnythingOfType("*model.Guild"))
 	})
 }
@@ -1134,9 +1182,13 @@ func TestHandler_DeleteGuildInvites(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageInvites).Return(true)
+
 		mockArgs := mock.Arguments{
 			mock.AnythingOfType("*context.emptyCtx"),
 			mockGuild,
+			authUser.ID,
 		}
 
 		mockGuildService.On("InvalidateInvites", mockArgs...).Return()
@@ -1148,8 +1200,9 @@ func TestHandler_DeleteGuildInvites(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:            router,
-			GuildService: mockGuildService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqUrl := fmt.Sprintf("/api/guilds/%s/invite", mockGuild.ID)
@@ -1200,9 +1253,13 @@ func TestHandler_DeleteGuildInvites(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageInvites).Return(true)
+
 		mockArgs := mock.Arguments{
 			mock.AnythingOfType("*context.emptyCtx"),
 			mockGuild,
+			authUser.ID,
 		}
 
 		mockGuildService.On("InvalidateInvites", mockArgs...).Return()
@@ -1216,8 +1273,9 @@ func TestHandler_DeleteGuildInvites(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:            router,
-			GuildService: mockGuildService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqUrl := fmt.Sprintf("/api/guilds/%s/invite", mockGuild.ID)
@@ -1236,20 +1294,24 @@ func TestHandler_DeleteGuildInvites(t *testing.T) {
 		mockGuildService.AssertExpectations(t)
 	})
 
-	t.Run("Not the server owner", func(t *testing.T) {
+	t.Run("Missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageInvites).Return(false)
+
 		// a response recorder for getting written http response
 		rr := httptest.NewRecorder()
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:            router,
-			GuildService: mockGuildService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqUrl := fmt.Sprintf("/api/guilds/%s/invite", mockGuild.ID)
@@ -1258,7 +1320,7 @@ func TestHandler_DeleteGuildInvites(t *testing.T) {
 
 		router.ServeHTTP(rr, request)
 
-		mockError := apperrors.NewAuthorization(apperrors.InvalidateInvitesError)
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		respBody, err := json.Marshal(gin.H{
 			"error": mockError,
 		})
@@ -1909,6 +1971,10 @@ func TestHandler_DeleteGuild(t *testing.T) {
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 		mockGuildService.On("DeleteGuild", mockGuild.ID).Return(nil)
 
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("VerifyMfa", authUser, "").Return(nil)
+
 		mockSocketService := new(mocks.SocketService)
 
 		members := make([]string, 0)
@@ -1923,6 +1989,7 @@ func TestHandler_DeleteGuild(t *testing.T) {
 			R:             router,
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
+			UserService:   mockUserService,
 		})
 
 		reqUrl := fmt.Sprintf("/api/guilds/%s/delete", mockGuild.ID)
@@ -2066,6 +2133,10 @@ func TestHandler_DeleteGuild(t *testing.T) {
 		mockError := apperrors.NewInternal()
 		mockGuildService.On("DeleteGuild", mockGuild.ID).Return(mockError)
 
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("VerifyMfa", authUser, "").Return(nil)
+
 		mockSocketService := new(mocks.SocketService)
 
 		// a response recorder for getting written http response
@@ -2077,6 +2148,7 @@ func TestHandler_DeleteGuild(t *testing.T) {
 			R:             router,
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
+			UserService:   mockUserService,
 		})
 
 		reqUrl := fmt.Sprintf("/api/guilds/%s/delete", mockGuild.ID)
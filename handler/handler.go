@@ -19,27 +19,50 @@ import (
 
 // Handler struct holds required services for handler to function
 type Handler struct {
-	userService    model.UserService
-	friendService  model.FriendService
-	guildService   model.GuildService
-	channelService model.ChannelService
-	messageService model.MessageService
-	socketService  model.SocketService
-	MaxBodyBytes   int64
+	userService            model.UserService
+	friendService          model.FriendService
+	guildService           model.GuildService
+	channelService         model.ChannelService
+	messageService         model.MessageService
+	socketService          model.SocketService
+	permissionService      model.PermissionService
+	webhookService         model.WebhookService
+	incomingWebhookService model.IncomingWebhookService
+	commandService         model.CommandService
+	sessionService         model.SessionService
+	fileStore              model.FileStore
+	webhookLimiter         model.RateLimiter
+	webhookFailureLimiter  model.RateLimiter
+	MaxBodyBytes           int64
+	FileSizeLimits         FileSizeLimits
 }
 
 // Config will hold services that will eventually be injected into this
 // handler layer on handler initialization
 type Config struct {
-	R               *gin.Engine
-	UserService     model.UserService
-	FriendService   model.FriendService
-	GuildService    model.GuildService
-	ChannelService  model.ChannelService
-	MessageService  model.MessageService
-	SocketService   model.SocketService
-	TimeoutDuration time.Duration
-	MaxBodyBytes    int64
+	R                      *gin.Engine
+	UserService            model.UserService
+	FriendService          model.FriendService
+	GuildService           model.GuildService
+	ChannelService         model.ChannelService
+	MessageService         model.MessageService
+	SocketService          model.SocketService
+	PermissionService      model.PermissionService
+	WebhookService         model.WebhookService
+	IncomingWebhookService model.IncomingWebhookService
+	CommandService         model.CommandService
+	SessionService         model.SessionService
+	// FileStore serves the uploaded files if they are stored locally
+	FileStore model.FileStore
+	// WebhookLimiter rate limits executing incoming webhooks per webhook.
+	// Only requests with a valid token are counted.
+	WebhookLimiter model.RateLimiter
+	// WebhookFailureLimiter rate limits failed attempts to execute incoming webhooks per IP
+	WebhookFailureLimiter model.RateLimiter
+	TimeoutDuration       time.Duration
+	MaxBodyBytes          int64
+	// FileSizeLimits limits the size of message attachments per kind
+	FileSizeLimits FileSizeLimits
 }
 
 // NewHandler initializes the handler with required injected services along with http routes
@@ -48,13 +71,22 @@ func NewHandler(c *Config) {
 
 	// Create a handler (which will later have injected services)
 	h := &Handler{
-		userService:    c.UserService,
-		friendService:  c.FriendService,
-		guildService:   c.GuildService,
-		channelService: c.ChannelService,
-		messageService: c.MessageService,
-		socketService:  c.SocketService,
-		MaxBodyBytes:   c.MaxBodyBytes,
+		userService:            c.UserService,
+		friendService:          c.FriendService,
+		guildService:           c.GuildService,
+		channelService:         c.ChannelService,
+		messageService:         c.MessageService,
+		socketService:          c.SocketService,
+		permissionService:      c.PermissionService,
+		webhookService:         c.WebhookService,
+		incomingWebhookService: c.IncomingWebhookService,
+		commandService:         c.CommandService,
+		sessionService:         c.SessionService,
+		fileStore:              c.FileStore,
+		webhookLimiter:         c.WebhookLimiter,
+		webhookFailureLimiter:  c.WebhookFailureLimiter,
+		MaxBodyBytes:           c.MaxBodyBytes,
+		FileSizeLimits:         c.FileSizeLimits,
 	}
 
 	c.R.NoRoute(func(c *gin.Context) {
@@ -79,11 +111,30 @@ func NewHandler(c *Config) {
 	ag.POST("/logout", h.Logout)
 	ag.POST("/forgot-password", h.ForgotPassword)
 	ag.POST("/reset-password", h.ResetPassword)
+	ag.POST("/mfa/login", h.LoginMfa)
+	ag.POST("/verify-email", h.VerifyEmail)
 
-	ag.Use(middleware.AuthUser())
+	ag.Use(middleware.AuthUser(h.userService, h.sessionService))
 	ag.GET("", h.GetCurrent)
 	ag.PUT("", h.Edit)
 	ag.PUT("/change-password", h.ChangePassword)
+	ag.POST("/verify-email/resend", h.ResendVerificationMail)
+
+	// Sessions, two-factor authentication and tokens cannot be managed with a token
+	sg := ag.Group("", middleware.RequireSession())
+	sg.GET("/sessions", h.GetSessions)
+	sg.DELETE("/sessions", h.RevokeSessions)
+	sg.DELETE("/sessions/:sessionId", h.RevokeSession)
+	sg.POST("/mfa/setup", h.SetupMfa)
+	sg.POST("/mfa/enable", h.EnableMfa)
+	sg.POST("/mfa/disable", h.DisableMfa)
+	sg.POST("/mfa/recovery-codes", h.RegenerateRecoveryCodes)
+	sg.GET("/tokens", h.GetTokens)
+	sg.POST("/tokens", h.CreateToken)
+	sg.DELETE("/tokens/:tokenId", h.RevokeToken)
+
+	ag.GET("/bots", h.GetBots)
+	ag.POST("/bots", h.CreateBot)
 
 	ag.GET("/me/friends", h.GetUserFriends)
 	ag.GET("/me/pending", h.GetUserRequests)
@@ -94,7 +145,7 @@ func NewHandler(c *Config) {
 
 	// Create a guild group
 	gg := c.R.Group("api/guilds")
-	gg.Use(middleware.AuthUser())
+	gg.Use(middleware.AuthUser(h.userService, h.sessionService))
 
 	gg.GET("/:guildId/members", h.GetGuildMembers)
 	gg.GET("", h.GetUserGuilds)
@@ -111,38 +162,106 @@ func NewHandler(c *Config) {
 	gg.POST("/:guildId/bans", h.BanMember)
 	gg.DELETE("/:guildId/bans", h.UnbanMember)
 	gg.POST("/:guildId/kick", h.KickMember)
+	gg.GET("/:guildId/roles", h.GetRoles)
+	gg.POST("/:guildId/roles", h.CreateRole)
+	gg.PUT("/:guildId/roles/:roleId", h.EditRole)
+	gg.DELETE("/:guildId/roles/:roleId", h.DeleteRole)
+	gg.POST("/:guildId/roles/:roleId/members", h.AddMemberRole)
+	gg.DELETE("/:guildId/roles/:roleId/members", h.RemoveMemberRole)
+	gg.GET("/:guildId/messages/search", h.SearchGuildMessages)
+	gg.GET("/:guildId/audit-logs", h.GetAuditLogs)
+	gg.GET("/:guildId/webhooks", h.GetWebhooks)
+	gg.POST("/:guildId/webhooks", h.CreateWebhook)
+	gg.PUT("/:guildId/webhooks/:webhookId", h.EditWebhook)
+	gg.DELETE("/:guildId/webhooks/:webhookId", h.DeleteWebhook)
+	gg.GET("/:guildId/webhooks/:webhookId/deliveries", h.GetWebhookDeliveries)
+	gg.GET("/:guildId/commands", h.GetCommands)
+	gg.POST("/:guildId/commands", h.RegisterCommand)
+	gg.DELETE("/:guildId/commands/:commandId", h.DeleteCommand)
 
 	// Create a channels group
 	cg := c.R.Group("api/channels")
-	cg.Use(middleware.AuthUser())
+	cg.Use(middleware.AuthUser(h.userService, h.sessionService))
 
 	// Route parameters cause conflicts so they have to use the same parameter name
-	cg.GET("/:id", h.GuildChannels)                 // id -> guildId
-	cg.POST("/:id", h.CreateChannel)                // id -> guildId
-	cg.GET("/:id/members", h.PrivateChannelMembers) // id -> channelId
-	cg.POST("/:id/dm", h.GetOrCreateDM)             // id -> memberId
-	cg.GET("/me/dm", h.DirectMessages)              //
-	cg.PUT("/:id", h.EditChannel)                   // id -> channelId
-	cg.DELETE("/:id", h.DeleteChannel)              // id -> channelId
-	cg.DELETE("/:id/dm", h.CloseDM)                 // id -> channelId
+	cg.GET("/:id", h.GuildChannels)                               // id -> guildId
+	cg.POST("/:id", h.CreateChannel)                              // id -> guildId
+	cg.GET("/:id/members", h.PrivateChannelMembers)               // id -> channelId
+	cg.POST("/:id/dm", h.GetOrCreateDM)                           // id -> memberId
+	cg.GET("/me/dm", h.DirectMessages)                            //
+	cg.PUT("/:id", h.EditChannel)                                 // id -> channelId
+	cg.DELETE("/:id", h.DeleteChannel)                            // id -> channelId
+	cg.DELETE("/:id/dm", h.CloseDM)                               // id -> channelId
+	cg.GET("/:id/overwrites", h.GetOverwrites)                    // id -> channelId
+	cg.PUT("/:id/overwrites/:targetId", h.SetOverwrite)           // id -> channelId
+	cg.DELETE("/:id/overwrites/:targetId", h.DeleteOverwrite)     // id -> channelId
+	cg.GET("/:id/threads", h.GetThreads)                          // id -> channelId
+	cg.POST("/:id/threads", h.CreateThread)                       // id -> channelId
+	cg.GET("/:id/messages/search", h.SearchChannelMessages)       // id -> channelId
+	cg.POST("/:id/ack", h.AckChannel)                             // id -> channelId
+	cg.GET("/:id/pins", h.GetPins)                                // id -> channelId
+	cg.GET("/:id/webhooks", h.GetChannelWebhooks)                 // id -> channelId
+	cg.POST("/:id/webhooks", h.CreateChannelWebhook)              // id -> channelId
+	cg.DELETE("/:id/webhooks/:webhookId", h.DeleteChannelWebhook) // id -> channelId
 
 	// Create a messages group
 	mg := c.R.Group("api/messages")
-	mg.Use(middleware.AuthUser())
+	mg.Use(middleware.AuthUser(h.userService, h.sessionService))
 
 	mg.GET("/:channelId", h.GetMessages)
 	mg.POST("/:channelId", h.CreateMessage)
+	mg.GET("/:channelId/history", h.GetMessageHistory) // channelId -> messageId
 	mg.PUT("/:messageId", h.EditMessage)
 	mg.DELETE("/:messageId", h.DeleteMessage)
+	mg.PUT("/:messageId/reactions/:emoji", h.AddReaction)
+	mg.DELETE("/:messageId/reactions/:emoji", h.RemoveReaction)
+	mg.PUT("/:messageId/pin", h.PinMessage)
+	mg.DELETE("/:messageId/pin", h.UnpinMessage)
+
+	// Create an interactions group
+	ig := c.R.Group("api/interactions")
+	ig.Use(middleware.AuthUser(h.userService, h.sessionService))
+
+	ig.POST("/:interactionId/respond", h.RespondToInteraction)
+
+	// Create an incoming webhooks group, authenticated by the token in the URL
+	wg := c.R.Group("api/webhooks")
+
+	wg.POST("/:id/:token", h.ExecuteWebhook)
+
+	// Locally stored files are authorized by the signature in the URL
+	if c.FileStore != nil {
+		c.R.GET("api/files/*path", h.GetFile)
+	}
 }
 
-// setUserSession saves the users ID in the session
-func setUserSession(c *gin.Context, id string) {
+// setUserSession starts a tracked session for the user and saves its ID and the users ID in the cookie
+func (h *Handler) setUserSession(c *gin.Context, id string) error {
+	tracked, err := h.sessionService.CreateSession(c.Request.Context(), id, c.ClientIP(), c.Request.UserAgent())
+
+	if err != nil {
+		return err
+	}
+
 	session := sessions.Default(c)
 	session.Set("userId", id)
-	if err := session.Save(); err != nil {
+	session.Set("sessionId", tracked.Id)
+	if err = session.Save(); err != nil {
 		log.Printf("error setting the session: %v\n", err.Error())
 	}
+
+	return nil
+}
+
+// clearUserSession removes the cookie session
+func clearUserSession(c *gin.Context) {
+	session := sessions.Default(c)
+	session.Set("userId", "")
+	session.Clear()
+	session.Options(sessions.Options{Path: "/", MaxAge: -1})
+	if err := session.Save(); err != nil {
+		log.Printf("error clearing session: %v\n", err.Error())
+	}
 }
 
 func toFieldErrorResponse(c *gin.Context, field, message string) {
@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+ * IncomingWebhookHandler contains all routes related to incoming webhooks
+ * (/api/channels/:id/webhooks, /api/webhooks/:id/:token)
+ */
+
+// GetChannelWebhooks returns the incoming webhooks of the given channel
+// GetChannelWebhooks godoc
+// @Tags Webhooks
+// @Summary Get Channel Webhooks
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Success 200 {array} model.IncomingWebhook
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/webhooks [get]
+func (h *Handler) GetChannelWebhooks(c *gin.Context) {
+	channel, ok := h.getWebhookChannel(c)
+
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.incomingWebhookService.GetWebhooks(channel.ID)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// If the channel does not have any webhooks, return an empty array
+	if len(*webhooks) == 0 {
+		empty := make([]model.IncomingWebhook, 0)
+		c.JSON(http.StatusOK, empty)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+type channelWebhookReq struct {
+	// Min 1, max 30 characters. Used as the default author name.
+	Name string `json:"name"`
+} //@name ChannelWebhookRequest
+
+func (r channelWebhookReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 30)),
+	)
+}
+
+func (r *channelWebhookReq) sanitize() {
+	r.Name = strings.TrimSpace(r.Name)
+}
+
+// CreateChannelWebhook creates an incoming webhook for the given channel.
+// This is the only response that contains the token.
+// CreateChannelWebhook godoc
+// @Tags Webhooks
+// @Summary Create Channel Webhook
+// @Accepts json
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Param request body channelWebhookReq true "Create Webhook"
+// @Success 201 {object} model.CreatedIncomingWebhook
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/webhooks [post]
+func (h *Handler) CreateChannelWebhook(c *gin.Context) {
+	var req channelWebhookReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	channel, ok := h.getWebhookChannel(c)
+
+	if !ok {
+		return
+	}
+
+	userId := c.MustGet("userId").(string)
+	webhook, err := h.incomingWebhookService.CreateWebhook(channel, userId, req.Name)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// DeleteChannelWebhook deletes the given incoming webhook. Its messages are kept.
+// DeleteChannelWebhook godoc
+// @Tags Webhooks
+// @Summary Delete Channel Webhook
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/webhooks/{webhookId} [delete]
+func (h *Handler) DeleteChannelWebhook(c *gin.Context) {
+	channel, ok := h.getWebhookChannel(c)
+
+	if !ok {
+		return
+	}
+
+	webhookId := c.Param("webhookId")
+	webhook, err := h.incomingWebhookService.GetWebhook(webhookId)
+
+	if err != nil || webhook.ChannelId != channel.ID {
+		e := apperrors.NewNotFound("webhook", webhookId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err = h.incomingWebhookService.DeleteWebhook(webhook); err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// executeWebhookReq specifies the input form for posting a message using a webhook
+type executeWebhookReq struct {
+	// Maximum 2000 characters
+	Text string `json:"text"`
+	// Overrides the webhook's name for this message. Min 1, max 30 characters.
+	Username *string `json:"username"`
+	// Overrides the webhook's avatar for this message. Must be a http or https URL.
+	AvatarUrl *string `json:"avatarUrl"`
+} //@name ExecuteWebhookRequest
+
+func (r executeWebhookReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Text, validation.Required, validation.Length(1, 2000)),
+		validation.Field(&r.Username, validation.NilOrNotEmpty, validation.Length(1, 30)),
+		validation.Field(&r.AvatarUrl, validation.NilOrNotEmpty, is.URL, validation.Match(webhookUrlRegex)),
+	)
+}
+
+func (r *executeWebhookReq) sanitize() {
+	r.Text = strings.TrimSpace(r.Text)
+
+	if r.Username != nil {
+		username := strings.TrimSpace(*r.Username)
+		r.Username = &username
+	}
+}
+
+// ExecuteWebhook posts a message into the webhook's channel.
+// It does not require a session as the token in the URL authenticates the request.
+// ExecuteWebhook godoc
+// @Tags Webhooks
+// @Summary Execute Webhook
+// @Accepts json
+// @Produce  json
+// @Param id path string true "Webhook ID"
+// @Param token path string true "Webhook Token"
+// @Param request body executeWebhookReq true "Message"
+// @Success 201 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 429 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id}/{token} [post]
+func (h *Handler) ExecuteWebhook(c *gin.Context) {
+	ip := c.ClientIP()
+
+	// Clients that failed too often are rejected before the token gets checked
+	if h.webhookFailureLimiter != nil {
+		if reached, err := h.webhookFailureLimiter.Reached(ip); err == nil && reached {
+			e := apperrors.NewTooManyRequests(apperrors.WebhookAuthLimited)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+	}
+
+	var req executeWebhookReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	webhook, err := h.incomingWebhookService.Authenticate(c.Param("id"), c.Param("token"))
+
+	if err != nil {
+		if h.webhookFailureLimiter != nil {
+			_, _ = h.webhookFailureLimiter.Take(ip)
+		}
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Only authenticated requests count towards the limit of the webhook
+	if h.webhookLimiter != nil {
+		if exceeded, err := h.webhookLimiter.Take(webhook.ID); err == nil && exceeded {
+			e := apperrors.NewTooManyRequests(apperrors.WebhookRateLimited)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+	}
+
+	channel, err := h.channelService.Get(webhook.ChannelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", webhook.ChannelId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	author, err := h.userService.Get(webhook.UserId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("user", webhook.UserId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	params := model.Message{
+		UserId:        author.ID,
+		ChannelId:     channel.ID,
+		Text:          &req.Text,
+		WebhookId:     &webhook.ID,
+		WebhookName:   req.Username,
+		WebhookAvatar: req.AvatarUrl,
+	}
+
+	message, err := h.messageService.CreateMessage(&params)
+
+	if err != nil {
+		log.Printf("Failed to create webhook message: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	response := model.MessageResponse{
+		Id:         message.ID,
+		Text:       message.Text,
+		CreatedAt:  message.CreatedAt,
+		UpdatedAt:  message.UpdatedAt,
+		Attachment: message.Attachment,
+		User: model.MemberResponse{
+			Id:        author.ID,
+			Username:  author.Username,
+			Image:     author.Image,
+			IsOnline:  author.IsOnline,
+			IsBot:     author.IsBot,
+			CreatedAt: author.CreatedAt,
+			UpdatedAt: author.UpdatedAt,
+			IsFriend:  false,
+		},
+		Reactions: make([]model.ReactionResponse, 0),
+		WebhookId: message.WebhookId,
+	}
+
+	if req.Username != nil {
+		response.User.Username = *req.Username
+	}
+
+	if req.AvatarUrl != nil {
+		response.User.Image = *req.AvatarUrl
+	}
+
+	// Emit new message to the channel
+	h.socketService.EmitNewMessage(channel.ID, &response)
+
+	// Notify the mentioned users
+	for i := range message.Mentions {
+		h.socketService.EmitNewMention(message.Mentions[i].UserId, &message.Mentions[i])
+	}
+
+	// Update last activity in channel
+	channel.LastActivity = time.Now()
+	_ = h.channelService.UpdateChannel(channel)
+	// Post a notification
+	h.socketService.EmitNewNotification(webhook.GuildId, channel.ID)
+
+	c.JSON(http.StatusCreated, true)
+}
+
+// getWebhookChannel returns the guild channel of the request if the user is allowed to manage it.
+// Otherwise it writes the error response.
+func (h *Handler) getWebhookChannel(c *gin.Context) (*model.Channel, bool) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil || channel.GuildID == nil {
+		e := apperrors.NewNotFound("channel", channelId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	guild, err := h.guildService.GetGuild(*channel.GuildID)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	if !h.permissionService.HasPermission(userId, guild, model.ManageChannels) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	return channel, true
+}
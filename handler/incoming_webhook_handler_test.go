@@ -0,0 +1,478 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_CreateChannelWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully created", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		mockWebhook := &model.CreatedIncomingWebhook{
+			IncomingWebhook: model.IncomingWebhook{
+				BaseModel: model.BaseModel{ID: fixture.RandID()},
+				ChannelId: mockChannel.ID,
+				GuildId:   mockGuild.ID,
+				UserId:    fixture.RandID(),
+				CreatorId: authUser.ID,
+				Name:      "CI",
+			},
+			Token: fixture.RandStr(43),
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
+		mockWebhookService := new(mocks.IncomingWebhookService)
+		mockWebhookService.On("CreateWebhook", mockChannel, authUser.ID, "CI").Return(mockWebhook, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                      router,
+			ChannelService:         mockChannelService,
+			GuildService:           mockGuildService,
+			PermissionService:      mockPermissionService,
+			IncomingWebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name": " CI ",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/channels/%s/webhooks", mockChannel.ID), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockWebhook)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.Contains(t, rr.Body.String(), mockWebhook.Token)
+
+		mockWebhookService.AssertExpectations(t)
+	})
+
+	t.Run("DM channel", func(t *testing.T) {
+		mockChannel := fixture.GetMockDMChannel()
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockWebhookService := new(mocks.IncomingWebhookService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                      router,
+			ChannelService:         mockChannelService,
+			GuildService:           mockGuildService,
+			IncomingWebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name": "CI",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/channels/%s/webhooks", mockChannel.ID), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockGuildService.AssertNotCalled(t, "GetGuild", mock.Anything)
+		mockWebhookService.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing permissions", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(fixture.RandID())
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(false)
+
+		mockWebhookService := new(mocks.IncomingWebhookService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                      router,
+			ChannelService:         mockChannelService,
+			GuildService:           mockGuildService,
+			PermissionService:      mockPermissionService,
+			IncomingWebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name": "CI",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/channels/%s/webhooks", mockChannel.ID), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		respBody, err := json.Marshal(gin.H{
+			"error": e,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, e.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookService.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_ExecuteWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successfully posted with overrides", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(fixture.RandID())
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockBot := fixture.GetMockUser()
+		mockBot.IsBot = true
+		token := fixture.RandStr(43)
+
+		mockWebhook := &model.IncomingWebhook{
+			BaseModel: model.BaseModel{ID: fixture.RandID()},
+			ChannelId: mockChannel.ID,
+			GuildId:   mockGuild.ID,
+			UserId:    mockBot.ID,
+			Name:      mockBot.Username,
+		}
+
+		text := "Build passed"
+		username := "Deploy Bot"
+		avatar := "https://example.com/avatar.png"
+
+		params := model.Message{
+			UserId:        mockBot.ID,
+			ChannelId:     mockChannel.ID,
+			Text:          &text,
+			WebhookId:     &mockWebhook.ID,
+			WebhookName:   &username,
+			WebhookAvatar: &avatar,
+		}
+
+		mockMessage := fixture.GetMockMessage(mockBot.ID, mockChannel.ID)
+		mockMessage.Text = &text
+		mockMessage.WebhookId = &mockWebhook.ID
+
+		mockWebhookService := new(mocks.IncomingWebhookService)
+		mockWebhookService.On("Authenticate", mockWebhook.ID, token).Return(mockWebhook, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("UpdateChannel", mockChannel).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", mockBot.ID).Return(mockBot, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("CreateMessage", &params).Return(mockMessage, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitNewMessage", mockChannel.ID, mock.MatchedBy(func(m *model.MessageResponse) bool {
+			return m.User.Username == username && m.User.Image == avatar && m.User.IsBot && *m.WebhookId == mockWebhook.ID
+		})).Return()
+		mockSocketService.On("EmitNewNotification", mockGuild.ID, mockChannel.ID).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:                      router,
+			UserService:            mockUserService,
+			ChannelService:         mockChannelService,
+			MessageService:         mockMessageService,
+			SocketService:          mockSocketService,
+			IncomingWebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text":      text,
+			"username":  username,
+			"avatarUrl": avatar,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/webhooks/%s/%s", mockWebhook.ID, token), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+
+		mockMessageService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+		mockChannelService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		webhookId := fixture.RandID()
+		token := fixture.RandStr(43)
+
+		mockError := apperrors.NewNotFound("webhook", webhookId)
+		mockWebhookService := new(mocks.IncomingWebhookService)
+		mockWebhookService.On("Authenticate", webhookId, token).Return(nil, mockError)
+
+		mockMessageService := new(mocks.MessageService)
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:                      router,
+			MessageService:         mockMessageService,
+			SocketService:          mockSocketService,
+			IncomingWebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": "Build passed",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/webhooks/%s/%s", webhookId, token), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitNewMessage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed attempts are limited per IP", func(t *testing.T) {
+		webhookId := fixture.RandID()
+		token := fixture.RandStr(43)
+		ip := "203.0.113.7"
+
+		mockError := apperrors.NewNotFound("webhook", webhookId)
+		mockWebhookService := new(mocks.IncomingWebhookService)
+		mockWebhookService.On("Authenticate", webhookId, token).Return(nil, mockError)
+
+		mockFailureLimiter := new(mocks.RateLimiter)
+		mockFailureLimiter.On("Reached", ip).Return(false, nil)
+		mockFailureLimiter.On("Take", ip).Return(false, nil)
+
+		mockWebhookLimiter := new(mocks.RateLimiter)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:                      router,
+			IncomingWebhookService: mockWebhookService,
+			WebhookLimiter:         mockWebhookLimiter,
+			WebhookFailureLimiter:  mockFailureLimiter,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": "Build passed",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/webhooks/%s/%s", webhookId, token), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		request.RemoteAddr = ip + ":4321"
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockFailureLimiter.AssertExpectations(t)
+		mockWebhookLimiter.AssertNotCalled(t, "Take", mock.Anything)
+	})
+
+	t.Run("Rejects IPs with too many failed attempts", func(t *testing.T) {
+		ip := "203.0.113.7"
+
+		mockWebhookService := new(mocks.IncomingWebhookService)
+
+		mockFailureLimiter := new(mocks.RateLimiter)
+		mockFailureLimiter.On("Reached", ip).Return(true, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:                      router,
+			IncomingWebhookService: mockWebhookService,
+			WebhookFailureLimiter:  mockFailureLimiter,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": "Build passed",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/webhooks/%s/%s", fixture.RandID(), fixture.RandStr(43)), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		request.RemoteAddr = ip + ":4321"
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": apperrors.NewTooManyRequests(apperrors.WebhookAuthLimited),
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookService.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+		mockFailureLimiter.AssertNotCalled(t, "Take", mock.Anything)
+	})
+
+	t.Run("Webhook limit exceeded", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel(fixture.RandID())
+		token := fixture.RandStr(43)
+		ip := "203.0.113.7"
+
+		mockWebhook := &model.IncomingWebhook{
+			BaseModel: model.BaseModel{ID: fixture.RandID()},
+			ChannelId: mockChannel.ID,
+			UserId:    fixture.RandID(),
+		}
+
+		mockWebhookService := new(mocks.IncomingWebhookService)
+		mockWebhookService.On("Authenticate", mockWebhook.ID, token).Return(mockWebhook, nil)
+
+		mockFailureLimiter := new(mocks.RateLimiter)
+		mockFailureLimiter.On("Reached", ip).Return(false, nil)
+
+		mockWebhookLimiter := new(mocks.RateLimiter)
+		mockWebhookLimiter.On("Take", mockWebhook.ID).Return(true, nil)
+
+		mockMessageService := new(mocks.MessageService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:                      router,
+			MessageService:         mockMessageService,
+			IncomingWebhookService: mockWebhookService,
+			WebhookLimiter:         mockWebhookLimiter,
+			WebhookFailureLimiter:  mockFailureLimiter,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": "Build passed",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/webhooks/%s/%s", mockWebhook.ID, token), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		request.RemoteAddr = ip + ":4321"
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": apperrors.NewTooManyRequests(apperrors.WebhookRateLimited),
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookLimiter.AssertExpectations(t)
+		mockFailureLimiter.AssertNotCalled(t, "Take", mock.Anything)
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		testCases := []gin.H{
+			{},
+			{"text": strings.Repeat("a", 2001)},
+			{"text": "Build passed", "avatarUrl": "ftp://example.com/avatar.png"},
+			{"text": "Build passed", "username": fixture.RandStr(31)},
+		}
+
+		for _, body := range testCases {
+			mockWebhookService := new(mocks.IncomingWebhookService)
+
+			rr := httptest.NewRecorder()
+
+			router := getTestRouter()
+
+			NewHandler(&Config{
+				R:                      router,
+				IncomingWebhookService: mockWebhookService,
+			})
+
+			reqBody, err := json.Marshal(body)
+			assert.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/webhooks/%s/%s", fixture.RandID(), fixture.RandStr(43)), strings.NewReader(string(reqBody)))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(rr, request)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockWebhookService.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+		}
+	})
+}
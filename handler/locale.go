@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"strings"
+)
+
+// isSupportedLocale is the validation rule for user provided locales
+var isSupportedLocale = func() validation.Rule {
+	locales := make([]interface{}, len(model.SupportedLocales))
+	for i, locale := range model.SupportedLocales {
+		locales[i] = locale
+	}
+	return validation.In(locales...).Error("must be a supported locale")
+}()
+
+// requestLocale returns the first supported locale of the request's
+// Accept-Language header or the default locale
+func requestLocale(c *gin.Context) string {
+	for _, tag := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		// Strip the quality value and the region, e.g. "de-CH;q=0.9" -> "de"
+		tag = strings.TrimSpace(strings.SplitN(tag, ";", 2)[0])
+		language := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+
+		for _, locale := range model.SupportedLocales {
+			if language == locale {
+				return locale
+			}
+		}
+	}
+
+	return model.DefaultLocale
+}
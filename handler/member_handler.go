@@ -178,8 +178,8 @@ func (h *Handler) GetBanList(c *gin.Context) {
 
 	userId := c.MustGet("userId").(string)
 
-	if guild.OwnerId != userId {
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+	if !h.permissionService.HasPermission(userId, guild, model.BanMembers) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		c.JSON(e.Status(), gin.H{
 			"error": e,
 		})
@@ -212,9 +212,11 @@ func (h *Handler) GetBanList(c *gin.Context) {
 // @Produce  json
 // @Param guildId path string true "Guild ID"
 // @Param request body memberReq true "Member ID"
+// @Param X-Audit-Log-Reason header string false "Reason for the audit log. Maximum 512 characters"
 // @Success 200 {array} model.Success
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /guilds/{guildId}/bans [post]
@@ -239,8 +241,8 @@ func (h *Handler) BanMember(c *gin.Context) {
 
 	userId := c.MustGet("userId").(string)
 
-	if guild.OwnerId != userId {
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+	if !h.permissionService.HasPermission(userId, guild, model.BanMembers) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		c.JSON(e.Status(), gin.H{
 			"error": e,
 		})
@@ -267,16 +269,31 @@ func (h *Handler) BanMember(c *gin.Context) {
 		return
 	}
 
-	guild.Bans = append(guild.Bans, *member)
+	// Moderators cannot remove the owner from their guild
+	if member.ID == guild.OwnerId {
+		e := apperrors.NewBadRequest(apperrors.ModerateOwnerError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
 
-	if err = h.guildService.UpdateGuild(guild); err != nil {
-		c.JSON(apperrors.Status(err), gin.H{
-			"error": err,
+	// Like with roles, moderators cannot remove members with permissions they do not have
+	if !canGrant(h.permissionService.GetMemberPermissions(userId, guild), h.permissionService.GetMemberPermissions(member.ID, guild)) {
+		e := apperrors.NewForbidden(apperrors.ModerateMemberError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
 		})
 		return
 	}
 
-	err = h.guildService.RemoveMember(req.MemberId, guildId)
+	reason, ok := getAuditLogReason(c)
+
+	if !ok {
+		return
+	}
+
+	err = h.guildService.BanMember(member, guild, userId, reason)
 
 	if err != nil {
 		log.Printf("Failed to ban member: %v\n", err.Error())
@@ -327,8 +344,8 @@ func (h *Handler) UnbanMember(c *gin.Context) {
 
 	userId := c.MustGet("userId").(string)
 
-	if guild.OwnerId != userId {
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+	if !h.permissionService.HasPermission(userId, guild, model.BanMembers) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		c.JSON(e.Status(), gin.H{
 			"error": e,
 		})
@@ -343,7 +360,7 @@ func (h *Handler) UnbanMember(c *gin.Context) {
 		return
 	}
 
-	if err := h.guildService.UnbanMember(req.MemberId, guildId); err != nil {
+	if err := h.guildService.UnbanMember(req.MemberId, guildId, userId); err != nil {
 		log.Printf("Failed to unban member: %v\n", err.Error())
 		c.JSON(apperrors.Status(err), gin.H{
 			"error": err,
@@ -361,9 +378,11 @@ func (h *Handler) UnbanMember(c *gin.Context) {
 // @Produce  json
 // @Param guildId path string true "Guild ID"
 // @Param request body memberReq true "Member ID"
+// @Param X-Audit-Log-Reason header string false "Reason for the audit log. Maximum 512 characters"
 // @Success 200 {array} model.Success
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /guilds/{guildId}/kick [post]
@@ -388,8 +407,8 @@ func (h *Handler) KickMember(c *gin.Context) {
 
 	userId := c.MustGet("userId").(string)
 
-	if guild.OwnerId != userId {
-		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+	if !h.permissionService.HasPermission(userId, guild, model.KickMembers) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		c.JSON(e.Status(), gin.H{
 			"error": e,
 		})
@@ -416,7 +435,31 @@ func (h *Handler) KickMember(c *gin.Context) {
 		return
 	}
 
-	err = h.guildService.RemoveMember(req.MemberId, guildId)
+	// Moderators cannot remove the owner from their guild
+	if member.ID == guild.OwnerId {
+		e := apperrors.NewBadRequest(apperrors.ModerateOwnerError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Like with roles, moderators cannot remove members with permissions they do not have
+	if !canGrant(h.permissionService.GetMemberPermissions(userId, guild), h.permissionService.GetMemberPermissions(member.ID, guild)) {
+		e := apperrors.NewForbidden(apperrors.ModerateMemberError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	reason, ok := getAuditLogReason(c)
+
+	if !ok {
+		return
+	}
+
+	err = h.guildService.KickMember(req.MemberId, guildId, userId, reason)
 
 	if err != nil {
 		log.Printf("Failed to kick member: %v\n", err.Error())
@@ -519,13 +519,17 @@ func TestHandler_GetBanList(t *testing.T) {
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 		mockGuildService.On("GetBanList", mockGuild.ID).Return(&response, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(true)
+
 		rr := httptest.NewRecorder()
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:            router,
-			GuildService: mockGuildService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqUrl := fmt.Sprintf("/api/guilds/%s/bans", mockGuild.ID)
@@ -542,19 +546,23 @@ func TestHandler_GetBanList(t *testing.T) {
 		mockGuildService.AssertExpectations(t)
 	})
 
-	t.Run("Not the owner", func(t *testing.T) {
+	t.Run("Missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(false)
+
 		rr := httptest.NewRecorder()
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:            router,
-			GuildService: mockGuildService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqUrl := fmt.Sprintf("/api/guilds/%s/bans", mockGuild.ID)
@@ -563,7 +571,7 @@ func TestHandler_GetBanList(t *testing.T) {
 
 		router.ServeHTTP(rr, request)
 
-		mockError := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		respBody, err := json.Marshal(gin.H{
 			"error": mockError,
 		})
@@ -614,6 +622,9 @@ func TestHandler_GetBanList(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(true)
+
 		mockError := apperrors.NewInternal()
 		mockGuildService.On("GetBanList", mockGuild.ID).Return(nil, mockError)
 
@@ -622,8 +633,9 @@ func TestHandler_GetBanList(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:            router,
-			GuildService: mockGuildService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqUrl := fmt.Sprintf("/api/guilds/%s/bans", mockGuild.ID)
@@ -655,13 +667,19 @@ func TestHandler_BanMember(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 		mockGuildService.On("GetUser", mockMember.ID).Return(mockMember, nil)
-		mockGuildService.On("UpdateGuild", mockGuild).Return(nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(true)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+		mockPermissionService.On("GetMemberPermissions", mockMember.ID, mockGuild).Return(model.DefaultPermissions)
 
 		args := mock.Arguments{
-			mockMember.ID,
-			mockGuild.ID,
+			mockMember,
+			mockGuild,
+			authUser.ID,
+			(*string)(nil),
 		}
-		mockGuildService.On("RemoveMember", args...).Return(nil)
+		mockGuildService.On("BanMember", args...).Return(nil)
 
 		mockSocketService := new(mocks.SocketService)
 		mockSocketService.On("EmitRemoveMember", mockGuild.ID, mockMember.ID)
@@ -672,9 +690,10 @@ func TestHandler_BanMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -698,13 +717,16 @@ func TestHandler_BanMember(t *testing.T) {
 		mockSocketService.AssertExpectations(t)
 	})
 
-	t.Run("Not the owner", func(t *testing.T) {
+	t.Run("Missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 		mockMember := fixture.GetMockUser()
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(false)
+
 		mockSocketService := new(mocks.SocketService)
 
 		rr := httptest.NewRecorder()
@@ -712,9 +734,10 @@ func TestHandler_BanMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -729,7 +752,7 @@ func TestHandler_BanMember(t *testing.T) {
 		request.Header.Set("Content-Type", "application/json")
 		router.ServeHTTP(rr, request)
 
-		mockError := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		respBody, err := json.Marshal(gin.H{
 			"error": mockError,
 		})
@@ -740,8 +763,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
 		mockGuildService.AssertNotCalled(t, "GetUser")
-		mockGuildService.AssertNotCalled(t, "UpdateGuild")
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "BanMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -786,8 +808,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		mockGuildService.AssertNotCalled(t, "GetGuild")
 		mockGuildService.AssertNotCalled(t, "GetUser")
-		mockGuildService.AssertNotCalled(t, "UpdateGuild")
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "BanMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -799,14 +820,20 @@ func TestHandler_BanMember(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 		mockGuildService.On("GetUser", mockMember.ID).Return(mockMember, nil)
-		mockGuildService.On("UpdateGuild", mockGuild).Return(nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(true)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+		mockPermissionService.On("GetMemberPermissions", mockMember.ID, mockGuild).Return(model.DefaultPermissions)
 
 		mockError := apperrors.NewInternal()
 		args := mock.Arguments{
-			mockMember.ID,
-			mockGuild.ID,
+			mockMember,
+			mockGuild,
+			authUser.ID,
+			(*string)(nil),
 		}
-		mockGuildService.On("RemoveMember", args...).Return(mockError)
+		mockGuildService.On("BanMember", args...).Return(mockError)
 
 		mockSocketService := new(mocks.SocketService)
 
@@ -815,9 +842,10 @@ func TestHandler_BanMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -887,8 +915,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
 		mockGuildService.AssertNotCalled(t, "GetUser")
-		mockGuildService.AssertNotCalled(t, "UpdateGuild")
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "BanMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -899,6 +926,9 @@ func TestHandler_BanMember(t *testing.T) {
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(true)
 		mockError := apperrors.NewNotFound("user", mockMember.ID)
 		mockGuildService.On("GetUser", mockMember.ID).Return(nil, mockError)
 
@@ -909,9 +939,10 @@ func TestHandler_BanMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -936,8 +967,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
 		mockGuildService.AssertCalled(t, "GetUser", mockMember.ID)
-		mockGuildService.AssertNotCalled(t, "UpdateGuild")
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "BanMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -972,8 +1002,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		mockGuildService.AssertNotCalled(t, "GetGuild")
 		mockGuildService.AssertNotCalled(t, "GetUser")
-		mockGuildService.AssertNotCalled(t, "UpdateGuild")
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "BanMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -985,6 +1014,9 @@ func TestHandler_BanMember(t *testing.T) {
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 		mockGuildService.On("GetUser", authUser.ID).Return(authUser, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(true)
+
 		mockSocketService := new(mocks.SocketService)
 
 		rr := httptest.NewRecorder()
@@ -992,9 +1024,10 @@ func TestHandler_BanMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -1020,7 +1053,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
 		mockGuildService.AssertCalled(t, "GetUser", authUser.ID)
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "BanMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -1039,11 +1072,18 @@ func TestHandler_KickMember(t *testing.T) {
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 		mockGuildService.On("GetUser", mockMember.ID).Return(mockMember, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.KickMembers).Return(true)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+		mockPermissionService.On("GetMemberPermissions", mockMember.ID, mockGuild).Return(model.DefaultPermissions)
+
 		args := mock.Arguments{
 			mockMember.ID,
 			mockGuild.ID,
+			authUser.ID,
+			(*string)(nil),
 		}
-		mockGuildService.On("RemoveMember", args...).Return(nil)
+		mockGuildService.On("KickMember", args...).Return(nil)
 
 		mockSocketService := new(mocks.SocketService)
 		mockSocketService.On("EmitRemoveMember", mockGuild.ID, mockMember.ID)
@@ -1054,9 +1094,10 @@ func TestHandler_KickMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -1080,13 +1121,64 @@ func TestHandler_KickMember(t *testing.T) {
 		mockSocketService.AssertExpectations(t)
 	})
 
-	t.Run("Not the owner", func(t *testing.T) {
+	t.Run("Cannot kick the owner", func(t *testing.T) {
+		mockOwner := fixture.GetMockUser()
+		mockGuild := fixture.GetMockGuild(mockOwner.ID)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+		mockGuildService.On("GetUser", mockOwner.ID).Return(mockOwner, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.KickMembers).Return(true)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"memberId": mockOwner.ID,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/kick", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.ModerateOwnerError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockGuildService.AssertNotCalled(t, "KickMember", mockOwner.ID, mockGuild.ID)
+		mockSocketService.AssertNotCalled(t, "EmitRemoveMember", mockGuild.ID, mockOwner.ID)
+	})
+
+	t.Run("Missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 		mockMember := fixture.GetMockUser()
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.KickMembers).Return(false)
+
 		mockSocketService := new(mocks.SocketService)
 
 		rr := httptest.NewRecorder()
@@ -1094,9 +1186,10 @@ func TestHandler_KickMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -1111,7 +1204,7 @@ func TestHandler_KickMember(t *testing.T) {
 		request.Header.Set("Content-Type", "application/json")
 		router.ServeHTTP(rr, request)
 
-		mockError := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		respBody, err := json.Marshal(gin.H{
 			"error": mockError,
 		})
@@ -1122,7 +1215,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
 		mockGuildService.AssertNotCalled(t, "GetUser")
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "KickMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -1167,7 +1260,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		mockGuildService.AssertNotCalled(t, "GetGuild")
 		mockGuildService.AssertNotCalled(t, "GetUser")
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "KickMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -1180,12 +1273,19 @@ func TestHandler_KickMember(t *testing.T) {
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 		mockGuildService.On("GetUser", mockMember.ID).Return(mockMember, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.KickMembers).Return(true)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+		mockPermissionService.On("GetMemberPermissions", mockMember.ID, mockGuild).Return(model.DefaultPermissions)
+
 		mockError := apperrors.NewInternal()
 		args := mock.Arguments{
 			mockMember.ID,
 			mockGuild.ID,
+			authUser.ID,
+			(*string)(nil),
 		}
-		mockGuildService.On("RemoveMember", args...).Return(mockError)
+		mockGuildService.On("KickMember", args...).Return(mockError)
 
 		mockSocketService := new(mocks.SocketService)
 
@@ -1194,9 +1294,10 @@ func TestHandler_KickMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -1266,7 +1367,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
 		mockGuildService.AssertNotCalled(t, "GetUser")
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "KickMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -1277,6 +1378,9 @@ func TestHandler_KickMember(t *testing.T) {
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.KickMembers).Return(true)
 		mockError := apperrors.NewNotFound("user", mockMember.ID)
 		mockGuildService.On("GetUser", mockMember.ID).Return(nil, mockError)
 
@@ -1287,9 +1391,10 @@ func TestHandler_KickMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -1314,7 +1419,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
 		mockGuildService.AssertCalled(t, "GetUser", mockMember.ID)
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "KickMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -1349,7 +1454,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		mockGuildService.AssertNotCalled(t, "GetGuild")
 		mockGuildService.AssertNotCalled(t, "GetUser")
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "KickMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
@@ -1361,6 +1466,9 @@ func TestHandler_KickMember(t *testing.T) {
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 		mockGuildService.On("GetUser", authUser.ID).Return(authUser, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.KickMembers).Return(true)
+
 		mockSocketService := new(mocks.SocketService)
 
 		rr := httptest.NewRecorder()
@@ -1368,9 +1476,10 @@ func TestHandler_KickMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -1396,12 +1505,129 @@ func TestHandler_KickMember(t *testing.T) {
 
 		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
 		mockGuildService.AssertCalled(t, "GetUser", authUser.ID)
-		mockGuildService.AssertNotCalled(t, "RemoveMember")
+		mockGuildService.AssertNotCalled(t, "KickMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveMember")
 		mockSocketService.AssertNotCalled(t, "EmitRemoveFromGuild")
 	})
 }
 
+func TestHandler_ModerateMember_Hierarchy(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+	moderator := model.DefaultPermissions | model.KickMembers | model.BanMembers
+
+	testCases := []struct {
+		name    string
+		actor   model.Permission
+		target  model.Permission
+		allowed bool
+	}{
+		{
+			name:    "Member with the same permissions",
+			actor:   moderator,
+			target:  moderator,
+			allowed: true,
+		},
+		{
+			name:    "Member with fewer permissions",
+			actor:   moderator,
+			target:  model.DefaultPermissions,
+			allowed: true,
+		},
+		{
+			name:   "Member with a permission the moderator lacks",
+			actor:  moderator,
+			target: model.DefaultPermissions | model.ManageGuild,
+		},
+		{
+			name:   "Administrator",
+			actor:  model.AllPermissions &^ model.Administrator,
+			target: model.DefaultPermissions | model.Administrator,
+		},
+		{
+			name:    "Administrators can moderate everyone but the owner",
+			actor:   model.DefaultPermissions | model.Administrator,
+			target:  model.AllPermissions,
+			allowed: true,
+		},
+	}
+
+	actions := []struct {
+		name       string
+		path       string
+		permission model.Permission
+		method     string
+	}{
+		{name: "Kick", path: "kick", permission: model.KickMembers, method: "KickMember"},
+		{name: "Ban", path: "bans", permission: model.BanMembers, method: "BanMember"},
+	}
+
+	for _, action := range actions {
+		for _, tc := range testCases {
+			t.Run(fmt.Sprintf("%s %s", action.name, tc.name), func(t *testing.T) {
+				mockGuild := fixture.GetMockGuild("")
+				mockMember := fixture.GetMockUser()
+
+				mockGuildService := new(mocks.GuildService)
+				mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+				mockGuildService.On("GetUser", mockMember.ID).Return(mockMember, nil)
+				mockGuildService.On("KickMember", mockMember.ID, mockGuild.ID, authUser.ID, (*string)(nil)).Return(nil)
+				mockGuildService.On("BanMember", mockMember, mockGuild, authUser.ID, (*string)(nil)).Return(nil)
+
+				mockPermissionService := new(mocks.PermissionService)
+				mockPermissionService.On("HasPermission", authUser.ID, mockGuild, action.permission).Return(true)
+				mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(tc.actor)
+				mockPermissionService.On("GetMemberPermissions", mockMember.ID, mockGuild).Return(tc.target)
+
+				mockSocketService := new(mocks.SocketService)
+				mockSocketService.On("EmitRemoveMember", mockGuild.ID, mockMember.ID)
+				mockSocketService.On("EmitRemoveFromGuild", mockMember.ID, mockGuild.ID)
+
+				rr := httptest.NewRecorder()
+
+				router := getAuthenticatedTestRouter(authUser.ID)
+
+				NewHandler(&Config{
+					R:                 router,
+					GuildService:      mockGuildService,
+					SocketService:     mockSocketService,
+					PermissionService: mockPermissionService,
+				})
+
+				reqBody, err := json.Marshal(gin.H{
+					"memberId": mockMember.ID,
+				})
+				assert.NoError(t, err)
+
+				reqUrl := fmt.Sprintf("/api/guilds/%s/%s", mockGuild.ID, action.path)
+				request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+				assert.NoError(t, err)
+
+				request.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(rr, request)
+
+				if tc.allowed {
+					assert.Equal(t, http.StatusOK, rr.Code)
+					mockGuildService.AssertCalled(t, action.method, mock.Anything, mock.Anything, authUser.ID, (*string)(nil))
+					return
+				}
+
+				mockError := apperrors.NewForbidden(apperrors.ModerateMemberError)
+				respBody, err := json.Marshal(gin.H{
+					"error": mockError,
+				})
+				assert.NoError(t, err)
+
+				assert.Equal(t, http.StatusForbidden, rr.Code)
+				assert.Equal(t, respBody, rr.Body.Bytes())
+				mockGuildService.AssertNotCalled(t, action.method, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				mockSocketService.AssertNotCalled(t, "EmitRemoveMember", mock.Anything, mock.Anything)
+			})
+		}
+	}
+}
+
 func TestHandler_UnbanMember(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
@@ -1414,9 +1640,13 @@ func TestHandler_UnbanMember(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(true)
+
 		args := mock.Arguments{
 			mockMember.ID,
 			mockGuild.ID,
+			authUser.ID,
 		}
 		mockGuildService.On("UnbanMember", args...).Return(nil)
 
@@ -1425,8 +1655,9 @@ func TestHandler_UnbanMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:            router,
-			GuildService: mockGuildService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -1449,20 +1680,24 @@ func TestHandler_UnbanMember(t *testing.T) {
 		mockGuildService.AssertExpectations(t)
 	})
 
-	t.Run("Not the owner", func(t *testing.T) {
+	t.Run("Missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 		mockMember := fixture.GetMockUser()
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(false)
+
 		rr := httptest.NewRecorder()
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:            router,
-			GuildService: mockGuildService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -1477,7 +1712,7 @@ func TestHandler_UnbanMember(t *testing.T) {
 		request.Header.Set("Content-Type", "application/json")
 		router.ServeHTTP(rr, request)
 
-		mockError := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
 		respBody, err := json.Marshal(gin.H{
 			"error": mockError,
 		})
@@ -1537,10 +1772,14 @@ func TestHandler_UnbanMember(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(true)
+
 		mockError := apperrors.NewInternal()
 		args := mock.Arguments{
 			mockMember.ID,
 			mockGuild.ID,
+			authUser.ID,
 		}
 		mockGuildService.On("UnbanMember", args...).Return(mockError)
 
@@ -1551,9 +1790,10 @@ func TestHandler_UnbanMember(t *testing.T) {
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:             router,
-			GuildService:  mockGuildService,
-			SocketService: mockSocketService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -1655,13 +1895,17 @@ func TestHandler_UnbanMember(t *testing.T) {
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.BanMembers).Return(true)
+
 		rr := httptest.NewRecorder()
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:            router,
-			GuildService: mockGuildService,
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
 		})
 
 		reqBody, err := json.Marshal(gin.H{
@@ -1,10 +1,8 @@
 package handler
 
 import (
-	"fmt"
 	"github.com/gin-gonic/gin"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	gonanoid "github.com/matoous/go-nanoid"
 	"github.com/sentrionic/valkyrie/model"
 	"github.com/sentrionic/valkyrie/model/apperrors"
 	"log"
@@ -46,7 +44,7 @@ func (h *Handler) GetMessages(c *gin.Context) {
 	}
 
 	// Check if the user has access to said channel
-	err = h.channelService.IsChannelMember(channel, userId)
+	permissions, err := h.channelService.GetPermissions(channel, userId)
 
 	if err != nil {
 		c.JSON(apperrors.Status(err), gin.H{
@@ -55,6 +53,14 @@ func (h *Handler) GetMessages(c *gin.Context) {
 		return
 	}
 
+	if !permissions.Has(model.ReadMessageHistory) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
 	// Cursor is based on the created_at field of the message
 	cursor := c.Query("cursor")
 
@@ -84,8 +90,11 @@ func (h *Handler) GetMessages(c *gin.Context) {
 type messageRequest struct {
 	// Maximum 2000 characters
 	Text *string `form:"text"`
-	// image/* or audio/*
+	// Image, audio, video, pdf, text or archive file.
+	// The size limit depends on the type.
 	File *multipart.FileHeader `form:"file" swaggertype:"string" format:"binary"`
+	// ID of the message in the same channel this message replies to
+	ReplyTo *string `form:"replyTo"`
 } //@name MessageRequest
 
 func (r messageRequest) validate() error {
@@ -96,6 +105,7 @@ func (r messageRequest) validate() error {
 				Error(apperrors.MessageOrFileRequired),
 			validation.Length(1, 2000),
 		),
+		validation.Field(&r.ReplyTo, validation.NilOrNotEmpty),
 	)
 }
 
@@ -124,6 +134,12 @@ func (h *Handler) CreateMessage(c *gin.Context) {
 	channelId := c.Param("channelId")
 	userId := c.MustGet("userId").(string)
 
+	// Reject bodies that cannot contain an allowed attachment before parsing them.
+	// The other form fields need some room as well.
+	if limit := h.maxUploadSize(); limit > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+formFieldsBytes)
+	}
+
 	var req messageRequest
 	// Bind incoming json to struct and check for validation errors
 	if ok := bindData(c, &req); !ok {
@@ -143,7 +159,7 @@ func (h *Handler) CreateMessage(c *gin.Context) {
 	}
 
 	// Check if the user has access to said channel
-	err = h.channelService.IsChannelMember(channel, userId)
+	permissions, err := h.channelService.GetPermissions(channel, userId)
 
 	if err != nil {
 		c.JSON(apperrors.Status(err), gin.H{
@@ -152,6 +168,32 @@ func (h *Handler) CreateMessage(c *gin.Context) {
 		return
 	}
 
+	// Check if the user is allowed to post in the channel
+	if !permissions.Has(model.SendMessages) || (req.File != nil && !permissions.Has(model.AttachFiles)) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Invoke the command instead of posting the text if it starts with a registered command
+	if channel.GuildID != nil && req.File == nil && req.Text != nil && strings.HasPrefix(*req.Text, "/") {
+		command, options, err := h.commandService.ParseCommand(*channel.GuildID, *req.Text)
+
+		if err != nil {
+			c.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			return
+		}
+
+		if command != nil {
+			h.invokeCommand(c, command, channel, userId, options)
+			return
+		}
+	}
+
 	author, err := h.userService.Get(userId)
 
 	if err != nil {
@@ -167,38 +209,64 @@ func (h *Handler) CreateMessage(c *gin.Context) {
 		ChannelId: channel.ID,
 	}
 
+	// Replies can only reference messages of the same channel
+	var replyTo *model.MessageReference
+	if req.ReplyTo != nil {
+		reply, err := h.messageService.Get(*req.ReplyTo)
+
+		if err != nil || reply.ChannelId != channel.ID {
+			e := apperrors.NewNotFound("message", *req.ReplyTo)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+
+		params.ReplyId = &reply.ID
+		replyTo = &model.MessageReference{
+			Id:     reply.ID,
+			Text:   reply.Text,
+			UserId: reply.UserId,
+		}
+
+		if replyAuthor, err := h.userService.Get(reply.UserId); err == nil {
+			replyTo.Username = replyAuthor.Username
+		}
+	}
+
 	params.Text = req.Text
 
 	if req.File != nil {
-		mimeType := req.File.Header.Get("Content-Type")
+		mimeType, err := detectContentType(req.File)
 
-		if valid := isAllowedFileType(mimeType); !valid {
-			toFieldErrorResponse(c, "File", apperrors.InvalidImageType)
+		if err != nil {
+			log.Printf("Failed to read the attachment: %v\n", err.Error())
+			e := apperrors.NewInternal()
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
 			return
 		}
 
-		// Prevent file upload on the live server.
-		// Remove the if part if you do want upload
-		var attachment *model.Attachment
-		if gin.Mode() == gin.ReleaseMode {
-			id, _ := gonanoid.Nanoid(20)
-
-			// Random image to test files in the app
-			attachment = &model.Attachment{
-				ID:       id,
-				Url:      fmt.Sprintf("https://picsum.photos/seed/%s/600", id),
-				FileType: "image/jpeg",
-				Filename: id,
-			}
-		} else {
-			attachment, err = h.messageService.UploadFile(req.File, channel.ID)
-
-			if err != nil {
-				c.JSON(apperrors.Status(err), gin.H{
-					"error": err,
-				})
-				return
-			}
+		kind, valid := fileKind(mimeType)
+
+		if !valid {
+			toFieldErrorResponse(c, "File", apperrors.InvalidFileType)
+			return
+		}
+
+		if limit := h.maxFileSize(kind); limit > 0 && req.File.Size > limit {
+			toFieldErrorResponse(c, "File", apperrors.FileTooLarge)
+			return
+		}
+
+		attachment, err := h.messageService.UploadFile(req.File, channel.ID, mimeType)
+
+		if err != nil {
+			c.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			return
 		}
 
 		params.Attachment = attachment
@@ -225,10 +293,13 @@ func (h *Handler) CreateMessage(c *gin.Context) {
 			Username:  author.Username,
 			Image:     author.Image,
 			IsOnline:  author.IsOnline,
+			IsBot:     author.IsBot,
 			CreatedAt: author.CreatedAt,
 			UpdatedAt: author.UpdatedAt,
 			IsFriend:  false,
 		},
+		Reactions: make([]model.ReactionResponse, 0),
+		ReplyTo:   replyTo,
 	}
 
 	// Get member settings if it is not a DM
@@ -238,9 +309,15 @@ func (h *Handler) CreateMessage(c *gin.Context) {
 		response.User.Color = settings.Color
 	}
 
-	// Emit new message to the channel
+	// Clear the typing indicator of the author and emit new message to the channel
+	h.socketService.StopTyping(channelId, userId)
 	h.socketService.EmitNewMessage(channelId, &response)
 
+	// Notify the mentioned users
+	for i := range message.Mentions {
+		h.socketService.EmitNewMention(message.Mentions[i].UserId, &message.Mentions[i])
+	}
+
 	if channel.IsDM {
 		// Open the DM and push it to the top
 		_ = h.channelService.OpenDMForAll(channelId)
@@ -363,7 +440,15 @@ func (h *Handler) DeleteMessage(c *gin.Context) {
 		return
 	}
 
-	// Check if message author or guild owner
+	// Check if the user has access to said channel
+	if _, err = h.channelService.GetPermissions(channel, userId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Check if message author or moderator
 	if !channel.IsDM {
 		guild, err := h.guildService.GetGuild(*channel.GuildID)
 
@@ -376,7 +461,7 @@ func (h *Handler) DeleteMessage(c *gin.Context) {
 			return
 		}
 
-		if message.UserId != userId && guild.OwnerId != userId {
+		if message.UserId != userId && !h.permissionService.HasPermission(userId, guild, model.ManageMessages) {
 			e := apperrors.NewAuthorization(apperrors.DeleteMessageError)
 			c.JSON(e.Status(), gin.H{
 				"error": e,
@@ -407,3 +492,116 @@ func (h *Handler) DeleteMessage(c *gin.Context) {
 
 	c.JSON(http.StatusOK, true)
 }
+
+// GetMessageHistory returns the previous revisions of the given message.
+// Also returns the content of deleted messages
+// GetMessageHistory godoc
+// @Tags Messages
+// @Summary Get Message History
+// @Produce  json
+// @Param messageId path string true "Message ID"
+// @Success 200 {object} model.MessageHistory
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /messages/{messageId}/history [get]
+func (h *Handler) GetMessageHistory(c *gin.Context) {
+	// The GET route shares the wildcard with GetMessages
+	messageId := c.Param("channelId")
+	userId := c.MustGet("userId").(string)
+	history, err := h.messageService.GetHistory(messageId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("message", messageId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	channel, err := h.channelService.Get(history.ChannelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("message", messageId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Check if the user can read the messages of said channel
+	permissions, err := h.channelService.GetPermissions(channel, userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if !permissions.Has(model.ReadMessageHistory) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Check if message author or moderator
+	if !channel.IsDM {
+		guild, err := h.guildService.GetGuild(*channel.GuildID)
+
+		if err != nil {
+			e := apperrors.NewNotFound("message", messageId)
+
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+
+		if history.UserId != userId && !h.permissionService.HasPermission(userId, guild, model.ManageMessages) {
+			e := apperrors.NewAuthorization(apperrors.MessageHistoryError)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+		// Only message author check required
+	} else {
+		if history.UserId != userId {
+			e := apperrors.NewAuthorization(apperrors.DMMessageHistoryError)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// formFieldsBytes is the room for the form fields next to the attachment
+const formFieldsBytes = 64 * 1024
+
+// maxFileSize returns the size limit for attachments of the given kind.
+// Returns 0 if there is no limit.
+func (h *Handler) maxFileSize(kind string) int64 {
+	if limit, ok := h.FileSizeLimits[kind]; ok && limit > 0 {
+		return limit
+	}
+	return h.MaxBodyBytes
+}
+
+// maxUploadSize returns the largest attachment size limit.
+// Returns 0 if there is no limit.
+func (h *Handler) maxUploadSize() int64 {
+	max := h.MaxBodyBytes
+	for _, limit := range h.FileSizeLimits {
+		if limit > max {
+			max = limit
+		}
+	}
+	return max
+}
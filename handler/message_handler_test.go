@@ -3,6 +3,7 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/sentrionic/valkyrie/mocks"
 	"github.com/sentrionic/valkyrie/model"
@@ -27,7 +28,7 @@ func TestHandler_GetMessages(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
-		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockMessageService := new(mocks.MessageService)
 
@@ -103,7 +104,7 @@ func TestHandler_GetMessages(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockChannelService.AssertCalled(t, "Get", id)
-		mockChannelService.AssertNotCalled(t, "IsChannelMember")
+		mockChannelService.AssertNotCalled(t, "GetPermissions")
 		mockMessageService.AssertNotCalled(t, "GetMessages")
 	})
 
@@ -114,7 +115,7 @@ func TestHandler_GetMessages(t *testing.T) {
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 
 		mockError := apperrors.NewAuthorization(apperrors.Unauthorized)
-		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(mockError)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.Permission(0), mockError)
 
 		mockMessageService := new(mocks.MessageService)
 
@@ -176,7 +177,7 @@ func TestHandler_GetMessages(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockChannelService.AssertNotCalled(t, "Get")
-		mockChannelService.AssertNotCalled(t, "IsChannelMember")
+		mockChannelService.AssertNotCalled(t, "GetPermissions")
 		mockMessageService.AssertNotCalled(t, "GetMessages")
 	})
 
@@ -185,7 +186,7 @@ func TestHandler_GetMessages(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
-		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockMessageService := new(mocks.MessageService)
 
@@ -237,7 +238,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
-		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockUserService := new(mocks.UserService)
 		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
@@ -269,8 +270,10 @@ func TestHandler_CreateMessage(t *testing.T) {
 				UpdatedAt: authUser.UpdatedAt,
 				IsFriend:  false,
 			},
+			Reactions: make([]model.ReactionResponse, 0),
 		}
 
+		mockSocketService.On("StopTyping", mockChannel.ID, authUser.ID).Return()
 		mockSocketService.On("EmitNewMessage", mockChannel.ID, &response).Return()
 		mockChannelService.On("UpdateChannel", mockChannel).Return(nil)
 		mockSocketService.On("EmitNewNotification", mockGuild.ID, mockChannel.ID)
@@ -353,7 +356,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockChannelService.AssertCalled(t, "Get", id)
-		mockChannelService.AssertNotCalled(t, "IsChannelMember")
+		mockChannelService.AssertNotCalled(t, "GetPermissions")
 		mockUserService.AssertNotCalled(t, "Get")
 		mockMessageService.AssertNotCalled(t, "CreateMessage")
 		mockSocketService.AssertNotCalled(t, "EmitNewMessage")
@@ -365,7 +368,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
-		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(mockError)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.Permission(0), mockError)
 
 		mockUserService := new(mocks.UserService)
 		mockMessageService := new(mocks.MessageService)
@@ -403,7 +406,55 @@ func TestHandler_CreateMessage(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockChannelService.AssertCalled(t, "Get", mockChannel.ID)
-		mockChannelService.AssertCalled(t, "IsChannelMember", mockChannel, authUser.ID)
+		mockChannelService.AssertCalled(t, "GetPermissions", mockChannel, authUser.ID)
+		mockUserService.AssertNotCalled(t, "Get")
+		mockMessageService.AssertNotCalled(t, "CreateMessage")
+		mockSocketService.AssertNotCalled(t, "EmitNewMessage")
+	})
+
+	t.Run("Missing send permission", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel(fixture.RandID())
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.ViewChannel|model.ReadMessageHistory, nil)
+
+		mockUserService := new(mocks.UserService)
+		mockMessageService := new(mocks.MessageService)
+		mockGuildService := new(mocks.GuildService)
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			GuildService:   mockGuildService,
+			SocketService:  mockSocketService,
+			UserService:    mockUserService,
+		})
+
+		form := url.Values{}
+		form.Add("text", fixture.RandStringRunes(8))
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
 		mockUserService.AssertNotCalled(t, "Get")
 		mockMessageService.AssertNotCalled(t, "CreateMessage")
 		mockSocketService.AssertNotCalled(t, "EmitNewMessage")
@@ -450,7 +501,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockChannelService.AssertNotCalled(t, "Get")
-		mockChannelService.AssertNotCalled(t, "IsChannelMember")
+		mockChannelService.AssertNotCalled(t, "GetPermissions")
 		mockUserService.AssertNotCalled(t, "Get")
 		mockMessageService.AssertNotCalled(t, "CreateMessage")
 		mockSocketService.AssertNotCalled(t, "EmitNewMessage")
@@ -463,7 +514,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
-		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockUserService := new(mocks.UserService)
 		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
@@ -511,7 +562,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockChannelService.AssertCalled(t, "Get", mockChannel.ID)
-		mockChannelService.AssertCalled(t, "IsChannelMember", mockChannel, authUser.ID)
+		mockChannelService.AssertCalled(t, "GetPermissions", mockChannel, authUser.ID)
 		mockMessageService.AssertCalled(t, "CreateMessage", &params)
 		mockUserService.AssertCalled(t, "Get", authUser.ID)
 		mockChannelService.AssertNotCalled(t, "UpdateChannel")
@@ -524,7 +575,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
-		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockUserService := new(mocks.UserService)
 		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
@@ -546,21 +597,24 @@ func TestHandler_CreateMessage(t *testing.T) {
 			UserService:    mockUserService,
 		})
 
-		multipartImageFixture := fixture.NewMultipartImage("image.txt", "image/txt")
-		defer multipartImageFixture.Close()
+		// The claimed type is ignored, the content decides
+		body, contentType := getTestMultipartFile("image.png", "image/png", []byte("<html><script>alert(1)</script></html>"))
 
-		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, multipartImageFixture.MultipartBody)
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, body)
 		assert.NoError(t, err)
 
-		request.Header.Set("Content-Type", multipartImageFixture.ContentType)
+		request.Header.Set("Content-Type", contentType)
 
 		router.ServeHTTP(rr, request)
 
+		respBody, err := json.Marshal(getTestFieldErrorResponse("File", apperrors.InvalidFileType))
 		assert.NoError(t, err)
+
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
 
 		mockChannelService.AssertCalled(t, "Get", mockChannel.ID)
-		mockChannelService.AssertCalled(t, "IsChannelMember", mockChannel, authUser.ID)
+		mockChannelService.AssertCalled(t, "GetPermissions", mockChannel, authUser.ID)
 		mockUserService.AssertCalled(t, "Get", authUser.ID)
 		mockMessageService.AssertNotCalled(t, "UploadFile")
 		mockMessageService.AssertNotCalled(t, "CreateMessage")
@@ -568,6 +622,301 @@ func TestHandler_CreateMessage(t *testing.T) {
 		mockSocketService.AssertNotCalled(t, "EmitNewMessage")
 	})
 
+	t.Run("File exceeds the limit of its type", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockMessageService := new(mocks.MessageService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			UserService:    mockUserService,
+			MaxBodyBytes:   4 * 1024 * 1024,
+			FileSizeLimits: FileSizeLimits{FileKindDocument: 16},
+		})
+
+		body, contentType := getTestMultipartFile("notes.txt", "text/plain", []byte("more than sixteen bytes of notes"))
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, body)
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", contentType)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(getTestFieldErrorResponse("File", apperrors.FileTooLarge))
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockMessageService.AssertNotCalled(t, "UploadFile", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Uploads the file with the detected type", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockError := apperrors.NewInternal()
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("UploadFile", mock.Anything, mockChannel.ID, "application/pdf").Return(nil, mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			UserService:    mockUserService,
+		})
+
+		body, contentType := getTestMultipartFile("report", "application/octet-stream", []byte("%PDF-1.4 report"))
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, body)
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", contentType)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		mockMessageService.AssertExpectations(t)
+	})
+
+	t.Run("Successfully created reply", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		replyAuthor := fixture.GetMockUser()
+		mockReply := fixture.GetMockMessage(replyAuthor.ID, mockChannel.ID)
+		mockMessage := fixture.GetMockMessage(authUser.ID, mockChannel.ID)
+		mockMessage.ReplyId = &mockReply.ID
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("Get", replyAuthor.ID).Return(replyAuthor, nil)
+
+		params := model.Message{
+			UserId:    mockMessage.UserId,
+			ChannelId: mockMessage.ChannelId,
+			Text:      mockMessage.Text,
+			ReplyId:   &mockReply.ID,
+		}
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockReply.ID).Return(mockReply, nil)
+		mockMessageService.On("CreateMessage", &params).Return(mockMessage, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetMemberSettings", authUser.ID, mockGuild.ID).Return(&model.MemberSettings{}, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		response := model.MessageResponse{
+			Id:         mockMessage.ID,
+			Text:       mockMessage.Text,
+			CreatedAt:  mockMessage.CreatedAt,
+			UpdatedAt:  mockMessage.UpdatedAt,
+			Attachment: mockMessage.Attachment,
+			User: model.MemberResponse{
+				Id:        authUser.ID,
+				Username:  authUser.Username,
+				Image:     authUser.Image,
+				IsOnline:  authUser.IsOnline,
+				CreatedAt: authUser.CreatedAt,
+				UpdatedAt: authUser.UpdatedAt,
+				IsFriend:  false,
+			},
+			Reactions: make([]model.ReactionResponse, 0),
+			ReplyTo: &model.MessageReference{
+				Id:       mockReply.ID,
+				Text:     mockReply.Text,
+				UserId:   replyAuthor.ID,
+				Username: replyAuthor.Username,
+			},
+		}
+
+		mockSocketService.On("StopTyping", mockChannel.ID, authUser.ID).Return()
+		mockSocketService.On("EmitNewMessage", mockChannel.ID, &response).Return()
+		mockChannelService.On("UpdateChannel", mockChannel).Return(nil)
+		mockSocketService.On("EmitNewNotification", mockGuild.ID, mockChannel.ID)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			GuildService:   mockGuildService,
+			SocketService:  mockSocketService,
+			UserService:    mockUserService,
+		})
+
+		form := url.Values{}
+		form.Add("text", *mockMessage.Text)
+		form.Add("replyTo", mockReply.ID)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockMessageService.AssertExpectations(t)
+		mockUserService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Notifies mentioned users", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mentioned := fixture.GetMockUser()
+		text := fmt.Sprintf("Hello <@%s>", mentioned.ID)
+		mockMessage := fixture.GetMockMessage(authUser.ID, mockChannel.ID)
+		mockMessage.Text = &text
+		mockMessage.Mentions = []model.Mention{{
+			MessageId: mockMessage.ID,
+			UserId:    mentioned.ID,
+			ChannelId: mockChannel.ID,
+			GuildId:   &mockGuild.ID,
+		}}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+		mockChannelService.On("UpdateChannel", mockChannel).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		params := model.Message{
+			UserId:    authUser.ID,
+			ChannelId: mockChannel.ID,
+			Text:      &text,
+		}
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("CreateMessage", &params).Return(mockMessage, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetMemberSettings", authUser.ID, mockGuild.ID).Return(&model.MemberSettings{}, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("StopTyping", mockChannel.ID, authUser.ID).Return()
+		mockSocketService.On("EmitNewMessage", mockChannel.ID, mock.Anything).Return()
+		mockSocketService.On("EmitNewMention", mentioned.ID, &mockMessage.Mentions[0])
+		mockSocketService.On("EmitNewNotification", mockGuild.ID, mockChannel.ID)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			GuildService:   mockGuildService,
+			SocketService:  mockSocketService,
+			UserService:    mockUserService,
+		})
+
+		form := url.Values{}
+		form.Add("text", text)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+
+		mockMessageService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Reply to message of another channel", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockReply := fixture.GetMockMessage("", fixture.RandID())
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockReply.ID).Return(mockReply, nil)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+			UserService:    mockUserService,
+		})
+
+		form := url.Values{}
+		form.Add("text", fixture.RandStringRunes(10))
+		form.Add("replyTo", mockReply.ID)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("message", mockReply.ID)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitNewMessage", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Image Message Creation Success", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 		mockChannel := fixture.GetMockChannel(mockGuild.ID)
@@ -591,7 +940,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
-		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockUserService := new(mocks.UserService)
 		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
@@ -602,7 +951,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 			Attachment: attachment,
 		}
 		mockMessageService := new(mocks.MessageService)
-		mockMessageService.On("UploadFile", formFile, mockChannel.ID).Return(attachment, nil)
+		mockMessageService.On("UploadFile", formFile, mockChannel.ID, "image/png").Return(attachment, nil)
 		mockMessageService.On("CreateMessage", &params).Return(mockMessage, nil)
 
 		mockGuildService := new(mocks.GuildService)
@@ -624,8 +973,10 @@ func TestHandler_CreateMessage(t *testing.T) {
 				UpdatedAt: authUser.UpdatedAt,
 				IsFriend:  false,
 			},
+			Reactions: make([]model.ReactionResponse, 0),
 		}
 
+		mockSocketService.On("StopTyping", mockChannel.ID, authUser.ID).Return()
 		mockSocketService.On("EmitNewMessage", mockChannel.ID, &response).Return()
 		mockChannelService.On("UpdateChannel", mockChannel).Return(nil)
 		mockSocketService.On("EmitNewNotification", mockGuild.ID, mockChannel.ID)
@@ -673,7 +1024,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
-		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockUserService := new(mocks.UserService)
 		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
@@ -702,8 +1053,10 @@ func TestHandler_CreateMessage(t *testing.T) {
 				UpdatedAt: authUser.UpdatedAt,
 				IsFriend:  false,
 			},
+			Reactions: make([]model.ReactionResponse, 0),
 		}
 
+		mockSocketService.On("StopTyping", mockChannel.ID, authUser.ID).Return()
 		mockSocketService.On("EmitNewMessage", mockChannel.ID, &response).Return()
 		mockSocketService.On("EmitNewDMNotification", mockChannel.ID, authUser).Return()
 		mockChannelService.On("OpenDMForAll", mockChannel.ID).Return(nil)
@@ -1105,6 +1458,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
@@ -1242,7 +1596,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 		mockSocketService.AssertNotCalled(t, "EmitDeleteMessage")
 	})
 
-	t.Run("Delete in guild - guild owner", func(t *testing.T) {
+	t.Run("Delete in guild - moderator", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild(authUser.ID)
 		mockChannel := fixture.GetMockChannel(mockGuild.ID)
 		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
@@ -1253,21 +1607,26 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageMessages).Return(true)
+
 		mockSocketService := new(mocks.SocketService)
 		mockSocketService.On("EmitDeleteMessage", mockChannel.ID, mockMessage.ID)
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			MessageService: mockMessageService,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			MessageService:    mockMessageService,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		// a response recorder for getting written http response
@@ -1293,7 +1652,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 		mockSocketService.AssertExpectations(t)
 	})
 
-	t.Run("Delete in guild - not the guild owner", func(t *testing.T) {
+	t.Run("Delete in guild - missing permissions", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 		mockChannel := fixture.GetMockChannel(mockGuild.ID)
 		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
@@ -1303,20 +1662,25 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageMessages).Return(false)
+
 		mockSocketService := new(mocks.SocketService)
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
-			R:              router,
-			MessageService: mockMessageService,
-			GuildService:   mockGuildService,
-			ChannelService: mockChannelService,
-			SocketService:  mockSocketService,
+			R:                 router,
+			MessageService:    mockMessageService,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
 		})
 
 		// a response recorder for getting written http response
@@ -1346,6 +1710,54 @@ func TestHandler_Delete_Message(t *testing.T) {
 		mockSocketService.AssertNotCalled(t, "EmitDeleteMessage")
 	})
 
+	t.Run("Delete in guild - cannot view the channel", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.Permission(0), mockError)
+
+		mockGuildService := new(mocks.GuildService)
+		mockPermissionService := new(mocks.PermissionService)
+		mockSocketService := new(mocks.SocketService)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			MessageService:    mockMessageService,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/messages/"+mockMessage.ID, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockPermissionService.AssertNotCalled(t, "HasPermission", mock.Anything, mock.Anything, mock.Anything)
+		mockMessageService.AssertNotCalled(t, "DeleteMessage", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitDeleteMessage", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Delete in DM - Author", func(t *testing.T) {
 		mockChannel := fixture.GetMockDMChannel()
 		mockMessage := fixture.GetMockMessage(authUser.ID, mockChannel.ID)
@@ -1356,6 +1768,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockSocketService := new(mocks.SocketService)
 		mockSocketService.On("EmitDeleteMessage", mockChannel.ID, mockMessage.ID)
@@ -1401,6 +1814,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockSocketService := new(mocks.SocketService)
 
@@ -1491,6 +1905,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
 
 		mockGuildService := new(mocks.GuildService)
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
@@ -1532,3 +1947,231 @@ func TestHandler_Delete_Message(t *testing.T) {
 		mockSocketService.AssertNotCalled(t, "EmitDeleteMessage")
 	})
 }
+
+func TestHandler_GetMessageHistory(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Moderator fetched history of deleted message", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+		deletedAt := time.Now()
+		previous := fixture.RandStringRunes(100)
+
+		mockHistory := &model.MessageHistory{
+			Id:        mockMessage.ID,
+			ChannelId: mockChannel.ID,
+			UserId:    mockMessage.UserId,
+			Text:      mockMessage.Text,
+			CreatedAt: mockMessage.CreatedAt,
+			UpdatedAt: mockMessage.UpdatedAt,
+			DeletedAt: &deletedAt,
+			Revisions: []model.MessageRevision{
+				{Text: &previous, CreatedAt: mockMessage.UpdatedAt},
+			},
+		}
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("GetHistory", mockMessage.ID).Return(mockHistory, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageMessages).Return(true)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			MessageService:    mockMessageService,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			PermissionService: mockPermissionService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/messages/%s/history", mockMessage.ID), nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockHistory)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertExpectations(t)
+		mockChannelService.AssertExpectations(t)
+		mockGuildService.AssertExpectations(t)
+		mockPermissionService.AssertExpectations(t)
+	})
+
+	t.Run("Not the author or a moderator", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+
+		mockHistory := &model.MessageHistory{
+			Id:        mockMessage.ID,
+			ChannelId: mockChannel.ID,
+			UserId:    mockMessage.UserId,
+			Text:      mockMessage.Text,
+			Revisions: make([]model.MessageRevision, 0),
+		}
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("GetHistory", mockMessage.ID).Return(mockHistory, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageMessages).Return(false)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			MessageService:    mockMessageService,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			PermissionService: mockPermissionService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/messages/%s/history", mockMessage.ID), nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MessageHistoryError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockPermissionService.AssertExpectations(t)
+	})
+
+	t.Run("Moderator without access to the channel", func(t *testing.T) {
+		testCases := []struct {
+			name        string
+			permissions model.Permission
+			err         error
+		}{
+			{
+				name: "Cannot view the channel",
+				err:  apperrors.NewAuthorization(apperrors.MissingPermissions),
+			},
+			{
+				name:        "Cannot read the message history",
+				permissions: model.ViewChannel | model.SendMessages,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockGuild := fixture.GetMockGuild(authUser.ID)
+				mockChannel := fixture.GetMockChannel(mockGuild.ID)
+				mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+
+				mockHistory := &model.MessageHistory{
+					Id:        mockMessage.ID,
+					ChannelId: mockChannel.ID,
+					UserId:    mockMessage.UserId,
+					Text:      mockMessage.Text,
+					Revisions: make([]model.MessageRevision, 0),
+				}
+
+				mockMessageService := new(mocks.MessageService)
+				mockMessageService.On("GetHistory", mockMessage.ID).Return(mockHistory, nil)
+
+				mockChannelService := new(mocks.ChannelService)
+				mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+				mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(tc.permissions, tc.err)
+
+				mockPermissionService := new(mocks.PermissionService)
+
+				router := getAuthenticatedTestRouter(authUser.ID)
+
+				NewHandler(&Config{
+					R:                 router,
+					MessageService:    mockMessageService,
+					GuildService:      new(mocks.GuildService),
+					ChannelService:    mockChannelService,
+					PermissionService: mockPermissionService,
+				})
+
+				rr := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/messages/%s/history", mockMessage.ID), nil)
+				assert.NoError(t, err)
+
+				router.ServeHTTP(rr, request)
+
+				mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
+				respBody, err := json.Marshal(gin.H{
+					"error": mockError,
+				})
+				assert.NoError(t, err)
+
+				assert.Equal(t, mockError.Status(), rr.Code)
+				assert.Equal(t, respBody, rr.Body.Bytes())
+
+				mockPermissionService.AssertNotCalled(t, "HasPermission", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("Message not found", func(t *testing.T) {
+		id := fixture.RandID()
+		mockError := apperrors.NewNotFound("message", id)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("GetHistory", id).Return(nil, mockError)
+
+		mockChannelService := new(mocks.ChannelService)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			MessageService: mockMessageService,
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/messages/%s/history", id), nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertNotCalled(t, "Get", mock.Anything)
+	})
+}
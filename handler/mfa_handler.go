@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+	"strings"
+)
+
+/*
+ * MfaHandler contains all routes related to two-factor authentication (/api/account/mfa)
+ */
+
+// MfaCodeHeader contains the two-factor code for requests without a body
+const MfaCodeHeader = "X-MFA-Code"
+
+// mfaCodeReq specifies the input form for actions that require a two-factor code
+type mfaCodeReq struct {
+	// 6 digit TOTP code or a recovery code if allowed
+	Code string `json:"code"`
+} //@name MfaCodeRequest
+
+func (r mfaCodeReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Code, validation.Required, validation.Length(6, 16)),
+	)
+}
+
+func (r *mfaCodeReq) sanitize() {
+	r.Code = strings.TrimSpace(r.Code)
+}
+
+// SetupMfa generates a new TOTP secret for the current user
+// SetupMfa godoc
+// @Tags Account
+// @Summary Set up Two-Factor Authentication
+// @Produce  json
+// @Success 200 {object} model.MfaSetup
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/mfa/setup [post]
+func (h *Handler) SetupMfa(c *gin.Context) {
+	user, ok := h.getMfaUser(c)
+
+	if !ok {
+		return
+	}
+
+	setup, err := h.userService.SetupMfa(user)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// EnableMfa verifies the TOTP code of the pending secret and enables two-factor authentication.
+// The recovery codes are only returned once.
+// EnableMfa godoc
+// @Tags Account
+// @Summary Enable Two-Factor Authentication
+// @Accepts json
+// @Produce  json
+// @Param request body mfaCodeReq true "TOTP code"
+// @Success 200 {object} model.RecoveryCodes
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/mfa/enable [post]
+func (h *Handler) EnableMfa(c *gin.Context) {
+	var req mfaCodeReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	user, ok := h.getMfaUser(c)
+
+	if !ok {
+		return
+	}
+
+	codes, err := h.userService.EnableMfa(user, req.Code)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// DisableMfa disables two-factor authentication using a TOTP or recovery code
+// DisableMfa godoc
+// @Tags Account
+// @Summary Disable Two-Factor Authentication
+// @Accepts json
+// @Produce  json
+// @Param request body mfaCodeReq true "TOTP or recovery code"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/mfa/disable [post]
+func (h *Handler) DisableMfa(c *gin.Context) {
+	var req mfaCodeReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	user, ok := h.getMfaUser(c)
+
+	if !ok {
+		return
+	}
+
+	if err := h.userService.DisableMfa(user, req.Code); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user
+// RegenerateRecoveryCodes godoc
+// @Tags Account
+// @Summary Regenerate Recovery Codes
+// @Accepts json
+// @Produce  json
+// @Param request body mfaCodeReq true "TOTP code"
+// @Success 200 {object} model.RecoveryCodes
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/mfa/recovery-codes [post]
+func (h *Handler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req mfaCodeReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	user, ok := h.getMfaUser(c)
+
+	if !ok {
+		return
+	}
+
+	codes, err := h.userService.RegenerateRecoveryCodes(user, req.Code)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// mfaLoginReq specifies the input form for completing a login with two-factor authentication
+type mfaLoginReq struct {
+	// The ticket returned by the login
+	Ticket string `json:"ticket"`
+	// 6 digit TOTP code or a recovery code
+	Code string `json:"code"`
+} //@name MfaLoginRequest
+
+func (r mfaLoginReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Ticket, validation.Required),
+		validation.Field(&r.Code, validation.Required, validation.Length(6, 16)),
+	)
+}
+
+func (r *mfaLoginReq) sanitize() {
+	r.Ticket = strings.TrimSpace(r.Ticket)
+	r.Code = strings.TrimSpace(r.Code)
+}
+
+// LoginMfa completes the login of a user with two-factor authentication
+// LoginMfa godoc
+// @Tags Account
+// @Summary Two-Factor Login
+// @Accept  json
+// @Produce  json
+// @Param request body mfaLoginReq true "Ticket and code"
+// @Success 200 {object} model.User
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/mfa/login [post]
+func (h *Handler) LoginMfa(c *gin.Context) {
+	var req mfaLoginReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	user, err := h.userService.LoginWithMfa(c.Request.Context(), req.Ticket, req.Code)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if err := h.setUserSession(c, user.ID); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// startLogin creates the session of the user or the two-factor challenge
+// if the user enabled two-factor authentication
+func (h *Handler) startLogin(c *gin.Context, user *model.User) {
+	if user.MfaEnabled {
+		challenge, err := h.userService.CreateMfaChallenge(c.Request.Context(), user)
+
+		if err != nil {
+			c.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	if err := h.setUserSession(c, user.ID); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// getMfaUser returns the current user or writes the error response
+func (h *Handler) getMfaUser(c *gin.Context) (*model.User, bool) {
+	userId := c.MustGet("userId").(string)
+	user, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	return user, true
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_Login_Mfa(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Returns a challenge instead of a session", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.MfaEnabled = true
+		password := "password123"
+
+		challenge := &model.MfaChallenge{
+			MfaRequired: true,
+			Ticket:      fixture.RandStr(32),
+		}
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Login", mockUser.Email, password).Return(mockUser, nil)
+		mockUserService.On("CreateMfaChallenge", mock.Anything, mockUser).Return(challenge, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"email":    mockUser.Email,
+			"password": password,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/login", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(challenge)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+		mockUserService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_LoginMfa(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	ticket := fixture.RandStr(32)
+
+	t.Run("Successfully logged in", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.MfaEnabled = true
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("LoginWithMfa", mock.Anything, ticket, "123456").Return(mockUser, nil)
+
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.
+			On("CreateSession", mock.Anything, mockUser.ID, mock.Anything, mock.Anything).
+			Return(&model.Session{Id: "session-id"}, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    mockUserService,
+			SessionService: mockSessionService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"ticket": ticket,
+			"code":   " 123456 ",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/mfa/login", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockUser)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.NotEmpty(t, rr.Header().Get("Set-Cookie"))
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		mockError := apperrors.NewAuthorization(apperrors.InvalidMfaCode)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("LoginWithMfa", mock.Anything, ticket, "654321").Return(nil, mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"ticket": ticket,
+			"code":   "654321",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/mfa/login", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+	})
+}
+
+func TestHandler_EnableMfa(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Returns the recovery codes", func(t *testing.T) {
+		codes := &model.RecoveryCodes{Codes: []string{"ABCDE-FGHJK"}}
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("EnableMfa", authUser, "123456").Return(codes, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"code": "123456",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/mfa/enable", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(codes)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Missing code", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/mfa/enable", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "EnableMfa", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_DeleteGuild_Mfa(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+	authUser.MfaEnabled = true
+
+	t.Run("Code required", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockError := apperrors.NewAuthorization(apperrors.MfaCodeRequired)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("VerifyMfa", authUser, "").Return(mockError)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:             router,
+			GuildService:  mockGuildService,
+			UserService:   mockUserService,
+			SocketService: mockSocketService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/guilds/%s/delete", mockGuild.ID), nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockGuildService.AssertNotCalled(t, "DeleteGuild", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitDeleteGuild", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Code from header", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+		mockGuildService.On("DeleteGuild", mockGuild.ID).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("VerifyMfa", authUser, "123456").Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitDeleteGuild", mockGuild.ID, make([]string, 0)).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:             router,
+			GuildService:  mockGuildService,
+			UserService:   mockUserService,
+			SocketService: mockSocketService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/guilds/%s/delete", mockGuild.ID), nil)
+		assert.NoError(t, err)
+		request.Header.Set(MfaCodeHeader, "123456")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockGuildService.AssertExpectations(t)
+		mockUserService.AssertExpectations(t)
+	})
+}
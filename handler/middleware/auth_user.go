@@ -3,14 +3,24 @@ package middleware
 import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model"
 	"github.com/sentrionic/valkyrie/model/apperrors"
 	"log"
+	"net/http"
+	"strings"
 )
 
-// AuthUser checks if the request contains a valid session
-// and saves the session's userId in the context
-func AuthUser() gin.HandlerFunc {
+// AuthUser checks if the request contains a valid token or session
+// and saves the userId in the context. Requests authenticated by a token are marked with tokenAuth.
+// Tokens are sent in the Authorization header as either "Bot <token>" or "Bearer <token>"
+// If a sessionService is given, sessions must not have been revoked and their sessionId gets saved as well.
+func AuthUser(userService model.UserService, sessionService model.SessionService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if header := c.GetHeader("Authorization"); header != "" {
+			authToken(c, userService, header)
+			return
+		}
+
 		session := sessions.Default(c)
 		id := session.Get("userId")
 
@@ -24,8 +34,20 @@ func AuthUser() gin.HandlerFunc {
 		}
 
 		userId := id.(string)
+		sessionId, _ := session.Get("sessionId").(string)
+
+		if sessionService != nil {
+			if err := touchSession(c, sessionService, userId, sessionId); err != nil {
+				c.JSON(apperrors.Status(err), gin.H{
+					"error": err,
+				})
+				c.Abort()
+				return
+			}
+		}
 
 		c.Set("userId", userId)
+		c.Set("sessionId", sessionId)
 
 		// Recreate session to extend its lifetime
 		session.Set("userId", id)
@@ -36,3 +58,73 @@ func AuthUser() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// touchSession checks that the session has not been revoked.
+// Revoked sessions and sessions created before they were tracked get removed.
+func touchSession(c *gin.Context, sessionService model.SessionService, userId, sessionId string) error {
+	var err error = apperrors.NewAuthorization(apperrors.InvalidSession)
+
+	if sessionId != "" {
+		err = sessionService.TouchSession(c.Request.Context(), userId, sessionId)
+	}
+
+	if err != nil && apperrors.Status(err) == http.StatusUnauthorized {
+		session := sessions.Default(c)
+		session.Clear()
+		session.Options(sessions.Options{Path: "/", MaxAge: -1})
+		if e := session.Save(); e != nil {
+			log.Printf("Failed to clear the session: %v\n", e.Error())
+		}
+	}
+
+	return err
+}
+
+// authToken authenticates the request using the token in the Authorization header
+func authToken(c *gin.Context, userService model.UserService, header string) {
+	parts := strings.SplitN(header, " ", 2)
+
+	if len(parts) != 2 ||
+		(parts[0] != model.BotScheme && parts[0] != model.BearerScheme) ||
+		strings.TrimSpace(parts[1]) == "" {
+		e := apperrors.NewAuthorization(apperrors.InvalidToken)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		c.Abort()
+		return
+	}
+
+	user, err := userService.Authenticate(parts[0], strings.TrimSpace(parts[1]))
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		c.Abort()
+		return
+	}
+
+	c.Set("userId", user.ID)
+	c.Set("tokenAuth", true)
+
+	c.Next()
+}
+
+// RequireSession rejects requests that were authenticated by a token and has to be used after AuthUser.
+// Sessions, two-factor authentication and tokens can only be managed with a session,
+// so that a leaked token cannot be used to take over the account.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("tokenAuth") {
+			e := apperrors.NewForbidden(apperrors.SessionRequired)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"encoding/json"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
+	"github.com/sentrionic/valkyrie/mocks"
 	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
 	"github.com/sentrionic/valkyrie/service"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +14,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestAuthUser(t *testing.T) {
@@ -28,13 +32,21 @@ func TestAuthUser(t *testing.T) {
 		r.Use(func(c *gin.Context) {
 			session := sessions.Default(c)
 			session.Set("userId", uid)
+			session.Set("sessionId", "session-id")
 		})
 
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.On("TouchSession", mock.Anything, uid, "session-id").Return(nil)
+
 		var contextUserId string
+		var contextSessionId string
+		var contextTokenAuth bool
 
-		r.GET("/api/accounts", AuthUser(), func(c *gin.Context) {
+		r.GET("/api/accounts", AuthUser(new(mocks.UserService), mockSessionService), func(c *gin.Context) {
 			contextKeyVal, _ := c.Get("userId")
 			contextUserId = contextKeyVal.(string)
+			contextSessionId = c.GetString("sessionId")
+			contextTokenAuth = c.GetBool("tokenAuth")
 		})
 
 		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
@@ -42,6 +54,58 @@ func TestAuthUser(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 		assert.Equal(t, contextUserId, uid)
+		assert.Equal(t, "session-id", contextSessionId)
+		assert.False(t, contextTokenAuth)
+		mockSessionService.AssertExpectations(t)
+	})
+
+	t.Run("Revoked Session", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		r.Use(func(c *gin.Context) {
+			session := sessions.Default(c)
+			session.Set("userId", uid)
+			session.Set("sessionId", "session-id")
+		})
+
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.On("TouchSession", mock.Anything, uid, "session-id").
+			Return(apperrors.NewAuthorization(apperrors.InvalidSession))
+
+		r.GET("/api/accounts", AuthUser(new(mocks.UserService), mockSessionService))
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockSessionService.AssertExpectations(t)
+	})
+
+	t.Run("Untracked Session", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		r.Use(func(c *gin.Context) {
+			session := sessions.Default(c)
+			session.Set("userId", uid)
+		})
+
+		mockSessionService := new(mocks.SessionService)
+
+		r.GET("/api/accounts", AuthUser(new(mocks.UserService), mockSessionService))
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockSessionService.AssertNotCalled(t, "TouchSession", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("Missing Session", func(t *testing.T) {
@@ -52,7 +116,7 @@ func TestAuthUser(t *testing.T) {
 		store := cookie.NewStore([]byte("secret"))
 		r.Use(sessions.Sessions(model.CookieName, store))
 
-		r.GET("/api/accounts", AuthUser())
+		r.GET("/api/accounts", AuthUser(new(mocks.UserService), new(mocks.SessionService)))
 
 		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
 
@@ -60,4 +124,149 @@ func TestAuthUser(t *testing.T) {
 
 		assert.Equal(t, http.StatusUnauthorized, rr.Code)
 	})
+
+	t.Run("Adds the token's userId to context", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		mockUser := &model.User{BaseModel: model.BaseModel{ID: uid}, IsBot: true}
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Authenticate", model.BotScheme, "secret-token").Return(mockUser, nil)
+
+		var contextUserId string
+		var contextTokenAuth bool
+
+		r.GET("/api/accounts", AuthUser(mockUserService, new(mocks.SessionService)), func(c *gin.Context) {
+			contextKeyVal, _ := c.Get("userId")
+			contextUserId = contextKeyVal.(string)
+			contextTokenAuth = c.GetBool("tokenAuth")
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
+		request.Header.Set("Authorization", "Bot secret-token")
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, contextUserId, uid)
+		assert.True(t, contextTokenAuth)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		// A valid session must not be used if the token is invalid
+		r.Use(func(c *gin.Context) {
+			session := sessions.Default(c)
+			session.Set("userId", uid)
+		})
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Authenticate", model.BearerScheme, "unknown").
+			Return(nil, apperrors.NewAuthorization(apperrors.InvalidToken))
+
+		r.GET("/api/accounts", AuthUser(mockUserService, new(mocks.SessionService)))
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
+		request.Header.Set("Authorization", "Bearer unknown")
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown scheme", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		mockUserService := new(mocks.UserService)
+
+		r.GET("/api/accounts", AuthUser(mockUserService, new(mocks.SessionService)))
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
+		request.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockUserService.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRequireSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	uid, _ := service.GenerateId()
+
+	t.Run("Session", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		r.Use(func(c *gin.Context) {
+			session := sessions.Default(c)
+			session.Set("userId", uid)
+			session.Set("sessionId", "session-id")
+		})
+
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.On("TouchSession", mock.Anything, uid, "session-id").Return(nil)
+
+		called := false
+
+		r.GET("/api/account/tokens", AuthUser(new(mocks.UserService), mockSessionService), RequireSession(), func(c *gin.Context) {
+			called = true
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/account/tokens", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, called)
+	})
+
+	t.Run("Token", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		mockUser := &model.User{BaseModel: model.BaseModel{ID: uid}}
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Authenticate", model.BearerScheme, "secret-token").Return(mockUser, nil)
+
+		called := false
+
+		r.GET("/api/account/tokens", AuthUser(mockUserService, new(mocks.SessionService)), RequireSession(), func(c *gin.Context) {
+			called = true
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/account/tokens", http.NoBody)
+		request.Header.Set("Authorization", "Bearer secret-token")
+		r.ServeHTTP(rr, request)
+
+		e := apperrors.NewForbidden(apperrors.SessionRequired)
+		respBody, _ := json.Marshal(gin.H{
+			"error": e,
+		})
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.False(t, called)
+		mockUserService.AssertExpectations(t)
+	})
 }
@@ -1,5 +1,12 @@
 package handler
 
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+)
+
 var validImageTypes = map[string]bool{
 	"image/jpeg": true,
 	"image/png":  true,
@@ -13,17 +20,73 @@ func isAllowedImageType(mimeType string) bool {
 	return exists
 }
 
-var validFileTypes = map[string]bool{
-	"image/jpeg": true,
-	"image/png":  true,
-	"audio/mp3":  true,
-	"audio/wave": true,
+// Kinds of attachments, each kind has its own size limit
+const (
+	FileKindImage    = "image"
+	FileKindAudio    = "audio"
+	FileKindVideo    = "video"
+	FileKindDocument = "document"
+	FileKindArchive  = "archive"
+)
+
+// FileSizeLimits contains the maximum size in bytes per kind of attachment.
+// Kinds without a limit fall back to MaxBodyBytes.
+type FileSizeLimits map[string]int64
+
+// validFileTypes maps the allowed attachment types to their kind.
+// The types are the ones detected by http.DetectContentType.
+var validFileTypes = map[string]string{
+	"image/jpeg":                   FileKindImage,
+	"image/png":                    FileKindImage,
+	"image/gif":                    FileKindImage,
+	"image/webp":                   FileKindImage,
+	"audio/mpeg":                   FileKindAudio,
+	"audio/wave":                   FileKindAudio,
+	"audio/aiff":                   FileKindAudio,
+	"audio/midi":                   FileKindAudio,
+	"application/ogg":              FileKindAudio,
+	"video/mp4":                    FileKindVideo,
+	"video/webm":                   FileKindVideo,
+	"video/avi":                    FileKindVideo,
+	"application/pdf":              FileKindDocument,
+	"text/plain":                   FileKindDocument,
+	"application/zip":              FileKindArchive,
+	"application/x-gzip":           FileKindArchive,
+	"application/x-rar-compressed": FileKindArchive,
 }
 
-// isAllowedFileType determines if the file is among types defined
-// in map of allowed file types
-func isAllowedFileType(mimeType string) bool {
-	_, exists := validFileTypes[mimeType]
+// fileKind returns the kind of the file type and
+// false if the type is not allowed as an attachment
+func fileKind(mimeType string) (string, bool) {
+	kind, exists := validFileTypes[mimeType]
 
-	return exists
+	return kind, exists
+}
+
+// detectContentType determines the type of the file by sniffing its first bytes.
+// The Content-Type sent by the client is ignored.
+func detectContentType(header *multipart.FileHeader) (string, error) {
+	file, err := header.Open()
+
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	// DetectContentType considers at most 512 bytes
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(file, buf)
+
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+
+	if err != nil {
+		return "", err
+	}
+
+	return mediaType, nil
 }
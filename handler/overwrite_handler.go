@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+)
+
+/*
+ * OverwriteHandler contains all routes related to channel permission overwrites (/api/channels/:id/overwrites)
+ */
+
+// GetOverwrites returns the permission overwrites of the given channel
+// GetOverwrites godoc
+// @Tags Channels
+// @Summary Get Channel Permission Overwrites
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Success 200 {array} model.OverwriteResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /channels/{id}/overwrites [get]
+func (h *Handler) GetOverwrites(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	channel, _, ok := h.getManageableChannel(c, userId, channelId)
+
+	if !ok {
+		return
+	}
+
+	overwrites := make([]model.OverwriteResponse, 0)
+	for _, overwrite := range channel.Overwrites {
+		overwrites = append(overwrites, overwrite.SerializeOverwrite())
+	}
+
+	c.JSON(http.StatusOK, overwrites)
+}
+
+// overwriteReq specifies the input form for setting a permission overwrite
+type overwriteReq struct {
+	// Either role or member. Use the guild ID as the role to target everyone
+	Type string `json:"type"`
+	// Bitfield of the allowed channel permissions
+	Allow model.Permission `json:"allow"`
+	// Bitfield of the denied channel permissions
+	Deny model.Permission `json:"deny"`
+} //@name OverwriteRequest
+
+func (r overwriteReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Type, validation.Required, validation.In(model.RoleOverwrite, model.MemberOverwrite)),
+		validation.Field(&r.Allow, validation.By(isChannelPermission)),
+		validation.Field(&r.Deny, validation.By(isChannelPermission)),
+	)
+}
+
+// isChannelPermission checks that the bitfield only contains channel permissions
+func isChannelPermission(value interface{}) error {
+	permissions, _ := value.(model.Permission)
+	if permissions&^model.ChannelPermissions != 0 {
+		return errors.New(apperrors.ChannelPermissionError)
+	}
+	return nil
+}
+
+// SetOverwrite creates or updates the overwrite of the given target in the channel
+// SetOverwrite godoc
+// @Tags Channels
+// @Summary Set Channel Permission Overwrite
+// @Accepts json
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Param targetId path string true "Role or Member ID"
+// @Param request body overwriteReq true "Set Overwrite"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/overwrites/{targetId} [put]
+func (h *Handler) SetOverwrite(c *gin.Context) {
+	var req overwriteReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+	targetId := c.Param("targetId")
+
+	channel, guild, ok := h.getManageableChannel(c, userId, channelId)
+
+	if !ok {
+		return
+	}
+
+	// Check that the target is a role or a member of the guild
+	if req.Type == model.RoleOverwrite && targetId != guild.ID && !hasRole(guild, targetId) {
+		e := apperrors.NewNotFound("role", targetId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if req.Type == model.MemberOverwrite && !isMember(guild, targetId) {
+		e := apperrors.NewNotFound("member", targetId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	overwrite := model.PermissionOverwrite{
+		ChannelId: channel.ID,
+		TargetId:  targetId,
+		Type:      req.Type,
+		Allow:     req.Allow,
+		Deny:      req.Deny &^ req.Allow,
+	}
+
+	if err := h.channelService.SetOverwrite(&overwrite); err != nil {
+		log.Printf("Failed to set overwrite: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Emit the channel changes to the guild members
+	response := channel.SerializeChannel()
+	h.socketService.EmitEditChannel(guild.ID, &response)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// DeleteOverwrite removes the overwrite of the given target from the channel
+// DeleteOverwrite godoc
+// @Tags Channels
+// @Summary Delete Channel Permission Overwrite
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Param targetId path string true "Role or Member ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/overwrites/{targetId} [delete]
+func (h *Handler) DeleteOverwrite(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+	targetId := c.Param("targetId")
+
+	channel, guild, ok := h.getManageableChannel(c, userId, channelId)
+
+	if !ok {
+		return
+	}
+
+	if err := h.channelService.DeleteOverwrite(channel.ID, targetId); err != nil {
+		log.Printf("Failed to delete overwrite: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Emit the channel changes to the guild members
+	response := channel.SerializeChannel()
+	h.socketService.EmitEditChannel(guild.ID, &response)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// getManageableChannel returns the guild channel and its guild if the user is allowed
+// to manage the channel. Otherwise it writes the error response.
+func (h *Handler) getManageableChannel(c *gin.Context, userId, channelId string) (*model.Channel, *model.Guild, bool) {
+	channel, err := h.channelService.Get(channelId)
+
+	// DMs do not have overwrites
+	if err != nil || channel.GuildID == nil {
+		e := apperrors.NewNotFound("channel", channelId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, nil, false
+	}
+
+	guild, err := h.guildService.GetGuild(*channel.GuildID)
+
+	if err != nil {
+		e := apperrors.NewNotFound("guild", *channel.GuildID)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, nil, false
+	}
+
+	if !h.permissionService.HasPermission(userId, guild, model.ManageChannels) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, nil, false
+	}
+
+	return channel, guild, true
+}
+
+// hasRole checks if the given role belongs to the guild
+func hasRole(guild *model.Guild, roleId string) bool {
+	for _, v := range guild.Roles {
+		if v.ID == roleId {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_SetOverwrite(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully set everyone overwrite", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		overwrite := &model.PermissionOverwrite{
+			ChannelId: mockChannel.ID,
+			TargetId:  mockGuild.ID,
+			Type:      model.RoleOverwrite,
+			Deny:      model.SendMessages,
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("SetOverwrite", overwrite).Return(nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
+		response := mockChannel.SerializeChannel()
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitEditChannel", mockGuild.ID, &response)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"type": model.RoleOverwrite,
+			"deny": model.SendMessages,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/overwrites/%s", mockChannel.ID, mockGuild.ID)
+		request, err := http.NewRequest(http.MethodPut, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockGuildService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Role not found", func(t *testing.T) {
+		roleId := fixture.RandID()
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(true)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"type":  model.RoleOverwrite,
+			"allow": model.ViewChannel,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/overwrites/%s", mockChannel.ID, roleId)
+		request, err := http.NewRequest(http.MethodPut, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("role", roleId)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertNotCalled(t, "SetOverwrite", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitEditChannel", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing permissions", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageChannels).Return(false)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"type":  model.MemberOverwrite,
+			"allow": model.ViewChannel,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/overwrites/%s", mockChannel.ID, authUser.ID)
+		request, err := http.NewRequest(http.MethodPut, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertNotCalled(t, "SetOverwrite", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitEditChannel", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_SetOverwrite_BadRequest(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	mockUser := fixture.GetMockUser()
+	router := getAuthenticatedTestRouter(mockUser.ID)
+
+	mockChannelService := new(mocks.ChannelService)
+
+	NewHandler(&Config{
+		R:              router,
+		ChannelService: mockChannelService,
+	})
+
+	testCases := []struct {
+		name string
+		body gin.H
+	}{
+		{
+			name: "Type required",
+			body: gin.H{},
+		},
+		{
+			name: "Invalid type",
+			body: gin.H{
+				"type": fixture.RandStr(6),
+			},
+		},
+		{
+			name: "Not a channel permission",
+			body: gin.H{
+				"type":  model.MemberOverwrite,
+				"allow": model.ManageGuild,
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			reqBody, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			reqUrl := fmt.Sprintf("/api/channels/%s/overwrites/%s", fixture.RandID(), fixture.RandID())
+			request, err := http.NewRequest(http.MethodPut, reqUrl, bytes.NewBuffer(reqBody))
+			assert.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(rr, request)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockChannelService.AssertNotCalled(t, "Get")
+			mockChannelService.AssertNotCalled(t, "SetOverwrite")
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+)
+
+/*
+ * PinHandler contains all routes related to pinned messages
+ * (/api/channels/:id/pins and /api/messages/:messageId/pin)
+ */
+
+// GetPins returns the pinned messages of the given channel
+// GetPins godoc
+// @Tags Channels
+// @Summary Get Channel Pins
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Success 200 {array} model.MessageResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /channels/{id}/pins [get]
+func (h *Handler) GetPins(c *gin.Context) {
+	channelId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Check if the user has access to said channel
+	permissions, err := h.channelService.GetPermissions(channel, userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if !permissions.Has(model.ReadMessageHistory) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	messages, err := h.messageService.GetPinnedMessages(userId, channel)
+
+	if err != nil {
+		e := apperrors.NewNotFound("messages", channelId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// If the channel does not have any pins, return an empty array
+	if len(*messages) == 0 {
+		var empty = make([]model.MessageResponse, 0)
+		c.JSON(http.StatusOK, empty)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+// PinMessage pins the given message in its channel
+// PinMessage godoc
+// @Tags Messages
+// @Summary Pin Message
+// @Produce  json
+// @Param messageId path string true "Message ID"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /messages/{messageId}/pin [put]
+func (h *Handler) PinMessage(c *gin.Context) {
+	message, ok := h.getPinnableMessage(c)
+
+	if !ok {
+		return
+	}
+
+	if err := h.messageService.PinMessage(message); err != nil {
+		log.Printf("Failed to pin message: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	h.socketService.EmitPinsUpdate(message.ChannelId, &model.PinsUpdate{
+		ChannelId: message.ChannelId,
+		MessageId: message.ID,
+		PinnedAt:  message.PinnedAt,
+	})
+
+	c.JSON(http.StatusOK, true)
+}
+
+// UnpinMessage removes the given message from the pins of its channel
+// UnpinMessage godoc
+// @Tags Messages
+// @Summary Unpin Message
+// @Produce  json
+// @Param messageId path string true "Message ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /messages/{messageId}/pin [delete]
+func (h *Handler) UnpinMessage(c *gin.Context) {
+	message, ok := h.getPinnableMessage(c)
+
+	if !ok {
+		return
+	}
+
+	if err := h.messageService.UnpinMessage(message); err != nil {
+		log.Printf("Failed to unpin message: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	h.socketService.EmitPinsUpdate(message.ChannelId, &model.PinsUpdate{
+		ChannelId: message.ChannelId,
+		MessageId: message.ID,
+		PinnedAt:  nil,
+	})
+
+	c.JSON(http.StatusOK, true)
+}
+
+// getPinnableMessage returns the message of the messageId param if the current user
+// is allowed to change its pin. Follows the same rules as deleting a message.
+// If the message cannot be pinned it writes the error to the response and returns false.
+func (h *Handler) getPinnableMessage(c *gin.Context) (*model.Message, bool) {
+	messageId := c.Param("messageId")
+	userId := c.MustGet("userId").(string)
+	message, err := h.messageService.Get(messageId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("message", messageId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	channel, err := h.channelService.Get(message.ChannelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("message", messageId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	// Check if the user has access to said channel
+	if _, err = h.channelService.GetPermissions(channel, userId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return nil, false
+	}
+
+	// Check if message author or moderator
+	if !channel.IsDM {
+		guild, err := h.guildService.GetGuild(*channel.GuildID)
+
+		if err != nil {
+			e := apperrors.NewNotFound("message", messageId)
+
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return nil, false
+		}
+
+		if message.UserId != userId && !h.permissionService.HasPermission(userId, guild, model.ManageMessages) {
+			e := apperrors.NewAuthorization(apperrors.PinMessageError)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return nil, false
+		}
+		// Only message author check required
+	} else {
+		if message.UserId != userId {
+			e := apperrors.NewAuthorization(apperrors.PinDMMessageError)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return nil, false
+		}
+	}
+
+	return message, true
+}
@@ -0,0 +1,370 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_GetPins(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully fetched pins", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		pinnedAt := time.Now()
+
+		response := make([]model.MessageResponse, 0)
+		for i := 0; i < 3; i++ {
+			mockMessage := fixture.GetMockMessageResponse("", mockChannel.ID)
+			mockMessage.PinnedAt = &pinnedAt
+			response = append(response, *mockMessage)
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("GetPinnedMessages", authUser.ID, mockChannel).Return(&response, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/pins", mockChannel.ID)
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockMessageService.AssertExpectations(t)
+	})
+
+	t.Run("Missing read message history permission", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.ViewChannel, nil)
+
+		mockMessageService := new(mocks.MessageService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/pins", mockChannel.ID)
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertNotCalled(t, "GetPinnedMessages", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_PinMessage(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Author successfully pinned message", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockMessage := fixture.GetMockMessage(authUser.ID, mockChannel.ID)
+		pinnedAt := time.Now()
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+		mockMessageService.
+			On("PinMessage", mockMessage).
+			Run(func(args mock.Arguments) {
+				mockMessage.PinnedAt = &pinnedAt
+			}).
+			Return(nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitPinsUpdate", mockChannel.ID, &model.PinsUpdate{
+			ChannelId: mockChannel.ID,
+			MessageId: mockMessage.ID,
+			PinnedAt:  &pinnedAt,
+		})
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			MessageService: mockMessageService,
+			GuildService:   mockGuildService,
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/messages/%s/pin", mockMessage.ID)
+		request, err := http.NewRequest(http.MethodPut, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertExpectations(t)
+		mockChannelService.AssertExpectations(t)
+		mockGuildService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Moderator successfully pinned message", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+		mockMessageService.On("PinMessage", mockMessage).Return(nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageMessages).Return(true)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitPinsUpdate", mockChannel.ID, mock.AnythingOfType("*model.PinsUpdate"))
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			MessageService:    mockMessageService,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			PermissionService: mockPermissionService,
+			SocketService:     mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/messages/%s/pin", mockMessage.ID)
+		request, err := http.NewRequest(http.MethodPut, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockMessageService.AssertExpectations(t)
+		mockPermissionService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Not the author or a moderator", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageMessages).Return(false)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			MessageService:    mockMessageService,
+			GuildService:      mockGuildService,
+			ChannelService:    mockChannelService,
+			PermissionService: mockPermissionService,
+			SocketService:     mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/messages/%s/pin", mockMessage.ID)
+		request, err := http.NewRequest(http.MethodPut, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.PinMessageError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertNotCalled(t, "PinMessage", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitPinsUpdate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Pin limit reached", func(t *testing.T) {
+		mockChannel := fixture.GetMockDMChannel()
+		mockMessage := fixture.GetMockMessage(authUser.ID, mockChannel.ID)
+		mockError := apperrors.NewBadRequest(apperrors.PinLimitError)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+		mockMessageService.On("PinMessage", mockMessage).Return(mockError)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			MessageService: mockMessageService,
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/messages/%s/pin", mockMessage.ID)
+		request, err := http.NewRequest(http.MethodPut, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertExpectations(t)
+		mockSocketService.AssertNotCalled(t, "EmitPinsUpdate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_UnpinMessage(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully unpinned message", func(t *testing.T) {
+		mockChannel := fixture.GetMockDMChannel()
+		mockMessage := fixture.GetMockMessage(authUser.ID, mockChannel.ID)
+		pinnedAt := time.Now()
+		mockMessage.PinnedAt = &pinnedAt
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+		mockMessageService.On("UnpinMessage", mockMessage).Return(nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitPinsUpdate", mockChannel.ID, &model.PinsUpdate{
+			ChannelId: mockChannel.ID,
+			MessageId: mockMessage.ID,
+			PinnedAt:  nil,
+		})
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			MessageService: mockMessageService,
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/messages/%s/pin", mockMessage.ID)
+		request, err := http.NewRequest(http.MethodDelete, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertExpectations(t)
+		mockChannelService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+ * ReactionHandler contains all routes related to message reactions (/api/messages/:messageId/reactions)
+ */
+
+// AddReaction adds the emoji reaction of the current user to the given message
+// AddReaction godoc
+// @Tags Messages
+// @Summary Add Reaction
+// @Produce  json
+// @Param messageId path string true "Message ID"
+// @Param emoji path string true "Emoji"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /messages/{messageId}/reactions/{emoji} [put]
+func (h *Handler) AddReaction(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	reaction, channelId, ok := h.getReaction(c, userId)
+
+	if !ok {
+		return
+	}
+
+	if err := h.messageService.AddReaction(reaction); err != nil {
+		log.Printf("Failed to add reaction: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Emit the reaction to the channel
+	h.socketService.EmitAddReaction(channelId, reaction)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// RemoveReaction removes the emoji reaction of the current user from the given message
+// RemoveReaction godoc
+// @Tags Messages
+// @Summary Remove Reaction
+// @Produce  json
+// @Param messageId path string true "Message ID"
+// @Param emoji path string true "Emoji"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /messages/{messageId}/reactions/{emoji} [delete]
+func (h *Handler) RemoveReaction(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	reaction, channelId, ok := h.getReaction(c, userId)
+
+	if !ok {
+		return
+	}
+
+	if err := h.messageService.RemoveReaction(reaction); err != nil {
+		log.Printf("Failed to remove reaction: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Emit the removal to the channel
+	h.socketService.EmitRemoveReaction(channelId, reaction)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// getReaction validates the emoji and checks that the user can see the message.
+// It returns the reaction and the channel ID of the message. Otherwise it writes the error response.
+func (h *Handler) getReaction(c *gin.Context, userId string) (*model.Reaction, string, bool) {
+	messageId := c.Param("messageId")
+	emoji := strings.TrimSpace(c.Param("emoji"))
+
+	if length := utf8.RuneCountInString(emoji); length == 0 || length > model.MaximumEmojiLen {
+		e := apperrors.NewBadRequest(apperrors.InvalidEmojiError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, "", false
+	}
+
+	message, err := h.messageService.Get(messageId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("message", messageId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, "", false
+	}
+
+	channel, err := h.channelService.Get(message.ChannelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("message", messageId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, "", false
+	}
+
+	// Only users that can read the message can react to it
+	permissions, err := h.channelService.GetPermissions(channel, userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return nil, "", false
+	}
+
+	if !permissions.Has(model.ReadMessageHistory) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, "", false
+	}
+
+	reaction := &model.Reaction{
+		MessageId: message.ID,
+		UserId:    userId,
+		Emoji:     emoji,
+	}
+
+	return reaction, channel.ID, true
+}
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestHandler_AddReaction(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+	emoji := "👍"
+
+	t.Run("Successfully added reaction", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel("")
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+
+		reaction := &model.Reaction{
+			MessageId: mockMessage.ID,
+			UserId:    authUser.ID,
+			Emoji:     emoji,
+		}
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+		mockMessageService.On("AddReaction", reaction).Return(nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitAddReaction", mockChannel.ID, reaction)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			MessageService: mockMessageService,
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/messages/%s/reactions/%s", mockMessage.ID, url.PathEscape(emoji))
+		request, err := http.NewRequest(http.MethodPut, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertExpectations(t)
+		mockChannelService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Emoji too long", func(t *testing.T) {
+		messageId := fixture.RandID()
+
+		mockMessageService := new(mocks.MessageService)
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/messages/%s/reactions/%s", messageId, strings.Repeat("a", model.MaximumEmojiLen+1))
+		request, err := http.NewRequest(http.MethodPut, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.InvalidEmojiError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertNotCalled(t, "Get", mock.Anything)
+		mockMessageService.AssertNotCalled(t, "AddReaction", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitAddReaction", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing permissions", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel("")
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.ViewChannel, nil)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			MessageService: mockMessageService,
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/messages/%s/reactions/%s", mockMessage.ID, url.PathEscape(emoji))
+		request, err := http.NewRequest(http.MethodPut, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertNotCalled(t, "AddReaction", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitAddReaction", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_RemoveReaction(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+	emoji := "🎉"
+
+	t.Run("Successfully removed reaction", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel("")
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+
+		reaction := &model.Reaction{
+			MessageId: mockMessage.ID,
+			UserId:    authUser.ID,
+			Emoji:     emoji,
+		}
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+		mockMessageService.On("RemoveReaction", reaction).Return(nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitRemoveReaction", mockChannel.ID, reaction)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			MessageService: mockMessageService,
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/messages/%s/reactions/%s", mockMessage.ID, url.PathEscape(emoji))
+		request, err := http.NewRequest(http.MethodDelete, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertExpectations(t)
+		mockChannelService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Message not found", func(t *testing.T) {
+		messageId := fixture.RandID()
+
+		mockError := apperrors.NewNotFound("message", messageId)
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", messageId).Return(nil, mockError)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/messages/%s/reactions/%s", messageId, url.PathEscape(emoji))
+		request, err := http.NewRequest(http.MethodDelete, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertNotCalled(t, "RemoveReaction", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitRemoveReaction", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+)
+
+/*
+ * ReadStateHandler contains all routes related to read states (/api/channels/:id/ack)
+ */
+
+// ackReq specifies the input form for acknowledging a channel
+type ackReq struct {
+	// ID of the last read message
+	MessageId string `json:"messageId"`
+} //@name AckRequest
+
+func (r ackReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.MessageId, validation.Required),
+	)
+}
+
+// AckChannel marks the channel as read up to the given message
+// and syncs the read state to the other sessions of the user
+// AckChannel godoc
+// @Tags Channels
+// @Summary Acknowledge Channel
+// @Accepts json
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Param request body ackReq true "Last read message"
+// @Success 200 {object} model.ReadState
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/ack [post]
+func (h *Handler) AckChannel(c *gin.Context) {
+	var req ackReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Check if the user has access to said channel
+	if _, err = h.channelService.GetPermissions(channel, userId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	state, err := h.channelService.AckChannel(channel, userId, req.MessageId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Sync the read state to all sessions of the user
+	h.socketService.EmitAck(userId, state)
+
+	c.JSON(http.StatusOK, state)
+}
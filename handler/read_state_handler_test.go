@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_AckChannel(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully acknowledged channel", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+
+		mockState := &model.ReadState{
+			UserId:        authUser.ID,
+			ChannelId:     mockChannel.ID,
+			LastMessageId: mockMessage.ID,
+			LastReadAt:    mockMessage.CreatedAt,
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+		mockChannelService.On("AckChannel", mockChannel, authUser.ID, mockMessage.ID).Return(mockState, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitAck", authUser.ID, mockState)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"messageId": mockMessage.ID,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/ack", mockChannel.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockState)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Message is required", func(t *testing.T) {
+		mockChannelService := new(mocks.ChannelService)
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/ack", fixture.RandID())
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		mockChannelService.AssertNotCalled(t, "AckChannel", mock.Anything, mock.Anything, mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitAck", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not a member of the channel", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		messageId := fixture.RandID()
+
+		mockError := apperrors.NewAuthorization(apperrors.Unauthorized)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.Permission(0), mockError)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"messageId": messageId,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/ack", mockChannel.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertNotCalled(t, "AckChannel", mock.Anything, mock.Anything, mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitAck", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,417 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"strings"
+)
+
+/*
+ * RoleHandler contains all routes related to guild roles (/api/guilds/:guildId/roles)
+ */
+
+// GetRoles returns the roles of the given guild
+// GetRoles godoc
+// @Tags Roles
+// @Summary Get Guild Roles
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Success 200 {array} model.RoleResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/roles [get]
+func (h *Handler) GetRoles(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	guildId := c.Param("guildId")
+
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("guild", guildId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if !isMember(guild, userId) {
+		e := apperrors.NewAuthorization(apperrors.NotAMember)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	roles, err := h.permissionService.GetRoles(guildId)
+
+	if err != nil {
+		log.Printf("Unable to find roles for guild id: %v\n%v", guildId, err)
+		e := apperrors.NewNotFound("roles", guildId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// If the guild does not have any roles, return an empty array
+	if len(*roles) == 0 {
+		empty := make([]model.RoleResponse, 0)
+		c.JSON(http.StatusOK, empty)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// roleReq specifies the input form for creating and editing a role
+type roleReq struct {
+	// Role Name. 3 to 30 characters
+	Name string `json:"name"`
+	// Hex color code of the role
+	Color *string `json:"color"`
+	// Bitfield of the role's permissions
+	Permissions model.Permission `json:"permissions"`
+} //@name RoleRequest
+
+func (r roleReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(3, 30)),
+		validation.Field(&r.Color, validation.NilOrNotEmpty, is.HexColor),
+		validation.Field(&r.Permissions, validation.Min(model.Permission(0)), validation.Max(model.AllPermissions)),
+	)
+}
+
+func (r *roleReq) sanitize() {
+	r.Name = strings.TrimSpace(r.Name)
+}
+
+// CreateRole creates a role for the given guild
+// CreateRole godoc
+// @Tags Roles
+// @Summary Create Role
+// @Accepts json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param request body roleReq true "Create Role"
+// @Success 201 {object} model.RoleResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/roles [post]
+func (h *Handler) CreateRole(c *gin.Context) {
+	var req roleReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+	guildId := c.Param("guildId")
+
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("guild", guildId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	permissions := h.permissionService.GetMemberPermissions(userId, guild)
+
+	if !permissions.Has(model.ManageRoles) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if !canGrant(permissions, req.Permissions) {
+		e := apperrors.NewAuthorization(apperrors.GrantPermissionError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Check if the guild already has 50 roles
+	if len(guild.Roles) >= model.MaximumRoles {
+		e := apperrors.NewBadRequest(apperrors.RoleLimitError)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	params := model.Role{
+		GuildID:     guildId,
+		Name:        req.Name,
+		Color:       req.Color,
+		Permissions: req.Permissions,
+	}
+
+	role, err := h.permissionService.CreateRole(&params)
+
+	if err != nil {
+		log.Printf("Failed to create role: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	response := role.SerializeRole()
+
+	// Emit the new role to the guild members
+	h.socketService.EmitAddRole(guildId, &response)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// EditRole edits the given role
+// EditRole godoc
+// @Tags Roles
+// @Summary Edit Role
+// @Accepts json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param roleId path string true "Role ID"
+// @Param request body roleReq true "Edit Role"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/roles/{roleId} [put]
+func (h *Handler) EditRole(c *gin.Context) {
+	var req roleReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+	guildId := c.Param("guildId")
+	roleId := c.Param("roleId")
+
+	guild, role, ok := h.getManageableRole(c, userId, guildId, roleId)
+
+	if !ok {
+		return
+	}
+
+	if !canGrant(h.permissionService.GetMemberPermissions(userId, guild), req.Permissions) {
+		e := apperrors.NewAuthorization(apperrors.GrantPermissionError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	role.Name = req.Name
+	role.Color = req.Color
+	role.Permissions = req.Permissions
+
+	if err := h.permissionService.UpdateRole(role); err != nil {
+		log.Printf("Failed to update role: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Emit the role changes to the guild members
+	response := role.SerializeRole()
+	h.socketService.EmitEditRole(guildId, &response)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// DeleteRole removes the given role from the guild and all of its members
+// DeleteRole godoc
+// @Tags Roles
+// @Summary Delete Role
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param roleId path string true "Role ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/roles/{roleId} [delete]
+func (h *Handler) DeleteRole(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	guildId := c.Param("guildId")
+	roleId := c.Param("roleId")
+
+	_, role, ok := h.getManageableRole(c, userId, guildId, roleId)
+
+	if !ok {
+		return
+	}
+
+	if err := h.permissionService.DeleteRole(role); err != nil {
+		log.Printf("Failed to delete role: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Emit signal to remove the role from the guild
+	h.socketService.EmitDeleteRole(guildId, role.ID)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// AddMemberRole assigns the given role to the provided member
+// AddMemberRole godoc
+// @Tags Roles
+// @Summary Add Role to Member
+// @Accepts json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param roleId path string true "Role ID"
+// @Param request body memberReq true "Member ID"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/roles/{roleId}/members [post]
+func (h *Handler) AddMemberRole(c *gin.Context) {
+	h.updateMemberRole(c, h.permissionService.AddMemberRole)
+}
+
+// RemoveMemberRole unassigns the given role from the provided member
+// RemoveMemberRole godoc
+// @Tags Roles
+// @Summary Remove Role from Member
+// @Accepts json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param roleId path string true "Role ID"
+// @Param request body memberReq true "Member ID"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/roles/{roleId}/members [delete]
+func (h *Handler) RemoveMemberRole(c *gin.Context) {
+	h.updateMemberRole(c, h.permissionService.RemoveMemberRole)
+}
+
+// updateMemberRole validates the request and applies the given
+// assignment function to the member and role
+func (h *Handler) updateMemberRole(c *gin.Context, update func(userId, guildId, roleId string) error) {
+	var req memberReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	userId := c.MustGet("userId").(string)
+	guildId := c.Param("guildId")
+	roleId := c.Param("roleId")
+
+	guild, role, ok := h.getManageableRole(c, userId, guildId, roleId)
+
+	if !ok {
+		return
+	}
+
+	if !isMember(guild, req.MemberId) {
+		e := apperrors.NewNotFound("member", req.MemberId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err := update(req.MemberId, guildId, role.ID); err != nil {
+		log.Printf("Failed to update member roles: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// The member might have lost access to some channels
+	h.socketService.RecheckSubscriptions(guildId)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// getManageableRole returns the guild and the role if the role belongs to the guild
+// and the user is allowed to manage it. Otherwise it writes the error response.
+func (h *Handler) getManageableRole(c *gin.Context, userId, guildId, roleId string) (*model.Guild, *model.Role, bool) {
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("guild", guildId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, nil, false
+	}
+
+	permissions := h.permissionService.GetMemberPermissions(userId, guild)
+
+	if !permissions.Has(model.ManageRoles) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, nil, false
+	}
+
+	role, err := h.permissionService.GetRole(roleId)
+
+	if err != nil || role.GuildID != guild.ID {
+		e := apperrors.NewNotFound("role", roleId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, nil, false
+	}
+
+	// Members cannot manage roles that are more powerful than their own
+	if !canGrant(permissions, role.Permissions) {
+		e := apperrors.NewAuthorization(apperrors.GrantPermissionError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, nil, false
+	}
+
+	return guild, role, true
+}
+
+// canGrant checks if the given permissions contain every permission of the role.
+// Members cannot hand out permissions they do not have themselves.
+func canGrant(permissions, role model.Permission) bool {
+	return permissions.Has(model.Administrator) || role&^permissions == 0
+}
@@ -0,0 +1,627 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_GetRoles(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successful Fetch", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = append(mockGuild.Members, *authUser)
+		mockRole := fixture.GetMockRole(mockGuild.ID, model.KickMembers)
+
+		response := []model.RoleResponse{mockRole.SerializeRole()}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("GetRoles", mockGuild.ID).Return(&response, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockGuildService.AssertExpectations(t)
+		mockPermissionService.AssertExpectations(t)
+	})
+
+	t.Run("Not a member of the guild", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.NotAMember)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockGuildService.AssertCalled(t, "GetGuild", mockGuild.ID)
+		mockPermissionService.AssertNotCalled(t, "GetRoles", mockGuild.ID)
+	})
+}
+
+func TestHandler_CreateRole(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successful role creation", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockRole := fixture.GetMockRole(mockGuild.ID, model.KickMembers)
+
+		params := &model.Role{
+			GuildID:     mockGuild.ID,
+			Name:        mockRole.Name,
+			Permissions: mockRole.Permissions,
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.ManageRoles | model.KickMembers)
+		mockPermissionService.On("CreateRole", params).Return(mockRole, nil)
+
+		response := mockRole.SerializeRole()
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitAddRole", mockGuild.ID, &response)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":        mockRole.Name,
+			"permissions": mockRole.Permissions,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockGuildService.AssertExpectations(t)
+		mockPermissionService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Missing permissions", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.KickMembers)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name": fixture.RandStr(8),
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockPermissionService.AssertNotCalled(t, "CreateRole", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitAddRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Cannot grant missing permissions", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.ManageRoles)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":        fixture.RandStr(8),
+			"permissions": model.BanMembers,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.GrantPermissionError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockPermissionService.AssertNotCalled(t, "CreateRole", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitAddRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Guild already has the maximum number of roles", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		for i := 0; i < model.MaximumRoles; i++ {
+			mockGuild.Roles = append(mockGuild.Roles, *fixture.GetMockRole(mockGuild.ID, 0))
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name": fixture.RandStr(8),
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.RoleLimitError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockPermissionService.AssertNotCalled(t, "CreateRole", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitAddRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_CreateRole_BadRequest(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	mockUser := fixture.GetMockUser()
+	router := getAuthenticatedTestRouter(mockUser.ID)
+
+	mockGuildService := new(mocks.GuildService)
+	mockPermissionService := new(mocks.PermissionService)
+
+	NewHandler(&Config{
+		R:                 router,
+		GuildService:      mockGuildService,
+		PermissionService: mockPermissionService,
+	})
+
+	testCases := []struct {
+		name string
+		body gin.H
+	}{
+		{
+			name: "Name required",
+			body: gin.H{},
+		},
+		{
+			name: "Name too short",
+			body: gin.H{
+				"name": fixture.RandStr(2),
+			},
+		},
+		{
+			name: "Name too long",
+			body: gin.H{
+				"name": fixture.RandStr(31),
+			},
+		},
+		{
+			name: "Color not a hex color",
+			body: gin.H{
+				"name":  fixture.RandStr(8),
+				"color": fixture.RandStr(6),
+			},
+		},
+		{
+			name: "Unknown permission",
+			body: gin.H{
+				"name":        fixture.RandStr(8),
+				"permissions": model.AllPermissions + 1,
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			reqBody, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			reqUrl := fmt.Sprintf("/api/guilds/%s/roles", fixture.RandID())
+			request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+			assert.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(rr, request)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockGuildService.AssertNotCalled(t, "GetGuild")
+			mockPermissionService.AssertNotCalled(t, "CreateRole")
+		})
+	}
+}
+
+func TestHandler_DeleteRole(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully deleted", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockRole := fixture.GetMockRole(mockGuild.ID, model.ManageMessages)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+		mockPermissionService.On("GetRole", mockRole.ID).Return(mockRole, nil)
+		mockPermissionService.On("DeleteRole", mockRole).Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitDeleteRole", mockGuild.ID, mockRole.ID)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles/%s", mockGuild.ID, mockRole.ID)
+		request, err := http.NewRequest(http.MethodDelete, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockGuildService.AssertExpectations(t)
+		mockPermissionService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Role belongs to another guild", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockRole := fixture.GetMockRole(fixture.RandID(), model.ManageMessages)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+		mockPermissionService.On("GetRole", mockRole.ID).Return(mockRole, nil)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles/%s", mockGuild.ID, mockRole.ID)
+		request, err := http.NewRequest(http.MethodDelete, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("role", mockRole.ID)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockPermissionService.AssertNotCalled(t, "DeleteRole", mockRole)
+		mockSocketService.AssertNotCalled(t, "EmitDeleteRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Role has more permissions than the member", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockRole := fixture.GetMockRole(mockGuild.ID, model.ManageRoles|model.BanMembers)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.ManageRoles)
+		mockPermissionService.On("GetRole", mockRole.ID).Return(mockRole, nil)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			SocketService:     mockSocketService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles/%s", mockGuild.ID, mockRole.ID)
+		request, err := http.NewRequest(http.MethodDelete, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.GrantPermissionError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockPermissionService.AssertNotCalled(t, "DeleteRole", mockRole)
+		mockSocketService.AssertNotCalled(t, "EmitDeleteRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_AddMemberRole(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully added", func(t *testing.T) {
+		mockMember := fixture.GetMockUser()
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockGuild.Members = append(mockGuild.Members, *mockMember)
+		mockRole := fixture.GetMockRole(mockGuild.ID, model.KickMembers)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+		mockPermissionService.On("GetRole", mockRole.ID).Return(mockRole, nil)
+		mockPermissionService.On("AddMemberRole", mockMember.ID, mockGuild.ID, mockRole.ID).Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("RecheckSubscriptions", mockGuild.ID).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+			SocketService:     mockSocketService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"memberId": mockMember.ID,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles/%s/members", mockGuild.ID, mockRole.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockGuildService.AssertExpectations(t)
+		mockPermissionService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Member not found", func(t *testing.T) {
+		memberId := fixture.RandID()
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockRole := fixture.GetMockRole(mockGuild.ID, model.KickMembers)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("GetMemberPermissions", authUser.ID, mockGuild).Return(model.AllPermissions)
+		mockPermissionService.On("GetRole", mockRole.ID).Return(mockRole, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"memberId": memberId,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/roles/%s/members", mockGuild.ID, mockRole.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("member", memberId)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockPermissionService.AssertNotCalled(t, "AddMemberRole", memberId, mockGuild.ID, mockRole.ID)
+	})
+}
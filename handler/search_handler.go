@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+ * SearchHandler contains all routes related to searching messages
+ * (/api/guilds/:guildId/messages/search and /api/channels/:id/messages/search)
+ */
+
+// searchReq specifies the query parameters of a message search.
+// At least the search term or one filter is required
+type searchReq struct {
+	// Search term. Maximum 200 characters
+	Query string `form:"q"`
+	// Only messages of the given author
+	AuthorId string `form:"author"`
+	// Only messages of the given channel. Only used for guild searches
+	ChannelId string `form:"channel"`
+	// "attachment" only returns messages with an attachment
+	Has string `form:"has"`
+	// Only messages that mention the given user
+	Mentions string `form:"mentions"`
+	// RFC3339 date. Can be used for pagination with the createdAt field
+	Before *time.Time `form:"before" time_format:"2006-01-02T15:04:05Z07:00"`
+	// RFC3339 date
+	After *time.Time `form:"after" time_format:"2006-01-02T15:04:05Z07:00"`
+} //@name SearchRequest
+
+func (r searchReq) validate() error {
+	hasFilter := r.AuthorId != "" || r.ChannelId != "" || r.Has != "" ||
+		r.Mentions != "" || r.Before != nil || r.After != nil
+
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Query,
+			validation.Required.When(!hasFilter).Error(apperrors.SearchFilterRequired),
+			validation.Length(0, 200),
+		),
+		validation.Field(&r.Has, validation.In("attachment")),
+	)
+}
+
+func (r *searchReq) sanitize() {
+	r.Query = strings.TrimSpace(r.Query)
+}
+
+// toSearch turns the request into the search for the given channels
+func (r *searchReq) toSearch(guildId *string, channelIds []string) *model.MessageSearch {
+	return &model.MessageSearch{
+		Query:         r.Query,
+		GuildId:       guildId,
+		ChannelIds:    channelIds,
+		AuthorId:      r.AuthorId,
+		Mentions:      r.Mentions,
+		HasAttachment: r.Has == "attachment",
+		Before:        r.Before,
+		After:         r.After,
+	}
+}
+
+// SearchGuildMessages searches the messages of all guild channels the user can read
+// SearchGuildMessages godoc
+// @Tags Messages
+// @Summary Search Guild Messages
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param q query string false "Search term. Maximum 200 characters"
+// @Param channel query string false "Channel ID"
+// @Param author query string false "Author ID"
+// @Param has query string false "Use attachment to only return messages with an attachment"
+// @Param mentions query string false "ID of the mentioned user"
+// @Param before query string false "RFC3339 date. Use the createdAt field for pagination"
+// @Param after query string false "RFC3339 date"
+// @Success 200 {array} model.MessageResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/messages/search [get]
+func (h *Handler) SearchGuildMessages(c *gin.Context) {
+	var req searchReq
+
+	// Bind incoming query to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+	guildId := c.Param("guildId")
+
+	// Only search in channels the user is allowed to read
+	channelIds, err := h.channelService.GetReadableChannelIds(userId, guildId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if req.ChannelId != "" {
+		channelIds = filterChannelIds(channelIds, req.ChannelId)
+	}
+
+	messages, err := h.messageService.SearchMessages(userId, req.toSearch(&guildId, channelIds))
+
+	if err != nil {
+		log.Printf("Failed to search messages: %v\n", err.Error())
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// If there are no results, return an empty array
+	if len(*messages) == 0 {
+		var empty = make([]model.MessageResponse, 0)
+		c.JSON(http.StatusOK, empty)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+// SearchChannelMessages searches the messages of the given channel and its threads
+// SearchChannelMessages godoc
+// @Tags Messages
+// @Summary Search Channel Messages
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Param q query string false "Search term. Maximum 200 characters"
+// @Param author query string false "Author ID"
+// @Param has query string false "Use attachment to only return messages with an attachment"
+// @Param mentions query string false "ID of the mentioned user"
+// @Param before query string false "RFC3339 date. Use the createdAt field for pagination"
+// @Param after query string false "RFC3339 date"
+// @Success 200 {array} model.MessageResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/messages/search [get]
+func (h *Handler) SearchChannelMessages(c *gin.Context) {
+	var req searchReq
+
+	// Bind incoming query to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Check if the user has access to said channel
+	permissions, err := h.channelService.GetPermissions(channel, userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if !permissions.Has(model.ReadMessageHistory) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	messages, err := h.messageService.SearchMessages(userId, req.toSearch(channel.GuildID, []string{channel.ID}))
+
+	if err != nil {
+		log.Printf("Failed to search messages: %v\n", err.Error())
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// If there are no results, return an empty array
+	if len(*messages) == 0 {
+		var empty = make([]model.MessageResponse, 0)
+		c.JSON(http.StatusOK, empty)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+// filterChannelIds returns the given channelId if it is contained in the channelIds
+func filterChannelIds(channelIds []string, channelId string) []string {
+	for _, id := range channelIds {
+		if id == channelId {
+			return []string{channelId}
+		}
+	}
+	return []string{}
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestHandler_SearchGuildMessages(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully searched messages", func(t *testing.T) {
+		guildId := fixture.RandID()
+		channelIds := []string{fixture.RandID(), fixture.RandID()}
+		mockMessage := fixture.GetMockMessageResponse("", channelIds[0])
+		query := fixture.RandStr(6)
+
+		search := &model.MessageSearch{
+			Query:         query,
+			GuildId:       &guildId,
+			ChannelIds:    channelIds,
+			AuthorId:      mockMessage.User.Id,
+			HasAttachment: true,
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetReadableChannelIds", authUser.ID, guildId).Return(channelIds, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("SearchMessages", authUser.ID, search).Return(&[]model.MessageResponse{*mockMessage}, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+		})
+
+		params := url.Values{}
+		params.Add("q", query)
+		params.Add("author", mockMessage.User.Id)
+		params.Add("has", "attachment")
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/messages/search?%s", guildId, params.Encode())
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal([]model.MessageResponse{*mockMessage})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockMessageService.AssertExpectations(t)
+	})
+
+	t.Run("Channel filter does not leak unreadable channels", func(t *testing.T) {
+		guildId := fixture.RandID()
+		channelIds := []string{fixture.RandID()}
+		hiddenChannelId := fixture.RandID()
+		query := fixture.RandStr(6)
+
+		search := &model.MessageSearch{
+			Query:      query,
+			GuildId:    &guildId,
+			ChannelIds: []string{},
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetReadableChannelIds", authUser.ID, guildId).Return(channelIds, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("SearchMessages", authUser.ID, search).Return(&[]model.MessageResponse{}, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+		})
+
+		params := url.Values{}
+		params.Add("q", query)
+		params.Add("channel", hiddenChannelId)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/messages/search?%s", guildId, params.Encode())
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal([]model.MessageResponse{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertExpectations(t)
+	})
+
+	t.Run("Not a member", func(t *testing.T) {
+		guildId := fixture.RandID()
+
+		mockError := apperrors.NewNotFound("guild", guildId)
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetReadableChannelIds", authUser.ID, guildId).Return(nil, mockError)
+
+		mockMessageService := new(mocks.MessageService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/messages/search?q=%s", guildId, fixture.RandStr(6))
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertNotCalled(t, "SearchMessages", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Search term or filter required", func(t *testing.T) {
+		mockChannelService := new(mocks.ChannelService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/messages/search", fixture.RandID())
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockChannelService.AssertNotCalled(t, "GetReadableChannelIds", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_SearchChannelMessages(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully searched DM", func(t *testing.T) {
+		mockChannel := fixture.GetMockDMChannel()
+		mockMessage := fixture.GetMockMessageResponse("", mockChannel.ID)
+		query := fixture.RandStr(6)
+
+		search := &model.MessageSearch{
+			Query:      query,
+			ChannelIds: []string{mockChannel.ID},
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("SearchMessages", authUser.ID, search).Return(&[]model.MessageResponse{*mockMessage}, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/messages/search?q=%s", mockChannel.ID, query)
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal([]model.MessageResponse{*mockMessage})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockMessageService.AssertExpectations(t)
+	})
+
+	t.Run("No access to the channel", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel(fixture.RandID())
+
+		mockError := apperrors.NewAuthorization(apperrors.Unauthorized)
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.Permission(0), mockError)
+
+		mockMessageService := new(mocks.MessageService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/messages/search?q=%s", mockChannel.ID, fixture.RandStr(6))
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertNotCalled(t, "SearchMessages", mock.Anything, mock.Anything)
+	})
+}
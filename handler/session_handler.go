@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+)
+
+/*
+ * SessionHandler contains all routes related to the devices the user is logged in with
+ */
+
+// GetSessions returns the active sessions of the current user
+// GetSessions godoc
+// @Tags Account
+// @Summary Get Current User's Sessions
+// @Produce  json
+// @Success 200 {array} model.Session
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/sessions [get]
+func (h *Handler) GetSessions(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	sessionId := c.GetString("sessionId")
+
+	sessions, err := h.sessionService.GetSessions(c.Request.Context(), userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	for i := range *sessions {
+		(*sessions)[i].Current = (*sessions)[i].Id == sessionId
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession logs out the given session of the current user
+// and closes its websocket connections
+// RevokeSession godoc
+// @Tags Account
+// @Summary Revoke Session
+// @Produce  json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/sessions/{sessionId} [delete]
+func (h *Handler) RevokeSession(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	sessionId := c.Param("sessionId")
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), userId, sessionId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	h.socketService.CloseSessions([]string{sessionId})
+
+	if sessionId == c.GetString("sessionId") {
+		clearUserSession(c)
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// RevokeSessions logs out all sessions of the current user except the current one
+// and closes their websocket connections
+// RevokeSessions godoc
+// @Tags Account
+// @Summary Revoke Other Sessions
+// @Produce  json
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/sessions [delete]
+func (h *Handler) RevokeSessions(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	revoked, err := h.sessionService.RevokeSessions(c.Request.Context(), userId, c.GetString("sessionId"))
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	h.socketService.CloseSessions(revoked)
+
+	c.JSON(http.StatusOK, true)
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_GetSessions(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	uid := fixture.RandID()
+
+	t.Run("Marks the current session", func(t *testing.T) {
+		mockSessions := &[]model.Session{{Id: testSessionId}, {Id: "laptop"}}
+
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.On("TouchSession", mock.Anything, uid, testSessionId).Return(nil)
+		mockSessionService.On("GetSessions", mock.Anything, uid).Return(mockSessions, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    new(mocks.UserService),
+			SessionService: mockSessionService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/account/sessions", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		var sessions []model.Session
+		err = json.Unmarshal(rr.Body.Bytes(), &sessions)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, sessions[0].Current)
+		assert.False(t, sessions[1].Current)
+		mockSessionService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_RevokeSession(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	uid := fixture.RandID()
+
+	t.Run("Closes the session's connections", func(t *testing.T) {
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.On("TouchSession", mock.Anything, uid, testSessionId).Return(nil)
+		mockSessionService.On("RevokeSession", mock.Anything, uid, "laptop").Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("CloseSessions", []string{"laptop"}).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    new(mocks.UserService),
+			SessionService: mockSessionService,
+			SocketService:  mockSocketService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/account/sessions/laptop", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(true)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockSessionService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Session not found", func(t *testing.T) {
+		mockError := apperrors.NewNotFound("session", "unknown")
+
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.On("TouchSession", mock.Anything, uid, testSessionId).Return(nil)
+		mockSessionService.On("RevokeSession", mock.Anything, uid, "unknown").Return(mockError)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    new(mocks.UserService),
+			SessionService: mockSessionService,
+			SocketService:  mockSocketService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/account/sessions/unknown", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockSocketService.AssertNotCalled(t, "CloseSessions", mock.Anything)
+	})
+}
+
+func TestHandler_RevokeSessions(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	uid := fixture.RandID()
+
+	t.Run("Revokes all other sessions", func(t *testing.T) {
+		revoked := []string{"laptop", "phone"}
+
+		mockSessionService := new(mocks.SessionService)
+		mockSessionService.On("TouchSession", mock.Anything, uid, testSessionId).Return(nil)
+		mockSessionService.On("RevokeSessions", mock.Anything, uid, testSessionId).Return(revoked, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("CloseSessions", revoked).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    new(mocks.UserService),
+			SessionService: mockSessionService,
+			SocketService:  mockSocketService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/account/sessions", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(true)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockSessionService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+}
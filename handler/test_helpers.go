@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 	"github.com/sentrionic/valkyrie/model"
+	"mime/multipart"
+	"net/textproto"
 )
 
+// testSessionId is the ID of the session created by getAuthenticatedTestRouter
+const testSessionId = "test-session"
+
 func getAuthenticatedTestRouter(uid string) *gin.Engine {
 	router := gin.Default()
 	store := cookie.NewStore([]byte("secret"))
@@ -15,6 +22,7 @@ func getAuthenticatedTestRouter(uid string) *gin.Engine {
 	router.Use(func(c *gin.Context) {
 		session := sessions.Default(c)
 		session.Set("userId", uid)
+		session.Set("sessionId", testSessionId)
 		c.Set("userId", uid)
 	})
 
@@ -38,3 +46,19 @@ func getTestFieldErrorResponse(field, message string) gin.H {
 		},
 	}
 }
+
+// getTestMultipartFile returns a multipart body containing the content as "file"
+// and its content type
+func getTestMultipartFile(filename, contentType string, content []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
+	h.Set("Content-Type", contentType)
+	part, _ := writer.CreatePart(h)
+	_, _ = part.Write(content)
+	_ = writer.Close()
+
+	return body, writer.FormDataContentType()
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+	"strings"
+)
+
+/*
+ * ThreadHandler contains all routes related to threads (/api/channels/:id/threads)
+ */
+
+// GetThreads returns the threads of the given channel
+// GetThreads godoc
+// @Tags Channels
+// @Summary Get Channel Threads
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Success 200 {array} model.ChannelResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /channels/{id}/threads [get]
+func (h *Handler) GetThreads(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Check if the user has access to said channel
+	if err = h.channelService.IsChannelMember(channel, userId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	threads, err := h.channelService.GetThreads(channel.ID)
+
+	if err != nil {
+		e := apperrors.NewNotFound("threads", channelId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	response := make([]model.ChannelResponse, 0)
+	for _, thread := range *threads {
+		response = append(response, thread.SerializeChannel())
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// threadReq specifies the input form for starting a thread
+type threadReq struct {
+	// Thread Name. 3 to 30 character
+	Name string `json:"name"`
+	// ID of the message that starts the thread
+	MessageId string `json:"messageId"`
+} //@name ThreadRequest
+
+func (r threadReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(3, 30)),
+		validation.Field(&r.MessageId, validation.Required),
+	)
+}
+
+func (r *threadReq) sanitize() {
+	r.Name = strings.TrimSpace(r.Name)
+}
+
+// CreateThread starts a thread from a message of the given channel
+// CreateThread godoc
+// @Tags Channels
+// @Summary Create Thread
+// @Accepts json
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Param request body threadReq true "Create Thread"
+// @Success 201 {object} model.ChannelResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/threads [post]
+func (h *Handler) CreateThread(c *gin.Context) {
+	var req threadReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Threads cannot be nested and DMs do not have threads
+	if channel.IsDM || channel.ParentId != nil {
+		e := apperrors.NewBadRequest(apperrors.ThreadChannelError)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Check if the user is allowed to post in the channel
+	permissions, err := h.channelService.GetPermissions(channel, userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if !permissions.Has(model.SendMessages) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	message, err := h.messageService.Get(req.MessageId)
+
+	if err != nil || message.ChannelId != channel.ID {
+		e := apperrors.NewNotFound("message", req.MessageId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	threads, err := h.channelService.GetThreads(channel.ID)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Every message can only start one thread
+	for _, thread := range *threads {
+		if thread.MessageId != nil && *thread.MessageId == message.ID {
+			e := apperrors.NewBadRequest(apperrors.ThreadExistsError)
+
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+	}
+
+	params := model.Channel{
+		Name:      req.Name,
+		IsPublic:  channel.IsPublic,
+		GuildID:   channel.GuildID,
+		ParentId:  &channel.ID,
+		MessageId: &message.ID,
+	}
+
+	thread, err := h.channelService.CreateChannel(&params)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	response := thread.SerializeChannel()
+
+	// Emit the new thread to the users viewing the parent channel
+	h.socketService.EmitAddThread(channel.ID, &response)
+
+	c.JSON(http.StatusCreated, response)
+}
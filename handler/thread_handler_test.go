@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_CreateThread(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully created thread", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+		name := fixture.RandStr(8)
+
+		params := &model.Channel{
+			Name:      name,
+			IsPublic:  mockChannel.IsPublic,
+			GuildID:   mockChannel.GuildID,
+			ParentId:  &mockChannel.ID,
+			MessageId: &mockMessage.ID,
+		}
+
+		mockThread := fixture.GetMockChannel(mockGuild.ID)
+		mockThread.Name = name
+		mockThread.ParentId = &mockChannel.ID
+		mockThread.MessageId = &mockMessage.ID
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+		mockChannelService.On("GetThreads", mockChannel.ID).Return(&[]model.Channel{}, nil)
+		mockChannelService.On("CreateChannel", params).Return(mockThread, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+
+		response := mockThread.SerializeChannel()
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitAddThread", mockChannel.ID, &response)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":      name,
+			"messageId": mockMessage.ID,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/threads", mockChannel.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockMessageService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Message already has a thread", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+
+		mockThread := fixture.GetMockChannel(mockGuild.ID)
+		mockThread.ParentId = &mockChannel.ID
+		mockThread.MessageId = &mockMessage.ID
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("GetPermissions", mockChannel, authUser.ID).Return(model.DefaultPermissions, nil)
+		mockChannelService.On("GetThreads", mockChannel.ID).Return(&[]model.Channel{*mockThread}, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":      fixture.RandStr(8),
+			"messageId": mockMessage.ID,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/threads", mockChannel.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.ThreadExistsError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertNotCalled(t, "CreateChannel", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitAddThread", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Cannot nest threads", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		parentId := fixture.RandID()
+		mockThread := fixture.GetMockChannel(mockGuild.ID)
+		mockThread.ParentId = &parentId
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockThread.ID).Return(mockThread, nil)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":      fixture.RandStr(8),
+			"messageId": fixture.RandID(),
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/channels/%s/threads", mockThread.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.ThreadChannelError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertNotCalled(t, "CreateChannel", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitAddThread", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+	"strings"
+)
+
+/*
+ * TokenHandler contains all routes related to bot accounts and
+ * API tokens (/api/account/bots, /api/account/tokens)
+ */
+
+// GetBots returns the bot accounts owned by the current user
+// GetBots godoc
+// @Tags Account
+// @Summary Get User's Bots
+// @Produce  json
+// @Success 200 {array} model.User
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/bots [get]
+func (h *Handler) GetBots(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	bots, err := h.userService.GetBots(userId)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, bots)
+}
+
+type createBotReq struct {
+	// Min 3, max 30 characters.
+	Username string `json:"username"`
+} //@name CreateBotRequest
+
+func (r createBotReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Username, validation.Required, validation.Length(3, 30)),
+	)
+}
+
+func (r *createBotReq) sanitize() {
+	r.Username = strings.TrimSpace(r.Username)
+}
+
+// CreateBot creates a bot account owned by the current user
+// CreateBot godoc
+// @Tags Account
+// @Summary Create Bot
+// @Accepts json
+// @Produce  json
+// @Param request body createBotReq true "Create Bot"
+// @Success 201 {object} model.User
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/bots [post]
+func (h *Handler) CreateBot(c *gin.Context) {
+	var req createBotReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Bots cannot create other bots
+	if authUser.IsBot {
+		e := apperrors.NewAuthorization(apperrors.Unauthorized)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	bot, err := h.userService.CreateBot(userId, req.Username)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, bot)
+}
+
+// GetTokens returns the tokens of the current user and of their bots.
+// The response does not contain the tokens themselves.
+// GetTokens godoc
+// @Tags Account
+// @Summary Get User's Tokens
+// @Produce  json
+// @Success 200 {array} model.APIToken
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/tokens [get]
+func (h *Handler) GetTokens(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	tokens, err := h.userService.GetTokens(userId)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+type createTokenReq struct {
+	// Min 1, max 64 characters.
+	Name string `json:"name"`
+	// ID of one of the user's bots. The token is created for the user if omitted.
+	BotId *string `json:"botId"`
+} //@name CreateTokenRequest
+
+func (r createTokenReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 64)),
+	)
+}
+
+func (r *createTokenReq) sanitize() {
+	r.Name = strings.TrimSpace(r.Name)
+}
+
+// CreateToken creates a token for the current user or one of their bots.
+// This is the only response that contains the plaintext token.
+// CreateToken godoc
+// @Tags Account
+// @Summary Create Token
+// @Accepts json
+// @Produce  json
+// @Param request body createTokenReq true "Create Token"
+// @Success 201 {object} model.CreatedToken
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/tokens [post]
+func (h *Handler) CreateToken(c *gin.Context) {
+	var req createTokenReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+	target, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Bots have to be managed by their owner
+	if target.IsBot {
+		e := apperrors.NewAuthorization(apperrors.Unauthorized)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if req.BotId != nil {
+		target, err = h.userService.Get(*req.BotId)
+
+		if err != nil || !isBotOwner(target, userId) {
+			e := apperrors.NewNotFound("bot", *req.BotId)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+	}
+
+	token, err := h.userService.CreateToken(target, req.Name)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// RevokeToken deletes a token of the current user or of one of their bots
+// RevokeToken godoc
+// @Tags Account
+// @Summary Revoke Token
+// @Produce  json
+// @Param tokenId path string true "Token ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/tokens/{tokenId} [delete]
+func (h *Handler) RevokeToken(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	tokenId := c.Param("tokenId")
+
+	if err := h.userService.RevokeToken(userId, tokenId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// isBotOwner checks if the given user is a bot owned by the owner
+func isBotOwner(bot *model.User, ownerId string) bool {
+	return bot.IsBot && bot.OwnerId != nil && *bot.OwnerId == ownerId
+}
@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_CreateBot(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully created", func(t *testing.T) {
+		mockBot := fixture.GetMockUser()
+		mockBot.IsBot = true
+		mockBot.OwnerId = &authUser.ID
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("CreateBot", authUser.ID, mockBot.Username).Return(mockBot, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"username": "  " + mockBot.Username + " ",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/bots", strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockBot)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Bots cannot create bots", func(t *testing.T) {
+		mockBot := fixture.GetMockUser()
+		mockBot.IsBot = true
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", mockBot.ID).Return(mockBot, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(mockBot.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"username": fixture.Username(),
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/bots", strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockUserService.AssertNotCalled(t, "CreateBot", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid username", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"username": "ab",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/bots", strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "CreateBot", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_CreateToken(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully created for a bot", func(t *testing.T) {
+		mockBot := fixture.GetMockUser()
+		mockBot.IsBot = true
+		mockBot.OwnerId = &authUser.ID
+
+		mockToken := &model.CreatedToken{
+			APIToken: model.APIToken{
+				BaseModel: model.BaseModel{ID: fixture.RandID()},
+				UserId:    mockBot.ID,
+				Name:      "Deploy",
+			},
+			Token: fixture.RandStr(43),
+		}
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("Get", mockBot.ID).Return(mockBot, nil)
+		mockUserService.On("CreateToken", mockBot, "Deploy").Return(mockToken, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":  "Deploy",
+			"botId": mockBot.ID,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/tokens", strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockToken)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Bot of another user", func(t *testing.T) {
+		ownerId := fixture.RandID()
+		mockBot := fixture.GetMockUser()
+		mockBot.IsBot = true
+		mockBot.OwnerId = &ownerId
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("Get", mockBot.ID).Return(mockBot, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":  "Deploy",
+			"botId": mockBot.ID,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/tokens", strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("bot", mockBot.ID)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertNotCalled(t, "CreateToken", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_RevokeToken(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully revoked", func(t *testing.T) {
+		tokenId := fixture.RandID()
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("RevokeToken", authUser.ID, tokenId).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/account/tokens/%s", tokenId), nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		tokenId := fixture.RandID()
+		mockError := apperrors.NewNotFound("token", tokenId)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("RevokeToken", authUser.ID, tokenId).Return(mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/account/tokens/%s", tokenId), nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		mockUserService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+	"strings"
+)
+
+/*
+ * VerificationHandler contains all routes related to verifying the user's email
+ */
+
+type verifyEmailReq struct {
+	// The token the user got from the email.
+	Token string `json:"token"`
+} //@name VerifyEmailRequest
+
+func (r verifyEmailReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Token, validation.Required),
+	)
+}
+
+func (r *verifyEmailReq) sanitize() {
+	r.Token = strings.TrimSpace(r.Token)
+}
+
+// VerifyEmail marks the email of the token's user as verified
+// VerifyEmail godoc
+// @Tags Account
+// @Summary Verify Email
+// @Accept  json
+// @Produce  json
+// @Param request body verifyEmailReq true "Verification token"
+// @Success 200 {object} model.User
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/verify-email [post]
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	var req verifyEmailReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	user, err := h.userService.VerifyEmail(c.Request.Context(), req.Token)
+
+	if err != nil {
+		if err.Error() == apperrors.NewBadRequest(apperrors.InvalidVerifyToken).Error() {
+			toFieldErrorResponse(c, "Token", apperrors.InvalidVerifyToken)
+			return
+		}
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ResendVerificationMail sends another verification mail to the current user.
+// Only one mail can be requested per minute.
+// ResendVerificationMail godoc
+// @Tags Account
+// @Summary Resend Verification Mail
+// @Produce  json
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 429 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/verify-email/resend [post]
+func (h *Handler) ResendVerificationMail(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err = h.userService.ResendVerificationMail(c.Request.Context(), authUser); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// requireVerifiedEmail writes an error response if the user has not verified their email yet.
+// Bots do not have an email and are always allowed.
+func requireVerifiedEmail(c *gin.Context, user *model.User) bool {
+	if user.EmailVerified || user.IsBot {
+		return true
+	}
+
+	e := apperrors.NewAuthorization(apperrors.EmailNotVerified)
+	c.JSON(e.Status(), gin.H{
+		"error": e,
+	})
+	return false
+}
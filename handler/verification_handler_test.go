@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_VerifyEmail(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	token := fixture.RandStringRunes(32)
+
+	t.Run("Successfully verified", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("VerifyEmail", mock.Anything, token).Return(mockUser, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"token": " " + token + " ",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/verify-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockUser)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("VerifyEmail", mock.Anything, token).
+			Return(nil, apperrors.NewBadRequest(apperrors.InvalidVerifyToken))
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"token": token,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/verify-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(getTestFieldErrorResponse("Token", apperrors.InvalidVerifyToken))
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+	})
+
+	t.Run("Token required", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/verify-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "VerifyEmail", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_ResendVerificationMail(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successfully sent", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", mockUser.ID).Return(mockUser, nil)
+		mockUserService.On("ResendVerificationMail", mock.Anything, mockUser).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(mockUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/verify-email/resend", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(true)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+		mockError := apperrors.NewTooManyRequests(apperrors.VerifyMailThrottled)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", mockUser.ID).Return(mockUser, nil)
+		mockUserService.On("ResendVerificationMail", mock.Anything, mockUser).Return(mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(mockUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/verify-email/resend", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+	})
+}
@@ -0,0 +1,399 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/lib/pq"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/ws"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+/*
+ * WebhookHandler contains all routes related to outgoing webhooks (/api/guilds/:guildId/webhooks)
+ */
+
+var webhookUrlRegex = regexp.MustCompile(`^https?://`)
+
+// webhookReq specifies the input form for creating and editing a webhook
+type webhookReq struct {
+	// http or https URL the events get POSTed to
+	Url string `json:"url"`
+	// Used to sign the deliveries. 16 to 128 characters. Keeps the current secret if omitted when editing.
+	Secret string `json:"secret"`
+	// Events the webhook receives. Receives all events if empty.
+	Events []string `json:"events"`
+	// Re-enables a disabled webhook. Only used when editing.
+	Enabled *bool `json:"enabled"`
+} //@name WebhookRequest
+
+func (r webhookReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Url, validation.Required, is.URL, validation.Match(webhookUrlRegex), validation.By(validatePublicHost)),
+		validation.Field(&r.Secret, validation.Length(16, 128)),
+		validation.Field(&r.Events, validation.By(validateWebhookEvents)),
+	)
+}
+
+func (r *webhookReq) sanitize() {
+	r.Url = strings.TrimSpace(r.Url)
+	if r.Events == nil {
+		r.Events = make([]string, 0)
+	}
+}
+
+// validateWebhookEvents checks that every event can be forwarded to webhooks
+func validateWebhookEvents(value interface{}) error {
+	events, _ := value.([]string)
+
+	for _, event := range events {
+		valid := false
+		for _, e := range ws.WebhookEvents {
+			if e == event {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return errors.New("contains an unknown event")
+		}
+	}
+
+	return nil
+}
+
+// validatePublicHost rejects urls that point to the server itself or its network.
+// Hosts resolving to internal addresses are rejected when the client connects.
+func validatePublicHost(value interface{}) error {
+	value, _ = validation.Indirect(value)
+	raw, _ := value.(string)
+	u, err := url.Parse(raw)
+
+	if err != nil || raw == "" {
+		return nil
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.New("must not point to an internal address")
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !model.IsPublicIP(ip) {
+		return errors.New("must not point to an internal address")
+	}
+
+	return nil
+}
+
+// GetWebhooks returns the webhooks of the given guild
+// GetWebhooks godoc
+// @Tags Webhooks
+// @Summary Get Guild Webhooks
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Success 200 {array} model.Webhook
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/webhooks [get]
+func (h *Handler) GetWebhooks(c *gin.Context) {
+	guild, ok := h.getWebhookGuild(c)
+
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.webhookService.GetWebhooks(guild.ID)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// If the guild does not have any webhooks, return an empty array
+	if len(*webhooks) == 0 {
+		empty := make([]model.Webhook, 0)
+		c.JSON(http.StatusOK, empty)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// CreateWebhook creates a webhook for the given guild
+// CreateWebhook godoc
+// @Tags Webhooks
+// @Summary Create Webhook
+// @Accepts json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param request body webhookReq true "Create Webhook"
+// @Success 201 {object} model.Webhook
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/webhooks [post]
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	var req webhookReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	if req.Secret == "" {
+		toFieldErrorResponse(c, "secret", "cannot be blank")
+		return
+	}
+
+	guild, ok := h.getWebhookGuild(c)
+
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(&model.Webhook{
+		GuildId: guild.ID,
+		Url:     req.Url,
+		Secret:  req.Secret,
+		Events:  pq.StringArray(req.Events),
+	})
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// EditWebhook edits the given webhook. Enabling a webhook resets its failure count.
+// EditWebhook godoc
+// @Tags Webhooks
+// @Summary Edit Webhook
+// @Accepts json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param request body webhookReq true "Edit Webhook"
+// @Success 200 {object} model.Webhook
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/webhooks/{webhookId} [put]
+func (h *Handler) EditWebhook(c *gin.Context) {
+	var req webhookReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	guild, ok := h.getWebhookGuild(c)
+
+	if !ok {
+		return
+	}
+
+	webhook, ok := h.getGuildWebhook(c, guild)
+
+	if !ok {
+		return
+	}
+
+	webhook.Url = req.Url
+	webhook.Events = req.Events
+
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+
+	if req.Enabled != nil {
+		if *req.Enabled && !webhook.Enabled {
+			webhook.FailureCount = 0
+		}
+		webhook.Enabled = *req.Enabled
+	}
+
+	if err := h.webhookService.UpdateWebhook(webhook); err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook deletes the given webhook and its delivery log
+// DeleteWebhook godoc
+// @Tags Webhooks
+// @Summary Delete Webhook
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/webhooks/{webhookId} [delete]
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	guild, ok := h.getWebhookGuild(c)
+
+	if !ok {
+		return
+	}
+
+	webhook, ok := h.getGuildWebhook(c, guild)
+
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(webhook); err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// deliveriesReq specifies the query parameters of the delivery log
+type deliveriesReq struct {
+	// Amount of deliveries. 1 to 100, defaults to 50
+	Limit int `form:"limit"`
+} //@name DeliveriesRequest
+
+func (r deliveriesReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Limit, validation.Min(0), validation.Max(model.MaximumDeliveries)),
+	)
+}
+
+// GetWebhookDeliveries returns the most recent deliveries of the given webhook
+// GetWebhookDeliveries godoc
+// @Tags Webhooks
+// @Summary Get Webhook Deliveries
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param limit query int false "Amount of deliveries. 1 to 100, defaults to 50"
+// @Success 200 {array} model.WebhookDelivery
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/webhooks/{webhookId}/deliveries [get]
+func (h *Handler) GetWebhookDeliveries(c *gin.Context) {
+	var req deliveriesReq
+
+	// Bind incoming query to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	guild, ok := h.getWebhookGuild(c)
+
+	if !ok {
+		return
+	}
+
+	webhook, ok := h.getGuildWebhook(c, guild)
+
+	if !ok {
+		return
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = model.DefaultDeliveries
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(webhook.ID, limit)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// If the webhook does not have any deliveries, return an empty array
+	if len(*deliveries) == 0 {
+		empty := make([]model.WebhookDelivery, 0)
+		c.JSON(http.StatusOK, empty)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// getWebhookGuild returns the guild of the request if the user is allowed to manage it.
+// Otherwise it writes the error response.
+func (h *Handler) getWebhookGuild(c *gin.Context) (*model.Guild, bool) {
+	userId := c.MustGet("userId").(string)
+	guildId := c.Param("guildId")
+
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("guild", guildId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	if !h.permissionService.HasPermission(userId, guild, model.ManageGuild) {
+		e := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	return guild, true
+}
+
+// getGuildWebhook returns the webhook of the request if it belongs to the guild.
+// Otherwise it writes the error response.
+func (h *Handler) getGuildWebhook(c *gin.Context, guild *model.Guild) (*model.Webhook, bool) {
+	webhookId := c.Param("webhookId")
+	webhook, err := h.webhookService.GetWebhook(webhookId)
+
+	if err != nil || webhook.GuildId != guild.ID {
+		e := apperrors.NewNotFound("webhook", webhookId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	return webhook, true
+}
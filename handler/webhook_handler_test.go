@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_CreateWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully created", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		secret := fixture.RandStr(32)
+
+		mockWebhook := &model.Webhook{
+			BaseModel: model.BaseModel{ID: fixture.RandID()},
+			GuildId:   mockGuild.ID,
+			Url:       "https://ci.example.com/hooks",
+			Secret:    secret,
+			Events:    []string{"new_message", "add_member"},
+			Enabled:   true,
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(true)
+
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("CreateWebhook", &model.Webhook{
+			GuildId: mockGuild.ID,
+			Url:     mockWebhook.Url,
+			Secret:  secret,
+			Events:  mockWebhook.Events,
+		}).Return(mockWebhook, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+			WebhookService:    mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"url":    mockWebhook.Url,
+			"secret": secret,
+			"events": mockWebhook.Events,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/guilds/%s/webhooks", mockGuild.ID), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockWebhook)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.NotContains(t, rr.Body.String(), secret)
+
+		mockWebhookService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		testCases := []gin.H{
+			{"url": "ftp://example.com", "secret": fixture.RandStr(32)},
+			{"url": "https://example.com", "secret": fixture.RandStr(8)},
+			{"url": "https://example.com"},
+			{"url": "https://example.com", "secret": fixture.RandStr(32), "events": []string{"new_mention"}},
+			{"url": "http://localhost:8080/hook", "secret": fixture.RandStr(32)},
+			{"url": "http://169.254.169.254/latest/meta-data", "secret": fixture.RandStr(32)},
+			{"url": "http://10.0.0.5/hook", "secret": fixture.RandStr(32)},
+			{"url": "http://[::1]/hook", "secret": fixture.RandStr(32)},
+		}
+
+		for _, body := range testCases {
+			mockGuildService := new(mocks.GuildService)
+			mockWebhookService := new(mocks.WebhookService)
+
+			rr := httptest.NewRecorder()
+
+			router := getAuthenticatedTestRouter(authUser.ID)
+
+			NewHandler(&Config{
+				R:              router,
+				GuildService:   mockGuildService,
+				WebhookService: mockWebhookService,
+			})
+
+			reqBody, err := json.Marshal(body)
+			assert.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/guilds/%s/webhooks", fixture.RandID()), strings.NewReader(string(reqBody)))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(rr, request)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockGuildService.AssertNotCalled(t, "GetGuild", mock.Anything)
+			mockWebhookService.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+		}
+	})
+
+	t.Run("Missing permissions", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(false)
+
+		mockWebhookService := new(mocks.WebhookService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+			WebhookService:    mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"url":    "https://example.com",
+			"secret": fixture.RandStr(32),
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/guilds/%s/webhooks", mockGuild.ID), strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MissingPermissions)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookService.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+	})
+}
+
+func TestHandler_EditWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Re-enabling resets the failure count", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		secret := fixture.RandStr(32)
+
+		mockWebhook := &model.Webhook{
+			BaseModel:    model.BaseModel{ID: fixture.RandID()},
+			GuildId:      mockGuild.ID,
+			Url:          "https://ci.example.com/hooks",
+			Secret:       secret,
+			Events:       []string{},
+			Enabled:      false,
+			FailureCount: model.WebhookFailureLimit,
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(true)
+
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("GetWebhook", mockWebhook.ID).Return(mockWebhook, nil)
+		mockWebhookService.On("UpdateWebhook", mock.MatchedBy(func(w *model.Webhook) bool {
+			// The secret is kept if it is omitted
+			return w.Enabled && w.FailureCount == 0 && w.Secret == secret && w.Url == "https://example.com/new"
+		})).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+			WebhookService:    mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"url":     "https://example.com/new",
+			"enabled": true,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/webhooks/%s", mockGuild.ID, mockWebhook.ID)
+		request, err := http.NewRequest(http.MethodPut, reqUrl, strings.NewReader(string(reqBody)))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockWebhookService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_DeleteWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Webhook of another guild", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockWebhook := &model.Webhook{
+			BaseModel: model.BaseModel{ID: fixture.RandID()},
+			GuildId:   fixture.RandID(),
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(true)
+
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("GetWebhook", mockWebhook.ID).Return(mockWebhook, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+			WebhookService:    mockWebhookService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/webhooks/%s", mockGuild.ID, mockWebhook.ID)
+		request, err := http.NewRequest(http.MethodDelete, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("webhook", mockWebhook.ID)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookService.AssertNotCalled(t, "DeleteWebhook", mock.Anything)
+	})
+}
+
+func TestHandler_GetWebhookDeliveries(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully fetched", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockWebhook := &model.Webhook{
+			BaseModel: model.BaseModel{ID: fixture.RandID()},
+			GuildId:   mockGuild.ID,
+		}
+		status := http.StatusOK
+		mockDeliveries := []model.WebhookDelivery{
+			{
+				ID:             fixture.RandID(),
+				WebhookId:      mockWebhook.ID,
+				Event:          "new_message",
+				Status:         model.DeliverySucceeded,
+				Attempts:       1,
+				ResponseStatus: &status,
+			},
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockPermissionService := new(mocks.PermissionService)
+		mockPermissionService.On("HasPermission", authUser.ID, mockGuild, model.ManageGuild).Return(true)
+
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("GetWebhook", mockWebhook.ID).Return(mockWebhook, nil)
+		mockWebhookService.On("GetDeliveries", mockWebhook.ID, model.DefaultDeliveries).Return(&mockDeliveries, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:                 router,
+			GuildService:      mockGuildService,
+			PermissionService: mockPermissionService,
+			WebhookService:    mockWebhookService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/webhooks/%s/deliveries", mockGuild.ID, mockWebhook.ID)
+		request, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockDeliveries)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookService.AssertExpectations(t)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -33,15 +34,41 @@ func inject(d *dataSources) (*gin.Engine, error) {
 	guildRepository := repository.NewGuildRepository(d.DB)
 	channelRepository := repository.NewChannelRepository(d.DB)
 	messageRepository := repository.NewMessageRepository(d.DB)
+	roleRepository := repository.NewRoleRepository(d.DB)
+	auditLogRepository := repository.NewAuditLogRepository(d.DB)
+	tokenRepository := repository.NewTokenRepository(d.DB)
+	webhookRepository := repository.NewWebhookRepository(d.DB)
+	incomingWebhookRepository := repository.NewIncomingWebhookRepository(d.DB)
+	commandRepository := repository.NewCommandRepository(d.DB)
+	webhookClient := repository.NewWebhookClient(10 * time.Second)
 
-	bucketName := os.Getenv("AWS_STORAGE_BUCKET_NAME")
-	fileRepository := repository.NewFileRepository(d.S3Session, bucketName)
+	var fileRepository model.FileRepository
+	var fileStore model.FileStore
+
+	switch driver := getEnvOrDefault("STORAGE_DRIVER", storageDriverS3); driver {
+	case storageDriverS3:
+		bucketName := os.Getenv("AWS_STORAGE_BUCKET_NAME")
+		fileRepository = repository.NewS3FileRepository(d.S3Session, bucketName)
+	case storageDriverLocal:
+		storageDir := getEnvOrDefault("STORAGE_DIR", "./uploads")
+		publicURL := getEnvOrDefault("STORAGE_PUBLIC_URL", "http://localhost:"+os.Getenv("PORT"))
+		localRepository := repository.NewLocalFileRepository(storageDir, publicURL, os.Getenv("SECRET"))
+		fileRepository = localRepository
+		fileStore = localRepository
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q, expected s3 or local", driver)
+	}
 	redisRepository := repository.NewRedisRepository(d.RedisClient)
 
-	gmailUser := os.Getenv("GMAIL_USER")
-	gmailPassword := os.Getenv("GMAIL_PASSWORD")
+	mailTransport, mailFrom, err := newMailTransport()
+	if err != nil {
+		return nil, err
+	}
+	mailQueue := repository.NewMailQueue(mailTransport, 30*time.Second)
+	go mailQueue.Run()
+
 	origin := os.Getenv("CORS_ORIGIN")
-	mailRepository := repository.NewMailRepository(gmailUser, gmailPassword, origin)
+	mailRepository := repository.NewMailRepository(mailQueue, mailFrom, origin)
 
 	/*
 	 * service layer
@@ -51,29 +78,63 @@ func inject(d *dataSources) (*gin.Engine, error) {
 		FileRepository:  fileRepository,
 		RedisRepository: redisRepository,
 		MailRepository:  mailRepository,
+		TokenRepository: tokenRepository,
+	})
+
+	sessionService := service.NewSessionService(&service.SNConfig{
+		RedisRepository: redisRepository,
 	})
 
 	friendService := service.NewFriendService(&service.FSConfig{
 		UserRepository:   userRepository,
 		FriendRepository: friendRepository,
+		RedisRepository:  redisRepository,
 	})
 
 	guildService := service.NewGuildService(&service.GSConfig{
-		UserRepository:    userRepository,
-		FileRepository:    fileRepository,
-		RedisRepository:   redisRepository,
-		GuildRepository:   guildRepository,
-		ChannelRepository: channelRepository,
+		UserRepository:     userRepository,
+		FileRepository:     fileRepository,
+		RedisRepository:    redisRepository,
+		GuildRepository:    guildRepository,
+		ChannelRepository:  channelRepository,
+		AuditLogRepository: auditLogRepository,
 	})
 
 	channelService := service.NewChannelService(&service.CSConfig{
-		ChannelRepository: channelRepository,
-		GuildRepository:   guildRepository,
+		ChannelRepository:  channelRepository,
+		GuildRepository:    guildRepository,
+		RoleRepository:     roleRepository,
+		MessageRepository:  messageRepository,
+		AuditLogRepository: auditLogRepository,
 	})
 
 	messageService := service.NewMessageService(&service.MSConfig{
 		MessageRepository: messageRepository,
 		FileRepository:    fileRepository,
+		ChannelRepository: channelRepository,
+		GuildRepository:   guildRepository,
+		RoleRepository:    roleRepository,
+	})
+
+	webhookService := service.NewWebhookService(&service.WHConfig{
+		WebhookRepository: webhookRepository,
+		WebhookClient:     webhookClient,
+		RetryDelay:        30 * time.Second,
+	})
+
+	incomingWebhookService := service.NewIncomingWebhookService(&service.IWConfig{
+		IncomingWebhookRepository: incomingWebhookRepository,
+		UserRepository:            userRepository,
+	})
+
+	commandService := service.NewCommandService(&service.CMConfig{
+		CommandRepository: commandRepository,
+		RedisRepository:   redisRepository,
+		WebhookClient:     webhookClient,
+	})
+
+	permissionService := service.NewPermissionService(&service.PSConfig{
+		RoleRepository: roleRepository,
 	})
 
 	// initialize gin.Engine
@@ -84,6 +145,7 @@ func inject(d *dataSources) (*gin.Engine, error) {
 		AllowedOrigins:   []string{origin},
 		AllowCredentials: true,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE"},
+		AllowedHeaders:   []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "Authorization", "X-Audit-Log-Reason", "X-MFA-Code"},
 	})
 	router.Use(c)
 
@@ -98,7 +160,7 @@ func inject(d *dataSources) (*gin.Engine, error) {
 
 	store.Options(sessions.Options{
 		Domain:   domain,
-		MaxAge:   60 * 60 * 24 * 7, // 7 days
+		MaxAge:   int(model.SessionTTL.Seconds()),
 		Secure:   gin.Mode() == gin.ReleaseMode,
 		HttpOnly: true,
 		Path:     "/",
@@ -121,6 +183,45 @@ func inject(d *dataSources) (*gin.Engine, error) {
 		return nil, fmt.Errorf("could not parse MAX_BODY_BYTES as int: %w", err)
 	}
 
+	// optional size limits per kind of attachment, defaulting to MAX_BODY_BYTES
+	fileSizeLimits := handler.FileSizeLimits{}
+	for kind, key := range map[string]string{
+		handler.FileKindImage:    "MAX_IMAGE_BYTES",
+		handler.FileKindAudio:    "MAX_AUDIO_BYTES",
+		handler.FileKindVideo:    "MAX_VIDEO_BYTES",
+		handler.FileKindDocument: "MAX_DOCUMENT_BYTES",
+		handler.FileKindArchive:  "MAX_ARCHIVE_BYTES",
+	} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+
+		limit, err := strconv.ParseInt(value, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s as int: %w", key, err)
+		}
+		fileSizeLimits[kind] = limit
+	}
+
+	// purge message revisions and deleted messages after the retention period
+	historyRetention := os.Getenv("MESSAGE_HISTORY_RETENTION_DAYS")
+	hr, err := strconv.ParseInt(historyRetention, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse MESSAGE_HISTORY_RETENTION_DAYS as int: %w", err)
+	}
+
+	go func() {
+		retention := time.Duration(hr) * 24 * time.Hour
+		ticker := time.NewTicker(time.Hour)
+		for {
+			if err := messageService.PurgeHistory(retention); err != nil {
+				log.Printf("could not purge the message history: %v\n", err)
+			}
+			<-ticker.C
+		}
+	}()
+
 	// add rate limit
 	rate := limiter.Rate{
 		Period: 1 * time.Hour,
@@ -130,7 +231,38 @@ func inject(d *dataSources) (*gin.Engine, error) {
 	limitStore, _ := sredis.NewStore(d.RedisClient)
 
 	rateLimiter := mgin.NewMiddleware(limiter.New(limitStore, rate))
-	router.Use(rateLimiter)
+	router.Use(func(c *gin.Context) {
+		// incoming webhooks use their own limiter
+		if strings.HasPrefix(c.FullPath(), "/api/webhooks/") {
+			c.Next()
+			return
+		}
+		rateLimiter(c)
+	})
+
+	// rate limit incoming webhooks per webhook, only counting requests with a valid token
+	webhookRate := limiter.Rate{
+		Period: 1 * time.Minute,
+		Limit:  30,
+	}
+
+	webhookStore, _ := sredis.NewStoreWithOptions(d.RedisClient, limiter.StoreOptions{
+		Prefix: "webhook_limiter",
+	})
+
+	webhookLimiter := repository.NewRateLimiter(webhookStore, webhookRate)
+
+	// rate limit failed incoming webhook requests per IP
+	webhookFailureRate := limiter.Rate{
+		Period: 10 * time.Minute,
+		Limit:  30,
+	}
+
+	webhookFailureStore, _ := sredis.NewStoreWithOptions(d.RedisClient, limiter.StoreOptions{
+		Prefix: "webhook_failure_limiter",
+	})
+
+	webhookFailureLimiter := repository.NewRateLimiter(webhookFailureStore, webhookFailureRate)
 
 	// Websockets Setup
 	hub := ws.NewWebsocketHub(&ws.Config{
@@ -141,7 +273,7 @@ func inject(d *dataSources) (*gin.Engine, error) {
 	})
 	go hub.Run()
 
-	router.GET("/ws", middleware.AuthUser(), func(c *gin.Context) {
+	router.GET("/ws", middleware.AuthUser(userService, sessionService), func(c *gin.Context) {
 		ws.ServeWs(hub, c)
 	})
 
@@ -149,19 +281,84 @@ func inject(d *dataSources) (*gin.Engine, error) {
 		Hub:               *hub,
 		GuildRepository:   guildRepository,
 		ChannelRepository: channelRepository,
+		WebhookService:    webhookService,
 	})
 
+	// deliver queued webhook events
+	go func() {
+		ticker := time.NewTicker(5 * time.Second)
+		for {
+			if err := webhookService.ProcessDeliveries(); err != nil {
+				log.Printf("could not process webhook deliveries: %v\n", err)
+			}
+			<-ticker.C
+		}
+	}()
+
 	handler.NewHandler(&handler.Config{
-		R:               router,
-		UserService:     userService,
-		FriendService:   friendService,
-		GuildService:    guildService,
-		ChannelService:  channelService,
-		MessageService:  messageService,
-		SocketService:   socketService,
-		TimeoutDuration: time.Duration(ht) * time.Second,
-		MaxBodyBytes:    mbb,
+		R:                      router,
+		UserService:            userService,
+		FriendService:          friendService,
+		GuildService:           guildService,
+		ChannelService:         channelService,
+		MessageService:         messageService,
+		SocketService:          socketService,
+		PermissionService:      permissionService,
+		WebhookService:         webhookService,
+		IncomingWebhookService: incomingWebhookService,
+		CommandService:         commandService,
+		SessionService:         sessionService,
+		FileStore:              fileStore,
+		WebhookLimiter:         webhookLimiter,
+		WebhookFailureLimiter:  webhookFailureLimiter,
+		TimeoutDuration:        time.Duration(ht) * time.Second,
+		MaxBodyBytes:           mbb,
+		FileSizeLimits:         fileSizeLimits,
 	})
 
 	return router, nil
 }
+
+// Supported values of STORAGE_DRIVER
+const (
+	storageDriverS3    = "s3"
+	storageDriverLocal = "local"
+)
+
+// newMailTransport creates the mail transport configured by MAIL_TRANSPORT
+// and returns it together with the sender address.
+// The SMTP settings default to Gmail to stay compatible with GMAIL_USER and GMAIL_PASSWORD.
+func newMailTransport() (model.MailTransport, string, error) {
+	username := getEnvOrDefault("SMTP_USERNAME", os.Getenv("GMAIL_USER"))
+	password := getEnvOrDefault("SMTP_PASSWORD", os.Getenv("GMAIL_PASSWORD"))
+	from := getEnvOrDefault("MAIL_FROM", username)
+
+	switch transport := getEnvOrDefault("MAIL_TRANSPORT", "smtp"); transport {
+	case "file":
+		return repository.NewFileTransport(os.Getenv("MAIL_DIR")), from, nil
+	case "smtp":
+	default:
+		return nil, "", fmt.Errorf("unknown MAIL_TRANSPORT %q, expected smtp or file", transport)
+	}
+
+	host := getEnvOrDefault("SMTP_HOST", "smtp.gmail.com")
+	port, err := strconv.Atoi(getEnvOrDefault("SMTP_PORT", "587"))
+	if err != nil {
+		return nil, "", fmt.Errorf("could not parse SMTP_PORT as int: %w", err)
+	}
+
+	mode := getEnvOrDefault("SMTP_TLS", repository.SMTPStartTLS)
+	if mode != repository.SMTPStartTLS && mode != repository.SMTPTLS && mode != repository.SMTPNone {
+		return nil, "", fmt.Errorf("unknown SMTP_TLS %q, expected starttls, tls or none", mode)
+	}
+
+	return repository.NewSMTPTransport(host, port, username, password, mode), from, nil
+}
+
+// getEnvOrDefault returns the environment variable or the fallback if it is not set
+func getEnvOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -14,7 +14,7 @@ import (
 
 // @title Valkyrie API
 // @version 1.0
-// @description Valkyrie REST API Specs. This service uses sessions for authentication
+// @description Valkyrie REST API Specs. This service uses sessions or "Bot"/"Bearer" tokens in the Authorization header for authentication
 
 // @license.name Apache 2.0
 // @host localhost:<PORT>
@@ -0,0 +1,50 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "github.com/sentrionic/valkyrie/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditLogRepository is an autogenerated mock type for the AuditLogRepository type
+type AuditLogRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: entry
+func (_m *AuditLogRepository) Create(entry *model.AuditLogEntry) error {
+	ret := _m.Called(entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.AuditLogEntry) error); ok {
+		r0 = rf(entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: query
+func (_m *AuditLogRepository) Find(query *model.AuditLogQuery) (*[]model.AuditLogEntry, error) {
+	ret := _m.Called(query)
+
+	var r0 *[]model.AuditLogEntry
+	if rf, ok := ret.Get(0).(func(*model.AuditLogQuery) *[]model.AuditLogEntry); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.AuditLogEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.AuditLogQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
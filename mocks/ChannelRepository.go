@@ -91,6 +91,20 @@ func (_m *ChannelRepository) DeleteChannel(channel *model.Channel) error {
 	return r0
 }
 
+// DeleteOverwrite provides a mock function with given fields: channelId, targetId
+func (_m *ChannelRepository) DeleteOverwrite(channelId string, targetId string) error {
+	ret := _m.Called(channelId, targetId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(channelId, targetId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindDMByUserAndChannelId provides a mock function with given fields: channelId, userId
 func (_m *ChannelRepository) FindDMByUserAndChannelId(channelId string, userId string) (string, error) {
 	ret := _m.Called(channelId, userId)
@@ -250,6 +264,29 @@ func (_m *ChannelRepository) GetGuildDefault(guildId string) (*model.Channel, er
 	return r0, r1
 }
 
+// GetGuildOverwrites provides a mock function with given fields: guildId
+func (_m *ChannelRepository) GetGuildOverwrites(guildId string) (*[]model.PermissionOverwrite, error) {
+	ret := _m.Called(guildId)
+
+	var r0 *[]model.PermissionOverwrite
+	if rf, ok := ret.Get(0).(func(string) *[]model.PermissionOverwrite); ok {
+		r0 = rf(guildId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.PermissionOverwrite)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPrivateChannelMembers provides a mock function with given fields: channelId
 func (_m *ChannelRepository) GetPrivateChannelMembers(channelId string) (*[]string, error) {
 	ret := _m.Called(channelId)
@@ -273,6 +310,29 @@ func (_m *ChannelRepository) GetPrivateChannelMembers(channelId string) (*[]stri
 	return r0, r1
 }
 
+// GetThreads provides a mock function with given fields: channelId
+func (_m *ChannelRepository) GetThreads(channelId string) (*[]model.Channel, error) {
+	ret := _m.Called(channelId)
+
+	var r0 *[]model.Channel
+	if rf, ok := ret.Get(0).(func(string) *[]model.Channel); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.Channel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OpenDMForAll provides a mock function with given fields: dmId
 func (_m *ChannelRepository) OpenDMForAll(dmId string) error {
 	ret := _m.Called(dmId)
@@ -315,6 +375,43 @@ func (_m *ChannelRepository) SetDirectMessageStatus(dmId string, userId string,
 	return r0
 }
 
+// SetOverwrite provides a mock function with given fields: overwrite
+func (_m *ChannelRepository) SetOverwrite(overwrite *model.PermissionOverwrite) error {
+	ret := _m.Called(overwrite)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.PermissionOverwrite) error); ok {
+		r0 = rf(overwrite)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetReadState provides a mock function with given fields: state
+func (_m *ChannelRepository) SetReadState(state *model.ReadState) (*model.ReadState, error) {
+	ret := _m.Called(state)
+
+	var r0 *model.ReadState
+	if rf, ok := ret.Get(0).(func(*model.ReadState) *model.ReadState); ok {
+		r0 = rf(state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ReadState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.ReadState) error); ok {
+		r1 = rf(state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateChannel provides a mock function with given fields: channel
 func (_m *ChannelRepository) UpdateChannel(channel *model.Channel) error {
 	ret := _m.Called(channel)
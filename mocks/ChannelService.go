@@ -12,6 +12,29 @@ type ChannelService struct {
 	mock.Mock
 }
 
+// AckChannel provides a mock function with given fields: channel, userId, messageId
+func (_m *ChannelService) AckChannel(channel *model.Channel, userId string, messageId string) (*model.ReadState, error) {
+	ret := _m.Called(channel, userId, messageId)
+
+	var r0 *model.ReadState
+	if rf, ok := ret.Get(0).(func(*model.Channel, string, string) *model.ReadState); ok {
+		r0 = rf(channel, userId, messageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ReadState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Channel, string, string) error); ok {
+		r1 = rf(channel, userId, messageId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddDMChannelMembers provides a mock function with given fields: memberIds, channelId, userId
 func (_m *ChannelService) AddDMChannelMembers(memberIds []string, channelId string, userId string) error {
 	ret := _m.Called(memberIds, channelId, userId)
@@ -77,13 +100,41 @@ func (_m *ChannelService) CreateChannel(channel *model.Channel) (*model.Channel,
 	return r0, r1
 }
 
-// DeleteChannel provides a mock function with given fields: channel
-func (_m *ChannelService) DeleteChannel(channel *model.Channel) error {
-	ret := _m.Called(channel)
+// DeleteChannel provides a mock function with given fields: channel, actorId
+func (_m *ChannelService) DeleteChannel(channel *model.Channel, actorId string) error {
+	ret := _m.Called(channel, actorId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*model.Channel) error); ok {
-		r0 = rf(channel)
+	if rf, ok := ret.Get(0).(func(*model.Channel, string) error); ok {
+		r0 = rf(channel, actorId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOverwrite provides a mock function with given fields: channelId, targetId
+func (_m *ChannelService) DeleteOverwrite(channelId string, targetId string) error {
+	ret := _m.Called(channelId, targetId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(channelId, targetId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditChannel provides a mock function with given fields: channel, actorId
+func (_m *ChannelService) EditChannel(channel *model.Channel, actorId string) error {
+	ret := _m.Called(channel, actorId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Channel, string) error); ok {
+		r0 = rf(channel, actorId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -204,6 +255,27 @@ func (_m *ChannelService) GetDirectMessages(userId string) (*[]model.DirectMessa
 	return r0, r1
 }
 
+// GetPermissions provides a mock function with given fields: channel, userId
+func (_m *ChannelService) GetPermissions(channel *model.Channel, userId string) (model.Permission, error) {
+	ret := _m.Called(channel, userId)
+
+	var r0 model.Permission
+	if rf, ok := ret.Get(0).(func(*model.Channel, string) model.Permission); ok {
+		r0 = rf(channel, userId)
+	} else {
+		r0 = ret.Get(0).(model.Permission)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Channel, string) error); ok {
+		r1 = rf(channel, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPrivateChannelMembers provides a mock function with given fields: channelId
 func (_m *ChannelService) GetPrivateChannelMembers(channelId string) (*[]string, error) {
 	ret := _m.Called(channelId)
@@ -227,6 +299,52 @@ func (_m *ChannelService) GetPrivateChannelMembers(channelId string) (*[]string,
 	return r0, r1
 }
 
+// GetReadableChannelIds provides a mock function with given fields: userId, guildId
+func (_m *ChannelService) GetReadableChannelIds(userId string, guildId string) ([]string, error) {
+	ret := _m.Called(userId, guildId)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(userId, guildId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, guildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreads provides a mock function with given fields: channelId
+func (_m *ChannelService) GetThreads(channelId string) (*[]model.Channel, error) {
+	ret := _m.Called(channelId)
+
+	var r0 *[]model.Channel
+	if rf, ok := ret.Get(0).(func(string) *[]model.Channel); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.Channel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsChannelMember provides a mock function with given fields: channel, userId
 func (_m *ChannelService) IsChannelMember(channel *model.Channel, userId string) error {
 	ret := _m.Called(channel, userId)
@@ -283,6 +401,20 @@ func (_m *ChannelService) SetDirectMessageStatus(dmId string, userId string, isO
 	return r0
 }
 
+// SetOverwrite provides a mock function with given fields: overwrite
+func (_m *ChannelService) SetOverwrite(overwrite *model.PermissionOverwrite) error {
+	ret := _m.Called(overwrite)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.PermissionOverwrite) error); ok {
+		r0 = rf(overwrite)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateChannel provides a mock function with given fields: channel
 func (_m *ChannelService) UpdateChannel(channel *model.Channel) error {
 	ret := _m.Called(channel)
@@ -0,0 +1,190 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/sentrionic/valkyrie/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CommandService is an autogenerated mock type for the CommandService type
+type CommandService struct {
+	mock.Mock
+}
+
+// CreateInteraction provides a mock function with given fields: ctx, command, channelId, userId, options
+func (_m *CommandService) CreateInteraction(ctx context.Context, command *model.Command, channelId string, userId string, options map[string]interface{}) (*model.Interaction, error) {
+	ret := _m.Called(ctx, command, channelId, userId, options)
+
+	var r0 *model.Interaction
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Command, string, string, map[string]interface{}) *model.Interaction); ok {
+		r0 = rf(ctx, command, channelId, userId, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Interaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Command, string, string, map[string]interface{}) error); ok {
+		r1 = rf(ctx, command, channelId, userId, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteCommand provides a mock function with given fields: command
+func (_m *CommandService) DeleteCommand(command *model.Command) error {
+	ret := _m.Called(command)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Command) error); ok {
+		r0 = rf(command)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DispatchInteraction provides a mock function with given fields: command, interaction
+func (_m *CommandService) DispatchInteraction(command *model.Command, interaction *model.Interaction) error {
+	ret := _m.Called(command, interaction)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Command, *model.Interaction) error); ok {
+		r0 = rf(command, interaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCommand provides a mock function with given fields: commandId
+func (_m *CommandService) GetCommand(commandId string) (*model.Command, error) {
+	ret := _m.Called(commandId)
+
+	var r0 *model.Command
+	if rf, ok := ret.Get(0).(func(string) *model.Command); ok {
+		r0 = rf(commandId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Command)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(commandId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommands provides a mock function with given fields: guildId
+func (_m *CommandService) GetCommands(guildId string) (*[]model.Command, error) {
+	ret := _m.Called(guildId)
+
+	var r0 *[]model.Command
+	if rf, ok := ret.Get(0).(func(string) *[]model.Command); ok {
+		r0 = rf(guildId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.Command)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInteraction provides a mock function with given fields: ctx, interactionId
+func (_m *CommandService) GetInteraction(ctx context.Context, interactionId string) (*model.Interaction, error) {
+	ret := _m.Called(ctx, interactionId)
+
+	var r0 *model.Interaction
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Interaction); ok {
+		r0 = rf(ctx, interactionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Interaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, interactionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParseCommand provides a mock function with given fields: guildId, text
+func (_m *CommandService) ParseCommand(guildId string, text string) (*model.Command, map[string]interface{}, error) {
+	ret := _m.Called(guildId, text)
+
+	var r0 *model.Command
+	if rf, ok := ret.Get(0).(func(string, string) *model.Command); ok {
+		r0 = rf(guildId, text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Command)
+		}
+	}
+
+	var r1 map[string]interface{}
+	if rf, ok := ret.Get(1).(func(string, string) map[string]interface{}); ok {
+		r1 = rf(guildId, text)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[string]interface{})
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(guildId, text)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RegisterCommand provides a mock function with given fields: command
+func (_m *CommandService) RegisterCommand(command *model.Command) (*model.Command, error) {
+	ret := _m.Called(command)
+
+	var r0 *model.Command
+	if rf, ok := ret.Get(0).(func(*model.Command) *model.Command); ok {
+		r0 = rf(command)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Command)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Command) error); ok {
+		r1 = rf(command)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
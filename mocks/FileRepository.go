@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
 	multipart "mime/multipart"
@@ -48,20 +50,20 @@ func (_m *FileRepository) UploadAvatar(header *multipart.FileHeader, directory s
 	return r0, r1
 }
 
-// UploadFile provides a mock function with given fields: header, directory, filename, mimetype
-func (_m *FileRepository) UploadFile(header *multipart.FileHeader, directory string, filename string, mimetype string) (string, error) {
-	ret := _m.Called(header, directory, filename, mimetype)
+// UploadFile provides a mock function with given fields: file, directory, filename, mimetype
+func (_m *FileRepository) UploadFile(file io.Reader, directory string, filename string, mimetype string) (string, error) {
+	ret := _m.Called(file, directory, filename, mimetype)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(*multipart.FileHeader, string, string, string) string); ok {
-		r0 = rf(header, directory, filename, mimetype)
+	if rf, ok := ret.Get(0).(func(io.Reader, string, string, string) string); ok {
+		r0 = rf(file, directory, filename, mimetype)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*multipart.FileHeader, string, string, string) error); ok {
-		r1 = rf(header, directory, filename, mimetype)
+	if rf, ok := ret.Get(1).(func(io.Reader, string, string, string) error); ok {
+		r1 = rf(file, directory, filename, mimetype)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,31 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// FileStore is an autogenerated mock type for the FileStore type
+type FileStore struct {
+	mock.Mock
+}
+
+// ResolveFile provides a mock function with given fields: key, signature
+func (_m *FileStore) ResolveFile(key string, signature string) (string, error) {
+	ret := _m.Called(key, signature)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(key, signature)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, signature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -14,6 +14,20 @@ type GuildService struct {
 	mock.Mock
 }
 
+// BanMember provides a mock function with given fields: member, guild, actorId, reason
+func (_m *GuildService) BanMember(member *model.User, guild *model.Guild, actorId string, reason *string) error {
+	ret := _m.Called(member, guild, actorId, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.User, *model.Guild, string, *string) error); ok {
+		r0 = rf(member, guild, actorId, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateGuild provides a mock function with given fields: guild
 func (_m *GuildService) CreateGuild(guild *model.Guild) (*model.Guild, error) {
 	ret := _m.Called(guild)
@@ -51,6 +65,20 @@ func (_m *GuildService) DeleteGuild(guildId string) error {
 	return r0
 }
 
+// EditGuild provides a mock function with given fields: guild, actorId
+func (_m *GuildService) EditGuild(guild *model.Guild, actorId string) error {
+	ret := _m.Called(guild, actorId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Guild, string) error); ok {
+		r0 = rf(guild, actorId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindUsersByIds provides a mock function with given fields: ids, guildId
 func (_m *GuildService) FindUsersByIds(ids []string, guildId string) (*[]model.User, error) {
 	ret := _m.Called(ids, guildId)
@@ -95,6 +123,29 @@ func (_m *GuildService) GenerateInviteLink(ctx context.Context, guildId string,
 	return r0, r1
 }
 
+// GetAuditLogs provides a mock function with given fields: query
+func (_m *GuildService) GetAuditLogs(query *model.AuditLogQuery) (*[]model.AuditLogEntry, error) {
+	ret := _m.Called(query)
+
+	var r0 *[]model.AuditLogEntry
+	if rf, ok := ret.Get(0).(func(*model.AuditLogQuery) *[]model.AuditLogEntry); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.AuditLogEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.AuditLogQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBanList provides a mock function with given fields: guildId
 func (_m *GuildService) GetBanList(guildId string) (*[]model.BanResponse, error) {
 	ret := _m.Called(guildId)
@@ -277,9 +328,23 @@ func (_m *GuildService) GetUserGuilds(uid string) (*[]model.GuildResponse, error
 	return r0, r1
 }
 
-// InvalidateInvites provides a mock function with given fields: ctx, guild
-func (_m *GuildService) InvalidateInvites(ctx context.Context, guild *model.Guild) {
-	_m.Called(ctx, guild)
+// InvalidateInvites provides a mock function with given fields: ctx, guild, actorId
+func (_m *GuildService) InvalidateInvites(ctx context.Context, guild *model.Guild, actorId string) {
+	_m.Called(ctx, guild, actorId)
+}
+
+// KickMember provides a mock function with given fields: memberId, guildId, actorId, reason
+func (_m *GuildService) KickMember(memberId string, guildId string, actorId string, reason *string) error {
+	ret := _m.Called(memberId, guildId, actorId, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, *string) error); ok {
+		r0 = rf(memberId, guildId, actorId, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // RemoveMember provides a mock function with given fields: userId, guildId
@@ -296,13 +361,13 @@ func (_m *GuildService) RemoveMember(userId string, guildId string) error {
 	return r0
 }
 
-// UnbanMember provides a mock function with given fields: userId, guildId
-func (_m *GuildService) UnbanMember(userId string, guildId string) error {
-	ret := _m.Called(userId, guildId)
+// UnbanMember provides a mock function with given fields: userId, guildId, actorId
+func (_m *GuildService) UnbanMember(userId string, guildId string, actorId string) error {
+	ret := _m.Called(userId, guildId, actorId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(userId, guildId)
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(userId, guildId, actorId)
 	} else {
 		r0 = ret.Error(0)
 	}
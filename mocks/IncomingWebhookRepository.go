@@ -0,0 +1,108 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "github.com/sentrionic/valkyrie/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IncomingWebhookRepository is an autogenerated mock type for the IncomingWebhookRepository type
+type IncomingWebhookRepository struct {
+	mock.Mock
+}
+
+// CountByChannel provides a mock function with given fields: channelId
+func (_m *IncomingWebhookRepository) CountByChannel(channelId string) (int64, error) {
+	ret := _m.Called(channelId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(channelId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: webhook
+func (_m *IncomingWebhookRepository) Create(webhook *model.IncomingWebhook) error {
+	ret := _m.Called(webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.IncomingWebhook) error); ok {
+		r0 = rf(webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: webhook
+func (_m *IncomingWebhookRepository) Delete(webhook *model.IncomingWebhook) error {
+	ret := _m.Called(webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.IncomingWebhook) error); ok {
+		r0 = rf(webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByChannel provides a mock function with given fields: channelId
+func (_m *IncomingWebhookRepository) FindByChannel(channelId string) (*[]model.IncomingWebhook, error) {
+	ret := _m.Called(channelId)
+
+	var r0 *[]model.IncomingWebhook
+	if rf, ok := ret.Get(0).(func(string) *[]model.IncomingWebhook); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.IncomingWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: id
+func (_m *IncomingWebhookRepository) FindByID(id string) (*model.IncomingWebhook, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.IncomingWebhook
+	if rf, ok := ret.Get(0).(func(string) *model.IncomingWebhook); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IncomingWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,119 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "github.com/sentrionic/valkyrie/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IncomingWebhookService is an autogenerated mock type for the IncomingWebhookService type
+type IncomingWebhookService struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: webhookId, token
+func (_m *IncomingWebhookService) Authenticate(webhookId string, token string) (*model.IncomingWebhook, error) {
+	ret := _m.Called(webhookId, token)
+
+	var r0 *model.IncomingWebhook
+	if rf, ok := ret.Get(0).(func(string, string) *model.IncomingWebhook); ok {
+		r0 = rf(webhookId, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IncomingWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(webhookId, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWebhook provides a mock function with given fields: channel, creatorId, name
+func (_m *IncomingWebhookService) CreateWebhook(channel *model.Channel, creatorId string, name string) (*model.CreatedIncomingWebhook, error) {
+	ret := _m.Called(channel, creatorId, name)
+
+	var r0 *model.CreatedIncomingWebhook
+	if rf, ok := ret.Get(0).(func(*model.Channel, string, string) *model.CreatedIncomingWebhook); ok {
+		r0 = rf(channel, creatorId, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CreatedIncomingWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Channel, string, string) error); ok {
+		r1 = rf(channel, creatorId, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhook provides a mock function with given fields: webhook
+func (_m *IncomingWebhookService) DeleteWebhook(webhook *model.IncomingWebhook) error {
+	ret := _m.Called(webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.IncomingWebhook) error); ok {
+		r0 = rf(webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetWebhook provides a mock function with given fields: webhookId
+func (_m *IncomingWebhookService) GetWebhook(webhookId string) (*model.IncomingWebhook, error) {
+	ret := _m.Called(webhookId)
+
+	var r0 *model.IncomingWebhook
+	if rf, ok := ret.Get(0).(func(string) *model.IncomingWebhook); ok {
+		r0 = rf(webhookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IncomingWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(webhookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooks provides a mock function with given fields: channelId
+func (_m *IncomingWebhookService) GetWebhooks(channelId string) (*[]model.IncomingWebhook, error) {
+	ret := _m.Called(channelId)
+
+	var r0 *[]model.IncomingWebhook
+	if rf, ok := ret.Get(0).(func(string) *[]model.IncomingWebhook); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.IncomingWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	multipart "mime/multipart"
+)
+
+// LocalFileRepository is an autogenerated mock type for the LocalFileRepository type
+type LocalFileRepository struct {
+	mock.Mock
+}
+
+// DeleteImage provides a mock function with given fields: key
+func (_m *LocalFileRepository) DeleteImage(key string) error {
+	ret := _m.Called(key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResolveFile provides a mock function with given fields: key, signature
+func (_m *LocalFileRepository) ResolveFile(key string, signature string) (string, error) {
+	ret := _m.Called(key, signature)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(key, signature)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, signature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadAvatar provides a mock function with given fields: header, directory
+func (_m *LocalFileRepository) UploadAvatar(header *multipart.FileHeader, directory string) (string, error) {
+	ret := _m.Called(header, directory)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*multipart.FileHeader, string) string); ok {
+		r0 = rf(header, directory)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*multipart.FileHeader, string) error); ok {
+		r1 = rf(header, directory)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadFile provides a mock function with given fields: file, directory, filename, mimetype
+func (_m *LocalFileRepository) UploadFile(file io.Reader, directory string, filename string, mimetype string) (string, error) {
+	ret := _m.Called(file, directory, filename, mimetype)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(io.Reader, string, string, string) string); ok {
+		r0 = rf(file, directory, filename, mimetype)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(io.Reader, string, string, string) error); ok {
+		r1 = rf(file, directory, filename, mimetype)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
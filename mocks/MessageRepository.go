@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	time "time"
+
 	model "github.com/sentrionic/valkyrie/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,6 +14,41 @@ type MessageRepository struct {
 	mock.Mock
 }
 
+// AddReaction provides a mock function with given fields: reaction
+func (_m *MessageRepository) AddReaction(reaction *model.Reaction) error {
+	ret := _m.Called(reaction)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Reaction) error); ok {
+		r0 = rf(reaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountPinnedMessages provides a mock function with given fields: channelId
+func (_m *MessageRepository) CountPinnedMessages(channelId string) (int64, error) {
+	ret := _m.Called(channelId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(channelId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateMessage provides a mock function with given fields: params
 func (_m *MessageRepository) CreateMessage(params *model.Message) (*model.Message, error) {
 	ret := _m.Called(params)
@@ -49,6 +86,57 @@ func (_m *MessageRepository) DeleteMessage(message *model.Message) error {
 	return r0
 }
 
+// DeleteMessagesBefore provides a mock function with given fields: date
+func (_m *MessageRepository) DeleteMessagesBefore(date time.Time) (*[]model.Attachment, int64, error) {
+	ret := _m.Called(date)
+
+	var r0 *[]model.Attachment
+	if rf, ok := ret.Get(0).(func(time.Time) *[]model.Attachment); ok {
+		r0 = rf(date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.Attachment)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(time.Time) int64); ok {
+		r1 = rf(date)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(time.Time) error); ok {
+		r2 = rf(date)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteRevisionsBefore provides a mock function with given fields: date
+func (_m *MessageRepository) DeleteRevisionsBefore(date time.Time) (int64, error) {
+	ret := _m.Called(date)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(date)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetById provides a mock function with given fields: messageId
 func (_m *MessageRepository) GetById(messageId string) (*model.Message, error) {
 	ret := _m.Called(messageId)
@@ -72,6 +160,29 @@ func (_m *MessageRepository) GetById(messageId string) (*model.Message, error) {
 	return r0, r1
 }
 
+// GetHistory provides a mock function with given fields: messageId
+func (_m *MessageRepository) GetHistory(messageId string) (*model.MessageHistory, error) {
+	ret := _m.Called(messageId)
+
+	var r0 *model.MessageHistory
+	if rf, ok := ret.Get(0).(func(string) *model.MessageHistory); ok {
+		r0 = rf(messageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MessageHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(messageId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMessages provides a mock function with given fields: userId, channel, cursor
 func (_m *MessageRepository) GetMessages(userId string, channel *model.Channel, cursor string) (*[]model.MessageResponse, error) {
 	ret := _m.Called(userId, channel, cursor)
@@ -95,6 +206,80 @@ func (_m *MessageRepository) GetMessages(userId string, channel *model.Channel,
 	return r0, r1
 }
 
+// GetPinnedMessages provides a mock function with given fields: userId, channel
+func (_m *MessageRepository) GetPinnedMessages(userId string, channel *model.Channel) (*[]model.MessageResponse, error) {
+	ret := _m.Called(userId, channel)
+
+	var r0 *[]model.MessageResponse
+	if rf, ok := ret.Get(0).(func(string, *model.Channel) *[]model.MessageResponse); ok {
+		r0 = rf(userId, channel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.MessageResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *model.Channel) error); ok {
+		r1 = rf(userId, channel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveReaction provides a mock function with given fields: reaction
+func (_m *MessageRepository) RemoveReaction(reaction *model.Reaction) error {
+	ret := _m.Called(reaction)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Reaction) error); ok {
+		r0 = rf(reaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchMessages provides a mock function with given fields: userId, search
+func (_m *MessageRepository) SearchMessages(userId string, search *model.MessageSearch) (*[]model.MessageResponse, error) {
+	ret := _m.Called(userId, search)
+
+	var r0 *[]model.MessageResponse
+	if rf, ok := ret.Get(0).(func(string, *model.MessageSearch) *[]model.MessageResponse); ok {
+		r0 = rf(userId, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.MessageResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *model.MessageSearch) error); ok {
+		r1 = rf(userId, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetPinned provides a mock function with given fields: message
+func (_m *MessageRepository) SetPinned(message *model.Message) error {
+	ret := _m.Called(message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Message) error); ok {
+		r0 = rf(message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateMessage provides a mock function with given fields: message
 func (_m *MessageRepository) UpdateMessage(message *model.Message) error {
 	ret := _m.Called(message)
@@ -3,10 +3,11 @@
 package mocks
 
 import (
+	multipart "mime/multipart"
+	time "time"
+
 	model "github.com/sentrionic/valkyrie/model"
 	mock "github.com/stretchr/testify/mock"
-
-	multipart "mime/multipart"
 )
 
 // MessageService is an autogenerated mock type for the MessageService type
@@ -14,6 +15,20 @@ type MessageService struct {
 	mock.Mock
 }
 
+// AddReaction provides a mock function with given fields: reaction
+func (_m *MessageService) AddReaction(reaction *model.Reaction) error {
+	ret := _m.Called(reaction)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Reaction) error); ok {
+		r0 = rf(reaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateMessage provides a mock function with given fields: params
 func (_m *MessageService) CreateMessage(params *model.Message) (*model.Message, error) {
 	ret := _m.Called(params)
@@ -74,6 +89,29 @@ func (_m *MessageService) Get(messageId string) (*model.Message, error) {
 	return r0, r1
 }
 
+// GetHistory provides a mock function with given fields: messageId
+func (_m *MessageService) GetHistory(messageId string) (*model.MessageHistory, error) {
+	ret := _m.Called(messageId)
+
+	var r0 *model.MessageHistory
+	if rf, ok := ret.Get(0).(func(string) *model.MessageHistory); ok {
+		r0 = rf(messageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MessageHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(messageId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMessages provides a mock function with given fields: userId, channel, cursor
 func (_m *MessageService) GetMessages(userId string, channel *model.Channel, cursor string) (*[]model.MessageResponse, error) {
 	ret := _m.Called(userId, channel, cursor)
@@ -97,6 +135,108 @@ func (_m *MessageService) GetMessages(userId string, channel *model.Channel, cur
 	return r0, r1
 }
 
+// GetPinnedMessages provides a mock function with given fields: userId, channel
+func (_m *MessageService) GetPinnedMessages(userId string, channel *model.Channel) (*[]model.MessageResponse, error) {
+	ret := _m.Called(userId, channel)
+
+	var r0 *[]model.MessageResponse
+	if rf, ok := ret.Get(0).(func(string, *model.Channel) *[]model.MessageResponse); ok {
+		r0 = rf(userId, channel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.MessageResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *model.Channel) error); ok {
+		r1 = rf(userId, channel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PinMessage provides a mock function with given fields: message
+func (_m *MessageService) PinMessage(message *model.Message) error {
+	ret := _m.Called(message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Message) error); ok {
+		r0 = rf(message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeHistory provides a mock function with given fields: retention
+func (_m *MessageService) PurgeHistory(retention time.Duration) error {
+	ret := _m.Called(retention)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Duration) error); ok {
+		r0 = rf(retention)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveReaction provides a mock function with given fields: reaction
+func (_m *MessageService) RemoveReaction(reaction *model.Reaction) error {
+	ret := _m.Called(reaction)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Reaction) error); ok {
+		r0 = rf(reaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchMessages provides a mock function with given fields: userId, search
+func (_m *MessageService) SearchMessages(userId string, search *model.MessageSearch) (*[]model.MessageResponse, error) {
+	ret := _m.Called(userId, search)
+
+	var r0 *[]model.MessageResponse
+	if rf, ok := ret.Get(0).(func(string, *model.MessageSearch) *[]model.MessageResponse); ok {
+		r0 = rf(userId, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.MessageResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *model.MessageSearch) error); ok {
+		r1 = rf(userId, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnpinMessage provides a mock function with given fields: message
+func (_m *MessageService) UnpinMessage(message *model.Message) error {
+	ret := _m.Called(message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Message) error); ok {
+		r0 = rf(message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateMessage provides a mock function with given fields: message
 func (_m *MessageService) UpdateMessage(message *model.Message) error {
 	ret := _m.Called(message)
@@ -111,13 +251,13 @@ func (_m *MessageService) UpdateMessage(message *model.Message) error {
 	return r0
 }
 
-// UploadFile provides a mock function with given fields: header, channelId
-func (_m *MessageService) UploadFile(header *multipart.FileHeader, channelId string) (*model.Attachment, error) {
-	ret := _m.Called(header, channelId)
+// UploadFile provides a mock function with given fields: header, channelId, mimetype
+func (_m *MessageService) UploadFile(header *multipart.FileHeader, channelId string, mimetype string) (*model.Attachment, error) {
+	ret := _m.Called(header, channelId, mimetype)
 
 	var r0 *model.Attachment
-	if rf, ok := ret.Get(0).(func(*multipart.FileHeader, string) *model.Attachment); ok {
-		r0 = rf(header, channelId)
+	if rf, ok := ret.Get(0).(func(*multipart.FileHeader, string, string) *model.Attachment); ok {
+		r0 = rf(header, channelId, mimetype)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Attachment)
@@ -125,8 +265,8 @@ func (_m *MessageService) UploadFile(header *multipart.FileHeader, channelId str
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*multipart.FileHeader, string) error); ok {
-		r1 = rf(header, channelId)
+	if rf, ok := ret.Get(1).(func(*multipart.FileHeader, string, string) error); ok {
+		r1 = rf(header, channelId, mimetype)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,166 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "github.com/sentrionic/valkyrie/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PermissionService is an autogenerated mock type for the PermissionService type
+type PermissionService struct {
+	mock.Mock
+}
+
+// AddMemberRole provides a mock function with given fields: userId, guildId, roleId
+func (_m *PermissionService) AddMemberRole(userId string, guildId string, roleId string) error {
+	ret := _m.Called(userId, guildId, roleId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(userId, guildId, roleId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRole provides a mock function with given fields: role
+func (_m *PermissionService) CreateRole(role *model.Role) (*model.Role, error) {
+	ret := _m.Called(role)
+
+	var r0 *model.Role
+	if rf, ok := ret.Get(0).(func(*model.Role) *model.Role); ok {
+		r0 = rf(role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Role) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRole provides a mock function with given fields: role
+func (_m *PermissionService) DeleteRole(role *model.Role) error {
+	ret := _m.Called(role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Role) error); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetMemberPermissions provides a mock function with given fields: userId, guild
+func (_m *PermissionService) GetMemberPermissions(userId string, guild *model.Guild) model.Permission {
+	ret := _m.Called(userId, guild)
+
+	var r0 model.Permission
+	if rf, ok := ret.Get(0).(func(string, *model.Guild) model.Permission); ok {
+		r0 = rf(userId, guild)
+	} else {
+		r0 = ret.Get(0).(model.Permission)
+	}
+
+	return r0
+}
+
+// GetRole provides a mock function with given fields: roleId
+func (_m *PermissionService) GetRole(roleId string) (*model.Role, error) {
+	ret := _m.Called(roleId)
+
+	var r0 *model.Role
+	if rf, ok := ret.Get(0).(func(string) *model.Role); ok {
+		r0 = rf(roleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoles provides a mock function with given fields: guildId
+func (_m *PermissionService) GetRoles(guildId string) (*[]model.RoleResponse, error) {
+	ret := _m.Called(guildId)
+
+	var r0 *[]model.RoleResponse
+	if rf, ok := ret.Get(0).(func(string) *[]model.RoleResponse); ok {
+		r0 = rf(guildId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.RoleResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasPermission provides a mock function with given fields: userId, guild, permission
+func (_m *PermissionService) HasPermission(userId string, guild *model.Guild, permission model.Permission) bool {
+	ret := _m.Called(userId, guild, permission)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, *model.Guild, model.Permission) bool); ok {
+		r0 = rf(userId, guild, permission)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// RemoveMemberRole provides a mock function with given fields: userId, guildId, roleId
+func (_m *PermissionService) RemoveMemberRole(userId string, guildId string, roleId string) error {
+	ret := _m.Called(userId, guildId, roleId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(userId, guildId, roleId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRole provides a mock function with given fields: role
+func (_m *PermissionService) UpdateRole(role *model.Role) error {
+	ret := _m.Called(role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Role) error); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
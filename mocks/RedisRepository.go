@@ -14,6 +14,25 @@ type RedisRepository struct {
 	mock.Mock
 }
 
+// DeleteMfaTicket provides a mock function with given fields: ctx, ticket
+func (_m *RedisRepository) DeleteMfaTicket(ctx context.Context, ticket string) {
+	_m.Called(ctx, ticket)
+}
+
+// DeleteSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *RedisRepository) DeleteSession(ctx context.Context, userId string, sessionId string) error {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetIdFromToken provides a mock function with given fields: ctx, token
 func (_m *RedisRepository) GetIdFromToken(ctx context.Context, token string) (string, error) {
 	ret := _m.Called(ctx, token)
@@ -35,6 +54,29 @@ func (_m *RedisRepository) GetIdFromToken(ctx context.Context, token string) (st
 	return r0, r1
 }
 
+// GetInteraction provides a mock function with given fields: ctx, id
+func (_m *RedisRepository) GetInteraction(ctx context.Context, id string) (*model.Interaction, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Interaction
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Interaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Interaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetInvite provides a mock function with given fields: ctx, token
 func (_m *RedisRepository) GetInvite(ctx context.Context, token string) (string, error) {
 	ret := _m.Called(ctx, token)
@@ -56,11 +98,94 @@ func (_m *RedisRepository) GetInvite(ctx context.Context, token string) (string,
 	return r0, r1
 }
 
+// GetPresences provides a mock function with given fields: ctx, userIds
+func (_m *RedisRepository) GetPresences(ctx context.Context, userIds []string) (map[string]model.Presence, error) {
+	ret := _m.Called(ctx, userIds)
+
+	var r0 map[string]model.Presence
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]model.Presence); ok {
+		r0 = rf(ctx, userIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]model.Presence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, userIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *RedisRepository) GetSession(ctx context.Context, userId string, sessionId string) (*model.Session, error) {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 *model.Session
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Session); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, sessionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessions provides a mock function with given fields: ctx, userId
+func (_m *RedisRepository) GetSessions(ctx context.Context, userId string) (*[]model.Session, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *[]model.Session
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]model.Session); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InvalidateInvites provides a mock function with given fields: ctx, guild
 func (_m *RedisRepository) InvalidateInvites(ctx context.Context, guild *model.Guild) {
 	_m.Called(ctx, guild)
 }
 
+// SaveInteraction provides a mock function with given fields: ctx, interaction
+func (_m *RedisRepository) SaveInteraction(ctx context.Context, interaction *model.Interaction) error {
+	ret := _m.Called(ctx, interaction)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Interaction) error); ok {
+		r0 = rf(ctx, interaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveInvite provides a mock function with given fields: ctx, guildId, id, isPermanent
 func (_m *RedisRepository) SaveInvite(ctx context.Context, guildId string, id string, isPermanent bool) error {
 	ret := _m.Called(ctx, guildId, id, isPermanent)
@@ -75,6 +200,41 @@ func (_m *RedisRepository) SaveInvite(ctx context.Context, guildId string, id st
 	return r0
 }
 
+// SaveSession provides a mock function with given fields: ctx, userId, session
+func (_m *RedisRepository) SaveSession(ctx context.Context, userId string, session *model.Session) error {
+	ret := _m.Called(ctx, userId, session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.Session) error); ok {
+		r0 = rf(ctx, userId, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetMfaTicket provides a mock function with given fields: ctx, userId
+func (_m *RedisRepository) SetMfaTicket(ctx context.Context, userId string) (string, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetResetToken provides a mock function with given fields: ctx, id
 func (_m *RedisRepository) SetResetToken(ctx context.Context, id string) (string, error) {
 	ret := _m.Called(ctx, id)
@@ -95,3 +255,101 @@ func (_m *RedisRepository) SetResetToken(ctx context.Context, id string) (string
 
 	return r0, r1
 }
+
+// SetVerificationToken provides a mock function with given fields: ctx, userId, email
+func (_m *RedisRepository) SetVerificationToken(ctx context.Context, userId string, email string) (string, error) {
+	ret := _m.Called(ctx, userId, email)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, userId, email)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ThrottleVerificationMail provides a mock function with given fields: ctx, userId
+func (_m *RedisRepository) ThrottleVerificationMail(ctx context.Context, userId string) (bool, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseMfaTicket provides a mock function with given fields: ctx, ticket
+func (_m *RedisRepository) UseMfaTicket(ctx context.Context, ticket string) (string, int64, error) {
+	ret := _m.Called(ctx, ticket)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, ticket)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, string) int64); ok {
+		r1 = rf(ctx, ticket)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, ticket)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UseVerificationToken provides a mock function with given fields: ctx, token
+func (_m *RedisRepository) UseVerificationToken(ctx context.Context, token string) (string, string, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, token)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
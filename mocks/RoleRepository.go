@@ -0,0 +1,205 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "github.com/sentrionic/valkyrie/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RoleRepository is an autogenerated mock type for the RoleRepository type
+type RoleRepository struct {
+	mock.Mock
+}
+
+// AddMemberRole provides a mock function with given fields: userId, guildId, roleId
+func (_m *RoleRepository) AddMemberRole(userId string, guildId string, roleId string) error {
+	ret := _m.Called(userId, guildId, roleId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(userId, guildId, roleId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: role
+func (_m *RoleRepository) Create(role *model.Role) (*model.Role, error) {
+	ret := _m.Called(role)
+
+	var r0 *model.Role
+	if rf, ok := ret.Get(0).(func(*model.Role) *model.Role); ok {
+		r0 = rf(role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Role) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: role
+func (_m *RoleRepository) Delete(role *model.Role) error {
+	ret := _m.Called(role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Role) error); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetById provides a mock function with given fields: roleId
+func (_m *RoleRepository) GetById(roleId string) (*model.Role, error) {
+	ret := _m.Called(roleId)
+
+	var r0 *model.Role
+	if rf, ok := ret.Get(0).(func(string) *model.Role); ok {
+		r0 = rf(roleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMemberPermissions provides a mock function with given fields: userId, guildId
+func (_m *RoleRepository) GetMemberPermissions(userId string, guildId string) (model.Permission, error) {
+	ret := _m.Called(userId, guildId)
+
+	var r0 model.Permission
+	if rf, ok := ret.Get(0).(func(string, string) model.Permission); ok {
+		r0 = rf(userId, guildId)
+	} else {
+		r0 = ret.Get(0).(model.Permission)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, guildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMemberRoles provides a mock function with given fields: userId, guildId
+func (_m *RoleRepository) GetMemberRoles(userId string, guildId string) (*[]model.Role, error) {
+	ret := _m.Called(userId, guildId)
+
+	var r0 *[]model.Role
+	if rf, ok := ret.Get(0).(func(string, string) *[]model.Role); ok {
+		r0 = rf(userId, guildId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, guildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleMemberIds provides a mock function with given fields: guildId, roleIds
+func (_m *RoleRepository) GetRoleMemberIds(guildId string, roleIds []string) (*[]string, error) {
+	ret := _m.Called(guildId, roleIds)
+
+	var r0 *[]string
+	if rf, ok := ret.Get(0).(func(string, []string) *[]string); ok {
+		r0 = rf(guildId, roleIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(guildId, roleIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: guildId
+func (_m *RoleRepository) List(guildId string) (*[]model.RoleResponse, error) {
+	ret := _m.Called(guildId)
+
+	var r0 *[]model.RoleResponse
+	if rf, ok := ret.Get(0).(func(string) *[]model.RoleResponse); ok {
+		r0 = rf(guildId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.RoleResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMemberRole provides a mock function with given fields: userId, guildId, roleId
+func (_m *RoleRepository) RemoveMemberRole(userId string, guildId string, roleId string) error {
+	ret := _m.Called(userId, guildId, roleId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(userId, guildId, roleId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: role
+func (_m *RoleRepository) Save(role *model.Role) error {
+	ret := _m.Called(role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Role) error); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
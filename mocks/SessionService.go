@@ -0,0 +1,112 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/sentrionic/valkyrie/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionService is an autogenerated mock type for the SessionService type
+type SessionService struct {
+	mock.Mock
+}
+
+// CreateSession provides a mock function with given fields: ctx, userId, ip, userAgent
+func (_m *SessionService) CreateSession(ctx context.Context, userId string, ip string, userAgent string) (*model.Session, error) {
+	ret := _m.Called(ctx, userId, ip, userAgent)
+
+	var r0 *model.Session
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.Session); ok {
+		r0 = rf(ctx, userId, ip, userAgent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userId, ip, userAgent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessions provides a mock function with given fields: ctx, userId
+func (_m *SessionService) GetSessions(ctx context.Context, userId string) (*[]model.Session, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *[]model.Session
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]model.Session); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *SessionService) RevokeSession(ctx context.Context, userId string, sessionId string) error {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSessions provides a mock function with given fields: ctx, userId, exceptId
+func (_m *SessionService) RevokeSessions(ctx context.Context, userId string, exceptId string) ([]string, error) {
+	ret := _m.Called(ctx, userId, exceptId)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, userId, exceptId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, exceptId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TouchSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *SessionService) TouchSession(ctx context.Context, userId string, sessionId string) error {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -12,6 +12,16 @@ type SocketService struct {
 	mock.Mock
 }
 
+// CloseSessions provides a mock function with given fields: sessionIds
+func (_m *SocketService) CloseSessions(sessionIds []string) {
+	_m.Called(sessionIds)
+}
+
+// EmitAck provides a mock function with given fields: userId, state
+func (_m *SocketService) EmitAck(userId string, state *model.ReadState) {
+	_m.Called(userId, state)
+}
+
 // EmitAddFriend provides a mock function with given fields: user, member
 func (_m *SocketService) EmitAddFriend(user *model.User, member *model.User) {
 	_m.Called(user, member)
@@ -27,6 +37,21 @@ func (_m *SocketService) EmitAddMember(room string, member *model.User) {
 	_m.Called(room, member)
 }
 
+// EmitAddReaction provides a mock function with given fields: room, reaction
+func (_m *SocketService) EmitAddReaction(room string, reaction *model.Reaction) {
+	_m.Called(room, reaction)
+}
+
+// EmitAddRole provides a mock function with given fields: guildId, role
+func (_m *SocketService) EmitAddRole(guildId string, role *model.RoleResponse) {
+	_m.Called(guildId, role)
+}
+
+// EmitAddThread provides a mock function with given fields: room, thread
+func (_m *SocketService) EmitAddThread(room string, thread *model.ChannelResponse) {
+	_m.Called(room, thread)
+}
+
 // EmitDeleteChannel provides a mock function with given fields: channel
 func (_m *SocketService) EmitDeleteChannel(channel *model.Channel) {
 	_m.Called(channel)
@@ -42,6 +67,11 @@ func (_m *SocketService) EmitDeleteMessage(room string, messageId string) {
 	_m.Called(room, messageId)
 }
 
+// EmitDeleteRole provides a mock function with given fields: guildId, roleId
+func (_m *SocketService) EmitDeleteRole(guildId string, roleId string) {
+	_m.Called(guildId, roleId)
+}
+
 // EmitEditChannel provides a mock function with given fields: room, channel
 func (_m *SocketService) EmitEditChannel(room string, channel *model.ChannelResponse) {
 	_m.Called(room, channel)
@@ -57,6 +87,21 @@ func (_m *SocketService) EmitEditMessage(room string, message *model.MessageResp
 	_m.Called(room, message)
 }
 
+// EmitEditRole provides a mock function with given fields: guildId, role
+func (_m *SocketService) EmitEditRole(guildId string, role *model.RoleResponse) {
+	_m.Called(guildId, role)
+}
+
+// EmitEphemeralMessage provides a mock function with given fields: userId, message
+func (_m *SocketService) EmitEphemeralMessage(userId string, message *model.EphemeralMessage) {
+	_m.Called(userId, message)
+}
+
+// EmitInteraction provides a mock function with given fields: botId, interaction
+func (_m *SocketService) EmitInteraction(botId string, interaction *model.Interaction) {
+	_m.Called(botId, interaction)
+}
+
 // EmitNewChannel provides a mock function with given fields: room, channel
 func (_m *SocketService) EmitNewChannel(room string, channel *model.ChannelResponse) {
 	_m.Called(room, channel)
@@ -67,6 +112,11 @@ func (_m *SocketService) EmitNewDMNotification(channelId string, user *model.Use
 	_m.Called(channelId, user)
 }
 
+// EmitNewMention provides a mock function with given fields: userId, mention
+func (_m *SocketService) EmitNewMention(userId string, mention *model.Mention) {
+	_m.Called(userId, mention)
+}
+
 // EmitNewMessage provides a mock function with given fields: room, message
 func (_m *SocketService) EmitNewMessage(room string, message *model.MessageResponse) {
 	_m.Called(room, message)
@@ -82,6 +132,11 @@ func (_m *SocketService) EmitNewPrivateChannel(members []string, channel *model.
 	_m.Called(members, channel)
 }
 
+// EmitPinsUpdate provides a mock function with given fields: room, update
+func (_m *SocketService) EmitPinsUpdate(room string, update *model.PinsUpdate) {
+	_m.Called(room, update)
+}
+
 // EmitRemoveFriend provides a mock function with given fields: userId, memberId
 func (_m *SocketService) EmitRemoveFriend(userId string, memberId string) {
 	_m.Called(userId, memberId)
@@ -97,7 +152,22 @@ func (_m *SocketService) EmitRemoveMember(room string, memberId string) {
 	_m.Called(room, memberId)
 }
 
+// EmitRemoveReaction provides a mock function with given fields: room, reaction
+func (_m *SocketService) EmitRemoveReaction(room string, reaction *model.Reaction) {
+	_m.Called(room, reaction)
+}
+
 // EmitSendRequest provides a mock function with given fields: room
 func (_m *SocketService) EmitSendRequest(room string) {
 	_m.Called(room)
 }
+
+// RecheckSubscriptions provides a mock function with given fields: guildId
+func (_m *SocketService) RecheckSubscriptions(guildId string) {
+	_m.Called(guildId)
+}
+
+// StopTyping provides a mock function with given fields: channelId, userId
+func (_m *SocketService) StopTyping(channelId string, userId string) {
+	_m.Called(channelId, userId)
+}
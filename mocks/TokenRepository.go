@@ -0,0 +1,126 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	model "github.com/sentrionic/valkyrie/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TokenRepository is an autogenerated mock type for the TokenRepository type
+type TokenRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: token
+func (_m *TokenRepository) Create(token *model.APIToken) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.APIToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: token
+func (_m *TokenRepository) Delete(token *model.APIToken) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.APIToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByHash provides a mock function with given fields: hash
+func (_m *TokenRepository) FindByHash(hash string) (*model.APIToken, error) {
+	ret := _m.Called(hash)
+
+	var r0 *model.APIToken
+	if rf, ok := ret.Get(0).(func(string) *model.APIToken); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APIToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: id
+func (_m *TokenRepository) FindByID(id string) (*model.APIToken, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.APIToken
+	if rf, ok := ret.Get(0).(func(string) *model.APIToken); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APIToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByOwner provides a mock function with given fields: ownerId
+func (_m *TokenRepository) FindByOwner(ownerId string) (*[]model.APIToken, error) {
+	ret := _m.Called(ownerId)
+
+	var r0 *[]model.APIToken
+	if rf, ok := ret.Get(0).(func(string) *[]model.APIToken); ok {
+		r0 = rf(ownerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.APIToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ownerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetLastUsed provides a mock function with given fields: id, usedAt
+func (_m *TokenRepository) SetLastUsed(id string, usedAt time.Time) error {
+	ret := _m.Called(id, usedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(id, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
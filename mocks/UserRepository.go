@@ -12,6 +12,27 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// CountBots provides a mock function with given fields: ownerId
+func (_m *UserRepository) CountBots(ownerId string) (int64, error) {
+	ret := _m.Called(ownerId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(ownerId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ownerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: user
 func (_m *UserRepository) Create(user *model.User) (*model.User, error) {
 	ret := _m.Called(user)
@@ -35,6 +56,29 @@ func (_m *UserRepository) Create(user *model.User) (*model.User, error) {
 	return r0, r1
 }
 
+// FindBots provides a mock function with given fields: ownerId
+func (_m *UserRepository) FindBots(ownerId string) (*[]model.User, error) {
+	ret := _m.Called(ownerId)
+
+	var r0 *[]model.User
+	if rf, ok := ret.Get(0).(func(string) *[]model.User); ok {
+		r0 = rf(ownerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ownerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByEmail provides a mock function with given fields: email
 func (_m *UserRepository) FindByEmail(email string) (*model.User, error) {
 	ret := _m.Called(email)
@@ -127,6 +171,27 @@ func (_m *UserRepository) GetRequestCount(userId string) (*int64, error) {
 	return r0, r1
 }
 
+// SetTotpStep provides a mock function with given fields: userId, step
+func (_m *UserRepository) SetTotpStep(userId string, step int64) (bool, error) {
+	ret := _m.Called(userId, step)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, int64) bool); ok {
+		r0 = rf(userId, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(userId, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: user
 func (_m *UserRepository) Update(user *model.User) error {
 	ret := _m.Called(user)
@@ -140,3 +205,24 @@ func (_m *UserRepository) Update(user *model.User) error {
 
 	return r0
 }
+
+// UseRecoveryCode provides a mock function with given fields: userId, codeHash
+func (_m *UserRepository) UseRecoveryCode(userId string, codeHash string) (bool, error) {
+	ret := _m.Called(userId, codeHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(userId, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -4,11 +4,10 @@ package mocks
 
 import (
 	context "context"
+	multipart "mime/multipart"
 
 	model "github.com/sentrionic/valkyrie/model"
 	mock "github.com/stretchr/testify/mock"
-
-	multipart "mime/multipart"
 )
 
 // UserService is an autogenerated mock type for the UserService type
@@ -16,6 +15,29 @@ type UserService struct {
 	mock.Mock
 }
 
+// Authenticate provides a mock function with given fields: scheme, token
+func (_m *UserService) Authenticate(scheme string, token string) (*model.User, error) {
+	ret := _m.Called(scheme, token)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(string, string) *model.User); ok {
+		r0 = rf(scheme, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(scheme, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ChangeAvatar provides a mock function with given fields: header, directory
 func (_m *UserService) ChangeAvatar(header *multipart.FileHeader, directory string) (string, error) {
 	ret := _m.Called(header, directory)
@@ -51,6 +73,75 @@ func (_m *UserService) ChangePassword(currentPassword string, newPassword string
 	return r0
 }
 
+// CreateBot provides a mock function with given fields: ownerId, username
+func (_m *UserService) CreateBot(ownerId string, username string) (*model.User, error) {
+	ret := _m.Called(ownerId, username)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(string, string) *model.User); ok {
+		r0 = rf(ownerId, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(ownerId, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateMfaChallenge provides a mock function with given fields: ctx, user
+func (_m *UserService) CreateMfaChallenge(ctx context.Context, user *model.User) (*model.MfaChallenge, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 *model.MfaChallenge
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) *model.MfaChallenge); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MfaChallenge)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateToken provides a mock function with given fields: user, name
+func (_m *UserService) CreateToken(user *model.User, name string) (*model.CreatedToken, error) {
+	ret := _m.Called(user, name)
+
+	var r0 *model.CreatedToken
+	if rf, ok := ret.Get(0).(func(*model.User, string) *model.CreatedToken); ok {
+		r0 = rf(user, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CreatedToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.User, string) error); ok {
+		r1 = rf(user, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteImage provides a mock function with given fields: key
 func (_m *UserService) DeleteImage(key string) error {
 	ret := _m.Called(key)
@@ -65,6 +156,43 @@ func (_m *UserService) DeleteImage(key string) error {
 	return r0
 }
 
+// DisableMfa provides a mock function with given fields: user, code
+func (_m *UserService) DisableMfa(user *model.User, code string) error {
+	ret := _m.Called(user, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.User, string) error); ok {
+		r0 = rf(user, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableMfa provides a mock function with given fields: user, code
+func (_m *UserService) EnableMfa(user *model.User, code string) (*model.RecoveryCodes, error) {
+	ret := _m.Called(user, code)
+
+	var r0 *model.RecoveryCodes
+	if rf, ok := ret.Get(0).(func(*model.User, string) *model.RecoveryCodes); ok {
+		r0 = rf(user, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RecoveryCodes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.User, string) error); ok {
+		r1 = rf(user, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ForgotPassword provides a mock function with given fields: ctx, user
 func (_m *UserService) ForgotPassword(ctx context.Context, user *model.User) error {
 	ret := _m.Called(ctx, user)
@@ -102,6 +230,29 @@ func (_m *UserService) Get(id string) (*model.User, error) {
 	return r0, r1
 }
 
+// GetBots provides a mock function with given fields: ownerId
+func (_m *UserService) GetBots(ownerId string) (*[]model.User, error) {
+	ret := _m.Called(ownerId)
+
+	var r0 *[]model.User
+	if rf, ok := ret.Get(0).(func(string) *[]model.User); ok {
+		r0 = rf(ownerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ownerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByEmail provides a mock function with given fields: email
 func (_m *UserService) GetByEmail(email string) (*model.User, error) {
 	ret := _m.Called(email)
@@ -171,6 +322,29 @@ func (_m *UserService) GetRequestCount(userId string) (*int64, error) {
 	return r0, r1
 }
 
+// GetTokens provides a mock function with given fields: ownerId
+func (_m *UserService) GetTokens(ownerId string) (*[]model.APIToken, error) {
+	ret := _m.Called(ownerId)
+
+	var r0 *[]model.APIToken
+	if rf, ok := ret.Get(0).(func(string) *[]model.APIToken); ok {
+		r0 = rf(ownerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.APIToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ownerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsEmailAlreadyInUse provides a mock function with given fields: email
 func (_m *UserService) IsEmailAlreadyInUse(email string) bool {
 	ret := _m.Called(email)
@@ -208,6 +382,52 @@ func (_m *UserService) Login(email string, password string) (*model.User, error)
 	return r0, r1
 }
 
+// LoginWithMfa provides a mock function with given fields: ctx, ticket, code
+func (_m *UserService) LoginWithMfa(ctx context.Context, ticket string, code string) (*model.User, error) {
+	ret := _m.Called(ctx, ticket, code)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.User); ok {
+		r0 = rf(ctx, ticket, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, ticket, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: user, code
+func (_m *UserService) RegenerateRecoveryCodes(user *model.User, code string) (*model.RecoveryCodes, error) {
+	ret := _m.Called(user, code)
+
+	var r0 *model.RecoveryCodes
+	if rf, ok := ret.Get(0).(func(*model.User, string) *model.RecoveryCodes); ok {
+		r0 = rf(user, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RecoveryCodes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.User, string) error); ok {
+		r1 = rf(user, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: user
 func (_m *UserService) Register(user *model.User) (*model.User, error) {
 	ret := _m.Called(user)
@@ -231,6 +451,20 @@ func (_m *UserService) Register(user *model.User) (*model.User, error) {
 	return r0, r1
 }
 
+// ResendVerificationMail provides a mock function with given fields: ctx, user
+func (_m *UserService) ResendVerificationMail(ctx context.Context, user *model.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetPassword provides a mock function with given fields: ctx, password, token
 func (_m *UserService) ResetPassword(ctx context.Context, password string, token string) (*model.User, error) {
 	ret := _m.Called(ctx, password, token)
@@ -254,6 +488,57 @@ func (_m *UserService) ResetPassword(ctx context.Context, password string, token
 	return r0, r1
 }
 
+// RevokeToken provides a mock function with given fields: ownerId, tokenId
+func (_m *UserService) RevokeToken(ownerId string, tokenId string) error {
+	ret := _m.Called(ownerId, tokenId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(ownerId, tokenId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendVerificationMail provides a mock function with given fields: ctx, user
+func (_m *UserService) SendVerificationMail(ctx context.Context, user *model.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetupMfa provides a mock function with given fields: user
+func (_m *UserService) SetupMfa(user *model.User) (*model.MfaSetup, error) {
+	ret := _m.Called(user)
+
+	var r0 *model.MfaSetup
+	if rf, ok := ret.Get(0).(func(*model.User) *model.MfaSetup); ok {
+		r0 = rf(user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MfaSetup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.User) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateAccount provides a mock function with given fields: user
 func (_m *UserService) UpdateAccount(user *model.User) error {
 	ret := _m.Called(user)
@@ -267,3 +552,40 @@ func (_m *UserService) UpdateAccount(user *model.User) error {
 
 	return r0
 }
+
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *UserService) VerifyEmail(ctx context.Context, token string) (*model.User, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMfa provides a mock function with given fields: user, code
+func (_m *UserService) VerifyMfa(user *model.User, code string) error {
+	ret := _m.Called(user, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.User, string) error); ok {
+		r0 = rf(user, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
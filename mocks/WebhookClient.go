@@ -0,0 +1,31 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// WebhookClient is an autogenerated mock type for the WebhookClient type
+type WebhookClient struct {
+	mock.Mock
+}
+
+// Post provides a mock function with given fields: url, body, headers
+func (_m *WebhookClient) Post(url string, body []byte, headers map[string]string) (int, error) {
+	ret := _m.Called(url, body, headers)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string, []byte, map[string]string) int); ok {
+		r0 = rf(url, body, headers)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte, map[string]string) error); ok {
+		r1 = rf(url, body, headers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
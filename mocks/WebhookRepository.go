@@ -0,0 +1,249 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	model "github.com/sentrionic/valkyrie/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookRepository is an autogenerated mock type for the WebhookRepository type
+type WebhookRepository struct {
+	mock.Mock
+}
+
+// ClaimDueDeliveries provides a mock function with given fields: now, lease, limit
+func (_m *WebhookRepository) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) (*[]model.WebhookDelivery, error) {
+	ret := _m.Called(now, lease, limit)
+
+	var r0 *[]model.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(time.Time, time.Duration, int) *[]model.WebhookDelivery); ok {
+		r0 = rf(now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.WebhookDelivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, time.Duration, int) error); ok {
+		r1 = rf(now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountByGuild provides a mock function with given fields: guildId
+func (_m *WebhookRepository) CountByGuild(guildId string) (int64, error) {
+	ret := _m.Called(guildId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(guildId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: webhook
+func (_m *WebhookRepository) Create(webhook *model.Webhook) error {
+	ret := _m.Called(webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook) error); ok {
+		r0 = rf(webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateDeliveries provides a mock function with given fields: deliveries
+func (_m *WebhookRepository) CreateDeliveries(deliveries []model.WebhookDelivery) error {
+	ret := _m.Called(deliveries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]model.WebhookDelivery) error); ok {
+		r0 = rf(deliveries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: webhook
+func (_m *WebhookRepository) Delete(webhook *model.Webhook) error {
+	ret := _m.Called(webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook) error); ok {
+		r0 = rf(webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByGuild provides a mock function with given fields: guildId
+func (_m *WebhookRepository) FindByGuild(guildId string) (*[]model.Webhook, error) {
+	ret := _m.Called(guildId)
+
+	var r0 *[]model.Webhook
+	if rf, ok := ret.Get(0).(func(string) *[]model.Webhook); ok {
+		r0 = rf(guildId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: id
+func (_m *WebhookRepository) FindByID(id string) (*model.Webhook, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.Webhook
+	if rf, ok := ret.Get(0).(func(string) *model.Webhook); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDeliveries provides a mock function with given fields: webhookId, limit
+func (_m *WebhookRepository) FindDeliveries(webhookId string, limit int) (*[]model.WebhookDelivery, error) {
+	ret := _m.Called(webhookId, limit)
+
+	var r0 *[]model.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(string, int) *[]model.WebhookDelivery); ok {
+		r0 = rf(webhookId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.WebhookDelivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(webhookId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindSubscribed provides a mock function with given fields: guildId, event
+func (_m *WebhookRepository) FindSubscribed(guildId string, event string) (*[]model.Webhook, error) {
+	ret := _m.Called(guildId, event)
+
+	var r0 *[]model.Webhook
+	if rf, ok := ret.Get(0).(func(string, string) *[]model.Webhook); ok {
+		r0 = rf(guildId, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildId, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordFailure provides a mock function with given fields: id, threshold
+func (_m *WebhookRepository) RecordFailure(id string, threshold int) error {
+	ret := _m.Called(id, threshold)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(id, threshold)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetFailures provides a mock function with given fields: id
+func (_m *WebhookRepository) ResetFailures(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: webhook
+func (_m *WebhookRepository) Update(webhook *model.Webhook) error {
+	ret := _m.Called(webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook) error); ok {
+		r0 = rf(webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDelivery provides a mock function with given fields: delivery
+func (_m *WebhookRepository) UpdateDelivery(delivery *model.WebhookDelivery) error {
+	ret := _m.Called(delivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.WebhookDelivery) error); ok {
+		r0 = rf(delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
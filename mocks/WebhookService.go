@@ -0,0 +1,152 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "github.com/sentrionic/valkyrie/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookService is an autogenerated mock type for the WebhookService type
+type WebhookService struct {
+	mock.Mock
+}
+
+// CreateWebhook provides a mock function with given fields: webhook
+func (_m *WebhookService) CreateWebhook(webhook *model.Webhook) (*model.Webhook, error) {
+	ret := _m.Called(webhook)
+
+	var r0 *model.Webhook
+	if rf, ok := ret.Get(0).(func(*model.Webhook) *model.Webhook); ok {
+		r0 = rf(webhook)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Webhook) error); ok {
+		r1 = rf(webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhook provides a mock function with given fields: webhook
+func (_m *WebhookService) DeleteWebhook(webhook *model.Webhook) error {
+	ret := _m.Called(webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook) error); ok {
+		r0 = rf(webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Dispatch provides a mock function with given fields: guildId, event, data
+func (_m *WebhookService) Dispatch(guildId string, event string, data interface{}) {
+	_m.Called(guildId, event, data)
+}
+
+// GetDeliveries provides a mock function with given fields: webhookId, limit
+func (_m *WebhookService) GetDeliveries(webhookId string, limit int) (*[]model.WebhookDelivery, error) {
+	ret := _m.Called(webhookId, limit)
+
+	var r0 *[]model.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(string, int) *[]model.WebhookDelivery); ok {
+		r0 = rf(webhookId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.WebhookDelivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(webhookId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhook provides a mock function with given fields: webhookId
+func (_m *WebhookService) GetWebhook(webhookId string) (*model.Webhook, error) {
+	ret := _m.Called(webhookId)
+
+	var r0 *model.Webhook
+	if rf, ok := ret.Get(0).(func(string) *model.Webhook); ok {
+		r0 = rf(webhookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(webhookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooks provides a mock function with given fields: guildId
+func (_m *WebhookService) GetWebhooks(guildId string) (*[]model.Webhook, error) {
+	ret := _m.Called(guildId)
+
+	var r0 *[]model.Webhook
+	if rf, ok := ret.Get(0).(func(string) *[]model.Webhook); ok {
+		r0 = rf(guildId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessDeliveries provides a mock function with given fields:
+func (_m *WebhookService) ProcessDeliveries() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWebhook provides a mock function with given fields: webhook
+func (_m *WebhookService) UpdateWebhook(webhook *model.Webhook) error {
+	ret := _m.Called(webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook) error); ok {
+		r0 = rf(webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Authorization schemes accepted by the AuthUser middleware.
+// Bot tokens can only authenticate bot accounts and Bearer tokens only regular accounts.
+const (
+	BotScheme    = "Bot"
+	BearerScheme = "Bearer"
+)
+
+// APIToken is a long-lived credential that authenticates requests
+// via the Authorization header. Only the SHA-256 hash of the token is stored.
+type APIToken struct {
+	BaseModel
+	UserId     string     `gorm:"not null;index" json:"userId"`
+	Name       string     `gorm:"not null" json:"name"`
+	TokenHash  string     `gorm:"not null;uniqueIndex" json:"-"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+} //@name APIToken
+
+// CreatedToken is returned once on creation and is the only
+// response that contains the plaintext token.
+type CreatedToken struct {
+	APIToken
+	Token string `json:"token"`
+} //@name CreatedToken
+
+// TokenRepository defines methods related to API token db operations the service layer expects
+// any repository it interacts with to implement
+type TokenRepository interface {
+	Create(token *APIToken) error
+	FindByID(id string) (*APIToken, error)
+	FindByHash(hash string) (*APIToken, error)
+	FindByOwner(ownerId string) (*[]APIToken, error)
+	Delete(token *APIToken) error
+	SetLastUsed(id string, usedAt time.Time) error
+}
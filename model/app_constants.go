@@ -1,9 +1,38 @@
 package model
 
+import "time"
+
 // Application Constants
 const (
-	MinimumChannels = 1
-	MaximumChannels = 50
-	MaximumGuilds   = 100
-	CookieName      = "vlk"
+	MinimumChannels        = 1
+	MaximumChannels        = 50
+	MaximumGuilds          = 100
+	MaximumRoles           = 50
+	MaximumEmojiLen        = 32
+	MaximumPins            = 50
+	MaximumReasonLen       = 512
+	DefaultAuditLogEntries = 50
+	MaximumAuditLogEntries = 100
+	MaximumBots            = 10
+	MaximumTokens          = 25
+	MaximumWebhooks        = 10
+	MaximumChannelWebhooks = 10
+	WebhookMaxAttempts     = 5
+	WebhookFailureLimit    = 5
+	DefaultDeliveries      = 50
+	MaximumDeliveries      = 100
+	MaximumCommands        = 50
+	MaximumCommandOptions  = 10
+	CookieName             = "vlk"
+)
+
+// InteractionTTL is the duration a bot can respond to an interaction
+const InteractionTTL = 15 * time.Minute
+
+// Email verification constants
+const (
+	// VerificationTokenTTL is the duration the link in the verification email is valid
+	VerificationTokenTTL = 24 * time.Hour
+	// VerificationMailInterval is the minimum duration between two verification emails
+	VerificationMailInterval = time.Minute
 )
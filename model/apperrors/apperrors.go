@@ -2,24 +2,41 @@ package apperrors
 
 // Guild Errors
 const (
-	NotAMember             = "Not a member of the guild"
-	AlreadyMember          = "Already a member of the guild"
-	GuildLimitReached      = "The guild limit is 100"
-	MustBeOwner            = "Must be the owner for that"
-	InvalidImageType       = "imageFile must be 'image/jpeg' or 'image/png'"
-	MustBeMemberInvite     = "Must be a member to fetch an invite"
-	IsPermanentError       = "isPermanent is not a boolean"
-	InvalidateInvitesError = "Only the owner can invalidate invites"
-	InvalidInviteError     = "Invalid Link or the server got deleted"
-	BannedFromServer       = "You are banned from this server"
-	DeleteGuildError       = "Only the owner can delete their server"
-	OwnerCantLeave         = "The owner cannot leave their server"
-	BanYourselfError       = "You cannot ban yourself"
-	KickYourselfError      = "You cannot kick yourself"
-	UnbanYourselfError     = "You cannot unban yourself"
-	OneChannelRequired     = "A server needs at least one channel"
-	ChannelLimitError      = "The channel limit is 50"
-	DMYourselfError        = "You cannot dm yourself"
+	NotAMember          = "Not a member of the guild"
+	AlreadyMember       = "Already a member of the guild"
+	GuildLimitReached   = "The guild limit is 100"
+	MissingPermissions  = "You do not have the permission for that"
+	InvalidImageType    = "imageFile must be 'image/jpeg' or 'image/png'"
+	MustBeMemberInvite  = "Must be a member to fetch an invite"
+	IsPermanentError    = "isPermanent is not a boolean"
+	InvalidInviteError  = "Invalid Link or the server got deleted"
+	BannedFromServer    = "You are banned from this server"
+	DeleteGuildError    = "Only the owner can delete their server"
+	OwnerCantLeave      = "The owner cannot leave their server"
+	BanYourselfError    = "You cannot ban yourself"
+	KickYourselfError   = "You cannot kick yourself"
+	UnbanYourselfError  = "You cannot unban yourself"
+	OneChannelRequired  = "A server needs at least one channel"
+	ChannelLimitError   = "The channel limit is 50"
+	DMYourselfError     = "You cannot dm yourself"
+	ModerateOwnerError  = "You cannot moderate the owner"
+	ModerateMemberError = "You cannot moderate members with permissions you do not have"
+	AuditLogReasonError = "The audit log reason must be at most 512 characters"
+	WebhookLimitError   = "The webhook limit is 10"
+	DMWebhookError      = "Webhooks can only be created in guild channels"
+	CommandLimitError   = "The command limit per bot is 50"
+	CommandExistsError  = "Another bot already registered a command with that name"
+	BotCommandError     = "Only bots can register commands"
+	InteractionError    = "Only the bot of the command can respond to the interaction"
+	WebhookRateLimited  = "The webhook sent too many messages. Try again later"
+	WebhookAuthLimited  = "Too many failed webhook requests. Try again later"
+)
+
+// Role Errors
+const (
+	RoleLimitError         = "The role limit is 50"
+	GrantPermissionError   = "You cannot grant permissions you do not have"
+	ChannelPermissionError = "Overwrites can only contain channel permissions"
 )
 
 // Account Errors
@@ -29,6 +46,19 @@ const (
 	DuplicateEmail      = "An account with that email already exists"
 	PasswordsDoNotMatch = "Passwords do not match"
 	InvalidResetToken   = "Invalid reset token"
+	InvalidToken        = "Provided token is invalid"
+	BotLimitError       = "The bot limit is 10"
+	TokenLimitError     = "The token limit is 25"
+	MfaCodeRequired     = "A two-factor authentication code is required"
+	InvalidMfaCode      = "Invalid two-factor authentication code"
+	InvalidMfaTicket    = "The login expired. Please log in again"
+	MfaAlreadyEnabled   = "Two-factor authentication is already enabled"
+	MfaNotEnabled       = "Two-factor authentication is not enabled"
+	MfaSetupRequired    = "Set up two-factor authentication first"
+	EmailNotVerified    = "Verify your email address first"
+	EmailVerified       = "The email address is already verified"
+	InvalidVerifyToken  = "Invalid verification token"
+	VerifyMailThrottled = "Please wait a minute before requesting another verification email"
 )
 
 // Friend Errors
@@ -44,15 +74,42 @@ const (
 
 // Generic Errors
 const (
-	InvalidSession = "Provided session is invalid"
-	ServerError    = "Something went wrong. Try again later"
-	Unauthorized   = "Not Authorized"
+	InvalidSession  = "Provided session is invalid"
+	SessionRequired = "This action requires logging in and cannot be done with a token"
+	ServerError     = "Something went wrong. Try again later"
+	Unauthorized    = "Not Authorized"
 )
 
 // Message Errors
 const (
 	MessageOrFileRequired = "Either a message or a file is required"
 	EditMessageError      = "Only the author can edit the message"
-	DeleteMessageError    = "Only the author or owner can delete the message"
+	DeleteMessageError    = "Only the author or a moderator can delete the message"
 	DeleteDMMessageError  = "Only the author can delete the message"
+	InvalidEmojiError     = "The emoji must be between 1 and 32 characters"
+	ThreadChannelError    = "Threads can only be started in guild channels"
+	ThreadExistsError     = "The message already has a thread"
+	SearchFilterRequired  = "Either a search term or a filter is required"
+	PinMessageError       = "Only the author or a moderator can pin the message"
+	PinDMMessageError     = "Only the author can pin the message"
+	PinLimitError         = "The pin limit for this channel has been reached"
+	MessageHistoryError   = "Only the author or a moderator can view the message history"
+	DMMessageHistoryError = "Only the author can view the message history"
+	InvalidFileType       = "The file must be an image, audio, video, pdf, text or archive file"
+	FileTooLarge          = "The file exceeds the size limit for its type"
+	InvalidImageFile      = "The image file is corrupted"
+)
+
+// Websocket Errors
+const (
+	InvalidFrame    = "The message must be a JSON object"
+	UnknownAction   = "Unknown action"
+	MessageRequired = "The message field is required for this action"
+	JoinUserError   = "You can only join your own room"
+	JoinRoomFirst   = "Join the room first"
+	InvalidSequence = "The sequence number must be a non-negative integer"
+	ResumePending   = "The session is already being resumed"
+	InvalidStatus   = "The status must be 'online', 'idle', 'dnd' or 'invisible'"
+	CustomStatusLen = "The custom status must be at most 128 characters"
+	ExpiryInPast    = "The expiry must be in the future"
 )
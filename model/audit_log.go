@@ -0,0 +1,86 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Audit log action types
+const (
+	AuditGuildUpdate   = "guild_update"
+	AuditInvitesDelete = "invites_delete"
+	AuditChannelUpdate = "channel_update"
+	AuditChannelDelete = "channel_delete"
+	AuditMemberKick    = "member_kick"
+	AuditMemberBan     = "member_ban"
+	AuditMemberUnban   = "member_unban"
+	AuditMemberUpdate  = "member_update"
+)
+
+// AuditLogEntry represents an administrative action in a guild.
+// ActorId is the user that performed the action on the TargetId
+// and Changes contains the values of the modified fields before and after the action.
+// Guild only defines the foreign key, so the entries get deleted with their guild.
+type AuditLogEntry struct {
+	ID         string          `gorm:"primaryKey" json:"id"`
+	GuildId    string          `gorm:"index;not null" json:"guildId"`
+	Guild      *Guild          `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	ActorId    string          `gorm:"index;not null" json:"actorId"`
+	ActionType string          `gorm:"index;not null" json:"actionType"`
+	TargetId   *string         `json:"targetId"`
+	Changes    AuditLogChanges `gorm:"type:jsonb" json:"changes"`
+	Reason     *string         `json:"reason"`
+	CreatedAt  time.Time       `gorm:"index" json:"createdAt"`
+} //@name AuditLogEntry
+
+// AuditLogChange contains the value of a field before and after an action
+type AuditLogChange struct {
+	Key    string      `json:"key"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+} //@name AuditLogChange
+
+// AuditLogChanges is stored as JSON in the DB
+type AuditLogChanges []AuditLogChange
+
+// Value turns the changes into JSON
+func (c AuditLogChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	value, err := json.Marshal(c)
+	return string(value), err
+}
+
+// Scan parses the stored JSON
+func (c *AuditLogChanges) Scan(value interface{}) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("could not scan audit log changes")
+	}
+	return json.Unmarshal(bytes, c)
+}
+
+// AuditLogQuery contains the filters of the audit log.
+// Before is the ID of an entry and only returns older entries.
+type AuditLogQuery struct {
+	GuildId    string
+	ActionType string
+	ActorId    string
+	Before     string
+	Limit      int
+}
+
+// AuditLogRepository defines methods related to audit log db operations the service layer expects
+// any repository it interacts with to implement
+type AuditLogRepository interface {
+	Create(entry *AuditLogEntry) error
+	Find(query *AuditLogQuery) (*[]AuditLogEntry, error)
+}
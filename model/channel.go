@@ -6,15 +6,22 @@ import "time"
 // or a text channel for DMs between users.
 // GuildID should only be nil if it is a DM channel
 // PCMembers should only be used if the channel is private.
+// Overwrites allow or deny channel permissions for roles and members.
+// Threads are channels with a ParentId that were started from the message with the MessageId.
 type Channel struct {
 	BaseModel
-	GuildID      *string   `gorm:"index"`
-	Name         string    `gorm:"name"`
-	IsPublic     bool      `gorm:"index"`
-	IsDM         bool      `gorm:"is_dm"`
-	LastActivity time.Time `gorm:"autoCreateTime"`
-	PCMembers    []User    `gorm:"many2many:pcmembers;constraint:OnDelete:CASCADE;"`
-	Messages     []Message `gorm:"constraint:OnDelete:CASCADE;"`
+	GuildID      *string               `gorm:"index"`
+	Name         string                `gorm:"name"`
+	IsPublic     bool                  `gorm:"index"`
+	IsDM         bool                  `gorm:"is_dm"`
+	LastActivity time.Time             `gorm:"autoCreateTime"`
+	PCMembers    []User                `gorm:"many2many:pcmembers;constraint:OnDelete:CASCADE;"`
+	Overwrites   []PermissionOverwrite `gorm:"constraint:OnDelete:CASCADE;"`
+	Messages     []Message             `gorm:"constraint:OnDelete:CASCADE;"`
+	ParentId     *string               `gorm:"index"`
+	MessageId    *string               `gorm:"uniqueIndex"`
+	Threads      []Channel             `gorm:"foreignKey:ParentId;constraint:OnDelete:CASCADE;"`
+	Webhooks     []IncomingWebhook     `gorm:"constraint:OnDelete:CASCADE;"`
 }
 
 // ChannelResponse is the JSON response of the channel
@@ -25,6 +32,10 @@ type ChannelResponse struct {
 	CreatedAt       time.Time `json:"createdAt"`
 	UpdatedAt       time.Time `json:"updatedAt"`
 	HasNotification bool      `json:"hasNotification"`
+	MentionCount    int       `json:"mentionCount"`
+	UnreadCount     int       `json:"unreadCount"`
+	ParentId        *string   `json:"parentId"`
+	IsPCMember      bool      `json:"-"`
 } //@name Channel
 
 // SerializeChannel returns the channel API response.
@@ -36,6 +47,7 @@ func (c Channel) SerializeChannel() ChannelResponse {
 		CreatedAt:       c.CreatedAt,
 		UpdatedAt:       c.UpdatedAt,
 		HasNotification: false,
+		ParentId:        c.ParentId,
 	}
 }
 
@@ -44,6 +56,7 @@ func (c Channel) SerializeChannel() ChannelResponse {
 type ChannelService interface {
 	CreateChannel(channel *Channel) (*Channel, error)
 	GetChannels(userId string, guildId string) (*[]ChannelResponse, error)
+	GetReadableChannelIds(userId string, guildId string) ([]string, error)
 	Get(channelId string) (*Channel, error)
 	GetPrivateChannelMembers(channelId string) (*[]string, error)
 	GetDirectMessages(userId string) (*[]DirectMessage, error)
@@ -51,13 +64,19 @@ type ChannelService interface {
 	GetDMByUserAndChannel(userId string, channelId string) (string, error)
 	AddDMChannelMembers(memberIds []string, channelId string, userId string) error
 	SetDirectMessageStatus(dmId string, userId string, isOpen bool) error
-	DeleteChannel(channel *Channel) error
+	DeleteChannel(channel *Channel, actorId string) error
 	UpdateChannel(channel *Channel) error
+	EditChannel(channel *Channel, actorId string) error
 	CleanPCMembers(channelId string) error
 	AddPrivateChannelMembers(memberIds []string, channelId string) error
 	RemovePrivateChannelMembers(memberIds []string, channelId string) error
 	IsChannelMember(channel *Channel, userId string) error
+	GetPermissions(channel *Channel, userId string) (Permission, error)
+	GetThreads(channelId string) (*[]Channel, error)
+	SetOverwrite(overwrite *PermissionOverwrite) error
+	DeleteOverwrite(channelId, targetId string) error
 	OpenDMForAll(dmId string) error
+	AckChannel(channel *Channel, userId string, messageId string) (*ReadState, error)
 }
 
 // ChannelRepository defines methods related to channel db operations the service layer expects
@@ -80,4 +99,9 @@ type ChannelRepository interface {
 	FindDMByUserAndChannelId(channelId, userId string) (string, error)
 	OpenDMForAll(dmId string) error
 	GetDMMemberIds(channelId string) (*[]string, error)
+	GetGuildOverwrites(guildId string) (*[]PermissionOverwrite, error)
+	GetThreads(channelId string) (*[]Channel, error)
+	SetOverwrite(overwrite *PermissionOverwrite) error
+	DeleteOverwrite(channelId, targetId string) error
+	SetReadState(state *ReadState) (*ReadState, error)
 }
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Command option types
+const (
+	StringOption  = "string"
+	IntegerOption = "integer"
+	BooleanOption = "boolean"
+	UserOption    = "user"
+	ChannelOption = "channel"
+)
+
+// CommandOptionTypes contains all valid option types
+var CommandOptionTypes = []interface{}{StringOption, IntegerOption, BooleanOption, UserOption, ChannelOption}
+
+// Command is a slash command a bot registered for a guild. Command names are unique per guild.
+// Invocations are sent to the CallbackUrl, signed with the Secret, if it is set
+// and to the bot's websocket room otherwise.
+// Guild only defines the foreign key, so commands get deleted with their guild.
+type Command struct {
+	BaseModel
+	GuildId     string         `gorm:"not null;uniqueIndex:idx_guild_command_name" json:"guildId"`
+	Guild       *Guild         `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	BotId       string         `gorm:"not null;index" json:"botId"`
+	Name        string         `gorm:"not null;uniqueIndex:idx_guild_command_name" json:"name"`
+	Description string         `gorm:"not null" json:"description"`
+	Options     CommandOptions `gorm:"type:jsonb;not null" json:"options"`
+	CallbackUrl *string        `json:"callbackUrl"`
+	Secret      *string        `json:"-"`
+} //@name Command
+
+// CommandOption is a typed argument of a command.
+// Arguments are passed in the order of the options.
+type CommandOption struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type" enums:"string,integer,boolean,user,channel"`
+	Required    bool   `json:"required"`
+} //@name CommandOption
+
+// CommandOptions stores the options of a command as JSON
+type CommandOptions []CommandOption
+
+// Value implements the driver.Valuer interface
+func (o CommandOptions) Value() (driver.Value, error) {
+	if o == nil {
+		return "[]", nil
+	}
+	value, err := json.Marshal(o)
+	return string(value), err
+}
+
+// Scan implements the sql.Scanner interface
+func (o *CommandOptions) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("invalid command options")
+	}
+	return json.Unmarshal(data, o)
+}
+
+// Interaction is a single invocation of a command. It is kept for InteractionTTL
+// during which the bot can send ephemeral responses to the invoking user.
+type Interaction struct {
+	Id          string                 `json:"id"`
+	CommandId   string                 `json:"commandId"`
+	CommandName string                 `json:"commandName"`
+	GuildId     string                 `json:"guildId"`
+	ChannelId   string                 `json:"channelId"`
+	UserId      string                 `json:"userId"`
+	BotId       string                 `json:"botId"`
+	Options     map[string]interface{} `json:"options"`
+	CreatedAt   time.Time              `json:"createdAt"`
+} //@name Interaction
+
+// EphemeralMessage is a response to an interaction that is only sent to the invoking user
+// and never stored.
+type EphemeralMessage struct {
+	Id            string         `json:"id"`
+	InteractionId string         `json:"interactionId"`
+	ChannelId     string         `json:"channelId"`
+	Text          string         `json:"text"`
+	User          MemberResponse `json:"user"`
+	CreatedAt     time.Time      `json:"createdAt"`
+} //@name EphemeralMessage
+
+// CommandService defines methods related to command operations the handler layer expects
+// any service it interacts with to implement
+type CommandService interface {
+	GetCommands(guildId string) (*[]Command, error)
+	GetCommand(commandId string) (*Command, error)
+	RegisterCommand(command *Command) (*Command, error)
+	DeleteCommand(command *Command) error
+	ParseCommand(guildId, text string) (*Command, map[string]interface{}, error)
+	CreateInteraction(ctx context.Context, command *Command, channelId, userId string, options map[string]interface{}) (*Interaction, error)
+	GetInteraction(ctx context.Context, interactionId string) (*Interaction, error)
+	DispatchInteraction(command *Command, interaction *Interaction) error
+}
+
+// CommandRepository defines methods related to command db operations the service layer expects
+// any repository it interacts with to implement
+type CommandRepository interface {
+	Create(command *Command) error
+	Update(command *Command) error
+	Delete(command *Command) error
+	FindByID(id string) (*Command, error)
+	FindByName(guildId, name string) (*Command, error)
+	FindByGuild(guildId string) (*[]Command, error)
+	CountByBot(guildId, botId string) (int64, error)
+}
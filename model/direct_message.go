@@ -2,9 +2,11 @@ package model
 
 // DirectMessage is the json response of the channel ID
 // and the other user of the DM.
+// UnreadCount is the number of messages the user has not acknowledged yet.
 type DirectMessage struct {
-	Id   string `json:"id"`
-	User DMUser `json:"user"`
+	Id          string `json:"id"`
+	User        DMUser `json:"user"`
+	UnreadCount int    `json:"unreadCount"`
 } //@name DirectMessage
 
 // DMUser is the other member of the DM.
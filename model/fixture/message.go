@@ -43,6 +43,7 @@ func GetMockMessageResponse(uid, cid string) *model.MessageResponse {
 			Username:  user.Username,
 			Image:     user.Image,
 			IsOnline:  user.IsOnline,
+			IsBot:     user.IsBot,
 			CreatedAt: user.CreatedAt,
 			UpdatedAt: user.UpdatedAt,
 			Nickname:  nil,
@@ -55,6 +55,8 @@ func NewMultipartImage(fileName string, contentType string) *MultipartImage {
 	h.Set("Content-Type", contentType)
 	part, _ := writer.CreatePart(h)
 
+	// the image was just written, so rewind before copying it
+	_, _ = f.Seek(0, io.SeekStart)
 	_, _ = io.Copy(part, f)
 	_ = writer.Close()
 
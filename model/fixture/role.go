@@ -0,0 +1,20 @@
+package fixture
+
+import (
+	"github.com/sentrionic/valkyrie/model"
+	"time"
+)
+
+// GetMockRole returns a mock role of the given guild with the given permissions.
+func GetMockRole(guildId string, permissions model.Permission) *model.Role {
+	return &model.Role{
+		BaseModel: model.BaseModel{
+			ID:        RandID(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		GuildID:     guildId,
+		Name:        RandStr(8),
+		Permissions: permissions,
+	}
+}
@@ -2,10 +2,12 @@ package model
 
 // Friend represents the api response of a user's friend.
 type Friend struct {
-	Id       string `json:"id"`
-	Username string `json:"username"`
-	Image    string `json:"image"`
-	IsOnline bool   `json:"isOnline"`
+	Id           string `json:"id"`
+	Username     string `json:"username"`
+	Image        string `json:"image"`
+	IsOnline     bool   `json:"isOnline"`
+	Status       string `gorm:"-" json:"status" enums:"online,idle,dnd,offline"`
+	CustomStatus string `gorm:"-" json:"customStatus,omitempty"`
 } //@name Friend
 
 // FriendService defines methods related to friend operations the handler layer expects
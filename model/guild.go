@@ -16,6 +16,7 @@ type Guild struct {
 	Members     []User         `gorm:"many2many:members;constraint:OnDelete:CASCADE;"`
 	Channels    []Channel      `gorm:"constraint:OnDelete:CASCADE;"`
 	Bans        []User         `gorm:"many2many:bans;constraint:OnDelete:CASCADE;"`
+	Roles       []Role         `gorm:"constraint:OnDelete:CASCADE;"`
 }
 
 // GuildResponse contains all info to display a guild.
@@ -29,6 +30,7 @@ type GuildResponse struct {
 	CreatedAt        time.Time `json:"createdAt"`
 	UpdatedAt        time.Time `json:"updatedAt"`
 	HasNotification  bool      `json:"hasNotification"`
+	MentionCount     int       `json:"mentionCount"`
 	DefaultChannelId string    `json:"default_channel_id"`
 } //@name GuildResponse
 
@@ -57,17 +59,21 @@ type GuildService interface {
 	CreateGuild(guild *Guild) (*Guild, error)
 	GenerateInviteLink(ctx context.Context, guildId string, isPermanent bool) (string, error)
 	UpdateGuild(guild *Guild) error
+	EditGuild(guild *Guild, actorId string) error
 	GetGuildIdFromInvite(ctx context.Context, token string) (string, error)
 	GetDefaultChannel(guildId string) (*Channel, error)
-	InvalidateInvites(ctx context.Context, guild *Guild)
+	InvalidateInvites(ctx context.Context, guild *Guild, actorId string)
 	RemoveMember(userId string, guildId string) error
-	UnbanMember(userId string, guildId string) error
+	KickMember(memberId string, guildId string, actorId string, reason *string) error
+	BanMember(member *User, guild *Guild, actorId string, reason *string) error
+	UnbanMember(userId string, guildId string, actorId string) error
 	DeleteGuild(guildId string) error
 	GetBanList(guildId string) (*[]BanResponse, error)
 	GetMemberSettings(userId string, guildId string) (*MemberSettings, error)
 	UpdateMemberSettings(settings *MemberSettings, userId string, guildId string) error
 	FindUsersByIds(ids []string, guildId string) (*[]User, error)
 	UpdateMemberLastSeen(userId, guildId string) error
+	GetAuditLogs(query *AuditLogQuery) (*[]AuditLogEntry, error)
 }
 
 // GuildRepository defines methods related to guild db operations the service layer expects
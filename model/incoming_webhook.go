@@ -0,0 +1,41 @@
+package model
+
+// IncomingWebhook allows external services to post messages into a channel without a user account.
+// Every webhook is backed by a bot user with the webhook's name that authors its messages.
+// Only the SHA-256 hash of the token is stored.
+type IncomingWebhook struct {
+	BaseModel
+	ChannelId string `gorm:"index;not null" json:"channelId"`
+	GuildId   string `gorm:"index;not null" json:"guildId"`
+	UserId    string `gorm:"not null" json:"userId"`
+	CreatorId string `gorm:"not null" json:"creatorId"`
+	Name      string `gorm:"not null" json:"name"`
+	TokenHash string `gorm:"not null" json:"-"`
+} //@name IncomingWebhook
+
+// CreatedIncomingWebhook is returned once on creation and is the only
+// response that contains the plaintext token.
+type CreatedIncomingWebhook struct {
+	IncomingWebhook
+	Token string `json:"token"`
+} //@name CreatedIncomingWebhook
+
+// IncomingWebhookService defines methods related to incoming webhook operations the handler layer expects
+// any service it interacts with to implement
+type IncomingWebhookService interface {
+	GetWebhooks(channelId string) (*[]IncomingWebhook, error)
+	GetWebhook(webhookId string) (*IncomingWebhook, error)
+	CreateWebhook(channel *Channel, creatorId, name string) (*CreatedIncomingWebhook, error)
+	DeleteWebhook(webhook *IncomingWebhook) error
+	Authenticate(webhookId, token string) (*IncomingWebhook, error)
+}
+
+// IncomingWebhookRepository defines methods related to incoming webhook db operations the service layer expects
+// any repository it interacts with to implement
+type IncomingWebhookRepository interface {
+	Create(webhook *IncomingWebhook) error
+	FindByID(id string) (*IncomingWebhook, error)
+	FindByChannel(channelId string) (*[]IncomingWebhook, error)
+	CountByChannel(channelId string) (int64, error)
+	Delete(webhook *IncomingWebhook) error
+}
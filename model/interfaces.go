@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 )
 
@@ -9,14 +10,28 @@ import (
 // any repository it interacts with to implement
 type FileRepository interface {
 	UploadAvatar(header *multipart.FileHeader, directory string) (string, error)
-	UploadFile(header *multipart.FileHeader, directory, filename, mimetype string) (string, error)
+	UploadFile(file io.Reader, directory, filename, mimetype string) (string, error)
 	DeleteImage(key string) error
 }
 
+// FileStore defines methods related to serving locally stored files the handler layer expects
+// any repository it interacts with to implement
+type FileStore interface {
+	ResolveFile(key, signature string) (string, error)
+}
+
+// LocalFileRepository is a FileRepository that stores the files on disk
+// and serves them using signed URLs
+type LocalFileRepository interface {
+	FileRepository
+	FileStore
+}
+
 // MailRepository defines methods related to mail operations the service layer expects
 // any repository it interacts with to implement
 type MailRepository interface {
-	SendResetMail(email string, html string) error
+	SendResetMail(email, token, locale string) error
+	SendVerificationMail(email, token, locale string) error
 }
 
 // RedisRepository defines methods related to the redis db the service layer expects
@@ -27,4 +42,25 @@ type RedisRepository interface {
 	SaveInvite(ctx context.Context, guildId string, id string, isPermanent bool) error
 	GetInvite(ctx context.Context, token string) (string, error)
 	InvalidateInvites(ctx context.Context, guild *Guild)
+	SaveInteraction(ctx context.Context, interaction *Interaction) error
+	GetInteraction(ctx context.Context, id string) (*Interaction, error)
+	SetMfaTicket(ctx context.Context, userId string) (string, error)
+	UseMfaTicket(ctx context.Context, ticket string) (string, int64, error)
+	DeleteMfaTicket(ctx context.Context, ticket string)
+	SetVerificationToken(ctx context.Context, userId, email string) (string, error)
+	UseVerificationToken(ctx context.Context, token string) (string, string, error)
+	ThrottleVerificationMail(ctx context.Context, userId string) (bool, error)
+	SaveSession(ctx context.Context, userId string, session *Session) error
+	GetSession(ctx context.Context, userId, sessionId string) (*Session, error)
+	GetSessions(ctx context.Context, userId string) (*[]Session, error)
+	DeleteSession(ctx context.Context, userId, sessionId string) error
+	GetPresences(ctx context.Context, userIds []string) (map[string]Presence, error)
+}
+
+// RateLimiter counts requests per key within a fixed period
+type RateLimiter interface {
+	// Reached returns whether the key used up its limit without counting a request
+	Reached(key string) (bool, error)
+	// Take counts a request and returns whether it exceeded the limit
+	Take(key string) (bool, error)
 }
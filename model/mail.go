@@ -0,0 +1,13 @@
+package model
+
+// DefaultLocale is used for emails if the user's locale is not supported
+const DefaultLocale = "en"
+
+// SupportedLocales contains the locales emails can be sent in
+var SupportedLocales = []string{"en", "de"}
+
+// MailTransport defines methods related to delivering rendered emails the mail repository expects
+// any transport it interacts with to implement
+type MailTransport interface {
+	Send(from, to string, message []byte) error
+}
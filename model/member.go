@@ -1,30 +1,40 @@
 package model
 
-import "time"
+import (
+	"github.com/lib/pq"
+	"time"
+)
 
 // Member represents a user in a guild and is the join table between
 // User and Guild.
+// Roles contains the IDs of the guild roles assigned to the member.
 type Member struct {
-	UserID    string    `gorm:"primaryKey;constraint:OnDelete:CASCADE;"`
-	GuildID   string    `gorm:"primaryKey;constraint:OnDelete:CASCADE;"`
-	Nickname  *string   `gorm:"nickname"`
-	Color     *string   `gorm:"color"`
-	LastSeen  time.Time `gorm:"autoCreateTime"`
-	CreatedAt time.Time `gorm:"index"`
+	UserID    string         `gorm:"primaryKey;constraint:OnDelete:CASCADE;"`
+	GuildID   string         `gorm:"primaryKey;constraint:OnDelete:CASCADE;"`
+	Nickname  *string        `gorm:"nickname"`
+	Color     *string        `gorm:"color"`
+	Roles     pq.StringArray `gorm:"type:text[]"`
+	LastSeen  time.Time      `gorm:"autoCreateTime"`
+	CreatedAt time.Time      `gorm:"index"`
 	UpdatedAt time.Time
 }
 
 // MemberResponse is the API response of a member.
+// Status and CustomStatus are only set in the list of guild members.
 type MemberResponse struct {
-	Id        string    `json:"id"`
-	Username  string    `json:"username"`
-	Image     string    `json:"image"`
-	IsOnline  bool      `json:"isOnline"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
-	Nickname  *string   `json:"nickname"`
-	Color     *string   `json:"color"`
-	IsFriend  bool      `json:"isFriend"`
+	Id           string         `json:"id"`
+	Username     string         `json:"username"`
+	Image        string         `json:"image"`
+	IsOnline     bool           `json:"isOnline"`
+	Status       string         `gorm:"-" json:"status,omitempty" enums:"online,idle,dnd,invisible,offline"`
+	CustomStatus string         `gorm:"-" json:"customStatus,omitempty"`
+	IsBot        bool           `json:"isBot"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	UpdatedAt    time.Time      `json:"updatedAt"`
+	Nickname     *string        `json:"nickname"`
+	Color        *string        `json:"color"`
+	IsFriend     bool           `json:"isFriend"`
+	Roles        pq.StringArray `json:"roles" swaggertype:"array,string"`
 } //@name Member
 
 // BanResponse is the API response of a banned member.
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Mention represents a user that got mentioned in a message.
+// Role and everyone mentions get stored as a mention for every member they reached.
+// GuildId should only be nil if the message was sent in a DM.
+type Mention struct {
+	MessageId string    `gorm:"primaryKey" json:"messageId"`
+	UserId    string    `gorm:"primaryKey" json:"-"`
+	ChannelId string    `gorm:"index" json:"channelId"`
+	GuildId   *string   `gorm:"index" json:"guildId"`
+	CreatedAt time.Time `json:"-"`
+} //@name Mention
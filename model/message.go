@@ -1,30 +1,67 @@
 package model
 
 import (
+	"gorm.io/gorm"
 	"mime/multipart"
 	"time"
 )
 
 // Message represents a text message in a channel.
 // It may contain an Attachment that is displayed instead of text.
+// ReplyId references the message in the same channel this message replies to.
+// PinnedAt should only be set if the message is pinned in its channel.
+// Deleted messages are kept as tombstones and Revisions store the text before each edit.
+// Messages of incoming webhooks have a WebhookId and may override the author's name and avatar.
 type Message struct {
 	BaseModel
-	Text       *string
-	UserId     string      `gorm:"index;constraint:OnDelete:CASCADE;"`
-	ChannelId  string      `gorm:"index;constraint:OnDelete:CASCADE;"`
-	Attachment *Attachment `gorm:"constraint:OnDelete:CASCADE;"`
+	Text          *string
+	ReplyId       *string           `gorm:"index"`
+	PinnedAt      *time.Time        `gorm:"index"`
+	UserId        string            `gorm:"index;constraint:OnDelete:CASCADE;"`
+	ChannelId     string            `gorm:"index;constraint:OnDelete:CASCADE;"`
+	Attachment    *Attachment       `gorm:"constraint:OnDelete:CASCADE;"`
+	Reactions     []Reaction        `gorm:"constraint:OnDelete:CASCADE;"`
+	Mentions      []Mention         `gorm:"constraint:OnDelete:CASCADE;"`
+	Revisions     []MessageRevision `gorm:"constraint:OnDelete:CASCADE;"`
+	DeletedAt     gorm.DeletedAt    `gorm:"index"`
+	WebhookId     *string           `gorm:"index"`
+	WebhookName   *string
+	WebhookAvatar *string
 }
 
-// MessageResponse is the API response of a Message
+// MessageResponse is the API response of a Message.
+// Deleted messages do not contain their text, attachment and reactions.
 type MessageResponse struct {
-	Id         string         `json:"id"`
-	Text       *string        `json:"text"`
-	CreatedAt  time.Time      `json:"createdAt"`
-	UpdatedAt  time.Time      `json:"updatedAt"`
-	Attachment *Attachment    `json:"attachment"`
-	User       MemberResponse `json:"user"`
+	Id         string             `json:"id"`
+	Text       *string            `json:"text"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+	Attachment *Attachment        `json:"attachment"`
+	User       MemberResponse     `json:"user"`
+	Reactions  []ReactionResponse `json:"reactions"`
+	ReplyTo    *MessageReference  `json:"replyTo"`
+	ThreadId   *string            `json:"threadId"`
+	PinnedAt   *time.Time         `json:"pinnedAt"`
+	Deleted    bool               `json:"deleted"`
+	WebhookId  *string            `json:"webhookId"`
 } //@name Message
 
+// MessageReference is a snippet of the message that got replied to
+type MessageReference struct {
+	Id       string  `json:"id"`
+	Text     *string `json:"text"`
+	UserId   string  `json:"userId"`
+	Username string  `json:"username"`
+} //@name MessageReference
+
+// PinsUpdate notifies the channel that a message got pinned or unpinned.
+// PinnedAt is nil if the message got unpinned.
+type PinsUpdate struct {
+	ChannelId string     `json:"channelId"`
+	MessageId string     `json:"messageId"`
+	PinnedAt  *time.Time `json:"pinnedAt"`
+} //@name PinsUpdate
+
 // Attachment represents a message attachment that displays
 // a file instead of text.
 type Attachment struct {
@@ -37,23 +74,54 @@ type Attachment struct {
 	MessageId string    `gorm:"index;constraint:OnDelete:CASCADE;" json:"-"`
 } //@name Attachment
 
+// MessageSearch contains the filters of a message search.
+// Only messages in the ChannelIds and their threads are searched.
+// GuildId should only be nil if the channels are DMs.
+type MessageSearch struct {
+	Query         string
+	GuildId       *string
+	ChannelIds    []string
+	AuthorId      string
+	Mentions      string
+	HasAttachment bool
+	Before        *time.Time
+	After         *time.Time
+}
+
 // MessageService defines methods related to message operations the handler layer expects
 // any service it interacts with to implement
 type MessageService interface {
 	GetMessages(userId string, channel *Channel, cursor string) (*[]MessageResponse, error)
+	SearchMessages(userId string, search *MessageSearch) (*[]MessageResponse, error)
 	CreateMessage(params *Message) (*Message, error)
 	UpdateMessage(message *Message) error
 	DeleteMessage(message *Message) error
-	UploadFile(header *multipart.FileHeader, channelId string) (*Attachment, error)
+	UploadFile(header *multipart.FileHeader, channelId, mimetype string) (*Attachment, error)
 	Get(messageId string) (*Message, error)
+	AddReaction(reaction *Reaction) error
+	RemoveReaction(reaction *Reaction) error
+	GetPinnedMessages(userId string, channel *Channel) (*[]MessageResponse, error)
+	PinMessage(message *Message) error
+	UnpinMessage(message *Message) error
+	GetHistory(messageId string) (*MessageHistory, error)
+	PurgeHistory(retention time.Duration) error
 }
 
 // MessageRepository defines methods related message db operations the service layer expects
 // any repository it interacts with to implement
 type MessageRepository interface {
 	GetMessages(userId string, channel *Channel, cursor string) (*[]MessageResponse, error)
+	SearchMessages(userId string, search *MessageSearch) (*[]MessageResponse, error)
 	CreateMessage(params *Message) (*Message, error)
 	UpdateMessage(message *Message) error
 	DeleteMessage(message *Message) error
 	GetById(messageId string) (*Message, error)
+	AddReaction(reaction *Reaction) error
+	RemoveReaction(reaction *Reaction) error
+	GetPinnedMessages(userId string, channel *Channel) (*[]MessageResponse, error)
+	CountPinnedMessages(channelId string) (int64, error)
+	SetPinned(message *Message) error
+	GetHistory(messageId string) (*MessageHistory, error)
+	DeleteRevisionsBefore(date time.Time) (int64, error)
+	DeleteMessagesBefore(date time.Time) (*[]Attachment, int64, error)
 }
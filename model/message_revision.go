@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// MessageRevision stores the text of a message before it got edited.
+// CreatedAt is the time the text got replaced.
+type MessageRevision struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	MessageId string    `gorm:"index" json:"-"`
+	Text      *string   `json:"text"`
+	CreatedAt time.Time `gorm:"index" json:"createdAt"`
+} //@name MessageRevision
+
+// MessageHistory contains the current state of a message, even if it got deleted,
+// and all of its previous revisions ordered from oldest to newest.
+type MessageHistory struct {
+	Id        string            `json:"id"`
+	ChannelId string            `json:"channelId"`
+	UserId    string            `json:"userId"`
+	Text      *string           `json:"text"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+	DeletedAt *time.Time        `json:"deletedAt"`
+	Revisions []MessageRevision `json:"revisions"`
+} //@name MessageHistory
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// Two-factor authentication constants
+const (
+	MfaIssuer         = "Valkyrie"
+	MfaTicketTTL      = 5 * time.Minute
+	MfaMaxAttempts    = 5
+	RecoveryCodeCount = 10
+)
+
+// MfaSetup contains the secret of a pending TOTP enrollment.
+// Uri is the otpauth:// provisioning URI that authenticator apps scan as a QR code.
+type MfaSetup struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"`
+} //@name MfaSetup
+
+// MfaChallenge is returned by the login instead of a session if the user enabled
+// two-factor authentication. The ticket has to be exchanged together with a code.
+type MfaChallenge struct {
+	MfaRequired bool   `json:"mfaRequired"`
+	Ticket      string `json:"ticket"`
+} //@name MfaChallenge
+
+// RecoveryCodes contains the plaintext single-use recovery codes.
+// They are only returned once and only their hashes get stored.
+type RecoveryCodes struct {
+	Codes []string `json:"codes"`
+} //@name RecoveryCodes
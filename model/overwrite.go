@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Overwrite types
+const (
+	RoleOverwrite   = "role"
+	MemberOverwrite = "member"
+)
+
+// PermissionOverwrite allows or denies channel permissions for a role or a member.
+// Role overwrites with the guild ID as the TargetId apply to every member of the guild.
+type PermissionOverwrite struct {
+	ChannelId string     `gorm:"primaryKey"`
+	TargetId  string     `gorm:"primaryKey"`
+	Type      string     `gorm:"not null"`
+	Allow     Permission `gorm:"not null;default:0"`
+	Deny      Permission `gorm:"not null;default:0"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OverwriteResponse is the API response of a permission overwrite.
+type OverwriteResponse struct {
+	TargetId string     `json:"targetId"`
+	Type     string     `json:"type"`
+	Allow    Permission `json:"allow"`
+	Deny     Permission `json:"deny"`
+} //@name PermissionOverwrite
+
+// SerializeOverwrite returns the overwrite API response.
+func (o PermissionOverwrite) SerializeOverwrite() OverwriteResponse {
+	return OverwriteResponse{
+		TargetId: o.TargetId,
+		Type:     o.Type,
+		Allow:    o.Allow,
+		Deny:     o.Deny,
+	}
+}
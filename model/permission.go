@@ -0,0 +1,45 @@
+package model
+
+// Permission is a bitfield of the actions a member is allowed to take in a guild.
+type Permission int64
+
+// Guild Permissions
+const (
+	// Administrator grants every other permission
+	Administrator Permission = 1 << iota
+	ManageGuild
+	ManageRoles
+	ManageChannels
+	KickMembers
+	BanMembers
+	ManageInvites
+	ManageMessages
+
+	// Channel Permissions. These can be overwritten per channel
+	ViewChannel
+	SendMessages
+	AttachFiles
+	ReadMessageHistory
+
+	// MentionEveryone allows mentioning @everyone and roles
+	MentionEveryone
+)
+
+// ChannelPermissions contains the permissions that can be overwritten per channel.
+const ChannelPermissions = ViewChannel | SendMessages | AttachFiles | ReadMessageHistory
+
+// DefaultPermissions contains the permissions every member has without any roles.
+const DefaultPermissions = ChannelPermissions
+
+// AllPermissions contains every permission and is what the guild owner has.
+const AllPermissions = Administrator | ManageGuild | ManageRoles | ManageChannels |
+	KickMembers | BanMembers | ManageInvites | ManageMessages | ChannelPermissions | MentionEveryone
+
+// Has checks if the bitfield contains the given permission.
+// Administrators implicitly have every permission.
+func (p Permission) Has(permission Permission) bool {
+	if p&Administrator == Administrator {
+		return true
+	}
+	return p&permission == permission
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// Presence statuses. Invisible users appear offline to everyone else.
+const (
+	StatusOnline    = "online"
+	StatusIdle      = "idle"
+	StatusDND       = "dnd"
+	StatusInvisible = "invisible"
+	StatusOffline   = "offline"
+)
+
+// PresenceStatuses are the statuses users can choose
+var PresenceStatuses = []string{StatusOnline, StatusIdle, StatusDND, StatusInvisible}
+
+// Presence is the status of a user as seen by their friends and guild members.
+// It is derived from the user's websocket connections and their chosen status.
+type Presence struct {
+	UserId                string     `json:"userId"`
+	Status                string     `json:"status"`
+	CustomStatus          string     `json:"customStatus,omitempty"`
+	CustomStatusExpiresAt *time.Time `json:"customStatusExpiresAt,omitempty"`
+} //@name Presence
+
+// VisibleTo returns the presence as seen by the given user.
+// Invisible users appear offline without their custom status to everyone but themselves.
+func (p Presence) VisibleTo(userId string) Presence {
+	if p.Status == StatusInvisible && p.UserId != userId {
+		return Presence{UserId: p.UserId, Status: StatusOffline}
+	}
+	return p
+}
+
+// PresenceKey is the redis hash containing the chosen status, the custom status
+// and the last emitted presence of the user
+func PresenceKey(userId string) string {
+	return "presence:" + userId
+}
+
+// PresenceConnectionsKey is the redis sorted set of the user's websocket connections, scored by their expiry
+func PresenceConnectionsKey(userId string) string {
+	return "presence-connections:" + userId
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Reaction represents an emoji reaction of a user to a message.
+// Every user can react with each emoji only once.
+type Reaction struct {
+	MessageId string    `gorm:"primaryKey" json:"messageId"`
+	UserId    string    `gorm:"primaryKey" json:"userId"`
+	Emoji     string    `gorm:"primaryKey" json:"emoji"`
+	CreatedAt time.Time `json:"-"`
+} //@name ReactionEvent
+
+// ReactionResponse contains the aggregated reactions for one emoji of a message.
+// Me is true if the current user reacted with the emoji.
+type ReactionResponse struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+	Me    bool   `json:"me"`
+} //@name Reaction
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// ReadState stores the last message a user acknowledged in a channel.
+// LastReadAt is the creation time of that message and is used to count unread messages.
+type ReadState struct {
+	UserId        string    `gorm:"primaryKey" json:"-"`
+	ChannelId     string    `gorm:"primaryKey" json:"channelId"`
+	LastMessageId string    `json:"lastMessageId"`
+	LastReadAt    time.Time `json:"lastReadAt"`
+	UpdatedAt     time.Time `json:"-"`
+} //@name ReadState
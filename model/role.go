@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+// Role represents a named set of permissions in a guild.
+// Members get the combined permissions of all the roles they are assigned.
+type Role struct {
+	BaseModel
+	GuildID     string `gorm:"index;not null"`
+	Name        string `gorm:"not null"`
+	Color       *string
+	Permissions Permission `gorm:"not null;default:0"`
+}
+
+// RoleResponse is the API response of a role.
+type RoleResponse struct {
+	Id          string     `json:"id"`
+	Name        string     `json:"name"`
+	Color       *string    `json:"color"`
+	Permissions Permission `json:"permissions"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+} //@name Role
+
+// SerializeRole returns the role API response.
+func (r Role) SerializeRole() RoleResponse {
+	return RoleResponse{
+		Id:          r.ID,
+		Name:        r.Name,
+		Color:       r.Color,
+		Permissions: r.Permissions,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+// PermissionService defines methods related to roles and permission checks the handler layer expects
+// any service it interacts with to implement
+type PermissionService interface {
+	GetRoles(guildId string) (*[]RoleResponse, error)
+	GetRole(roleId string) (*Role, error)
+	CreateRole(role *Role) (*Role, error)
+	UpdateRole(role *Role) error
+	DeleteRole(role *Role) error
+	AddMemberRole(userId, guildId, roleId string) error
+	RemoveMemberRole(userId, guildId, roleId string) error
+	GetMemberPermissions(userId string, guild *Guild) Permission
+	HasPermission(userId string, guild *Guild, permission Permission) bool
+}
+
+// RoleRepository defines methods related to role db operations the service layer expects
+// any repository it interacts with to implement
+type RoleRepository interface {
+	Create(role *Role) (*Role, error)
+	GetById(roleId string) (*Role, error)
+	List(guildId string) (*[]RoleResponse, error)
+	Save(role *Role) error
+	Delete(role *Role) error
+	AddMemberRole(userId, guildId, roleId string) error
+	RemoveMemberRole(userId, guildId, roleId string) error
+	GetMemberPermissions(userId, guildId string) (Permission, error)
+	GetMemberRoles(userId, guildId string) (*[]Role, error)
+	GetRoleMemberIds(guildId string, roleIds []string) (*[]string, error)
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Session constants
+const (
+	// SessionTTL is how long a session stays valid without being used
+	SessionTTL = 7 * 24 * time.Hour
+	// SessionTouchInterval throttles how often the last used time of a session gets updated
+	SessionTouchInterval = time.Minute
+)
+
+// Session represents a device the user is logged in with.
+// Current is true for the session that made the request.
+type Session struct {
+	Id        string    `json:"id"`
+	Device    string    `json:"device"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"userAgent"`
+	CreatedAt time.Time `json:"createdAt"`
+	LastUsed  time.Time `json:"lastUsed"`
+	Current   bool      `json:"current"`
+} //@name Session
+
+// SessionService defines methods related to session tracking the handler layer expects
+// any service it interacts with to implement
+type SessionService interface {
+	CreateSession(ctx context.Context, userId, ip, userAgent string) (*Session, error)
+	GetSessions(ctx context.Context, userId string) (*[]Session, error)
+	TouchSession(ctx context.Context, userId, sessionId string) error
+	RevokeSession(ctx context.Context, userId, sessionId string) error
+	RevokeSessions(ctx context.Context, userId, exceptId string) ([]string, error)
+}
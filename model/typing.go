@@ -0,0 +1,9 @@
+package model
+
+// TypingEvent is emitted when a user starts or stops typing in a channel.
+// Username is the display name of the member and only set when they start typing.
+type TypingEvent struct {
+	ChannelId string `json:"channelId"`
+	UserId    string `json:"userId"`
+	Username  string `json:"username,omitempty"`
+} //@name TypingEvent
@@ -2,21 +2,30 @@ package model
 
 import (
 	"context"
+	"github.com/lib/pq"
 	"mime/multipart"
 )
 
 // User represents the user of the website.
 type User struct {
 	BaseModel
-	Username string    `gorm:"not null" json:"username"`
-	Email    string    `gorm:"not null;uniqueIndex" json:"email"`
-	Password string    `gorm:"not null" json:"-"`
-	Image    string    `json:"image"`
-	IsOnline bool      `gorm:"index;default:true" json:"isOnline"`
-	Friends  []User    `gorm:"many2many:friends;" json:"-"`
-	Requests []User    `gorm:"many2many:friend_requests;joinForeignKey:sender_id;joinReferences:receiver_id" json:"-"`
-	Guilds   []Guild   `gorm:"many2many:members;" json:"-"`
-	Message  []Message `json:"-"`
+	Username      string         `gorm:"not null" json:"username"`
+	Email         string         `gorm:"not null;uniqueIndex" json:"email"`
+	Password      string         `gorm:"not null" json:"-"`
+	Image         string         `json:"image"`
+	Locale        string         `gorm:"not null;default:'en'" json:"locale"`
+	IsOnline      bool           `gorm:"index;default:true" json:"isOnline"`
+	IsBot         bool           `gorm:"not null;default:false" json:"isBot"`
+	OwnerId       *string        `gorm:"index" json:"-"`
+	EmailVerified bool           `gorm:"not null;default:false" json:"emailVerified"`
+	MfaEnabled    bool           `gorm:"not null;default:false" json:"mfaEnabled"`
+	TotpSecret    *string        `json:"-"`
+	TotpLastStep  int64          `gorm:"not null;default:0" json:"-"`
+	RecoveryCodes pq.StringArray `gorm:"type:text[]" json:"-"`
+	Friends       []User         `gorm:"many2many:friends;" json:"-"`
+	Requests      []User         `gorm:"many2many:friend_requests;joinForeignKey:sender_id;joinReferences:receiver_id" json:"-"`
+	Guilds        []Guild        `gorm:"many2many:members;" json:"-"`
+	Message       []Message      `json:"-"`
 } //@name User
 
 // UserService defines methods related to account operations the handler layer expects
@@ -35,6 +44,22 @@ type UserService interface {
 	ResetPassword(ctx context.Context, password string, token string) (*User, error)
 	GetFriendAndGuildIds(userId string) (*[]string, error)
 	GetRequestCount(userId string) (*int64, error)
+	GetBots(ownerId string) (*[]User, error)
+	CreateBot(ownerId, username string) (*User, error)
+	GetTokens(ownerId string) (*[]APIToken, error)
+	CreateToken(user *User, name string) (*CreatedToken, error)
+	RevokeToken(ownerId, tokenId string) error
+	Authenticate(scheme, token string) (*User, error)
+	SetupMfa(user *User) (*MfaSetup, error)
+	EnableMfa(user *User, code string) (*RecoveryCodes, error)
+	DisableMfa(user *User, code string) error
+	RegenerateRecoveryCodes(user *User, code string) (*RecoveryCodes, error)
+	VerifyMfa(user *User, code string) error
+	CreateMfaChallenge(ctx context.Context, user *User) (*MfaChallenge, error)
+	LoginWithMfa(ctx context.Context, ticket, code string) (*User, error)
+	SendVerificationMail(ctx context.Context, user *User) error
+	ResendVerificationMail(ctx context.Context, user *User) error
+	VerifyEmail(ctx context.Context, token string) (*User, error)
 }
 
 // UserRepository defines methods related to account db operations the service layer expects
@@ -46,4 +71,8 @@ type UserRepository interface {
 	Update(user *User) error
 	GetFriendAndGuildIds(userId string) (*[]string, error)
 	GetRequestCount(userId string) (*int64, error)
+	FindBots(ownerId string) (*[]User, error)
+	CountBots(ownerId string) (int64, error)
+	SetTotpStep(userId string, step int64) (bool, error)
+	UseRecoveryCode(userId, codeHash string) (bool, error)
 }
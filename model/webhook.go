@@ -0,0 +1,111 @@
+package model
+
+import (
+	"github.com/lib/pq"
+	"net"
+	"time"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook is an outgoing subscription of a guild. Events emitted to the guild are POSTed
+// as JSON to the Url and signed with the Secret. An empty Events filter subscribes to all events.
+// FailureCount is the amount of consecutive failed deliveries and disables the webhook once it
+// reaches the limit. Guild only defines the foreign key, so webhooks get deleted with their guild.
+type Webhook struct {
+	BaseModel
+	GuildId      string            `gorm:"index;not null" json:"guildId"`
+	Guild        *Guild            `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Url          string            `gorm:"not null" json:"url"`
+	Secret       string            `gorm:"not null" json:"-"`
+	Events       pq.StringArray    `gorm:"type:text[]" json:"events" swaggertype:"array,string"`
+	Enabled      bool              `gorm:"not null;default:true" json:"enabled"`
+	FailureCount int               `gorm:"not null;default:0" json:"failureCount"`
+	Deliveries   []WebhookDelivery `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+} //@name Webhook
+
+// WebhookDelivery is a single event sent to a webhook.
+// Pending deliveries are retried with an exponential backoff until NextAttemptAt.
+type WebhookDelivery struct {
+	ID             string     `gorm:"primaryKey" json:"id"`
+	WebhookId      string     `gorm:"index;not null" json:"webhookId"`
+	Webhook        *Webhook   `json:"-"`
+	Event          string     `gorm:"not null" json:"event"`
+	Payload        string     `gorm:"type:jsonb;not null" json:"-"`
+	Status         string     `gorm:"index;not null" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	ResponseStatus *int       `json:"responseStatus"`
+	LastError      *string    `json:"lastError"`
+	NextAttemptAt  *time.Time `gorm:"index" json:"nextAttemptAt"`
+	CreatedAt      time.Time  `gorm:"index" json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+} //@name WebhookDelivery
+
+// WebhookPayload is the body of a delivery
+type WebhookPayload struct {
+	Id        string      `json:"id"`
+	Event     string      `json:"event"`
+	GuildId   string      `json:"guildId"`
+	Data      interface{} `json:"data"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+// WebhookService defines methods related to webhook operations the handler layer expects
+// any service it interacts with to implement
+type WebhookService interface {
+	GetWebhooks(guildId string) (*[]Webhook, error)
+	GetWebhook(webhookId string) (*Webhook, error)
+	CreateWebhook(webhook *Webhook) (*Webhook, error)
+	UpdateWebhook(webhook *Webhook) error
+	DeleteWebhook(webhook *Webhook) error
+	GetDeliveries(webhookId string, limit int) (*[]WebhookDelivery, error)
+	Dispatch(guildId, event string, data interface{})
+	ProcessDeliveries() error
+}
+
+// WebhookRepository defines methods related to webhook db operations the service layer expects
+// any repository it interacts with to implement
+type WebhookRepository interface {
+	Create(webhook *Webhook) error
+	FindByID(id string) (*Webhook, error)
+	FindByGuild(guildId string) (*[]Webhook, error)
+	FindSubscribed(guildId, event string) (*[]Webhook, error)
+	CountByGuild(guildId string) (int64, error)
+	Update(webhook *Webhook) error
+	Delete(webhook *Webhook) error
+	RecordFailure(id string, threshold int) error
+	ResetFailures(id string) error
+	CreateDeliveries(deliveries []WebhookDelivery) error
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) (*[]WebhookDelivery, error)
+	UpdateDelivery(delivery *WebhookDelivery) error
+	FindDeliveries(webhookId string, limit int) (*[]WebhookDelivery, error)
+}
+
+// WebhookClient sends the signed deliveries to the webhook endpoints
+type WebhookClient interface {
+	Post(url string, body []byte, headers map[string]string) (int, error)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which is not routable on the internet
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicIP checks if webhooks and command callbacks may be sent to the address.
+// Private, loopback, link-local (e.g. the 169.254.169.254 metadata service),
+// multicast and unspecified addresses are internal to the server's network.
+func IsPublicIP(ip net.IP) bool {
+	return ip != nil &&
+		!ip.IsPrivate() &&
+		!ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip) &&
+		!(ip.To4() != nil && ip.To4()[0] == 0)
+}
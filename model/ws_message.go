@@ -10,6 +10,8 @@ type ReceivedMessage struct {
 	Action  string  `json:"action"`
 	Room    string  `json:"room"`
 	Message *string `json:"message"`
+	// Optional ID chosen by the client. Messages with a nonce get acknowledged.
+	Nonce string `json:"nonce,omitempty"`
 }
 
 // WebsocketMessage represents an emitted message
@@ -34,11 +36,15 @@ type SocketService interface {
 	EmitNewMessage(room string, message *MessageResponse)
 	EmitEditMessage(room string, message *MessageResponse)
 	EmitDeleteMessage(room, messageId string)
+	EmitAddReaction(room string, reaction *Reaction)
+	EmitRemoveReaction(room string, reaction *Reaction)
+	EmitPinsUpdate(room string, update *PinsUpdate)
 
 	EmitNewChannel(room string, channel *ChannelResponse)
 	EmitNewPrivateChannel(members []string, channel *ChannelResponse)
 	EmitEditChannel(room string, channel *ChannelResponse)
 	EmitDeleteChannel(channel *Channel)
+	EmitAddThread(room string, thread *ChannelResponse)
 
 	EmitEditGuild(guild *Guild)
 	EmitDeleteGuild(guildId string, members []string)
@@ -47,11 +53,24 @@ type SocketService interface {
 	EmitAddMember(room string, member *User)
 	EmitRemoveMember(room, memberId string)
 
+	EmitAddRole(guildId string, role *RoleResponse)
+	EmitEditRole(guildId string, role *RoleResponse)
+	EmitDeleteRole(guildId, roleId string)
+
 	EmitNewDMNotification(channelId string, user *User)
 	EmitNewNotification(guildId, channelId string)
+	EmitNewMention(userId string, mention *Mention)
+	EmitAck(userId string, state *ReadState)
+
+	EmitInteraction(botId string, interaction *Interaction)
+	EmitEphemeralMessage(userId string, message *EphemeralMessage)
 
 	EmitSendRequest(room string)
 	EmitAddFriendRequest(room string, request *FriendRequest)
 	EmitAddFriend(user, member *User)
 	EmitRemoveFriend(userId, memberId string)
+
+	StopTyping(channelId, userId string)
+	RecheckSubscriptions(guildId string)
+	CloseSessions(sessionIds []string)
 }
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+)
+
+// auditLogRepository is data/repository implementation
+// of service layer AuditLogRepository
+type auditLogRepository struct {
+	DB *gorm.DB
+}
+
+// NewAuditLogRepository is a factory for initializing Audit Log Repositories
+func NewAuditLogRepository(db *gorm.DB) model.AuditLogRepository {
+	return &auditLogRepository{
+		DB: db,
+	}
+}
+
+// Create inserts the entry in the DB
+func (r *auditLogRepository) Create(entry *model.AuditLogEntry) error {
+	if result := r.DB.Create(entry); result.Error != nil {
+		log.Printf("Could not create an audit log entry for guild: %v. Reason: %v\n", entry.GuildId, result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// Find returns the most recent entries of the guild that match the query
+func (r *auditLogRepository) Find(query *model.AuditLogQuery) (*[]model.AuditLogEntry, error) {
+	var entries []model.AuditLogEntry
+
+	tx := r.DB.Where("guild_id = ?", query.GuildId)
+
+	if query.ActionType != "" {
+		tx = tx.Where("action_type = ?", query.ActionType)
+	}
+
+	if query.ActorId != "" {
+		tx = tx.Where("actor_id = ?", query.ActorId)
+	}
+
+	if query.Before != "" {
+		tx = tx.Where("created_at < (?)", r.DB.
+			Model(&model.AuditLogEntry{}).
+			Select("created_at").
+			Where("id = ?", query.Before),
+		)
+	}
+
+	if result := tx.Order("created_at DESC").Limit(query.Limit).Find(&entries); result.Error != nil {
+		log.Printf("Could not fetch the audit log of guild: %v. Reason: %v\n", query.GuildId, result.Error)
+		return nil, apperrors.NewInternal()
+	}
+
+	return &entries, nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/sentrionic/valkyrie/model"
 	"github.com/sentrionic/valkyrie/model/apperrors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"log"
 	"time"
 )
@@ -43,24 +44,35 @@ func (r *channelRepository) GetGuildDefault(guildId string) (*model.Channel, err
 	return &channel, result.Error
 }
 
-// Get fetches all public channels for the given guildId
-// and the private channels the given user is part in
+// Get fetches all channels except threads for the given guildId,
+// whether the given user is a member of the private ones and their unread messages and mentions.
+// Channels without a read state fall back to the last time the user visited the guild
 func (r *channelRepository) Get(userId string, guildId string) (*[]model.ChannelResponse, error) {
 	var channels []model.ChannelResponse
 
 	result := r.DB.
 		Raw(`
-			SELECT DISTINCT ON (c.id, c."created_at") c.id, c.name, 
-			c."is_public", c."created_at", c."updated_at",
-			(c."last_activity" > m."last_seen") AS "hasNotification"
+			SELECT c.id, c.name, c."is_public", c."created_at", c."updated_at",
+			(c."last_activity" > COALESCE(rs."last_read_at", m."last_seen")) AS "has_notification",
+			(SELECT COUNT(*) FROM mentions mn
+			 JOIN messages msg ON msg."id" = mn."message_id" AND msg."deleted_at" IS NULL
+			 WHERE mn."channel_id" = c."id" AND mn."user_id" = @userId
+			 AND mn."created_at" > COALESCE(rs."last_read_at", m."last_seen")) AS "mention_count",
+			(SELECT COUNT(*) FROM messages msg
+			 WHERE msg."channel_id" = c."id" AND msg."user_id" != @userId AND msg."deleted_at" IS NULL
+			 AND msg."created_at" > COALESCE(rs."last_read_at", m."last_seen")) AS "unread_count",
+			(pc."user_id" IS NOT NULL) AS "is_pc_member"
 			FROM channels AS c
 			LEFT OUTER JOIN pcmembers as pc
-			ON c."id"::text = pc."channel_id"::text
-			LEFT OUTER JOIN members m on c."guild_id" = m."guild_id"
-			WHERE c."guild_id"::text = ?
-			AND (c."is_public" = true or pc."user_id"::text = ?)
+			ON c."id"::text = pc."channel_id"::text AND pc."user_id"::text = @userId
+			LEFT OUTER JOIN members m
+			ON c."guild_id" = m."guild_id" AND m."user_id" = @userId
+			LEFT OUTER JOIN read_states rs
+			ON rs."channel_id" = c."id" AND rs."user_id" = @userId
+			WHERE c."guild_id"::text = @guildId
+			AND c."parent_id" IS NULL
 			ORDER BY c."created_at"
-		`, guildId, userId).
+		`, sql.Named("userId", userId), sql.Named("guildId", guildId)).
 		Scan(&channels)
 
 	return &channels, result.Error
@@ -68,21 +80,27 @@ func (r *channelRepository) Get(userId string, guildId string) (*[]model.Channel
 
 // dmQuery represents the fetched fields for GetDirectMessages
 type dmQuery struct {
-	ChannelId string
-	Id        string
-	Username  string
-	Image     string
-	IsOnline  bool
-	IsFriend  bool
+	ChannelId   string
+	Id          string
+	Username    string
+	Image       string
+	IsOnline    bool
+	IsFriend    bool
+	UnreadCount int
 }
 
-// GetDirectMessages returns all DMs for the given user
+// GetDirectMessages returns all open DMs for the given user with the number of unread messages
 func (r *channelRepository) GetDirectMessages(userId string) (*[]model.DirectMessage, error) {
 	var results []dmQuery
 
 	err := r.DB.
 		Raw(`
-			SELECT dm."channel_id", u.username, u.image, u.id, u."is_online", u."created_at", u."updated_at"
+			SELECT dm."channel_id", u.username, u.image, u.id, u."is_online", u."created_at", u."updated_at",
+			(SELECT COUNT(*) FROM messages msg
+			 LEFT OUTER JOIN read_states rs
+			 ON rs."channel_id" = msg."channel_id" AND rs."user_id" = @id
+			 WHERE msg."channel_id" = dm."channel_id" AND msg."user_id" != @id AND msg."deleted_at" IS NULL
+			 AND msg."created_at" > COALESCE(rs."last_read_at", 'epoch')) AS "unread_count"
 			FROM users u
 			JOIN dm_members dm ON dm."user_id" = u.id
 			WHERE u.id != @id
@@ -114,6 +132,7 @@ func (r *channelRepository) GetDirectMessages(userId string) (*[]model.DirectMes
 				IsOnline: dm.IsOnline,
 				IsFriend: dm.IsFriend,
 			},
+			UnreadCount: dm.UnreadCount,
 		}
 		channels = append(channels, channel)
 	}
@@ -140,10 +159,14 @@ func (r *channelRepository) GetDirectMessageChannel(userId string, memberId stri
 	return &id, result.Error
 }
 
-// GetById returns the channel with its PCMembers for the given channel id
+// GetById returns the channel with its PCMembers and Overwrites for the given channel id
 func (r *channelRepository) GetById(channelId string) (*model.Channel, error) {
 	var channel model.Channel
-	err := r.DB.Preload("PCMembers").Where("id = ?", channelId).First(&channel).Error
+	err := r.DB.
+		Preload("PCMembers").
+		Preload("Overwrites").
+		Where("id = ?", channelId).
+		First(&channel).Error
 	return &channel, err
 }
 
@@ -262,3 +285,76 @@ func (r *channelRepository) GetDMMemberIds(channelId string) (*[]string, error)
 		Scan(&members).Error
 	return &members, err
 }
+
+// GetGuildOverwrites returns the permission overwrites of all channels in the given guild
+func (r *channelRepository) GetGuildOverwrites(guildId string) (*[]model.PermissionOverwrite, error) {
+	var overwrites []model.PermissionOverwrite
+	err := r.DB.
+		Raw(`
+			SELECT po.*
+			FROM permission_overwrites po
+			JOIN channels c ON po."channel_id" = c.id
+			WHERE c."guild_id" = ?
+		`, guildId).
+		Scan(&overwrites).Error
+	return &overwrites, err
+}
+
+// SetOverwrite inserts the given overwrite or updates it if the target already has one
+func (r *channelRepository) SetOverwrite(overwrite *model.PermissionOverwrite) error {
+	if result := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel_id"}, {Name: "target_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"type", "allow", "deny", "updated_at"}),
+	}).Create(&overwrite); result.Error != nil {
+		log.Printf("Could not set the overwrite for channel %s. Reason: %v\n", overwrite.ChannelId, result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// DeleteOverwrite removes the overwrite of the given target from the channel
+func (r *channelRepository) DeleteOverwrite(channelId, targetId string) error {
+	if result := r.DB.
+		Exec("DELETE FROM permission_overwrites WHERE channel_id = ? AND target_id = ?", channelId, targetId); result.Error != nil {
+		log.Printf("Could not delete the overwrite for channel %s. Reason: %v\n", channelId, result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// GetThreads returns the threads of the given channel ordered by their creation
+func (r *channelRepository) GetThreads(channelId string) (*[]model.Channel, error) {
+	var threads []model.Channel
+	err := r.DB.
+		Where("parent_id = ?", channelId).
+		Order("created_at ASC").
+		Find(&threads).Error
+	return &threads, err
+}
+
+// SetReadState stores the given read state unless the user already acknowledged a newer message
+// in the channel and returns the current read state
+func (r *channelRepository) SetReadState(state *model.ReadState) (*model.ReadState, error) {
+	if err := r.DB.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "channel_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"last_message_id", "last_read_at", "updated_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: `read_states."last_read_at" < excluded."last_read_at"`},
+			}},
+		}).
+		Create(state).Error; err != nil {
+		log.Printf("Could not set the read state for channel: %v. Reason: %v\n", state.ChannelId, err)
+		return nil, apperrors.NewInternal()
+	}
+
+	current := &model.ReadState{}
+	if err := r.DB.
+		Where("user_id = ? AND channel_id = ?", state.UserId, state.ChannelId).
+		First(current).Error; err != nil {
+		log.Printf("Could not fetch the read state for channel: %v. Reason: %v\n", state.ChannelId, err)
+		return nil, apperrors.NewInternal()
+	}
+
+	return current, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+)
+
+// commandRepository is data/repository implementation
+// of service layer CommandRepository
+type commandRepository struct {
+	DB *gorm.DB
+}
+
+// NewCommandRepository is a factory for initializing Command Repositories
+func NewCommandRepository(db *gorm.DB) model.CommandRepository {
+	return &commandRepository{
+		DB: db,
+	}
+}
+
+// Create inserts the command in the DB
+func (r *commandRepository) Create(command *model.Command) error {
+	if result := r.DB.Create(command); result.Error != nil {
+		log.Printf("Could not create a command for guild: %v. Reason: %v\n", command.GuildId, result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// Update updates the command in the DB
+func (r *commandRepository) Update(command *model.Command) error {
+	return r.DB.Save(command).Error
+}
+
+// Delete removes the command from the DB
+func (r *commandRepository) Delete(command *model.Command) error {
+	return r.DB.Delete(command).Error
+}
+
+// FindByID returns the command for the given ID
+func (r *commandRepository) FindByID(id string) (*model.Command, error) {
+	command := &model.Command{}
+
+	if err := r.DB.Where("id = ?", id).First(command).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return command, apperrors.NewNotFound("command", id)
+		}
+		return command, apperrors.NewInternal()
+	}
+
+	return command, nil
+}
+
+// FindByName returns the command with the given name in the given guild
+func (r *commandRepository) FindByName(guildId, name string) (*model.Command, error) {
+	command := &model.Command{}
+
+	if err := r.DB.Where("guild_id = ? AND name = ?", guildId, name).First(command).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return command, apperrors.NewNotFound("command", name)
+		}
+		return command, apperrors.NewInternal()
+	}
+
+	return command, nil
+}
+
+// FindByGuild returns all commands of the given guild ordered by name
+func (r *commandRepository) FindByGuild(guildId string) (*[]model.Command, error) {
+	var commands []model.Command
+	result := r.DB.
+		Where("guild_id = ?", guildId).
+		Order("name").
+		Find(&commands)
+
+	return &commands, result.Error
+}
+
+// CountByBot returns the amount of commands the bot registered in the given guild
+func (r *commandRepository) CountByBot(guildId, botId string) (int64, error) {
+	var count int64
+	err := r.DB.
+		Model(&model.Command{}).
+		Where("guild_id = ? AND bot_id = ?", guildId, botId).
+		Count(&count).
+		Error
+
+	return count, err
+}
@@ -13,7 +13,10 @@ import (
 	"github.com/sentrionic/valkyrie/service"
 	"image"
 	"image/jpeg"
+	"io"
 	"log"
+	"net/url"
+	"strings"
 
 	// Register accepted file type jpeg
 	_ "image/jpeg"
@@ -22,14 +25,15 @@ import (
 	"mime/multipart"
 )
 
-// s3FileRepository includes the S3 session and the BucketName
+// s3FileRepository includes the S3 session and the BucketName.
+// The session decides the endpoint, so any S3 compatible storage like MinIO can be used.
 type s3FileRepository struct {
 	S3Session  *session.Session
 	BucketName string
 }
 
-// NewFileRepository is a factory for initializing the FileRepository
-func NewFileRepository(session *session.Session, bucketName string) model.FileRepository {
+// NewS3FileRepository is a factory for initializing S3 File Repositories
+func NewS3FileRepository(session *session.Session, bucketName string) model.FileRepository {
 	return &s3FileRepository{
 		S3Session:  session,
 		BucketName: bucketName,
@@ -43,31 +47,12 @@ func NewFileRepository(session *session.Session, bucketName string) model.FileRe
 func (s *s3FileRepository) UploadAvatar(header *multipart.FileHeader, directory string) (string, error) {
 	uploader := s3manager.NewUploader(s.S3Session)
 
-	id, _ := service.GenerateId()
-	key := fmt.Sprintf("files/%s/%s.jpeg", directory, id)
-
-	file, err := header.Open()
-
-	if err != nil {
-		log.Printf("Failed to open header: %v\n", err.Error())
-		return "", apperrors.NewInternal()
-	}
-
-	src, _, err := image.Decode(file)
+	key := avatarKey(directory)
 
-	if err != nil {
-		log.Printf("Failed to decode image: %v\n", err.Error())
-		return "", apperrors.NewInternal()
-	}
-
-	img := imaging.Resize(src, 150, 0, imaging.Lanczos)
-
-	buf := new(bytes.Buffer)
-	err = jpeg.Encode(buf, img, &jpeg.Options{Quality: 75})
+	buf, err := resizeAvatar(header)
 
 	if err != nil {
-		log.Printf("Failed to encode image: %v\n", err.Error())
-		return "", apperrors.NewInternal()
+		return "", err
 	}
 
 	up, err := uploader.Upload(&s3manager.UploadInput{
@@ -82,27 +67,15 @@ func (s *s3FileRepository) UploadAvatar(header *multipart.FileHeader, directory
 		return "", apperrors.NewInternal()
 	}
 
-	if err = file.Close(); err != nil {
-		log.Printf("Failed to close file: %v\n", err.Error())
-		return "", apperrors.NewInternal()
-	}
-
 	return up.Location, nil
 }
 
 // UploadFile uploads the given file to the initialized Bucket.
 // It returns the url of the uploaded file.
-func (s *s3FileRepository) UploadFile(header *multipart.FileHeader, directory, filename, mimetype string) (string, error) {
+func (s *s3FileRepository) UploadFile(file io.Reader, directory, filename, mimetype string) (string, error) {
 	uploader := s3manager.NewUploader(s.S3Session)
 
-	key := fmt.Sprintf("files/%s/%s", directory, filename)
-
-	file, err := header.Open()
-
-	if err != nil {
-		log.Printf("Failed to open header: %v\n", err.Error())
-		return "", apperrors.NewInternal()
-	}
+	key := fileKey(directory, filename)
 
 	up, err := uploader.Upload(&s3manager.UploadInput{
 		Body:        file,
@@ -116,20 +89,16 @@ func (s *s3FileRepository) UploadFile(header *multipart.FileHeader, directory, f
 		return "", apperrors.NewInternal()
 	}
 
-	if err = file.Close(); err != nil {
-		log.Printf("Failed to close file: %v\n", err.Error())
-		return "", apperrors.NewInternal()
-	}
-
 	return up.Location, nil
 }
 
 // DeleteImage deletes the file from the Bucket.
+// The key can also be the URL returned by an upload.
 func (s *s3FileRepository) DeleteImage(key string) error {
 	srv := s3.New(s.S3Session)
 	_, err := srv.DeleteObject(&s3.DeleteObjectInput{
 		Bucket: aws.String(s.BucketName),
-		Key:    aws.String(key),
+		Key:    aws.String(keyFromURL(key)),
 	})
 
 	if err != nil {
@@ -139,3 +108,65 @@ func (s *s3FileRepository) DeleteImage(key string) error {
 
 	return nil
 }
+
+// avatarKey returns a new key for an avatar in the given directory
+func avatarKey(directory string) string {
+	id, _ := service.GenerateId()
+	return fmt.Sprintf("files/%s/%s.jpeg", directory, id)
+}
+
+// fileKey returns the key of the file in the given directory
+func fileKey(directory, filename string) string {
+	return fmt.Sprintf("files/%s/%s", directory, filename)
+}
+
+// keyFromURL returns the storage key of an uploaded file's URL.
+// All keys start with "files/", so everything before is the storage's address.
+// Keys are returned unchanged.
+func keyFromURL(location string) string {
+	if i := strings.Index(location, "?"); i >= 0 {
+		location = location[:i]
+	}
+
+	if i := strings.Index(location, "/files/"); i >= 0 {
+		location = location[i+1:]
+	}
+
+	if key, err := url.PathUnescape(location); err == nil {
+		return key
+	}
+
+	return location
+}
+
+// resizeAvatar resizes the image to a width of 150 pixels
+// and returns it as a jpeg image
+func resizeAvatar(header *multipart.FileHeader) (*bytes.Buffer, error) {
+	file, err := header.Open()
+
+	if err != nil {
+		log.Printf("Failed to open header: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	defer file.Close()
+
+	src, _, err := image.Decode(file)
+
+	if err != nil {
+		log.Printf("Failed to decode image: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	img := imaging.Resize(src, 150, 0, imaging.Lanczos)
+
+	buf := new(bytes.Buffer)
+	err = jpeg.Encode(buf, img, &jpeg.Options{Quality: 75})
+
+	if err != nil {
+		log.Printf("Failed to encode image: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	return buf, nil
+}
@@ -23,7 +23,7 @@ func NewGuildRepository(db *gorm.DB) model.GuildRepository {
 	}
 }
 
-// List returns all of the given users guilds
+// List returns all of the given users guilds and their unread mentions
 func (r *guildRepository) List(uid string) (*[]model.GuildResponse, error) {
 	var guilds []model.GuildResponse
 	result := r.DB.Raw(`
@@ -33,12 +33,18 @@ func (r *guildRepository) List(uid string) (*[]model.GuildResponse, error) {
 		g."icon",
 		g."created_at",
 		g."updated_at",
-		((SELECT c."last_activity"
+		EXISTS (SELECT 1
 		 FROM channels c
-		 JOIN guilds g ON g.id = c."guild_id"
-		 WHERE g.id = member."guild_id"
-		 order by c."last_activity" DESC
-		 limit 1) > member."last_seen") AS "hasNotification",
+		 LEFT OUTER JOIN read_states rs
+		 ON rs."channel_id" = c."id" AND rs."user_id" = member."user_id"
+		 WHERE c."guild_id" = member."guild_id"
+		 AND c."last_activity" > COALESCE(rs."last_read_at", member."last_seen")) AS "has_notification",
+		(SELECT COUNT(*) FROM mentions mn
+		 LEFT OUTER JOIN read_states rs
+		 ON rs."channel_id" = mn."channel_id" AND rs."user_id" = mn."user_id"
+		 JOIN messages msg ON msg."id" = mn."message_id" AND msg."deleted_at" IS NULL
+		 WHERE mn."guild_id" = g."id" AND mn."user_id" = member."user_id"
+		 AND mn."created_at" > COALESCE(rs."last_read_at", member."last_seen")) AS "mention_count",
 		(SELECT c.id AS "default_channel_id"
 		FROM channels c
 	    JOIN guilds g ON g.id = c."guild_id"
@@ -64,10 +70,12 @@ func (r *guildRepository) GuildMembers(userId string, guildId string) (*[]model.
 		u.username,
 		u.image,
 		u."is_online",
+		u."is_bot",
 		u."created_at",
 		u."updated_at",
 		m.nickname,
 		m.color,
+		m.roles,
 		EXISTS(
 			SELECT 1
 			FROM users
@@ -112,12 +120,14 @@ func (r *guildRepository) FindUserByID(uid string) (*model.User, error) {
 	return user, nil
 }
 
-// FindByID returns the guild for the given id containing all of their fields
+// FindByID returns the guild for the given id containing all of their fields.
+// Threads are not included in the guild's channels
 func (r *guildRepository) FindByID(id string) (*model.Guild, error) {
 	guild := &model.Guild{}
 
 	if err := r.DB.
 		Preload(clause.Associations).
+		Preload("Channels", "parent_id IS NULL").
 		Where("id = ?", id).
 		First(&guild).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuildRepository_FindByID(t *testing.T) {
+	t.Run("Only preloads the members, channels, bans and roles", func(t *testing.T) {
+		db, mock := getTestDB(t)
+		guildId := fixture.RandID()
+		channelId := fixture.RandID()
+
+		mock.ExpectQuery(`SELECT \* FROM "guilds" WHERE id = \$1`).
+			WithArgs(guildId).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "owner_id"}).AddRow(guildId, "guild", fixture.RandID()))
+		mock.ExpectQuery(`SELECT \* FROM "bans" WHERE "bans"\."guild_id" = \$1`).
+			WithArgs(guildId).
+			WillReturnRows(sqlmock.NewRows([]string{"guild_id", "user_id"}))
+		mock.ExpectQuery(`SELECT \* FROM "channels" WHERE "channels"\."guild_id" = \$1 AND parent_id IS NULL`).
+			WithArgs(guildId).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "guild_id"}).AddRow(channelId, guildId))
+		mock.ExpectQuery(`SELECT \* FROM "members" WHERE "members"\."guild_id" = \$1`).
+			WithArgs(guildId).
+			WillReturnRows(sqlmock.NewRows([]string{"guild_id", "user_id"}))
+		mock.ExpectQuery(`SELECT \* FROM "roles" WHERE "roles"\."guild_id" = \$1`).
+			WithArgs(guildId).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "guild_id"}))
+
+		guild, err := NewGuildRepository(db).FindByID(guildId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, guildId, guild.ID)
+		assert.Len(t, guild.Channels, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+)
+
+// incomingWebhookRepository is data/repository implementation
+// of service layer IncomingWebhookRepository
+type incomingWebhookRepository struct {
+	DB *gorm.DB
+}
+
+// NewIncomingWebhookRepository is a factory for initializing Incoming Webhook Repositories
+func NewIncomingWebhookRepository(db *gorm.DB) model.IncomingWebhookRepository {
+	return &incomingWebhookRepository{
+		DB: db,
+	}
+}
+
+// Create inserts the webhook in the DB
+func (r *incomingWebhookRepository) Create(webhook *model.IncomingWebhook) error {
+	if result := r.DB.Create(webhook); result.Error != nil {
+		log.Printf("Could not create a webhook for channel: %v. Reason: %v\n", webhook.ChannelId, result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// FindByID returns the webhook for the given ID
+func (r *incomingWebhookRepository) FindByID(id string) (*model.IncomingWebhook, error) {
+	webhook := &model.IncomingWebhook{}
+
+	if err := r.DB.Where("id = ?", id).First(webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return webhook, apperrors.NewNotFound("webhook", id)
+		}
+		return webhook, apperrors.NewInternal()
+	}
+
+	return webhook, nil
+}
+
+// FindByChannel returns all webhooks of the given channel
+func (r *incomingWebhookRepository) FindByChannel(channelId string) (*[]model.IncomingWebhook, error) {
+	var webhooks []model.IncomingWebhook
+	result := r.DB.
+		Where("channel_id = ?", channelId).
+		Order("created_at").
+		Find(&webhooks)
+
+	return &webhooks, result.Error
+}
+
+// CountByChannel returns the amount of webhooks of the given channel
+func (r *incomingWebhookRepository) CountByChannel(channelId string) (int64, error) {
+	var count int64
+	err := r.DB.
+		Model(&model.IncomingWebhook{}).
+		Where("channel_id = ?", channelId).
+		Count(&count).
+		Error
+
+	return count, err
+}
+
+// Delete removes the webhook and its bot user from the DB.
+// Bot users that authored messages are kept, as those messages still reference them.
+func (r *incomingWebhookRepository) Delete(webhook *model.IncomingWebhook) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM incoming_webhooks WHERE id = ?", webhook.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			DELETE FROM users
+			WHERE id = @userId
+			AND is_bot
+			AND NOT EXISTS (SELECT 1 FROM messages WHERE user_id = @userId)
+		`, sql.Named("userId", webhook.UserId)).Error
+	})
+
+	if err != nil {
+		log.Printf("Could not delete the webhook with id: %v. Reason: %v\n", webhook.ID, err)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncomingWebhookRepository_Delete(t *testing.T) {
+	webhook := &model.IncomingWebhook{BaseModel: model.BaseModel{ID: fixture.RandID()}, UserId: fixture.RandID()}
+
+	t.Run("Deletes the webhook and its bot user in a transaction", func(t *testing.T) {
+		db, mock := getTestDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM incoming_webhooks WHERE id = \$1`).
+			WithArgs(webhook.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM users\s+WHERE id = \$1\s+AND is_bot\s+AND NOT EXISTS \(SELECT 1 FROM messages WHERE user_id = \$2\)`).
+			WithArgs(webhook.UserId, webhook.UserId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := NewIncomingWebhookRepository(db).Delete(webhook)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rolls back if the bot user cannot be deleted", func(t *testing.T) {
+		db, mock := getTestDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM incoming_webhooks`).
+			WithArgs(webhook.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM users`).
+			WithArgs(webhook.UserId, webhook.UserId).
+			WillReturnError(errors.New("connection lost"))
+		mock.ExpectRollback()
+
+		err := NewIncomingWebhookRepository(db).Delete(webhook)
+
+		assert.Equal(t, apperrors.NewInternal(), err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
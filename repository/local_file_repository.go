@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// localFileRepository stores the files in a directory on disk.
+// The files are served by the API using URLs signed with the secret,
+// so only URLs handed out by the repository can be used.
+type localFileRepository struct {
+	dir     string
+	baseURL string
+	secret  []byte
+}
+
+// NewLocalFileRepository is a factory for initializing Local File Repositories.
+// baseURL is the public address of the API, e.g. http://localhost:4000
+func NewLocalFileRepository(dir, baseURL, secret string) model.LocalFileRepository {
+	return &localFileRepository{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  []byte(secret),
+	}
+}
+
+// UploadAvatar resizes the given image and saves it as a jpeg image.
+// It returns the url of the saved file.
+func (l *localFileRepository) UploadAvatar(header *multipart.FileHeader, directory string) (string, error) {
+	key := avatarKey(directory)
+
+	buf, err := resizeAvatar(header)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err = l.write(key, buf); err != nil {
+		return "", err
+	}
+
+	return l.url(key), nil
+}
+
+// UploadFile saves the given file.
+// It returns the url of the saved file.
+func (l *localFileRepository) UploadFile(file io.Reader, directory, filename, mimetype string) (string, error) {
+	key := fileKey(directory, filename)
+
+	if err := l.write(key, file); err != nil {
+		return "", err
+	}
+
+	return l.url(key), nil
+}
+
+// DeleteImage deletes the file from disk.
+// The key can also be the URL returned by an upload.
+func (l *localFileRepository) DeleteImage(key string) error {
+	path, ok := l.path(keyFromURL(key))
+
+	if !ok {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Printf("Failed to delete file: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// ResolveFile returns the path of the file if the signature matches the key
+func (l *localFileRepository) ResolveFile(key, signature string) (string, error) {
+	path, ok := l.path(key)
+
+	if !ok || !hmac.Equal([]byte(l.sign(key)), []byte(signature)) {
+		return "", apperrors.NewNotFound("file", key)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return "", apperrors.NewNotFound("file", key)
+	}
+
+	return path, nil
+}
+
+// write saves the content under the given key
+func (l *localFileRepository) write(key string, content io.Reader) error {
+	path, _ := l.path(key)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Printf("Failed to create directory: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	file, err := os.Create(path)
+
+	if err != nil {
+		log.Printf("Failed to create file: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	if _, err = io.Copy(file, content); err != nil {
+		_ = file.Close()
+		log.Printf("Failed to write file: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	if err = file.Close(); err != nil {
+		log.Printf("Failed to close file: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// path returns the location of the key on disk.
+// Returns false if the key would point outside of the storage directory.
+func (l *localFileRepository) path(key string) (string, bool) {
+	key = filepath.Clean("/" + key)
+
+	if !strings.HasPrefix(key, "/files/") {
+		return "", false
+	}
+
+	return filepath.Join(l.dir, filepath.FromSlash(key)), true
+}
+
+// url returns the signed URL the file is served under
+func (l *localFileRepository) url(key string) string {
+	path := (&url.URL{Path: key}).EscapedPath()
+	return fmt.Sprintf("%s/api/%s?signature=%s", l.baseURL, path, l.sign(key))
+}
+
+// sign returns the signature of the key
+func (l *localFileRepository) sign(key string) string {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(key))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
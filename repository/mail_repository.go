@@ -1,39 +1,160 @@
 package repository
 
 import (
+	"bytes"
+	"embed"
 	"fmt"
 	"github.com/sentrionic/valkyrie/model"
-	"net/smtp"
+	"html/template"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	texttemplate "text/template"
+	"time"
 )
 
-// mailRepository contains the gmail username and password
-// as well as the frontend origin.
+// mailTemplates contains a text and an html template per mail and locale.
+// The text template also defines the subject as "<mail>.subject".
+//
+//go:embed templates/mail
+var mailTemplates embed.FS
+
+// mailTemplateSet contains the parsed templates of one locale
+type mailTemplateSet struct {
+	text *texttemplate.Template
+	html *template.Template
+}
+
+// mailData is passed to every mail template
+type mailData struct {
+	Email string
+	Link  string
+}
+
+// mailRepository renders the mails and hands them to the transport
 type mailRepository struct {
-	username string
-	password string
-	origin   string
+	transport model.MailTransport
+	from      string
+	origin    string
+	templates map[string]*mailTemplateSet
 }
 
-// NewMailRepository is a factory for initializing Mail Repositories
-func NewMailRepository(username string, password string, origin string) model.MailRepository {
+// NewMailRepository is a factory for initializing Mail Repositories.
+// It panics if the embedded templates cannot be parsed.
+func NewMailRepository(transport model.MailTransport, from string, origin string) model.MailRepository {
+	templates := make(map[string]*mailTemplateSet)
+
+	for _, locale := range model.SupportedLocales {
+		dir := "templates/mail/" + locale
+		templates[locale] = &mailTemplateSet{
+			text: texttemplate.Must(texttemplate.ParseFS(mailTemplates, dir+"/*.txt")),
+			html: template.Must(template.ParseFS(mailTemplates, dir+"/*.html")),
+		}
+	}
+
 	return &mailRepository{
-		username: username,
-		password: password,
-		origin:   origin,
+		transport: transport,
+		from:      from,
+		origin:    origin,
+		templates: templates,
 	}
 }
 
 // SendResetMail sends a password reset email with the given reset token
-func (m *mailRepository) SendResetMail(email string, token string) error {
+func (m *mailRepository) SendResetMail(email, token, locale string) error {
+	return m.send(email, locale, "reset", mailData{
+		Email: email,
+		Link:  fmt.Sprintf("%s/reset-password/%s", m.origin, token),
+	})
+}
+
+// SendVerificationMail sends an email verification mail with the given token
+func (m *mailRepository) SendVerificationMail(email, token, locale string) error {
+	return m.send(email, locale, "verification", mailData{
+		Email: email,
+		Link:  fmt.Sprintf("%s/verify-email/%s", m.origin, token),
+	})
+}
+
+// send renders the given mail in the locale and passes it to the transport
+func (m *mailRepository) send(email, locale, name string, data mailData) error {
+	set, ok := m.templates[locale]
+	if !ok {
+		set = m.templates[model.DefaultLocale]
+	}
+
+	var subject, text, html bytes.Buffer
+
+	if err := set.text.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		log.Printf("Failed to render the %s mail subject: %v\n", name, err.Error())
+		return err
+	}
+
+	if err := set.text.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		log.Printf("Failed to render the %s mail: %v\n", name, err.Error())
+		return err
+	}
+
+	if err := set.html.ExecuteTemplate(&html, name+".html", data); err != nil {
+		log.Printf("Failed to render the %s mail: %v\n", name, err.Error())
+		return err
+	}
+
+	message, err := buildMessage(m.from, email, subject.String(), text.Bytes(), html.Bytes())
+
+	if err != nil {
+		log.Printf("Failed to build the %s mail: %v\n", name, err.Error())
+		return err
+	}
+
+	return m.transport.Send(m.from, email, message)
+}
+
+// buildMessage creates a multipart/alternative message with a text and an html part
+func buildMessage(from, to, subject string, text, html []byte) ([]byte, error) {
+	var msg bytes.Buffer
+	body := multipart.NewWriter(&msg)
 
-	msg := "From: " + m.username + "\n" +
-		"To: " + email + "\n" +
-		"Subject: Reset Email\n\n" +
-		fmt.Sprintf("<a href=\"%s/reset-password/%s\">Reset Password</a>", m.origin, token)
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", body.Boundary())
 
-	err := smtp.SendMail("smtp.gmail.com:587",
-		smtp.PlainAuth("", m.username, m.password, "smtp.gmail.com"),
-		m.username, []string{email}, []byte(msg))
+	parts := []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	}
+
+	for _, p := range parts {
+		part, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(part)
+		if _, err = qp.Write(p.content); err != nil {
+			return nil, err
+		}
+
+		if err = qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
 
-	return err
+	return msg.Bytes(), nil
 }
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"crypto/tls"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"io"
+	"log"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Supported SMTP encryption modes
+const (
+	SMTPStartTLS = "starttls"
+	SMTPTLS      = "tls"
+	SMTPNone     = "none"
+)
+
+// smtpDialTimeout is the maximum time to wait for the SMTP server
+const smtpDialTimeout = 10 * time.Second
+
+// smtpTransport delivers mails to an SMTP server
+type smtpTransport struct {
+	host     string
+	port     int
+	username string
+	password string
+	tlsMode  string
+}
+
+// NewSMTPTransport is a factory for initializing SMTP Transports.
+// The mode is one of SMTPStartTLS, SMTPTLS and SMTPNone.
+func NewSMTPTransport(host string, port int, username, password, mode string) model.MailTransport {
+	return &smtpTransport{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		tlsMode:  mode,
+	}
+}
+
+// Send delivers the message to the SMTP server
+func (t *smtpTransport) Send(from, to string, message []byte) error {
+	addr := net.JoinHostPort(t.host, strconv.Itoa(t.port))
+	tlsConfig := &tls.Config{ServerName: t.host}
+	dialer := &net.Dialer{Timeout: smtpDialTimeout}
+
+	var conn net.Conn
+	var err error
+
+	if t.tlsMode == SMTPTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, t.host)
+
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	defer client.Close()
+
+	if t.tlsMode == SMTPStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server %s does not support STARTTLS", t.host)
+		}
+
+		if err = client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if t.username != "" {
+		if err = client.Auth(smtp.PlainAuth("", t.username, t.password, t.host)); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(from); err != nil {
+		return err
+	}
+
+	if err = client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(message); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// fileTransport writes mails into a directory or to stdout
+// so they can be inspected during development and tests.
+type fileTransport struct {
+	dir string
+	out io.Writer
+	mu  sync.Mutex
+}
+
+// NewFileTransport is a factory for initializing File Transports.
+// Mails are printed to stdout if dir is empty.
+func NewFileTransport(dir string) model.MailTransport {
+	return &fileTransport{
+		dir: dir,
+		out: os.Stdout,
+	}
+}
+
+// Send stores the message as an .eml file or prints it
+func (t *fileTransport) Send(from, to string, message []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.dir == "" {
+		_, err := fmt.Fprintf(t.out, "----- Mail from %s to %s -----\n%s\n", from, to, message)
+		return err
+	}
+
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("/", "_", "\\", "_", "@", "_at_").Replace(to)
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), recipient)
+
+	return os.WriteFile(filepath.Join(t.dir, name), message, 0644)
+}
+
+// Mail queue limits
+const (
+	mailQueueSize   = 100
+	mailMaxAttempts = 5
+)
+
+// queuedMail is a mail waiting for delivery
+type queuedMail struct {
+	from     string
+	to       string
+	message  []byte
+	attempts int
+}
+
+// MailQueue delivers mails in the background using the wrapped transport
+// so requests do not have to wait for the mail server.
+// Failed deliveries are retried with an exponential backoff.
+type MailQueue struct {
+	transport  model.MailTransport
+	retryDelay time.Duration
+	queue      chan *queuedMail
+}
+
+// NewMailQueue is a factory for initializing Mail Queues.
+// Run has to be started for the mails to be delivered.
+func NewMailQueue(transport model.MailTransport, retryDelay time.Duration) *MailQueue {
+	return &MailQueue{
+		transport:  transport,
+		retryDelay: retryDelay,
+		queue:      make(chan *queuedMail, mailQueueSize),
+	}
+}
+
+// Send queues the message for delivery.
+// Returns an error if the queue is full.
+func (q *MailQueue) Send(from, to string, message []byte) error {
+	if !q.enqueue(&queuedMail{from: from, to: to, message: message}) {
+		log.Printf("Mail queue is full, dropping mail to %s\n", to)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// Run delivers the queued mails
+func (q *MailQueue) Run() {
+	for mail := range q.queue {
+		q.deliver(mail)
+	}
+}
+
+// enqueue adds the mail to the queue without blocking
+func (q *MailQueue) enqueue(mail *queuedMail) bool {
+	select {
+	case q.queue <- mail:
+		return true
+	default:
+		return false
+	}
+}
+
+// deliver sends the mail and schedules a retry if it fails
+func (q *MailQueue) deliver(mail *queuedMail) {
+	err := q.transport.Send(mail.from, mail.to, mail.message)
+
+	if err == nil {
+		return
+	}
+
+	mail.attempts++
+
+	if mail.attempts >= mailMaxAttempts {
+		log.Printf("Giving up on mail to %s after %d attempts: %v\n", mail.to, mail.attempts, err.Error())
+		return
+	}
+
+	delay := q.retryDelay * time.Duration(1<<(mail.attempts-1))
+	log.Printf("Failed to send mail to %s, retrying in %s: %v\n", mail.to, delay, err.Error())
+
+	time.AfterFunc(delay, func() {
+		if !q.enqueue(mail) {
+			log.Printf("Mail queue is full, dropping mail to %s\n", mail.to)
+		}
+	})
+}
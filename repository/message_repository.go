@@ -2,12 +2,15 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/sentrionic/valkyrie/model"
 	"github.com/sentrionic/valkyrie/model/apperrors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -40,33 +43,109 @@ type messageQuery struct {
 	Username      string
 	Image         string
 	IsOnline      bool
+	IsBot         bool
 	Nickname      *string
 	Color         *string
 	IsFriend      bool
+	Reactions     string
+	ReplyId       *string
+	ReplyText     *string
+	ReplyUserId   *string
+	ReplyUsername *string
+	ThreadId      *string
+	PinnedAt      *time.Time
+	DeletedAt     *time.Time
+	WebhookId     *string
 }
 
-// GetMessages returns the 35 most recent messages for the given channel.
+// GetMessages returns the 35 most recent messages for the given channel including deleted ones.
 // If a cursor is specified it returns the 35 messages after the cursor.
 func (r *messageRepository) GetMessages(userId string, channel *model.Channel, cursor string) (*[]model.MessageResponse, error) {
+	crs := ""
+	if cursor != "" {
+		// Remove the timezone from the string since it's stored differently in the DB
+		date := cursor[:len(cursor)-6]
+		crs = fmt.Sprintf("AND messages.created_at < '%s'", date)
+	}
+
+	return r.findMessages(
+		userId,
+		channel.GuildID,
+		fmt.Sprintf("messages.channel_id = @channelId %s", crs),
+		35,
+		sql.Named("channelId", channel.ID),
+	)
+}
+
+// GetPinnedMessages returns the pinned messages of the given channel
+func (r *messageRepository) GetPinnedMessages(userId string, channel *model.Channel) (*[]model.MessageResponse, error) {
+	return r.findMessages(
+		userId,
+		channel.GuildID,
+		"messages.channel_id = @channelId AND messages.pinned_at IS NOT NULL AND messages.deleted_at IS NULL",
+		model.MaximumPins,
+		sql.Named("channelId", channel.ID),
+	)
+}
+
+// SearchMessages returns the 25 most recent messages of the given channels and
+// their threads that match the search. Use Before to paginate the results.
+func (r *messageRepository) SearchMessages(userId string, search *model.MessageSearch) (*[]model.MessageResponse, error) {
+	where := []string{
+		"(messages.channel_id IN @channelIds OR messages.channel_id IN (SELECT id FROM channels WHERE parent_id IN @channelIds))",
+		"messages.deleted_at IS NULL",
+	}
+	args := []interface{}{sql.Named("channelIds", search.ChannelIds)}
+
+	if search.Query != "" {
+		where = append(where, "messages.text_search @@ websearch_to_tsquery('english', @query)")
+		args = append(args, sql.Named("query", search.Query))
+	}
+
+	if search.AuthorId != "" {
+		where = append(where, "messages.user_id = @authorId")
+		args = append(args, sql.Named("authorId", search.AuthorId))
+	}
+
+	if search.Mentions != "" {
+		where = append(where, "EXISTS(SELECT 1 FROM mentions mn WHERE mn.message_id = messages.id AND mn.user_id = @mentions)")
+		args = append(args, sql.Named("mentions", search.Mentions))
+	}
+
+	if search.HasAttachment {
+		where = append(where, "a.id IS NOT NULL")
+	}
+
+	if search.Before != nil {
+		where = append(where, "messages.created_at < @before")
+		args = append(args, sql.Named("before", *search.Before))
+	}
+
+	if search.After != nil {
+		where = append(where, "messages.created_at > @after")
+		args = append(args, sql.Named("after", *search.After))
+	}
+
+	return r.findMessages(userId, search.GuildId, strings.Join(where, " AND "), 25, args...)
+}
+
+// findMessages returns the most recent messages that match the where clause.
+// If the guildId is not nil it also fetches the message author's settings
+func (r *messageRepository) findMessages(userId string, guildId *string, where string, limit int, args ...interface{}) (*[]model.MessageResponse, error) {
 	var result []messageQuery
 
 	memberSelect := ""
 	memberJoin := ""
-	memberWhere := ""
 
-	// If the channel is not a DM channel, also fetch the message author's settings
-	if !channel.IsDM {
+	// If the channel is not a DM channel, also fetch the message author's settings.
+	// Authors that are not members, e.g. the bots of incoming webhooks, have no settings.
+	if guildId != nil {
 		memberSelect = "member.nickname, member.color,"
-		memberJoin = "LEFT JOIN members member on messages.user_id = member.user_id"
-		memberWhere = fmt.Sprintf("AND member.guild_id = %s::text", *channel.GuildID)
+		memberJoin = "LEFT JOIN members member on messages.user_id = member.user_id AND member.guild_id = @guildId"
+		args = append(args, sql.Named("guildId", *guildId))
 	}
 
-	crs := ""
-	if cursor != "" {
-		// Remove the timezone from the string since it's stored differently in the DB
-		date := cursor[:len(cursor)-6]
-		crs = fmt.Sprintf("AND messages.created_at < '%s'", date)
-	}
+	args = append(args, sql.Named("userId", userId))
 
 	err := r.DB.
 		Raw(fmt.Sprintf(`
@@ -74,6 +153,9 @@ func (r *messageRepository) GetMessages(userId string, channel *model.Channel, c
 			messages.text,
 			messages.created_at,
 			messages.updated_at,
+			messages.pinned_at,
+			messages.deleted_at,
+			messages.webhook_id,
 			a.file_type,
 			a.url,
 			a.filename,
@@ -81,30 +163,46 @@ func (r *messageRepository) GetMessages(userId string, channel *model.Channel, c
 			users.id         as "user_id",
 			users.created_at as "user_created_at",
 			users.updated_at as "user_updated_at",
-			users.username,
-			users.image,
+			COALESCE(messages.webhook_name, users.username) as "username",
+			COALESCE(messages.webhook_avatar, users.image) as "image",
 			users.is_online,
+			users.is_bot,
 			%s 
 			EXISTS(
 			  SELECT 1
 			  FROM users
 			   LEFT JOIN friends f ON users.id = f.user_id
 			  WHERE f.friend_id = messages.user_id
-				AND f.user_id = @userId) as is_friend
+				AND f.user_id = @userId) as is_friend,
+			COALESCE((
+			  SELECT json_agg(json_build_object('emoji', r.emoji, 'count', r.count, 'me', r.me) ORDER BY r.first_reacted)
+			  FROM (
+			    SELECT emoji, COUNT(*) as count, bool_or(user_id = @userId) as me, MIN(created_at) as first_reacted
+			    FROM reactions
+			    WHERE message_id = messages.id
+			    GROUP BY emoji
+			  ) r), '[]')::text as reactions,
+			reply.id            as "reply_id",
+			CASE WHEN reply.deleted_at IS NULL THEN reply.text END as "reply_text",
+			reply_user.id       as "reply_user_id",
+			COALESCE(reply.webhook_name, reply_user.username) as "reply_username",
+			thread.id           as "thread_id"
 		FROM messages
 		LEFT JOIN "users"
 		ON users.id = messages.user_id
 		LEFT JOIN attachments a
 		ON a.message_id = messages.id
+		LEFT JOIN messages reply
+		ON reply.id = messages.reply_id
+		LEFT JOIN users reply_user
+		ON reply_user.id = reply.user_id
+		LEFT JOIN channels thread
+		ON thread.message_id = messages.id
 		%s
-		WHERE messages.channel_id = @channelId
-		%s 
-		%s 
+		WHERE %s
 		ORDER BY messages.created_at DESC
-		LIMIT 35
-`, memberSelect, memberJoin, memberWhere, crs),
-			sql.Named("userId", userId),
-			sql.Named("channelId", channel.ID)).
+		LIMIT %d
+`, memberSelect, memberJoin, where, limit), args...).
 		Scan(&result).Error
 
 	var messages []model.MessageResponse
@@ -121,6 +219,31 @@ func (r *messageRepository) GetMessages(userId string, channel *model.Channel, c
 			}
 		}
 
+		var replyTo *model.MessageReference = nil
+		if m.ReplyId != nil {
+			replyTo = &model.MessageReference{
+				Id:   *m.ReplyId,
+				Text: m.ReplyText,
+			}
+
+			if m.ReplyUserId != nil {
+				replyTo.UserId = *m.ReplyUserId
+				replyTo.Username = *m.ReplyUsername
+			}
+		}
+
+		reactions := make([]model.ReactionResponse, 0)
+		if err := json.Unmarshal([]byte(m.Reactions), &reactions); err != nil && m.Reactions != "" {
+			log.Printf("Could not parse the reactions of message: %v. Reason: %v\n", m.Id, err)
+		}
+
+		// Only return a tombstone for deleted messages
+		if m.DeletedAt != nil {
+			m.Text = nil
+			attachment = nil
+			reactions = make([]model.ReactionResponse, 0)
+		}
+
 		message := model.MessageResponse{
 			Id:         m.Id,
 			Text:       m.Text,
@@ -132,12 +255,19 @@ func (r *messageRepository) GetMessages(userId string, channel *model.Channel, c
 				Username:  m.Username,
 				Image:     m.Image,
 				IsOnline:  m.IsOnline,
+				IsBot:     m.IsBot,
 				CreatedAt: m.UserCreatedAt,
 				UpdatedAt: m.UserUpdatedAt,
 				Nickname:  m.Nickname,
 				Color:     m.Color,
 				IsFriend:  m.IsFriend,
 			},
+			Reactions: reactions,
+			ReplyTo:   replyTo,
+			ThreadId:  m.ThreadId,
+			PinnedAt:  m.PinnedAt,
+			Deleted:   m.DeletedAt != nil,
+			WebhookId: m.WebhookId,
 		}
 		messages = append(messages, message)
 	}
@@ -155,16 +285,69 @@ func (r *messageRepository) CreateMessage(message *model.Message) (*model.Messag
 	return message, nil
 }
 
-// UpdateMessage updates the message in the DB
+// UpdateMessage updates the message in the DB.
+// If the text changed the previous text gets stored as a revision
 func (r *messageRepository) UpdateMessage(message *model.Message) error {
-	if result := r.DB.Save(&message); result.Error != nil {
-		log.Printf("Could not update message with id: %v. Reason: %v\n", message.ID, result.Error)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		previous := model.Message{}
+		if err := tx.Select("text").Where("id = ?", message.ID).First(&previous).Error; err != nil {
+			return err
+		}
+
+		if !equalText(previous.Text, message.Text) {
+			revision := model.MessageRevision{
+				MessageId: message.ID,
+				Text:      previous.Text,
+			}
+
+			if err := tx.Create(&revision).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(&message).Error
+	})
+
+	if err != nil {
+		log.Printf("Could not update message with id: %v. Reason: %v\n", message.ID, err)
 		return apperrors.NewInternal()
 	}
 	return nil
 }
 
-// DeleteMessage removes the message from the DB
+// equalText returns true if both texts are nil or have the same value
+func equalText(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// CountPinnedMessages returns the amount of pinned messages in the given channel
+func (r *messageRepository) CountPinnedMessages(channelId string) (int64, error) {
+	var count int64
+	if result := r.DB.
+		Model(&model.Message{}).
+		Where("channel_id = ? AND pinned_at IS NOT NULL", channelId).
+		Count(&count); result.Error != nil {
+		log.Printf("Could not count the pins of channel: %v. Reason: %v\n", channelId, result.Error)
+		return 0, apperrors.NewInternal()
+	}
+	return count, nil
+}
+
+// SetPinned updates the pinned date of the message without changing its last update
+func (r *messageRepository) SetPinned(message *model.Message) error {
+	if result := r.DB.
+		Model(message).
+		UpdateColumn("pinned_at", message.PinnedAt); result.Error != nil {
+		log.Printf("Could not update the pin of message with id: %v. Reason: %v\n", message.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// DeleteMessage soft deletes the message so it only remains as a tombstone
 func (r *messageRepository) DeleteMessage(message *model.Message) error {
 	if result := r.DB.Delete(message); result.Error != nil {
 		log.Printf("Could not delete message with id: %v. Reason: %v\n", message.ID, result.Error)
@@ -186,3 +369,97 @@ func (r *messageRepository) GetById(messageId string) (*model.Message, error) {
 
 	return message, nil
 }
+
+// AddReaction inserts the reaction in the DB. Reacting twice with the same emoji is a no-op
+func (r *messageRepository) AddReaction(reaction *model.Reaction) error {
+	if result := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction); result.Error != nil {
+		log.Printf("Could not add reaction to message with id: %v. Reason: %v\n", reaction.MessageId, result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// RemoveReaction removes the reaction of the user from the DB
+func (r *messageRepository) RemoveReaction(reaction *model.Reaction) error {
+	if result := r.DB.Delete(reaction); result.Error != nil {
+		log.Printf("Could not remove reaction from message with id: %v. Reason: %v\n", reaction.MessageId, result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// GetHistory returns the given message, even if it got deleted, and its revisions
+func (r *messageRepository) GetHistory(messageId string) (*model.MessageHistory, error) {
+	message := model.Message{}
+
+	if result := r.DB.Unscoped().Where("id = ?", messageId).First(&message); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFound("message", messageId)
+		}
+		return nil, apperrors.NewInternal()
+	}
+
+	revisions := make([]model.MessageRevision, 0)
+	if result := r.DB.
+		Where("message_id = ?", messageId).
+		Order("created_at ASC").
+		Find(&revisions); result.Error != nil {
+		log.Printf("Could not fetch the revisions of message with id: %v. Reason: %v\n", messageId, result.Error)
+		return nil, apperrors.NewInternal()
+	}
+
+	var deletedAt *time.Time = nil
+	if message.DeletedAt.Valid {
+		deletedAt = &message.DeletedAt.Time
+	}
+
+	return &model.MessageHistory{
+		Id:        message.ID,
+		ChannelId: message.ChannelId,
+		UserId:    message.UserId,
+		Text:      message.Text,
+		CreatedAt: message.CreatedAt,
+		UpdatedAt: message.UpdatedAt,
+		DeletedAt: deletedAt,
+		Revisions: revisions,
+	}, nil
+}
+
+// DeleteRevisionsBefore removes all revisions that were created before the given date
+// and returns the amount of removed revisions
+func (r *messageRepository) DeleteRevisionsBefore(date time.Time) (int64, error) {
+	result := r.DB.Where("created_at < ?", date).Delete(&model.MessageRevision{})
+
+	if result.Error != nil {
+		log.Printf("Could not delete revisions before: %v. Reason: %v\n", date, result.Error)
+		return 0, apperrors.NewInternal()
+	}
+
+	return result.RowsAffected, nil
+}
+
+// DeleteMessagesBefore permanently removes the messages that were deleted before the given date
+// and returns their attachments and the amount of removed messages
+func (r *messageRepository) DeleteMessagesBefore(date time.Time) (*[]model.Attachment, int64, error) {
+	var attachments []model.Attachment
+	var count int64
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Unscoped().Model(&model.Message{}).Select("id").Where("deleted_at < ?", date)
+
+		if err := tx.Where("message_id IN (?)", deleted).Find(&attachments).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at < ?", date).Delete(&model.Message{})
+		count = result.RowsAffected
+		return result.Error
+	})
+
+	if err != nil {
+		log.Printf("Could not delete messages deleted before: %v. Reason: %v\n", date, err)
+		return nil, 0, apperrors.NewInternal()
+	}
+
+	return &attachments, count, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func getTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return gdb, mock
+}
+
+func TestMessageRepository_GetMessages(t *testing.T) {
+	t.Run("Includes messages of authors that are not members", func(t *testing.T) {
+		db, mock := getTestDB(t)
+		guild := fixture.GetMockGuild("")
+		channel := fixture.GetMockChannel(guild.ID)
+		member := fixture.GetMockUser()
+		bot := fixture.GetMockUser()
+		nickname := "nick"
+		text := "hello"
+		webhookId := fixture.RandID()
+		webhookName := "CI"
+		now := time.Now()
+
+		columns := []string{
+			"id", "text", "created_at", "updated_at", "user_id", "user_created_at", "user_updated_at",
+			"username", "image", "is_bot", "nickname", "reactions", "webhook_id",
+		}
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixture.RandID(), text, now, now, bot.ID, now, now, webhookName, bot.Image, true, nil, "[]", webhookId).
+			AddRow(fixture.RandID(), text, now, now, member.ID, now, now, member.Username, member.Image, false, nickname, "[]", nil)
+
+		// The guild has to be part of the join, otherwise the members condition filters out non-members
+		query := `LEFT JOIN members member on messages\.user_id = member\.user_id AND member\.guild_id = \$\d+ ` +
+			`WHERE messages\.channel_id = \$\d+ ORDER BY`
+		mock.ExpectQuery(query).
+			WithArgs(member.ID, member.ID, guild.ID, channel.ID).
+			WillReturnRows(rows)
+
+		repo := NewMessageRepository(db)
+		messages, err := repo.GetMessages(member.ID, channel, "")
+
+		assert.NoError(t, err)
+		assert.Len(t, *messages, 2)
+
+		webhookMessage := (*messages)[0]
+		assert.Equal(t, &webhookId, webhookMessage.WebhookId)
+		assert.Equal(t, webhookName, webhookMessage.User.Username)
+		assert.Nil(t, webhookMessage.User.Nickname)
+
+		memberMessage := (*messages)[1]
+		assert.Equal(t, &nickname, memberMessage.User.Nickname)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMessageRepository_DeleteMessagesBefore(t *testing.T) {
+	t.Run("Removes the deleted messages and returns their attachments", func(t *testing.T) {
+		db, mock := getTestDB(t)
+		date := time.Now()
+		url := "https://cdn.example.com/file.png"
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "attachments" WHERE message_id IN \(SELECT "id" FROM "messages" WHERE deleted_at < \$1\)`).
+			WithArgs(date).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "url", "message_id"}).AddRow(fixture.RandID(), url, fixture.RandID()))
+		mock.ExpectExec(`DELETE FROM "messages" WHERE deleted_at < \$1`).
+			WithArgs(date).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		attachments, count, err := NewMessageRepository(db).DeleteMessagesBefore(date)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		assert.Len(t, *attachments, 1)
+		assert.Equal(t, url, (*attachments)[0].Url)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
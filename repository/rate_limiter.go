@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/ulule/limiter/v3"
+)
+
+// rateLimiter counts the requests in the limiter store
+type rateLimiter struct {
+	limiter *limiter.Limiter
+}
+
+// NewRateLimiter is a factory for initializing Rate Limiters
+func NewRateLimiter(store limiter.Store, rate limiter.Rate) model.RateLimiter {
+	return &rateLimiter{
+		limiter: limiter.New(store, rate),
+	}
+}
+
+// Reached returns whether the key used up its limit without counting a request
+func (r *rateLimiter) Reached(key string) (bool, error) {
+	ctx, err := r.limiter.Peek(context.Background(), key)
+
+	if err != nil {
+		return false, err
+	}
+
+	return ctx.Remaining <= 0, nil
+}
+
+// Take counts a request and returns whether it exceeded the limit
+func (r *rateLimiter) Take(key string) (bool, error) {
+	ctx, err := r.limiter.Get(context.Background(), key)
+
+	if err != nil {
+		return false, err
+	}
+
+	return ctx.Reached, nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/sentrionic/valkyrie/model"
 	"github.com/sentrionic/valkyrie/model/apperrors"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -27,6 +29,13 @@ func NewRedisRepository(rds *redis.Client) model.RedisRepository {
 const (
 	InviteLinkPrefix     = "inviteLink"
 	ForgotPasswordPrefix = "forgot-password"
+	InteractionPrefix    = "interaction"
+	MfaTicketPrefix      = "mfa-ticket"
+	MfaAttemptsPrefix    = "mfa-attempts"
+	SessionPrefix        = "session"
+	UserSessionsPrefix   = "user-sessions"
+	VerifyEmailPrefix    = "verify-email"
+	VerifyThrottlePrefix = "verify-email-throttle"
 )
 
 // SetResetToken inserts a password reset token in the DB and returns the generated token
@@ -122,3 +131,315 @@ func (r *redisRepository) InvalidateInvites(ctx context.Context, guild *model.Gu
 		r.rds.Del(ctx, key)
 	}
 }
+
+// SaveInteraction stores the given interaction for model.InteractionTTL
+func (r *redisRepository) SaveInteraction(ctx context.Context, interaction *model.Interaction) error {
+	value, err := json.Marshal(interaction)
+
+	if err != nil {
+		log.Printf("Error marshalling: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	key := fmt.Sprintf("%s:%s", InteractionPrefix, interaction.Id)
+	if err = r.rds.Set(ctx, key, value, model.InteractionTTL).Err(); err != nil {
+		log.Printf("Failed to set interaction in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// GetInteraction returns the interaction for the given id if it has not expired yet
+func (r *redisRepository) GetInteraction(ctx context.Context, id string) (*model.Interaction, error) {
+	val, err := r.rds.Get(ctx, fmt.Sprintf("%s:%s", InteractionPrefix, id)).Result()
+
+	if err == redis.Nil {
+		return nil, apperrors.NewNotFound("interaction", id)
+	}
+	if err != nil {
+		log.Printf("Failed to get interaction from redis: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	var interaction model.Interaction
+	if err = json.Unmarshal([]byte(val), &interaction); err != nil {
+		log.Printf("Error unmarshalling: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	return &interaction, nil
+}
+
+// SetMfaTicket stores a login ticket for the user that has to be completed with a
+// two-factor code within model.MfaTicketTTL and returns the ticket
+func (r *redisRepository) SetMfaTicket(ctx context.Context, userId string) (string, error) {
+	ticket, err := gonanoid.New(32)
+
+	if err != nil {
+		log.Printf("Failed to generate id: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	if err = r.rds.Set(ctx, fmt.Sprintf("%s:%s", MfaTicketPrefix, ticket), userId, model.MfaTicketTTL).Err(); err != nil {
+		log.Printf("Failed to set mfa ticket in redis: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	return ticket, nil
+}
+
+// UseMfaTicket returns the user ID of the ticket and the amount of attempts
+// made with it including this one
+func (r *redisRepository) UseMfaTicket(ctx context.Context, ticket string) (string, int64, error) {
+	userId, err := r.rds.Get(ctx, fmt.Sprintf("%s:%s", MfaTicketPrefix, ticket)).Result()
+
+	if err == redis.Nil {
+		return "", 0, apperrors.NewAuthorization(apperrors.InvalidMfaTicket)
+	}
+	if err != nil {
+		log.Printf("Failed to get mfa ticket from redis: %v\n", err)
+		return "", 0, apperrors.NewInternal()
+	}
+
+	key := fmt.Sprintf("%s:%s", MfaAttemptsPrefix, ticket)
+	attempts, err := r.rds.Incr(ctx, key).Result()
+
+	if err != nil {
+		log.Printf("Failed to count mfa attempts in redis: %v\n", err)
+		return "", 0, apperrors.NewInternal()
+	}
+
+	r.rds.Expire(ctx, key, model.MfaTicketTTL)
+
+	return userId, attempts, nil
+}
+
+// DeleteMfaTicket invalidates the ticket
+func (r *redisRepository) DeleteMfaTicket(ctx context.Context, ticket string) {
+	r.rds.Del(ctx, fmt.Sprintf("%s:%s", MfaTicketPrefix, ticket), fmt.Sprintf("%s:%s", MfaAttemptsPrefix, ticket))
+}
+
+// SetVerificationToken inserts an email verification token for the given user and email
+// and returns the generated token
+func (r *redisRepository) SetVerificationToken(ctx context.Context, userId, email string) (string, error) {
+	token, err := gonanoid.New(32)
+
+	if err != nil {
+		log.Printf("Failed to generate id: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	key := fmt.Sprintf("%s:%s", VerifyEmailPrefix, token)
+	if err = r.rds.Set(ctx, key, fmt.Sprintf("%s:%s", userId, email), model.VerificationTokenTTL).Err(); err != nil {
+		log.Printf("Failed to set verification token in redis: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	return token, nil
+}
+
+// UseVerificationToken returns the user ID and the email the token was created for
+// and invalidates the token
+func (r *redisRepository) UseVerificationToken(ctx context.Context, token string) (string, string, error) {
+	key := fmt.Sprintf("%s:%s", VerifyEmailPrefix, token)
+	val, err := r.rds.Get(ctx, key).Result()
+
+	if err == redis.Nil {
+		return "", "", apperrors.NewBadRequest(apperrors.InvalidVerifyToken)
+	}
+	if err != nil {
+		log.Printf("Failed to get value from redis: %v\n", err)
+		return "", "", apperrors.NewInternal()
+	}
+
+	r.rds.Del(ctx, key)
+
+	parts := strings.SplitN(val, ":", 2)
+	if len(parts) != 2 {
+		return "", "", apperrors.NewBadRequest(apperrors.InvalidVerifyToken)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// ThrottleVerificationMail returns true if no verification email has been
+// requested by the user within model.VerificationMailInterval
+func (r *redisRepository) ThrottleVerificationMail(ctx context.Context, userId string) (bool, error) {
+	key := fmt.Sprintf("%s:%s", VerifyThrottlePrefix, userId)
+	ok, err := r.rds.SetNX(ctx, key, 1, model.VerificationMailInterval).Result()
+
+	if err != nil {
+		log.Printf("Failed to throttle verification mail in redis: %v\n", err)
+		return false, apperrors.NewInternal()
+	}
+
+	return ok, nil
+}
+
+// SaveSession stores the session of the given user for model.SessionTTL
+// and adds it to the user's list of sessions
+func (r *redisRepository) SaveSession(ctx context.Context, userId string, session *model.Session) error {
+	value, err := json.Marshal(session)
+
+	if err != nil {
+		log.Printf("Error marshalling: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	listKey := fmt.Sprintf("%s:%s", UserSessionsPrefix, userId)
+
+	_, err = r.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, fmt.Sprintf("%s:%s:%s", SessionPrefix, userId, session.Id), value, model.SessionTTL)
+		pipe.SAdd(ctx, listKey, session.Id)
+		pipe.Expire(ctx, listKey, model.SessionTTL)
+		return nil
+	})
+
+	if err != nil {
+		log.Printf("Failed to set session in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// GetSession returns the session of the given user if it has not expired or been revoked
+func (r *redisRepository) GetSession(ctx context.Context, userId, sessionId string) (*model.Session, error) {
+	val, err := r.rds.Get(ctx, fmt.Sprintf("%s:%s:%s", SessionPrefix, userId, sessionId)).Result()
+
+	if err == redis.Nil {
+		return nil, apperrors.NewNotFound("session", sessionId)
+	}
+	if err != nil {
+		log.Printf("Failed to get session from redis: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	var session model.Session
+	if err = json.Unmarshal([]byte(val), &session); err != nil {
+		log.Printf("Error unmarshalling: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	return &session, nil
+}
+
+// GetSessions returns all active sessions of the given user.
+// Expired sessions get removed from the user's list of sessions.
+func (r *redisRepository) GetSessions(ctx context.Context, userId string) (*[]model.Session, error) {
+	listKey := fmt.Sprintf("%s:%s", UserSessionsPrefix, userId)
+	ids, err := r.rds.SMembers(ctx, listKey).Result()
+
+	if err != nil {
+		log.Printf("Failed to get sessions from redis: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	sessions := make([]model.Session, 0)
+
+	if len(ids) == 0 {
+		return &sessions, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = fmt.Sprintf("%s:%s:%s", SessionPrefix, userId, id)
+	}
+
+	values, err := r.rds.MGet(ctx, keys...).Result()
+
+	if err != nil {
+		log.Printf("Failed to get sessions from redis: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	for i, value := range values {
+		val, ok := value.(string)
+		if !ok {
+			r.rds.SRem(ctx, listKey, ids[i])
+			continue
+		}
+
+		var session model.Session
+		if err = json.Unmarshal([]byte(val), &session); err != nil {
+			log.Printf("Error unmarshalling: %v\n", err.Error())
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	return &sessions, nil
+}
+
+// DeleteSession revokes the session of the given user
+func (r *redisRepository) DeleteSession(ctx context.Context, userId, sessionId string) error {
+	_, err := r.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, fmt.Sprintf("%s:%s:%s", SessionPrefix, userId, sessionId))
+		pipe.SRem(ctx, fmt.Sprintf("%s:%s", UserSessionsPrefix, userId), sessionId)
+		return nil
+	})
+
+	if err != nil {
+		log.Printf("Failed to delete session in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// GetPresences returns the presence of each of the given users.
+// Like the presence emitted over the websocket, users without a live connection are offline and
+// expired custom statuses are left out. Invisible users keep their status, see model.Presence.VisibleTo.
+func (r *redisRepository) GetPresences(ctx context.Context, userIds []string) (map[string]model.Presence, error) {
+	presences := make(map[string]model.Presence, len(userIds))
+
+	if len(userIds) == 0 {
+		return presences, nil
+	}
+
+	now := time.Now().Unix()
+	connections := make([]*redis.IntCmd, len(userIds))
+	values := make([]*redis.SliceCmd, len(userIds))
+
+	_, err := r.rds.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range userIds {
+			connections[i] = pipe.ZCount(ctx, model.PresenceConnectionsKey(id), fmt.Sprintf("(%d", now), "+inf")
+			values[i] = pipe.HMGet(ctx, model.PresenceKey(id), "status", "customStatus", "expiresAt")
+		}
+		return nil
+	})
+
+	if err != nil {
+		log.Printf("Failed to get presences from redis: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	for i, id := range userIds {
+		presence := model.Presence{UserId: id, Status: model.StatusOffline}
+		fields := values[i].Val()
+		status, _ := fields[0].(string)
+		customStatus, _ := fields[1].(string)
+		expiry, _ := fields[2].(string)
+		expiresAt, _ := strconv.ParseInt(expiry, 10, 64)
+
+		if connections[i].Val() > 0 {
+			presence.Status = model.StatusOnline
+			if status != "" {
+				presence.Status = status
+			}
+
+			if customStatus != "" && (expiresAt == 0 || expiresAt > now) {
+				presence.CustomStatus = customStatus
+				if expiresAt > 0 {
+					t := time.Unix(expiresAt, 0).UTC()
+					presence.CustomStatusExpiresAt = &t
+				}
+			}
+		}
+
+		presences[id] = presence
+	}
+
+	return presences, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisRepository_GetPresences(t *testing.T) {
+	now := time.Now()
+	live := float64(now.Add(time.Minute).Unix())
+	expired := float64(now.Add(-time.Second).Unix())
+	later := now.Add(time.Hour).Unix()
+	earlier := now.Add(-time.Second).Unix()
+	laterTime := time.Unix(later, 0).UTC()
+
+	testCases := []struct {
+		name string
+		// Expiry of the user's connection, 0 if the user has none
+		connection float64
+		// Fields of the user's presence hash
+		fields   []string
+		expected model.Presence
+	}{
+		{
+			name:     "Never connected",
+			expected: model.Presence{Status: model.StatusOffline},
+		},
+		{
+			name:       "Connected without choosing a status",
+			connection: live,
+			expected:   model.Presence{Status: model.StatusOnline},
+		},
+		{
+			name:       "Chosen status and custom status",
+			connection: live,
+			fields:     []string{"status", model.StatusDND, "customStatus", "Busy", "expiresAt", "0"},
+			expected:   model.Presence{Status: model.StatusDND, CustomStatus: "Busy"},
+		},
+		{
+			name:       "Custom status with an expiry",
+			connection: live,
+			fields:     []string{"status", model.StatusIdle, "customStatus", "Lunch", "expiresAt", strconv.FormatInt(later, 10)},
+			expected:   model.Presence{Status: model.StatusIdle, CustomStatus: "Lunch", CustomStatusExpiresAt: &laterTime},
+		},
+		{
+			name:       "Expired custom status",
+			connection: live,
+			fields:     []string{"status", model.StatusIdle, "customStatus", "Lunch", "expiresAt", strconv.FormatInt(earlier, 10)},
+			expected:   model.Presence{Status: model.StatusIdle},
+		},
+		{
+			name:       "Invisible users keep their status",
+			connection: live,
+			fields:     []string{"status", model.StatusInvisible, "customStatus", "Busy", "expiresAt", "0"},
+			expected:   model.Presence{Status: model.StatusInvisible, CustomStatus: "Busy"},
+		},
+		{
+			name:     "Disconnected users are offline without their custom status",
+			fields:   []string{"status", model.StatusDND, "customStatus", "Busy", "expiresAt", "0"},
+			expected: model.Presence{Status: model.StatusOffline},
+		},
+		{
+			name:       "Missing heartbeats make the user offline",
+			connection: expired,
+			fields:     []string{"status", model.StatusDND},
+			expected:   model.Presence{Status: model.StatusOffline},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			rds := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			t.Cleanup(func() { _ = rds.Close() })
+
+			userId := fixture.RandID()
+			if tc.connection != 0 {
+				mr.ZAdd(model.PresenceConnectionsKey(userId), tc.connection, fixture.RandID())
+			}
+			if len(tc.fields) > 0 {
+				mr.HSet(model.PresenceKey(userId), tc.fields...)
+			}
+
+			repo := NewRedisRepository(rds)
+			presences, err := repo.GetPresences(context.Background(), []string{userId})
+
+			tc.expected.UserId = userId
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]model.Presence{userId: tc.expected}, presences)
+		})
+	}
+
+	t.Run("No users", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		rds := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = rds.Close() })
+
+		repo := NewRedisRepository(rds)
+		presences, err := repo.GetPresences(context.Background(), []string{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, presences)
+	})
+}
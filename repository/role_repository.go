@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+// roleRepository is data/repository implementation
+// of service layer RoleRepository
+type roleRepository struct {
+	DB *gorm.DB
+}
+
+// NewRoleRepository is a factory for initializing Role Repositories
+func NewRoleRepository(db *gorm.DB) model.RoleRepository {
+	return &roleRepository{
+		DB: db,
+	}
+}
+
+// Create inserts the role in the DB
+func (r *roleRepository) Create(role *model.Role) (*model.Role, error) {
+	if result := r.DB.Create(&role); result.Error != nil {
+		log.Printf("Could not create a role for guild: %v. Reason: %v\n", role.GuildID, result.Error)
+		return nil, apperrors.NewInternal()
+	}
+
+	return role, nil
+}
+
+// GetById fetches the role for the given id
+func (r *roleRepository) GetById(roleId string) (*model.Role, error) {
+	role := &model.Role{}
+
+	if err := r.DB.Where("id = ?", roleId).First(role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return role, apperrors.NewNotFound("role", roleId)
+		}
+		return role, apperrors.NewInternal()
+	}
+
+	return role, nil
+}
+
+// List returns all roles of the given guild ordered by their creation date
+func (r *roleRepository) List(guildId string) (*[]model.RoleResponse, error) {
+	var roles []model.RoleResponse
+	result := r.DB.
+		Table("roles").
+		Select("id, name, color, permissions, created_at, updated_at").
+		Where("guild_id = ?", guildId).
+		Order("created_at").
+		Scan(&roles)
+
+	return &roles, result.Error
+}
+
+// Save updates the given role
+func (r *roleRepository) Save(role *model.Role) error {
+	if result := r.DB.Save(&role); result.Error != nil {
+		log.Printf("Could not update the role with id: %v. Reason: %v\n", role.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// Delete removes the given role and unassigns it from all members of the guild
+func (r *roleRepository) Delete(role *model.Role) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE members SET roles = array_remove(roles, ?) WHERE guild_id = ?", role.ID, role.GuildID).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec("DELETE FROM roles WHERE id = ?", role.ID).Error
+	})
+
+	if err != nil {
+		log.Printf("Could not delete the role with id: %v. Reason: %v\n", role.ID, err)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// AddMemberRole assigns the given role to the member if they do not have it already
+func (r *roleRepository) AddMemberRole(userId, guildId, roleId string) error {
+	if result := r.DB.Exec(`
+		UPDATE members
+		SET roles = array_append(roles, @roleId), updated_at = @now
+		WHERE user_id = @userId
+		AND guild_id = @guildId
+		AND NOT (@roleId = ANY(COALESCE(roles, '{}')))
+	`,
+		sql.Named("roleId", roleId),
+		sql.Named("userId", userId),
+		sql.Named("guildId", guildId),
+		sql.Named("now", time.Now()),
+	); result.Error != nil {
+		log.Printf("Could not add role %s to member %s. Reason: %v\n", roleId, userId, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// RemoveMemberRole unassigns the given role from the member
+func (r *roleRepository) RemoveMemberRole(userId, guildId, roleId string) error {
+	if result := r.DB.
+		Table("members").
+		Where("user_id = ? AND guild_id = ?", userId, guildId).
+		Updates(map[string]interface{}{
+			"roles":      gorm.Expr("array_remove(roles, ?)", roleId),
+			"updated_at": time.Now(),
+		}); result.Error != nil {
+		log.Printf("Could not remove role %s from member %s. Reason: %v\n", roleId, userId, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// GetMemberPermissions returns the combined permissions of all roles
+// the given member has in the given guild
+func (r *roleRepository) GetMemberPermissions(userId, guildId string) (model.Permission, error) {
+	var permissions int64
+	result := r.DB.Raw(`
+		SELECT COALESCE(bit_or(r.permissions), 0)
+		FROM roles r
+		JOIN members m ON r.id = ANY(m.roles)
+		WHERE m.user_id = ?
+		AND m.guild_id = ?
+		AND r.guild_id = m.guild_id
+	`, userId, guildId).Scan(&permissions)
+
+	return model.Permission(permissions), result.Error
+}
+
+// GetMemberRoles returns the roles the given member has in the given guild
+func (r *roleRepository) GetMemberRoles(userId, guildId string) (*[]model.Role, error) {
+	var roles []model.Role
+	result := r.DB.Raw(`
+		SELECT r.*
+		FROM roles r
+		JOIN members m ON r.id = ANY(m.roles)
+		WHERE m.user_id = ?
+		AND m.guild_id = ?
+		AND r.guild_id = m.guild_id
+	`, userId, guildId).Scan(&roles)
+
+	return &roles, result.Error
+}
+
+// GetRoleMemberIds returns the ids of the members of the guild that have any of the given roles
+func (r *roleRepository) GetRoleMemberIds(guildId string, roleIds []string) (*[]string, error) {
+	var ids []string
+	result := r.DB.Raw(`
+		SELECT m.user_id
+		FROM members m
+		WHERE m.guild_id = ?
+		AND m.roles && ?
+	`, guildId, pq.StringArray(roleIds)).Scan(&ids)
+
+	return &ids, result.Error
+}
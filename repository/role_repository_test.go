@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleRepository_Delete(t *testing.T) {
+	role := &model.Role{BaseModel: model.BaseModel{ID: fixture.RandID()}, GuildID: fixture.RandID()}
+
+	t.Run("Unassigns and deletes the role in a transaction", func(t *testing.T) {
+		db, mock := getTestDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE members SET roles = array_remove\(roles, \$1\) WHERE guild_id = \$2`).
+			WithArgs(role.ID, role.GuildID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE FROM roles WHERE id = \$1`).
+			WithArgs(role.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := NewRoleRepository(db).Delete(role)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rolls back if the role cannot be deleted", func(t *testing.T) {
+		db, mock := getTestDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE members`).
+			WithArgs(role.ID, role.GuildID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE FROM roles`).
+			WithArgs(role.ID).
+			WillReturnError(errors.New("connection lost"))
+		mock.ExpectRollback()
+
+		err := NewRoleRepository(db).Delete(role)
+
+		assert.Equal(t, apperrors.NewInternal(), err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+// tokenRepository is data/repository implementation
+// of service layer TokenRepository
+type tokenRepository struct {
+	DB *gorm.DB
+}
+
+// NewTokenRepository is a factory for initializing Token Repositories
+func NewTokenRepository(db *gorm.DB) model.TokenRepository {
+	return &tokenRepository{
+		DB: db,
+	}
+}
+
+// Create inserts the token in the DB
+func (r *tokenRepository) Create(token *model.APIToken) error {
+	if result := r.DB.Create(token); result.Error != nil {
+		log.Printf("Could not create a token for user: %v. Reason: %v\n", token.UserId, result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// FindByID returns the token for the given ID
+func (r *tokenRepository) FindByID(id string) (*model.APIToken, error) {
+	token := &model.APIToken{}
+
+	if err := r.DB.Where("id = ?", id).First(token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return token, apperrors.NewNotFound("token", id)
+		}
+		return token, apperrors.NewInternal()
+	}
+
+	return token, nil
+}
+
+// FindByHash returns the token matching the given hash
+func (r *tokenRepository) FindByHash(hash string) (*model.APIToken, error) {
+	token := &model.APIToken{}
+
+	if err := r.DB.Where("token_hash = ?", hash).First(token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return token, apperrors.NewAuthorization(apperrors.InvalidToken)
+		}
+		return token, apperrors.NewInternal()
+	}
+
+	return token, nil
+}
+
+// FindByOwner returns the tokens of the given user and of the bots they own
+func (r *tokenRepository) FindByOwner(ownerId string) (*[]model.APIToken, error) {
+	var tokens []model.APIToken
+	result := r.DB.
+		Joins("JOIN users u ON u.id = api_tokens.user_id").
+		Where("u.id = ? OR u.owner_id = ?", ownerId, ownerId).
+		Order("api_tokens.created_at").
+		Find(&tokens)
+
+	return &tokens, result.Error
+}
+
+// Delete removes the token from the DB
+func (r *tokenRepository) Delete(token *model.APIToken) error {
+	return r.DB.Delete(token).Error
+}
+
+// SetLastUsed updates the time the token was last used at
+func (r *tokenRepository) SetLastUsed(id string, usedAt time.Time) error {
+	return r.DB.
+		Model(&model.APIToken{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", usedAt).
+		Error
+}
@@ -104,6 +104,52 @@ func (r *userRepository) GetRequestCount(userId string) (*int64, error) {
 	return &count, err
 }
 
+// FindBots returns all bot accounts owned by the given user
+func (r *userRepository) FindBots(ownerId string) (*[]model.User, error) {
+	var bots []model.User
+	result := r.DB.
+		Where("owner_id = ? AND is_bot = true", ownerId).
+		Order("created_at").
+		Find(&bots)
+
+	return &bots, result.Error
+}
+
+// CountBots returns the amount of bot accounts owned by the given user
+func (r *userRepository) CountBots(ownerId string) (int64, error) {
+	var count int64
+	err := r.DB.
+		Model(&model.User{}).
+		Where("owner_id = ? AND is_bot = true", ownerId).
+		Count(&count).
+		Error
+
+	return count, err
+}
+
+// SetTotpStep stores the time step of the last accepted TOTP code.
+// It returns false if the step is not newer than the stored one so every code can only be used once.
+func (r *userRepository) SetTotpStep(userId string, step int64) (bool, error) {
+	result := r.DB.
+		Model(&model.User{}).
+		Where("id = ? AND totp_last_step < ?", userId, step).
+		Update("totp_last_step", step)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+// UseRecoveryCode removes the recovery code hash from the user.
+// It returns false if the user does not have the code.
+func (r *userRepository) UseRecoveryCode(userId, codeHash string) (bool, error) {
+	result := r.DB.Exec(`
+		UPDATE users
+		SET recovery_codes = array_remove(recovery_codes, @hash)
+		WHERE id = @id AND @hash = ANY(recovery_codes)
+	`, sql.Named("id", userId), sql.Named("hash", codeHash))
+
+	return result.RowsAffected == 1, result.Error
+}
+
 // isDuplicateKeyError checks if the provided error is a PostgreSQL duplicate key error
 func isDuplicateKeyError(err error) bool {
 	duplicate := regexp.MustCompile(`\(SQLSTATE 23505\)$`)
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// errInternalAddress is returned when the url resolves to an address inside the server's network
+var errInternalAddress = errors.New("the url resolves to an internal address")
+
+// webhookClient sends deliveries using a http client with a timeout
+type webhookClient struct {
+	client *http.Client
+}
+
+// NewWebhookClient is a factory for initializing Webhook Clients.
+// The client only connects to public addresses, so that webhooks and command
+// callbacks cannot be used to reach services inside the server's network.
+func NewWebhookClient(timeout time.Duration) model.WebhookClient {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		// Checked after the host got resolved, so DNS names pointing to internal addresses are rejected as well
+		Control: rejectInternalAddress,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would connect to the endpoint on our behalf and bypass the check
+	transport.Proxy = nil
+
+	return &webhookClient{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// Do not follow redirects, the endpoint has to respond directly.
+			// This also prevents redirects to internal addresses.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// rejectInternalAddress aborts connections to addresses that are not public
+func rejectInternalAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return err
+	}
+
+	if !model.IsPublicIP(net.ParseIP(host)) {
+		return fmt.Errorf("%w: %s", errInternalAddress, host)
+	}
+
+	return nil
+}
+
+// Post sends the body as JSON to the url and returns the response status
+func (w *webhookClient) Post(url string, body []byte, headers map[string]string) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Valkyrie-Webhook/1.0")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := w.client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookClient_RejectInternalAddress(t *testing.T) {
+	testCases := []struct {
+		address string
+		allowed bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:80", false},
+		{"[::1]:80", false},
+		{"10.1.2.3:80", false},
+		{"172.16.0.1:80", false},
+		{"192.168.1.1:80", false},
+		{"100.64.0.1:80", false},
+		{"169.254.169.254:80", false},
+		{"[fe80::1]:80", false},
+		{"[fd00::1]:80", false},
+		{"0.0.0.0:80", false},
+		{"[::]:80", false},
+		{"[::ffff:127.0.0.1]:80", false},
+	}
+
+	for _, tc := range testCases {
+		err := rejectInternalAddress("tcp", tc.address, nil)
+
+		if tc.allowed {
+			assert.NoError(t, err, tc.address)
+		} else {
+			assert.True(t, errors.Is(err, errInternalAddress), tc.address)
+		}
+	}
+}
+
+func TestWebhookClient_Post(t *testing.T) {
+	t.Run("Does not connect to internal addresses", func(t *testing.T) {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer server.Close()
+
+		client := NewWebhookClient(time.Second)
+		status, err := client.Post(server.URL, []byte("{}"), nil)
+
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, errInternalAddress))
+		assert.Equal(t, 0, status)
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"time"
+)
+
+// webhookRepository is data/repository implementation
+// of service layer WebhookRepository
+type webhookRepository struct {
+	DB *gorm.DB
+}
+
+// NewWebhookRepository is a factory for initializing Webhook Repositories
+func NewWebhookRepository(db *gorm.DB) model.WebhookRepository {
+	return &webhookRepository{
+		DB: db,
+	}
+}
+
+// Create inserts the webhook in the DB
+func (r *webhookRepository) Create(webhook *model.Webhook) error {
+	if result := r.DB.Create(webhook); result.Error != nil {
+		log.Printf("Could not create a webhook for guild: %v. Reason: %v\n", webhook.GuildId, result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// FindByID returns the webhook for the given ID
+func (r *webhookRepository) FindByID(id string) (*model.Webhook, error) {
+	webhook := &model.Webhook{}
+
+	if err := r.DB.Where("id = ?", id).First(webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return webhook, apperrors.NewNotFound("webhook", id)
+		}
+		return webhook, apperrors.NewInternal()
+	}
+
+	return webhook, nil
+}
+
+// FindByGuild returns all webhooks of the given guild
+func (r *webhookRepository) FindByGuild(guildId string) (*[]model.Webhook, error) {
+	var webhooks []model.Webhook
+	result := r.DB.
+		Where("guild_id = ?", guildId).
+		Order("created_at").
+		Find(&webhooks)
+
+	return &webhooks, result.Error
+}
+
+// FindSubscribed returns the enabled webhooks of the guild that subscribed to the given event.
+// Webhooks without an event filter receive all events.
+func (r *webhookRepository) FindSubscribed(guildId, event string) (*[]model.Webhook, error) {
+	var webhooks []model.Webhook
+	result := r.DB.
+		Where("guild_id = ? AND enabled = true", guildId).
+		Where("(cardinality(events) = 0 OR events IS NULL OR ? = ANY(events))", event).
+		Find(&webhooks)
+
+	return &webhooks, result.Error
+}
+
+// CountByGuild returns the amount of webhooks of the given guild
+func (r *webhookRepository) CountByGuild(guildId string) (int64, error) {
+	var count int64
+	err := r.DB.
+		Model(&model.Webhook{}).
+		Where("guild_id = ?", guildId).
+		Count(&count).
+		Error
+
+	return count, err
+}
+
+// Update updates the webhook in the DB
+func (r *webhookRepository) Update(webhook *model.Webhook) error {
+	return r.DB.Save(webhook).Error
+}
+
+// Delete removes the webhook and its deliveries from the DB
+func (r *webhookRepository) Delete(webhook *model.Webhook) error {
+	return r.DB.Delete(webhook).Error
+}
+
+// RecordFailure increments the failure count of the webhook
+// and disables it once the count reaches the threshold
+func (r *webhookRepository) RecordFailure(id string, threshold int) error {
+	return r.DB.Exec(`
+		UPDATE webhooks
+		SET failure_count = failure_count + 1,
+		    enabled = CASE WHEN failure_count + 1 >= ? THEN false ELSE enabled END,
+		    updated_at = ?
+		WHERE id = ?
+	`, threshold, time.Now(), id).Error
+}
+
+// ResetFailures resets the failure count after a successful delivery
+func (r *webhookRepository) ResetFailures(id string) error {
+	return r.DB.
+		Model(&model.Webhook{}).
+		Where("id = ? AND failure_count > 0", id).
+		UpdateColumn("failure_count", 0).
+		Error
+}
+
+// CreateDeliveries inserts the deliveries in the DB
+func (r *webhookRepository) CreateDeliveries(deliveries []model.WebhookDelivery) error {
+	if result := r.DB.Create(&deliveries); result.Error != nil {
+		log.Printf("Could not create webhook deliveries. Reason: %v\n", result.Error)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
+// ClaimDueDeliveries returns the oldest pending deliveries that should be attempted now
+// and postpones their next attempt by the lease, so that no other instance picks them up.
+// Rows locked by a concurrent claim are skipped. If the delivery does not get updated
+// before the lease ends, e.g. because the instance crashed, it gets claimed again.
+func (r *webhookRepository) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) (*[]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		result := tx.
+			Model(&model.WebhookDelivery{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", model.DeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Pluck("id", &ids)
+
+		if result.Error != nil || len(ids) == 0 {
+			return result.Error
+		}
+
+		result = tx.
+			Model(&model.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			UpdateColumn("next_attempt_at", now.Add(lease))
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.
+			Preload("Webhook").
+			Where("id IN ?", ids).
+			Order("created_at").
+			Find(&deliveries).
+			Error
+	})
+
+	return &deliveries, err
+}
+
+// UpdateDelivery updates the delivery in the DB
+func (r *webhookRepository) UpdateDelivery(delivery *model.WebhookDelivery) error {
+	return r.DB.Omit("Webhook").Save(delivery).Error
+}
+
+// FindDeliveries returns the most recent deliveries of the given webhook
+func (r *webhookRepository) FindDeliveries(webhookId string, limit int) (*[]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	result := r.DB.
+		Where("webhook_id = ?", webhookId).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries)
+
+	return &deliveries, result.Error
+}
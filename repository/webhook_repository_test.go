@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	t.Run("Locks due deliveries and postpones them by the lease", func(t *testing.T) {
+		db, mock := getTestDB(t)
+		webhookId := fixture.RandID()
+		deliveryId := fixture.RandID()
+		now := time.Now()
+		lease := time.Minute
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id" FROM "webhook_deliveries" WHERE status = \$1 AND next_attempt_at <= \$2 `+
+			`ORDER BY next_attempt_at LIMIT 10 FOR UPDATE SKIP LOCKED`).
+			WithArgs(model.DeliveryPending, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(deliveryId))
+		mock.ExpectExec(`UPDATE "webhook_deliveries" SET "next_attempt_at"=\$1 WHERE id IN \(\$2\)`).
+			WithArgs(now.Add(lease), deliveryId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT \* FROM "webhook_deliveries" WHERE id IN \(\$1\)`).
+			WithArgs(deliveryId).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "status"}).
+				AddRow(deliveryId, webhookId, model.DeliveryPending))
+		mock.ExpectQuery(`SELECT \* FROM "webhooks" WHERE "webhooks"\."id" = \$1`).
+			WithArgs(webhookId).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "enabled"}).AddRow(webhookId, true))
+		mock.ExpectCommit()
+
+		repo := NewWebhookRepository(db)
+		deliveries, err := repo.ClaimDueDeliveries(now, lease, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, *deliveries, 1)
+		assert.Equal(t, deliveryId, (*deliveries)[0].ID)
+		assert.Equal(t, webhookId, (*deliveries)[0].Webhook.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Does not update anything if no delivery is due", func(t *testing.T) {
+		db, mock := getTestDB(t)
+		now := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FOR UPDATE SKIP LOCKED`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		repo := NewWebhookRepository(db)
+		deliveries, err := repo.ClaimDueDeliveries(now, time.Minute, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, *deliveries, 0)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
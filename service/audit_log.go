@@ -0,0 +1,44 @@
+package service
+
+import (
+	"github.com/sentrionic/valkyrie/model"
+	"log"
+)
+
+// writeAuditLog stores the entry in the audit log of its guild.
+// Failing to write the entry does not fail the action itself
+func writeAuditLog(repository model.AuditLogRepository, entry *model.AuditLogEntry) {
+	id, err := GenerateId()
+
+	if err != nil {
+		return
+	}
+
+	entry.ID = id
+
+	if err = repository.Create(entry); err != nil {
+		log.Printf("Could not write %s to the audit log of guild %s: %v\n", entry.ActionType, entry.GuildId, err)
+	}
+}
+
+// appendChange adds the change to the list if the value got modified
+func appendChange(changes model.AuditLogChanges, key string, before, after interface{}) model.AuditLogChanges {
+	if before == after {
+		return changes
+	}
+
+	return append(changes, model.AuditLogChange{
+		Key:    key,
+		Before: before,
+		After:  after,
+	})
+}
+
+// optionalValue returns the value of the given pointer or nil
+// so that optional fields can be compared in appendChange
+func optionalValue(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
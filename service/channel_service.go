@@ -8,23 +8,32 @@ import (
 // channelService acts as a struct for injecting an implementation of ChannelRepository
 // for use in service methods
 type channelService struct {
-	ChannelRepository model.ChannelRepository
-	GuildRepository   model.GuildRepository
+	ChannelRepository  model.ChannelRepository
+	GuildRepository    model.GuildRepository
+	RoleRepository     model.RoleRepository
+	MessageRepository  model.MessageRepository
+	AuditLogRepository model.AuditLogRepository
 }
 
 // CSConfig will hold repositories that will eventually be injected into
 // this service layer
 type CSConfig struct {
-	ChannelRepository model.ChannelRepository
-	GuildRepository   model.GuildRepository
+	ChannelRepository  model.ChannelRepository
+	GuildRepository    model.GuildRepository
+	RoleRepository     model.RoleRepository
+	MessageRepository  model.MessageRepository
+	AuditLogRepository model.AuditLogRepository
 }
 
 // NewChannelService is a factory function for
 // initializing a ChannelService with its repository layer dependencies
 func NewChannelService(c *CSConfig) model.ChannelService {
 	return &channelService{
-		ChannelRepository: c.ChannelRepository,
-		GuildRepository:   c.GuildRepository,
+		ChannelRepository:  c.ChannelRepository,
+		GuildRepository:    c.GuildRepository,
+		RoleRepository:     c.RoleRepository,
+		MessageRepository:  c.MessageRepository,
+		AuditLogRepository: c.AuditLogRepository,
 	}
 }
 
@@ -40,8 +49,67 @@ func (c *channelService) CreateChannel(channel *model.Channel) (*model.Channel,
 	return c.ChannelRepository.Create(channel)
 }
 
+// GetChannels returns the channels of the guild the user is allowed to view
 func (c *channelService) GetChannels(userId string, guildId string) (*[]model.ChannelResponse, error) {
-	return c.ChannelRepository.Get(userId, guildId)
+	return c.getChannelsWithPermission(userId, guildId, model.ViewChannel)
+}
+
+// GetReadableChannelIds returns the ids of the guild channels the user is allowed to read the messages of
+func (c *channelService) GetReadableChannelIds(userId string, guildId string) ([]string, error) {
+	channels, err := c.getChannelsWithPermission(userId, guildId, model.ViewChannel|model.ReadMessageHistory)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0)
+	for _, channel := range *channels {
+		ids = append(ids, channel.Id)
+	}
+
+	return ids, nil
+}
+
+// getChannelsWithPermission returns the channels of the guild in which the user has all the given permissions
+func (c *channelService) getChannelsWithPermission(userId string, guildId string, permission model.Permission) (*[]model.ChannelResponse, error) {
+	channels, err := c.ChannelRepository.Get(userId, guildId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	base, roleIds, err := c.getBasePermissions(userId, guildId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	overwrites, err := c.ChannelRepository.GetGuildOverwrites(guildId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	channelOverwrites := make(map[string][]model.PermissionOverwrite)
+	for _, overwrite := range *overwrites {
+		channelOverwrites[overwrite.ChannelId] = append(channelOverwrites[overwrite.ChannelId], overwrite)
+	}
+
+	visible := make([]model.ChannelResponse, 0)
+	for _, response := range *channels {
+		channel := model.Channel{
+			GuildID:    &guildId,
+			IsPublic:   response.IsPublic,
+			Overwrites: channelOverwrites[response.Id],
+		}
+
+		permissions := computeChannelPermissions(base, userId, roleIds, &channel, response.IsPCMember)
+		if permissions&permission == permission {
+			visible = append(visible, response)
+		}
+	}
+
+	return &visible, nil
 }
 
 func (c *channelService) Get(channelId string) (*model.Channel, error) {
@@ -85,14 +153,58 @@ func (c *channelService) SetDirectMessageStatus(dmId string, userId string, isOp
 	return c.ChannelRepository.SetDirectMessageStatus(dmId, userId, isOpen)
 }
 
-func (c *channelService) DeleteChannel(channel *model.Channel) error {
-	return c.ChannelRepository.DeleteChannel(channel)
+// DeleteChannel removes the channel and adds the deletion of guild channels to the audit log
+func (c *channelService) DeleteChannel(channel *model.Channel, actorId string) error {
+	if err := c.ChannelRepository.DeleteChannel(channel); err != nil {
+		return err
+	}
+
+	if channel.GuildID != nil {
+		writeAuditLog(c.AuditLogRepository, &model.AuditLogEntry{
+			GuildId:    *channel.GuildID,
+			ActorId:    actorId,
+			ActionType: model.AuditChannelDelete,
+			TargetId:   &channel.ID,
+			Changes:    appendChange(nil, "name", channel.Name, nil),
+		})
+	}
+
+	return nil
 }
 
 func (c *channelService) UpdateChannel(channel *model.Channel) error {
 	return c.ChannelRepository.UpdateChannel(channel)
 }
 
+// EditChannel saves the changes of the guild channel and adds them to the audit log
+func (c *channelService) EditChannel(channel *model.Channel, actorId string) error {
+	previous, err := c.ChannelRepository.GetById(channel.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if err = c.ChannelRepository.UpdateChannel(channel); err != nil {
+		return err
+	}
+
+	var changes model.AuditLogChanges
+	changes = appendChange(changes, "name", previous.Name, channel.Name)
+	changes = appendChange(changes, "isPublic", previous.IsPublic, channel.IsPublic)
+
+	if len(changes) > 0 && channel.GuildID != nil {
+		writeAuditLog(c.AuditLogRepository, &model.AuditLogEntry{
+			GuildId:    *channel.GuildID,
+			ActorId:    actorId,
+			ActionType: model.AuditChannelUpdate,
+			TargetId:   &channel.ID,
+			Changes:    changes,
+		})
+	}
+
+	return nil
+}
+
 func (c *channelService) CleanPCMembers(channelId string) error {
 	return c.ChannelRepository.CleanPCMembers(channelId)
 }
@@ -116,30 +228,94 @@ func (c *channelService) GetDMByUserAndChannel(userId string, channelId string)
 // IsChannelMember checks if the user has access to the given channel.
 // Returns an error if they do not, otherwise nil
 func (c *channelService) IsChannelMember(channel *model.Channel, userId string) error {
-	// Check if user has access to the channel if it's private
-	if !channel.IsPublic {
-		// Channel is DM -> Check if one of the members
-		if channel.IsDM {
-			id, err := c.ChannelRepository.FindDMByUserAndChannelId(channel.ID, userId)
-
-			if err != nil || id == "" {
-				return apperrors.NewAuthorization(apperrors.Unauthorized)
-			}
-			return nil
+	_, err := c.GetPermissions(channel, userId)
+	return err
+}
+
+// GetPermissions returns the permissions the user has in the given channel.
+// Returns an error if the user cannot view the channel
+func (c *channelService) GetPermissions(channel *model.Channel, userId string) (model.Permission, error) {
+	// Threads inherit the permissions of their parent channel
+	if channel.ParentId != nil {
+		parent, err := c.ChannelRepository.GetById(*channel.ParentId)
+
+		if err != nil {
+			return 0, apperrors.NewNotFound("channel", *channel.ParentId)
 		}
-		// Channel is private
-		for _, member := range channel.PCMembers {
-			if member.ID == userId {
-				return nil
-			}
+
+		return c.GetPermissions(parent, userId)
+	}
+
+	// Channel is DM -> Check if one of the members
+	if channel.IsDM {
+		id, err := c.ChannelRepository.FindDMByUserAndChannelId(channel.ID, userId)
+
+		if err != nil || id == "" {
+			return 0, apperrors.NewAuthorization(apperrors.Unauthorized)
 		}
-		return apperrors.NewAuthorization(apperrors.Unauthorized)
+		return model.DefaultPermissions, nil
 	}
 
-	// Check if user has access to the channel
-	member, err := c.GuildRepository.GetMember(userId, *channel.GuildID)
-	if err != nil || member.ID == "" {
-		return apperrors.NewAuthorization(apperrors.Unauthorized)
+	guild, err := c.GuildRepository.FindByID(*channel.GuildID)
+
+	if err != nil {
+		return 0, apperrors.NewAuthorization(apperrors.Unauthorized)
 	}
-	return nil
+
+	permissions, err := memberChannelPermissions(c.RoleRepository, guild, channel, userId)
+
+	if err != nil {
+		return 0, apperrors.NewAuthorization(apperrors.Unauthorized)
+	}
+
+	if !permissions.Has(model.ViewChannel) {
+		return 0, apperrors.NewAuthorization(apperrors.Unauthorized)
+	}
+
+	return permissions, nil
+}
+
+func (c *channelService) GetThreads(channelId string) (*[]model.Channel, error) {
+	return c.ChannelRepository.GetThreads(channelId)
+}
+
+func (c *channelService) SetOverwrite(overwrite *model.PermissionOverwrite) error {
+	return c.ChannelRepository.SetOverwrite(overwrite)
+}
+
+func (c *channelService) DeleteOverwrite(channelId, targetId string) error {
+	return c.ChannelRepository.DeleteOverwrite(channelId, targetId)
+}
+
+// getBasePermissions returns the guild wide permissions of the member and the ids of their roles.
+// Returns an error if the user is not a member of the guild
+func (c *channelService) getBasePermissions(userId, guildId string) (model.Permission, []string, error) {
+	guild, err := c.GuildRepository.FindByID(guildId)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return memberPermissions(c.RoleRepository, guild, userId)
+}
+
+// AckChannel marks the given message and all older ones in the channel as read for the user.
+// Acknowledging an older message than the current read state does not move it back.
+func (c *channelService) AckChannel(channel *model.Channel, userId string, messageId string) (*model.ReadState, error) {
+	message, err := c.MessageRepository.GetById(messageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if message.ChannelId != channel.ID {
+		return nil, apperrors.NewNotFound("message", messageId)
+	}
+
+	return c.ChannelRepository.SetReadState(&model.ReadState{
+		UserId:        userId,
+		ChannelId:     channel.ID,
+		LastMessageId: message.ID,
+		LastReadAt:    message.CreatedAt,
+	})
 }
@@ -177,28 +177,42 @@ func TestChannelService_IsChannelMember(t *testing.T) {
 	})
 
 	t.Run("User is member of the private channel", func(t *testing.T) {
-		mockChannel := fixture.GetMockChannel("")
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = append(mockGuild.Members, *mockUser)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
 		mockChannel.IsPublic = false
 		mockChannel.PCMembers = append(mockChannel.PCMembers, *mockUser)
 
-		mockChannelRepository := new(mocks.ChannelRepository)
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
 		cs := NewChannelService(&CSConfig{
-			ChannelRepository: mockChannelRepository,
+			GuildRepository: mockGuildRepository,
+			RoleRepository:  mockRoleRepository,
 		})
 
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockRoleRepository.On("GetMemberRoles", mockUser.ID, mockGuild.ID).Return(&[]model.Role{}, nil)
+
 		err := cs.IsChannelMember(mockChannel, mockUser.ID)
 		assert.NoError(t, err)
 	})
 
 	t.Run("User is not member of the private channel", func(t *testing.T) {
-		mockChannel := fixture.GetMockChannel("")
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = append(mockGuild.Members, *mockUser)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
 		mockChannel.IsPublic = false
 
-		mockChannelRepository := new(mocks.ChannelRepository)
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
 		cs := NewChannelService(&CSConfig{
-			ChannelRepository: mockChannelRepository,
+			GuildRepository: mockGuildRepository,
+			RoleRepository:  mockRoleRepository,
 		})
 
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockRoleRepository.On("GetMemberRoles", mockUser.ID, mockGuild.ID).Return(&[]model.Role{}, nil)
+
 		err := cs.IsChannelMember(mockChannel, mockUser.ID)
 		assert.Error(t, err)
 		assert.Equal(t, err, apperrors.NewAuthorization(apperrors.Unauthorized))
@@ -210,11 +224,14 @@ func TestChannelService_IsChannelMember(t *testing.T) {
 		mockChannel := fixture.GetMockChannel(mockGuild.ID)
 
 		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
 		cs := NewChannelService(&CSConfig{
 			GuildRepository: mockGuildRepository,
+			RoleRepository:  mockRoleRepository,
 		})
 
-		mockGuildRepository.On("GetMember", mockUser.ID, *mockChannel.GuildID).Return(mockUser, nil)
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockRoleRepository.On("GetMemberRoles", mockUser.ID, mockGuild.ID).Return(&[]model.Role{}, nil)
 
 		err := cs.IsChannelMember(mockChannel, mockUser.ID)
 		assert.NoError(t, err)
@@ -229,11 +246,259 @@ func TestChannelService_IsChannelMember(t *testing.T) {
 			GuildRepository: mockGuildRepository,
 		})
 
-		mockError := apperrors.NewAuthorization(apperrors.Unauthorized)
-		mockGuildRepository.On("GetMember", mockUser.ID, *mockChannel.GuildID).Return(nil, mockError)
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
 
 		err := cs.IsChannelMember(mockChannel, mockUser.ID)
 		assert.Error(t, err)
-		assert.Equal(t, err, mockError)
+		assert.Equal(t, err, apperrors.NewAuthorization(apperrors.Unauthorized))
+	})
+
+	t.Run("Everyone denied but role allowed", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = append(mockGuild.Members, *mockUser)
+		mockRole := fixture.GetMockRole(mockGuild.ID, 0)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockChannel.Overwrites = []model.PermissionOverwrite{
+			{ChannelId: mockChannel.ID, TargetId: mockGuild.ID, Type: model.RoleOverwrite, Deny: model.ViewChannel},
+			{ChannelId: mockChannel.ID, TargetId: mockRole.ID, Type: model.RoleOverwrite, Allow: model.ViewChannel},
+		}
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+			RoleRepository:  mockRoleRepository,
+		})
+
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockRoleRepository.On("GetMemberRoles", mockUser.ID, mockGuild.ID).Return(&[]model.Role{*mockRole}, nil)
+
+		err := cs.IsChannelMember(mockChannel, mockUser.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Everyone denied", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = append(mockGuild.Members, *mockUser)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockChannel.Overwrites = []model.PermissionOverwrite{
+			{ChannelId: mockChannel.ID, TargetId: mockGuild.ID, Type: model.RoleOverwrite, Deny: model.ViewChannel},
+		}
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+			RoleRepository:  mockRoleRepository,
+		})
+
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockRoleRepository.On("GetMemberRoles", mockUser.ID, mockGuild.ID).Return(&[]model.Role{}, nil)
+
+		err := cs.IsChannelMember(mockChannel, mockUser.ID)
+		assert.Error(t, err)
+		assert.Equal(t, err, apperrors.NewAuthorization(apperrors.Unauthorized))
+	})
+}
+
+func TestChannelService_GetPermissions(t *testing.T) {
+	mockUser := fixture.GetMockUser()
+
+	t.Run("Read only channel", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = append(mockGuild.Members, *mockUser)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockChannel.Overwrites = []model.PermissionOverwrite{
+			{ChannelId: mockChannel.ID, TargetId: mockGuild.ID, Type: model.RoleOverwrite, Deny: model.SendMessages | model.AttachFiles},
+		}
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+			RoleRepository:  mockRoleRepository,
+		})
+
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockRoleRepository.On("GetMemberRoles", mockUser.ID, mockGuild.ID).Return(&[]model.Role{}, nil)
+
+		permissions, err := cs.GetPermissions(mockChannel, mockUser.ID)
+		assert.NoError(t, err)
+		assert.True(t, permissions.Has(model.ViewChannel))
+		assert.True(t, permissions.Has(model.ReadMessageHistory))
+		assert.False(t, permissions.Has(model.SendMessages))
+		assert.False(t, permissions.Has(model.AttachFiles))
+	})
+
+	t.Run("Member overwrite beats role overwrite", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = append(mockGuild.Members, *mockUser)
+		mockRole := fixture.GetMockRole(mockGuild.ID, 0)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockChannel.Overwrites = []model.PermissionOverwrite{
+			{ChannelId: mockChannel.ID, TargetId: mockRole.ID, Type: model.RoleOverwrite, Deny: model.SendMessages},
+			{ChannelId: mockChannel.ID, TargetId: mockUser.ID, Type: model.MemberOverwrite, Allow: model.SendMessages},
+		}
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+			RoleRepository:  mockRoleRepository,
+		})
+
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockRoleRepository.On("GetMemberRoles", mockUser.ID, mockGuild.ID).Return(&[]model.Role{*mockRole}, nil)
+
+		permissions, err := cs.GetPermissions(mockChannel, mockUser.ID)
+		assert.NoError(t, err)
+		assert.True(t, permissions.Has(model.SendMessages))
+	})
+
+	t.Run("Owner ignores overwrites", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(mockUser.ID)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockChannel.Overwrites = []model.PermissionOverwrite{
+			{ChannelId: mockChannel.ID, TargetId: mockGuild.ID, Type: model.RoleOverwrite, Deny: model.ChannelPermissions},
+		}
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+			RoleRepository:  mockRoleRepository,
+		})
+
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+
+		permissions, err := cs.GetPermissions(mockChannel, mockUser.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, model.AllPermissions, permissions)
+		mockRoleRepository.AssertNotCalled(t, "GetMemberRoles", mockUser.ID, mockGuild.ID)
+	})
+
+	t.Run("Thread inherits parent permissions", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = append(mockGuild.Members, *mockUser)
+		mockParent := fixture.GetMockChannel(mockGuild.ID)
+		mockParent.Overwrites = []model.PermissionOverwrite{
+			{ChannelId: mockParent.ID, TargetId: mockGuild.ID, Type: model.RoleOverwrite, Deny: model.SendMessages},
+		}
+		mockThread := fixture.GetMockChannel(mockGuild.ID)
+		mockThread.ParentId = &mockParent.ID
+
+		mockChannelRepository := new(mocks.ChannelRepository)
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
+		cs := NewChannelService(&CSConfig{
+			ChannelRepository: mockChannelRepository,
+			GuildRepository:   mockGuildRepository,
+			RoleRepository:    mockRoleRepository,
+		})
+
+		mockChannelRepository.On("GetById", mockParent.ID).Return(mockParent, nil)
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockRoleRepository.On("GetMemberRoles", mockUser.ID, mockGuild.ID).Return(&[]model.Role{}, nil)
+
+		permissions, err := cs.GetPermissions(mockThread, mockUser.ID)
+		assert.NoError(t, err)
+		assert.True(t, permissions.Has(model.ViewChannel))
+		assert.False(t, permissions.Has(model.SendMessages))
+		mockChannelRepository.AssertExpectations(t)
+	})
+}
+
+func TestChannelService_GetReadableChannelIds(t *testing.T) {
+	mockUser := fixture.GetMockUser()
+	mockGuild := fixture.GetMockGuild("")
+	mockGuild.Members = append(mockGuild.Members, *mockUser)
+
+	readable := fixture.GetMockChannel(mockGuild.ID)
+	viewOnly := fixture.GetMockChannel(mockGuild.ID)
+	private := fixture.GetMockChannel(mockGuild.ID)
+	private.IsPublic = false
+
+	mockChannelRepository := new(mocks.ChannelRepository)
+	mockGuildRepository := new(mocks.GuildRepository)
+	mockRoleRepository := new(mocks.RoleRepository)
+	cs := NewChannelService(&CSConfig{
+		ChannelRepository: mockChannelRepository,
+		GuildRepository:   mockGuildRepository,
+		RoleRepository:    mockRoleRepository,
+	})
+
+	channels := []model.ChannelResponse{
+		readable.SerializeChannel(),
+		viewOnly.SerializeChannel(),
+		private.SerializeChannel(),
+	}
+	overwrites := []model.PermissionOverwrite{
+		{ChannelId: viewOnly.ID, TargetId: mockGuild.ID, Type: model.RoleOverwrite, Deny: model.ReadMessageHistory},
+	}
+
+	mockChannelRepository.On("Get", mockUser.ID, mockGuild.ID).Return(&channels, nil)
+	mockChannelRepository.On("GetGuildOverwrites", mockGuild.ID).Return(&overwrites, nil)
+	mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+	mockRoleRepository.On("GetMemberRoles", mockUser.ID, mockGuild.ID).Return(&[]model.Role{}, nil)
+
+	ids, err := cs.GetReadableChannelIds(mockUser.ID, mockGuild.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{readable.ID}, ids)
+}
+
+func TestChannelService_AckChannel(t *testing.T) {
+	uid := fixture.RandID()
+
+	t.Run("Success", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel("")
+		mockMessage := fixture.GetMockMessage("", mockChannel.ID)
+
+		params := &model.ReadState{
+			UserId:        uid,
+			ChannelId:     mockChannel.ID,
+			LastMessageId: mockMessage.ID,
+			LastReadAt:    mockMessage.CreatedAt,
+		}
+
+		mockChannelRepository := new(mocks.ChannelRepository)
+		mockMessageRepository := new(mocks.MessageRepository)
+		cs := NewChannelService(&CSConfig{
+			ChannelRepository: mockChannelRepository,
+			MessageRepository: mockMessageRepository,
+		})
+
+		mockMessageRepository.On("GetById", mockMessage.ID).Return(mockMessage, nil)
+		mockChannelRepository.On("SetReadState", params).Return(params, nil)
+
+		state, err := cs.AckChannel(mockChannel, uid, mockMessage.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, params, state)
+
+		mockMessageRepository.AssertExpectations(t)
+		mockChannelRepository.AssertExpectations(t)
+	})
+
+	t.Run("Message in another channel", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel("")
+		mockMessage := fixture.GetMockMessage("", fixture.RandID())
+
+		mockChannelRepository := new(mocks.ChannelRepository)
+		mockMessageRepository := new(mocks.MessageRepository)
+		cs := NewChannelService(&CSConfig{
+			ChannelRepository: mockChannelRepository,
+			MessageRepository: mockMessageRepository,
+		})
+
+		mockMessageRepository.On("GetById", mockMessage.ID).Return(mockMessage, nil)
+
+		state, err := cs.AckChannel(mockChannel, uid, mockMessage.ID)
+
+		assert.Nil(t, state)
+		assert.Equal(t, apperrors.NewNotFound("message", mockMessage.ID), err)
+
+		mockMessageRepository.AssertExpectations(t)
+		mockChannelRepository.AssertNotCalled(t, "SetReadState", mock.Anything)
 	})
 }
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commandService acts as a struct for injecting an implementation of CommandRepository
+// for use in service methods
+type commandService struct {
+	CommandRepository model.CommandRepository
+	RedisRepository   model.RedisRepository
+	WebhookClient     model.WebhookClient
+}
+
+// CMConfig will hold repositories that will eventually be injected into
+// this service layer
+type CMConfig struct {
+	CommandRepository model.CommandRepository
+	RedisRepository   model.RedisRepository
+	WebhookClient     model.WebhookClient
+}
+
+// NewCommandService is a factory function for
+// initializing a CommandService with its repository layer dependencies
+func NewCommandService(c *CMConfig) model.CommandService {
+	return &commandService{
+		CommandRepository: c.CommandRepository,
+		RedisRepository:   c.RedisRepository,
+		WebhookClient:     c.WebhookClient,
+	}
+}
+
+// InteractionEvent is the X-Valkyrie-Event header of interaction callbacks
+const InteractionEvent = "interaction"
+
+var (
+	commandRegex    = regexp.MustCompile(`^/([a-z0-9_-]{1,32})(?:\s+|$)`)
+	userArgRegex    = regexp.MustCompile(`^(?:<@(\d+)>|(\d+))$`)
+	channelArgRegex = regexp.MustCompile(`^(?:<#(\d+)>|(\d+))$`)
+)
+
+func (s *commandService) GetCommands(guildId string) (*[]model.Command, error) {
+	return s.CommandRepository.FindByGuild(guildId)
+}
+
+func (s *commandService) GetCommand(commandId string) (*model.Command, error) {
+	return s.CommandRepository.FindByID(commandId)
+}
+
+// RegisterCommand creates the command or replaces the bot's command with the same name
+func (s *commandService) RegisterCommand(command *model.Command) (*model.Command, error) {
+	existing, err := s.CommandRepository.FindByName(command.GuildId, command.Name)
+
+	if err == nil {
+		if existing.BotId != command.BotId {
+			return nil, apperrors.NewBadRequest(apperrors.CommandExistsError)
+		}
+
+		existing.Description = command.Description
+		existing.Options = command.Options
+		existing.CallbackUrl = command.CallbackUrl
+		existing.Secret = command.Secret
+
+		if err = s.CommandRepository.Update(existing); err != nil {
+			return nil, err
+		}
+
+		return existing, nil
+	}
+
+	if apperrors.Status(err) != http.StatusNotFound {
+		return nil, err
+	}
+
+	count, err := s.CommandRepository.CountByBot(command.GuildId, command.BotId)
+
+	if err != nil {
+		return nil, apperrors.NewInternal()
+	}
+
+	if count >= model.MaximumCommands {
+		return nil, apperrors.NewBadRequest(apperrors.CommandLimitError)
+	}
+
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	command.ID = id
+
+	if err = s.CommandRepository.Create(command); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (s *commandService) DeleteCommand(command *model.Command) error {
+	return s.CommandRepository.Delete(command)
+}
+
+// ParseCommand returns the guild's command invoked by the text and its parsed arguments.
+// If the text does not invoke a registered command it returns no command and no error
+// so the text gets posted as a regular message.
+// Arguments are separated by whitespace and assigned to the options in order. Double quotes
+// group an argument containing whitespace and the last option takes the remaining text if
+// it is a string option.
+func (s *commandService) ParseCommand(guildId, text string) (*model.Command, map[string]interface{}, error) {
+	match := commandRegex.FindStringSubmatch(text)
+
+	if match == nil {
+		return nil, nil, nil
+	}
+
+	command, err := s.CommandRepository.FindByName(guildId, match[1])
+
+	if err != nil {
+		if apperrors.Status(err) == http.StatusNotFound {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	args, err := splitArguments(strings.TrimSpace(text[len(match[0]):]))
+
+	if err != nil {
+		return nil, nil, apperrors.NewBadRequest(err.Error())
+	}
+
+	options := command.Options
+	if len(options) > 0 && len(args) > len(options) && options[len(options)-1].Type == model.StringOption {
+		last := len(options) - 1
+		args = append(args[:last], strings.Join(args[last:], " "))
+	}
+
+	if len(args) > len(options) {
+		return nil, nil, apperrors.NewBadRequest(fmt.Sprintf("/%s takes at most %d arguments", command.Name, len(options)))
+	}
+
+	values := make(map[string]interface{})
+	for i, option := range options {
+		if i >= len(args) {
+			if option.Required {
+				return nil, nil, apperrors.NewBadRequest(fmt.Sprintf("Missing required option %s", option.Name))
+			}
+			continue
+		}
+
+		value, err := parseOption(option, args[i])
+
+		if err != nil {
+			return nil, nil, apperrors.NewBadRequest(err.Error())
+		}
+
+		values[option.Name] = value
+	}
+
+	return command, values, nil
+}
+
+// CreateInteraction stores the invocation of the command so the bot can respond to it
+func (s *commandService) CreateInteraction(
+	ctx context.Context,
+	command *model.Command,
+	channelId, userId string,
+	options map[string]interface{},
+) (*model.Interaction, error) {
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := model.Interaction{
+		Id:          id,
+		CommandId:   command.ID,
+		CommandName: command.Name,
+		GuildId:     command.GuildId,
+		ChannelId:   channelId,
+		UserId:      userId,
+		BotId:       command.BotId,
+		Options:     options,
+		CreatedAt:   time.Now(),
+	}
+
+	if err = s.RedisRepository.SaveInteraction(ctx, &interaction); err != nil {
+		return nil, err
+	}
+
+	return &interaction, nil
+}
+
+// GetInteraction returns the interaction if it has not expired yet
+func (s *commandService) GetInteraction(ctx context.Context, interactionId string) (*model.Interaction, error) {
+	return s.RedisRepository.GetInteraction(ctx, interactionId)
+}
+
+// DispatchInteraction sends the interaction to the command's callback URL
+// with the same signature headers as guild webhooks
+func (s *commandService) DispatchInteraction(command *model.Command, interaction *model.Interaction) error {
+	if command.CallbackUrl == nil || command.Secret == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(interaction)
+
+	if err != nil {
+		log.Printf("error marshalling interaction: %v\n", err)
+		return apperrors.NewInternal()
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		"X-Valkyrie-Event":     InteractionEvent,
+		"X-Valkyrie-Delivery":  interaction.Id,
+		"X-Valkyrie-Timestamp": timestamp,
+		"X-Valkyrie-Signature": signPayload(*command.Secret, timestamp, string(payload)),
+	}
+
+	status, err := s.WebhookClient.Post(*command.CallbackUrl, payload, headers)
+
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("unexpected response status %d", status)
+	}
+
+	return nil
+}
+
+// splitArguments splits the text on whitespace while keeping double quoted arguments together
+func splitArguments(text string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inQuotes, hasArg := false, false
+
+	for _, r := range text {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasArg = true
+		case !inQuotes && (r == ' ' || r == '\t' || r == '\n'):
+			if hasArg {
+				args = append(args, current.String())
+				current.Reset()
+				hasArg = false
+			}
+		default:
+			current.WriteRune(r)
+			hasArg = true
+		}
+	}
+
+	if inQuotes {
+		return nil, errors.New("Unterminated quote")
+	}
+
+	if hasArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+// parseOption converts the argument to the type of the option
+func parseOption(option model.CommandOption, arg string) (interface{}, error) {
+	switch option.Type {
+	case model.IntegerOption:
+		value, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Option %s must be an integer", option.Name)
+		}
+		return value, nil
+	case model.BooleanOption:
+		switch strings.ToLower(arg) {
+		case "yes", "y", "on":
+			return true, nil
+		case "no", "n", "off":
+			return false, nil
+		}
+		value, err := strconv.ParseBool(arg)
+		if err != nil {
+			return nil, fmt.Errorf("Option %s must be true or false", option.Name)
+		}
+		return value, nil
+	case model.UserOption:
+		match := userArgRegex.FindStringSubmatch(arg)
+		if match == nil {
+			return nil, fmt.Errorf("Option %s must be a user", option.Name)
+		}
+		return match[1] + match[2], nil
+	case model.ChannelOption:
+		match := channelArgRegex.FindStringSubmatch(arg)
+		if match == nil {
+			return nil, fmt.Errorf("Option %s must be a channel", option.Name)
+		}
+		return match[1] + match[2], nil
+	default:
+		return arg, nil
+	}
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+)
+
+func getMockCommand(guildId string) *model.Command {
+	return &model.Command{
+		BaseModel:   model.BaseModel{ID: fixture.RandID()},
+		GuildId:     guildId,
+		BotId:       fixture.RandID(),
+		Name:        "remind",
+		Description: "Sets a reminder",
+		Options: model.CommandOptions{
+			{Name: "minutes", Description: "Delay", Type: model.IntegerOption, Required: true},
+			{Name: "user", Description: "Who to remind", Type: model.UserOption, Required: true},
+			{Name: "silent", Description: "Hide the reminder", Type: model.BooleanOption},
+			{Name: "text", Description: "Reminder text", Type: model.StringOption},
+		},
+	}
+}
+
+func TestCommandService_ParseCommand(t *testing.T) {
+	guildId := fixture.RandID()
+	mockCommand := getMockCommand(guildId)
+	userId := fixture.RandID()
+
+	t.Run("Parses typed arguments", func(t *testing.T) {
+		mockCommandRepository := new(mocks.CommandRepository)
+		mockCommandRepository.On("FindByName", guildId, "remind").Return(mockCommand, nil)
+
+		cs := NewCommandService(&CMConfig{
+			CommandRepository: mockCommandRepository,
+		})
+
+		command, options, err := cs.ParseCommand(guildId, "/remind 15 <@"+userId+"> yes \"deploy the\"   release now")
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockCommand, command)
+		assert.Equal(t, map[string]interface{}{
+			"minutes": int64(15),
+			"user":    userId,
+			"silent":  true,
+			"text":    "deploy the release now",
+		}, options)
+	})
+
+	t.Run("Optional arguments can be omitted", func(t *testing.T) {
+		mockCommandRepository := new(mocks.CommandRepository)
+		mockCommandRepository.On("FindByName", guildId, "remind").Return(mockCommand, nil)
+
+		cs := NewCommandService(&CMConfig{
+			CommandRepository: mockCommandRepository,
+		})
+
+		_, options, err := cs.ParseCommand(guildId, "/remind 5 "+userId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"minutes": int64(5),
+			"user":    userId,
+		}, options)
+	})
+
+	t.Run("Unknown commands are regular messages", func(t *testing.T) {
+		mockCommandRepository := new(mocks.CommandRepository)
+		mockCommandRepository.On("FindByName", guildId, "shrug").Return(nil, apperrors.NewNotFound("command", "shrug"))
+
+		cs := NewCommandService(&CMConfig{
+			CommandRepository: mockCommandRepository,
+		})
+
+		command, options, err := cs.ParseCommand(guildId, "/shrug ok")
+
+		assert.NoError(t, err)
+		assert.Nil(t, command)
+		assert.Nil(t, options)
+	})
+
+	t.Run("Text that is not a command", func(t *testing.T) {
+		mockCommandRepository := new(mocks.CommandRepository)
+
+		cs := NewCommandService(&CMConfig{
+			CommandRepository: mockCommandRepository,
+		})
+
+		command, _, err := cs.ParseCommand(guildId, "/usr/bin is a path")
+
+		assert.NoError(t, err)
+		assert.Nil(t, command)
+		mockCommandRepository.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		testCases := map[string]string{
+			"/remind":                         "Missing required option minutes",
+			"/remind soon " + userId:          "Option minutes must be an integer",
+			"/remind 5 someone":               "Option user must be a user",
+			"/remind 5 " + userId + " maybe":  "Option silent must be true or false",
+			"/remind 5 " + userId + " \"open": "Unterminated quote",
+		}
+
+		for text, message := range testCases {
+			mockCommandRepository := new(mocks.CommandRepository)
+			mockCommandRepository.On("FindByName", guildId, "remind").Return(mockCommand, nil)
+
+			cs := NewCommandService(&CMConfig{
+				CommandRepository: mockCommandRepository,
+			})
+
+			command, _, err := cs.ParseCommand(guildId, text)
+
+			assert.Nil(t, command)
+			assert.Equal(t, apperrors.NewBadRequest(message), err, text)
+		}
+	})
+}
+
+func TestCommandService_RegisterCommand(t *testing.T) {
+	t.Run("Replaces the bot's command", func(t *testing.T) {
+		existing := getMockCommand(fixture.RandID())
+		command := &model.Command{
+			GuildId:     existing.GuildId,
+			BotId:       existing.BotId,
+			Name:        existing.Name,
+			Description: "Updated",
+			Options:     model.CommandOptions{},
+		}
+
+		mockCommandRepository := new(mocks.CommandRepository)
+		mockCommandRepository.On("FindByName", existing.GuildId, existing.Name).Return(existing, nil)
+		mockCommandRepository.On("Update", existing).Return(nil)
+
+		cs := NewCommandService(&CMConfig{
+			CommandRepository: mockCommandRepository,
+		})
+
+		registered, err := cs.RegisterCommand(command)
+
+		assert.NoError(t, err)
+		assert.Equal(t, existing.ID, registered.ID)
+		assert.Equal(t, "Updated", registered.Description)
+		assert.Empty(t, registered.Options)
+		mockCommandRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Name taken by another bot", func(t *testing.T) {
+		existing := getMockCommand(fixture.RandID())
+		command := getMockCommand(existing.GuildId)
+
+		mockCommandRepository := new(mocks.CommandRepository)
+		mockCommandRepository.On("FindByName", existing.GuildId, existing.Name).Return(existing, nil)
+
+		cs := NewCommandService(&CMConfig{
+			CommandRepository: mockCommandRepository,
+		})
+
+		registered, err := cs.RegisterCommand(command)
+
+		assert.Nil(t, registered)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.CommandExistsError), err)
+		mockCommandRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Command limit reached", func(t *testing.T) {
+		command := getMockCommand(fixture.RandID())
+
+		mockCommandRepository := new(mocks.CommandRepository)
+		mockCommandRepository.On("FindByName", command.GuildId, command.Name).Return(nil, apperrors.NewNotFound("command", command.Name))
+		mockCommandRepository.On("CountByBot", command.GuildId, command.BotId).Return(int64(model.MaximumCommands), nil)
+
+		cs := NewCommandService(&CMConfig{
+			CommandRepository: mockCommandRepository,
+		})
+
+		registered, err := cs.RegisterCommand(command)
+
+		assert.Nil(t, registered)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.CommandLimitError), err)
+		mockCommandRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestCommandService_DispatchInteraction(t *testing.T) {
+	t.Run("Signs the callback", func(t *testing.T) {
+		command := getMockCommand(fixture.RandID())
+		url := "https://bot.example.com/interactions"
+		secret := fixture.RandStr(32)
+		command.CallbackUrl = &url
+		command.Secret = &secret
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("SaveInteraction", mock.Anything, mock.AnythingOfType("*model.Interaction")).Return(nil)
+
+		mockClient := new(mocks.WebhookClient)
+		mockClient.On("Post", url, mock.Anything, mock.MatchedBy(func(headers map[string]string) bool {
+			return headers["X-Valkyrie-Event"] == InteractionEvent && headers["X-Valkyrie-Signature"] != ""
+		})).Return(http.StatusNoContent, nil)
+
+		cs := NewCommandService(&CMConfig{
+			RedisRepository: mockRedisRepository,
+			WebhookClient:   mockClient,
+		})
+
+		interaction, err := cs.CreateInteraction(context.TODO(), command, fixture.RandID(), fixture.RandID(), map[string]interface{}{})
+		assert.NoError(t, err)
+
+		err = cs.DispatchInteraction(command, interaction)
+		assert.NoError(t, err)
+
+		body := mockClient.Calls[0].Arguments.Get(1).([]byte)
+		headers := mockClient.Calls[0].Arguments.Get(2).(map[string]string)
+		assert.Equal(t, signPayload(secret, headers["X-Valkyrie-Timestamp"], string(body)), headers["X-Valkyrie-Signature"])
+	})
+
+	t.Run("Unsuccessful response", func(t *testing.T) {
+		command := getMockCommand(fixture.RandID())
+		url := "https://bot.example.com/interactions"
+		secret := fixture.RandStr(32)
+		command.CallbackUrl = &url
+		command.Secret = &secret
+
+		mockClient := new(mocks.WebhookClient)
+		mockClient.On("Post", url, mock.Anything, mock.Anything).Return(http.StatusInternalServerError, nil)
+
+		cs := NewCommandService(&CMConfig{
+			WebhookClient: mockClient,
+		})
+
+		err := cs.DispatchInteraction(command, &model.Interaction{Id: fixture.RandID()})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"io"
+	"log"
+)
+
+// stripMetadata removes the EXIF and text metadata from jpeg, png and webp images
+// without re-encoding them. Other types are returned unchanged.
+func stripMetadata(file io.Reader, mimetype string) (io.Reader, error) {
+	var strip func([]byte) ([]byte, error)
+
+	switch mimetype {
+	case "image/jpeg":
+		strip = stripJPEGMetadata
+	case "image/png":
+		strip = stripPNGMetadata
+	case "image/webp":
+		strip = stripWebPMetadata
+	default:
+		return file, nil
+	}
+
+	content, err := io.ReadAll(file)
+
+	if err != nil {
+		log.Printf("Failed to read file: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	stripped, err := strip(content)
+
+	if err != nil {
+		log.Printf("Failed to strip the image metadata: %v\n", err.Error())
+		return nil, apperrors.NewBadRequest(apperrors.InvalidImageFile)
+	}
+
+	return bytes.NewReader(stripped), nil
+}
+
+var errMalformedImage = errors.New("malformed image")
+
+// jpegMetadataPrefixes contains the identifiers of APP1 segments with metadata
+var jpegMetadataPrefixes = [][]byte{
+	[]byte("Exif\x00"),
+	[]byte("http://ns.adobe.com/xap/1.0/\x00"),
+}
+
+// stripJPEGMetadata removes the EXIF and XMP segments of the jpeg image
+func stripJPEGMetadata(content []byte) ([]byte, error) {
+	if len(content) < 2 || content[0] != 0xFF || content[1] != 0xD8 {
+		return nil, errMalformedImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(content)))
+	out.Write(content[:2])
+	pos := 2
+
+	for {
+		if pos+2 > len(content) || content[pos] != 0xFF {
+			return nil, errMalformedImage
+		}
+
+		marker := content[pos+1]
+
+		// Fill bytes
+		if marker == 0xFF {
+			pos++
+			continue
+		}
+
+		// Markers without a segment
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out.Write(content[pos : pos+2])
+			pos += 2
+			continue
+		}
+
+		if pos+4 > len(content) {
+			return nil, errMalformedImage
+		}
+
+		// The length includes its own two bytes
+		length := int(binary.BigEndian.Uint16(content[pos+2 : pos+4]))
+		end := pos + 2 + length
+		if length < 2 || end > len(content) {
+			return nil, errMalformedImage
+		}
+
+		// The compressed data follows the start of scan, nothing to strip after it
+		if marker == 0xDA {
+			out.Write(content[pos:])
+			return out.Bytes(), nil
+		}
+
+		if !(marker == 0xE1 && hasAnyPrefix(content[pos+4:end], jpegMetadataPrefixes)) {
+			out.Write(content[pos:end])
+		}
+
+		pos = end
+	}
+}
+
+// pngMetadataChunks contains the chunk types with metadata
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// stripPNGMetadata removes the EXIF, text and time chunks of the png image
+func stripPNGMetadata(content []byte) ([]byte, error) {
+	if !bytes.HasPrefix(content, pngSignature) {
+		return nil, errMalformedImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(content)))
+	out.Write(pngSignature)
+	pos := len(pngSignature)
+
+	for pos < len(content) {
+		// Length, type and CRC take 12 bytes
+		if pos+12 > len(content) {
+			return nil, errMalformedImage
+		}
+
+		length := binary.BigEndian.Uint32(content[pos : pos+4])
+		if uint64(length) > uint64(len(content)-pos-12) {
+			return nil, errMalformedImage
+		}
+		end := pos + 12 + int(length)
+
+		if !pngMetadataChunks[string(content[pos+4:pos+8])] {
+			out.Write(content[pos:end])
+		}
+
+		pos = end
+	}
+
+	return out.Bytes(), nil
+}
+
+// webpMetadataChunks contains the chunk types with metadata
+var webpMetadataChunks = map[string]bool{
+	"EXIF": true,
+	"XMP ": true,
+}
+
+// VP8X flags announcing the metadata chunks
+const (
+	webpExifFlag = 0x08
+	webpXMPFlag  = 0x04
+)
+
+// stripWebPMetadata removes the EXIF and XMP chunks of the webp image
+func stripWebPMetadata(content []byte) ([]byte, error) {
+	// RIFF header: "RIFF", file size and "WEBP"
+	if len(content) < 12 || string(content[:4]) != "RIFF" || string(content[8:12]) != "WEBP" {
+		return nil, errMalformedImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(content)))
+	out.Write(content[:12])
+	pos := 12
+
+	for pos < len(content) {
+		// Type and size take 8 bytes
+		if pos+8 > len(content) {
+			return nil, errMalformedImage
+		}
+
+		// Chunks with an odd size are followed by a padding byte
+		length := uint64(binary.LittleEndian.Uint32(content[pos+4 : pos+8]))
+		padded := length + length&1
+		if padded > uint64(len(content)-pos-8) {
+			return nil, errMalformedImage
+		}
+		end := pos + 8 + int(padded)
+		chunk := string(content[pos : pos+4])
+
+		if !webpMetadataChunks[chunk] {
+			start := out.Len()
+			out.Write(content[pos:end])
+
+			// The VP8X flags must not announce the removed chunks
+			if chunk == "VP8X" && length > 0 {
+				out.Bytes()[start+8] &^= webpExifFlag | webpXMPFlag
+			}
+		}
+
+		pos = end
+	}
+
+	// The RIFF size excludes the first 8 bytes
+	stripped := out.Bytes()
+	binary.LittleEndian.PutUint32(stripped[4:8], uint32(len(stripped)-8))
+
+	return stripped, nil
+}
+
+// hasAnyPrefix checks if the data starts with one of the prefixes
+func hasAnyPrefix(data []byte, prefixes [][]byte) bool {
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(data, prefix) {
+			return true
+		}
+	}
+	return false
+}
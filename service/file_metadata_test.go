@@ -0,0 +1,182 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestStripMetadata(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+
+	t.Run("Removes EXIF from jpeg images", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := jpeg.Encode(&buf, img, nil)
+		assert.NoError(t, err)
+
+		// Insert an APP1 segment with the GPS position after the start of image marker
+		exif := append([]byte("Exif\x00\x00"), []byte("GPSLatitude")...)
+		segment := []byte{0xFF, 0xE1, 0, 0}
+		binary.BigEndian.PutUint16(segment[2:], uint16(len(exif)+2))
+		content := append(append(append([]byte{}, buf.Bytes()[:2]...), append(segment, exif...)...), buf.Bytes()[2:]...)
+
+		stripped, err := stripMetadata(bytes.NewReader(content), "image/jpeg")
+		assert.NoError(t, err)
+
+		result, _ := io.ReadAll(stripped)
+		assert.NotContains(t, string(result), "GPSLatitude")
+		assert.Equal(t, buf.Bytes(), result)
+
+		_, err = jpeg.Decode(bytes.NewReader(result))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Removes text chunks from png images", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := png.Encode(&buf, img)
+		assert.NoError(t, err)
+
+		// Insert a tEXt chunk after the IHDR chunk
+		data := []byte("Author\x00Someone")
+		chunk := make([]byte, 8, 12+len(data))
+		binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+		copy(chunk[4:], "tEXt")
+		chunk = append(chunk, data...)
+		crc := make([]byte, 4)
+		binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(chunk[4:]))
+		chunk = append(chunk, crc...)
+
+		// Signature (8) + IHDR (25)
+		headerEnd := 33
+		content := append(append(append([]byte{}, buf.Bytes()[:headerEnd]...), chunk...), buf.Bytes()[headerEnd:]...)
+
+		_, err = png.Decode(bytes.NewReader(content))
+		assert.NoError(t, err)
+
+		stripped, err := stripMetadata(bytes.NewReader(content), "image/png")
+		assert.NoError(t, err)
+
+		result, _ := io.ReadAll(stripped)
+		assert.Equal(t, buf.Bytes(), result)
+	})
+
+	t.Run("Removes EXIF and XMP chunks from webp images", func(t *testing.T) {
+		// VP8X announcing EXIF and XMP, followed by the image data
+		vp8x := webpChunk("VP8X", []byte{webpExifFlag | webpXMPFlag, 0, 0, 0, 3, 0, 0, 3, 0, 0})
+		vp8l := webpChunk("VP8L", []byte{0x2F, 0x03, 0xC0, 0x00, 0x00})
+		exif := webpChunk("EXIF", []byte("GPSLatitude"))
+		xmp := webpChunk("XMP ", []byte("<x:xmpmeta/>"))
+
+		content := webpFile(vp8x, vp8l, exif, xmp)
+		assert.Equal(t, "image/webp", http.DetectContentType(content))
+
+		stripped, err := stripMetadata(bytes.NewReader(content), "image/webp")
+		assert.NoError(t, err)
+
+		result, _ := io.ReadAll(stripped)
+		assert.NotContains(t, string(result), "GPSLatitude")
+		assert.NotContains(t, string(result), "xmpmeta")
+		assert.Equal(t, webpFile(webpChunk("VP8X", []byte{0, 0, 0, 0, 3, 0, 0, 3, 0, 0}), vp8l), result)
+	})
+
+	t.Run("Other types are unchanged", func(t *testing.T) {
+		content := strings.NewReader("Exif\x00 not an image")
+
+		stripped, err := stripMetadata(content, "text/plain")
+
+		assert.NoError(t, err)
+		assert.Equal(t, content, stripped)
+	})
+
+	t.Run("Malformed image", func(t *testing.T) {
+		_, err := stripMetadata(strings.NewReader("not a jpeg"), "image/jpeg")
+
+		assert.Error(t, err)
+	})
+
+	malformed := []struct {
+		name     string
+		mimetype string
+		content  []byte
+	}{
+		{
+			name:     "Zero length jpeg segment",
+			mimetype: "image/jpeg",
+			content:  []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:     "One byte jpeg segment",
+			mimetype: "image/jpeg",
+			content:  []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01, 0x00, 0x00},
+		},
+		{
+			name:     "Truncated jpeg segment",
+			mimetype: "image/jpeg",
+			content:  []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x10, 0x00},
+		},
+		{
+			name:     "Truncated png chunk",
+			mimetype: "image/png",
+			content:  append(append([]byte{}, pngSignature...), 0x00, 0x00, 0x00, 0x10, 't', 'E', 'X', 't', 0, 0, 0, 0),
+		},
+		{
+			name:     "Png chunk exceeding the file",
+			mimetype: "image/png",
+			content:  append(append([]byte{}, pngSignature...), 0xFF, 0xFF, 0xFF, 0xFF, 't', 'E', 'X', 't', 0, 0, 0, 0),
+		},
+		{
+			name:     "Truncated webp chunk",
+			mimetype: "image/webp",
+			content:  append([]byte("RIFF\x00\x00\x00\x00WEBP"), 'E', 'X', 'I', 'F', 0x10),
+		},
+		{
+			name:     "Webp chunk exceeding the file",
+			mimetype: "image/webp",
+			content:  append([]byte("RIFF\x00\x00\x00\x00WEBP"), 'E', 'X', 'I', 'F', 0xFF, 0xFF, 0xFF, 0xFF, 0),
+		},
+		{
+			name:     "Missing webp padding byte",
+			mimetype: "image/webp",
+			content:  append([]byte("RIFF\x00\x00\x00\x00WEBP"), 'E', 'X', 'I', 'F', 0x01, 0, 0, 0, 0),
+		},
+	}
+
+	for _, tc := range malformed {
+		t.Run(tc.name, func(t *testing.T) {
+			stripped, err := stripMetadata(bytes.NewReader(tc.content), tc.mimetype)
+
+			assert.Error(t, err)
+			assert.Nil(t, stripped)
+		})
+	}
+}
+
+// webpChunk builds a RIFF chunk with its padding byte
+func webpChunk(fourCC string, data []byte) []byte {
+	chunk := make([]byte, 8, 9+len(data))
+	copy(chunk, fourCC)
+	binary.LittleEndian.PutUint32(chunk[4:], uint32(len(data)))
+	chunk = append(chunk, data...)
+	if len(data)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+// webpFile builds a webp file out of the chunks
+func webpFile(chunks ...[]byte) []byte {
+	content := []byte("RIFF\x00\x00\x00\x00WEBP")
+	for _, chunk := range chunks {
+		content = append(content, chunk...)
+	}
+	binary.LittleEndian.PutUint32(content[4:], uint32(len(content)-8))
+	return content
+}
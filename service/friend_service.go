@@ -1,14 +1,16 @@
 package service
 
 import (
+	"context"
 	"github.com/sentrionic/valkyrie/model"
 )
 
-// friendService acts as a struct for injecting an implementation of UserRepository
-// and FriendRepository for use in service methods
+// friendService acts as a struct for injecting an implementation of UserRepository,
+// FriendRepository and RedisRepository for use in service methods
 type friendService struct {
 	UserRepository   model.UserRepository
 	FriendRepository model.FriendRepository
+	RedisRepository  model.RedisRepository
 }
 
 // FSConfig will hold repositories that will eventually be injected into
@@ -16,6 +18,7 @@ type friendService struct {
 type FSConfig struct {
 	UserRepository   model.UserRepository
 	FriendRepository model.FriendRepository
+	RedisRepository  model.RedisRepository
 }
 
 // NewFriendService is a factory function for
@@ -24,11 +27,37 @@ func NewFriendService(c *FSConfig) model.FriendService {
 	return &friendService{
 		UserRepository:   c.UserRepository,
 		FriendRepository: c.FriendRepository,
+		RedisRepository:  c.RedisRepository,
 	}
 }
 
+// GetFriends returns the friends of the given user with their presence
 func (f *friendService) GetFriends(id string) (*[]model.Friend, error) {
-	return f.FriendRepository.FriendsList(id)
+	friends, err := f.FriendRepository.FriendsList(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(*friends))
+	for i, friend := range *friends {
+		ids[i] = friend.Id
+	}
+
+	presences, err := f.RedisRepository.GetPresences(context.Background(), ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range *friends {
+		friend := &(*friends)[i]
+		presence := presences[friend.Id].VisibleTo(id)
+		friend.Status = presence.Status
+		friend.CustomStatus = presence.CustomStatus
+	}
+
+	return friends, nil
 }
 
 func (f *friendService) GetRequests(id string) (*[]model.FriendRequest, error) {
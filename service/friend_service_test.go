@@ -0,0 +1,65 @@
+package service
+
+import (
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestFriendService_GetFriends(t *testing.T) {
+	uid, _ := GenerateId()
+
+	t.Run("Adds the presence of the friends", func(t *testing.T) {
+		idle := fixture.RandID()
+		invisible := fixture.RandID()
+		friends := []model.Friend{{Id: idle}, {Id: invisible}}
+		presences := map[string]model.Presence{
+			idle:      {UserId: idle, Status: model.StatusIdle, CustomStatus: "Lunch"},
+			invisible: {UserId: invisible, Status: model.StatusInvisible, CustomStatus: "Hidden"},
+		}
+
+		mockFriendRepository := new(mocks.FriendRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		fs := NewFriendService(&FSConfig{
+			FriendRepository: mockFriendRepository,
+			RedisRepository:  mockRedisRepository,
+		})
+
+		mockFriendRepository.On("FriendsList", uid).Return(&friends, nil)
+		mockRedisRepository.On("GetPresences", mock.Anything, []string{idle, invisible}).Return(presences, nil)
+
+		result, err := fs.GetFriends(uid)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &[]model.Friend{
+			{Id: idle, Status: model.StatusIdle, CustomStatus: "Lunch"},
+			{Id: invisible, Status: model.StatusOffline},
+		}, result)
+
+		mockFriendRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockFriendRepository := new(mocks.FriendRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		fs := NewFriendService(&FSConfig{
+			FriendRepository: mockFriendRepository,
+			RedisRepository:  mockRedisRepository,
+		})
+
+		mockError := apperrors.NewInternal()
+		mockFriendRepository.On("FriendsList", uid).Return(nil, mockError)
+
+		result, err := fs.GetFriends(uid)
+
+		assert.Nil(t, result)
+		assert.Equal(t, mockError, err)
+
+		mockRedisRepository.AssertNotCalled(t, "GetPresences", mock.Anything, mock.Anything)
+	})
+}
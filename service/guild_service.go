@@ -9,32 +9,35 @@ import (
 // GuildService acts as a struct for injecting an implementation of GuildRepository
 // for use in service methods
 type guildService struct {
-	UserRepository    model.UserRepository
-	FileRepository    model.FileRepository
-	RedisRepository   model.RedisRepository
-	GuildRepository   model.GuildRepository
-	ChannelRepository model.ChannelRepository
+	UserRepository     model.UserRepository
+	FileRepository     model.FileRepository
+	RedisRepository    model.RedisRepository
+	GuildRepository    model.GuildRepository
+	ChannelRepository  model.ChannelRepository
+	AuditLogRepository model.AuditLogRepository
 }
 
 // GSConfig will hold repositories that will eventually be injected into
 // this service layer
 type GSConfig struct {
-	UserRepository    model.UserRepository
-	FileRepository    model.FileRepository
-	RedisRepository   model.RedisRepository
-	GuildRepository   model.GuildRepository
-	ChannelRepository model.ChannelRepository
+	UserRepository     model.UserRepository
+	FileRepository     model.FileRepository
+	RedisRepository    model.RedisRepository
+	GuildRepository    model.GuildRepository
+	ChannelRepository  model.ChannelRepository
+	AuditLogRepository model.AuditLogRepository
 }
 
 // NewGuildService is a factory function for
 // initializing a GuildService with its repository layer dependencies
 func NewGuildService(c *GSConfig) model.GuildService {
 	return &guildService{
-		UserRepository:    c.UserRepository,
-		FileRepository:    c.FileRepository,
-		RedisRepository:   c.RedisRepository,
-		GuildRepository:   c.GuildRepository,
-		ChannelRepository: c.ChannelRepository,
+		UserRepository:     c.UserRepository,
+		FileRepository:     c.FileRepository,
+		RedisRepository:    c.RedisRepository,
+		GuildRepository:    c.GuildRepository,
+		ChannelRepository:  c.ChannelRepository,
+		AuditLogRepository: c.AuditLogRepository,
 	}
 }
 
@@ -42,8 +45,33 @@ func (g *guildService) GetUserGuilds(uid string) (*[]model.GuildResponse, error)
 	return g.GuildRepository.List(uid)
 }
 
+// GetGuildMembers returns the members of the guild with their presence as seen by the given user
 func (g *guildService) GetGuildMembers(userId string, guildId string) (*[]model.MemberResponse, error) {
-	return g.GuildRepository.GuildMembers(userId, guildId)
+	members, err := g.GuildRepository.GuildMembers(userId, guildId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(*members))
+	for i, member := range *members {
+		ids[i] = member.Id
+	}
+
+	presences, err := g.RedisRepository.GetPresences(context.Background(), ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range *members {
+		member := &(*members)[i]
+		presence := presences[member.Id].VisibleTo(userId)
+		member.Status = presence.Status
+		member.CustomStatus = presence.CustomStatus
+	}
+
+	return members, nil
 }
 
 func (g *guildService) CreateGuild(guild *model.Guild) (*model.Guild, error) {
@@ -84,6 +112,35 @@ func (g *guildService) UpdateGuild(guild *model.Guild) error {
 	return g.GuildRepository.Save(guild)
 }
 
+// EditGuild saves the changes of the guild and adds them to the audit log
+func (g *guildService) EditGuild(guild *model.Guild, actorId string) error {
+	previous, err := g.GuildRepository.FindByID(guild.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if err = g.GuildRepository.Save(guild); err != nil {
+		return err
+	}
+
+	var changes model.AuditLogChanges
+	changes = appendChange(changes, "name", previous.Name, guild.Name)
+	changes = appendChange(changes, "icon", optionalValue(previous.Icon), optionalValue(guild.Icon))
+
+	if len(changes) > 0 {
+		writeAuditLog(g.AuditLogRepository, &model.AuditLogEntry{
+			GuildId:    guild.ID,
+			ActorId:    actorId,
+			ActionType: model.AuditGuildUpdate,
+			TargetId:   &guild.ID,
+			Changes:    changes,
+		})
+	}
+
+	return nil
+}
+
 func (g *guildService) GetGuildIdFromInvite(ctx context.Context, token string) (string, error) {
 	return g.RedisRepository.GetInvite(ctx, token)
 }
@@ -92,20 +149,81 @@ func (g *guildService) GetDefaultChannel(guildId string) (*model.Channel, error)
 	return g.ChannelRepository.GetGuildDefault(guildId)
 }
 
-func (g *guildService) InvalidateInvites(ctx context.Context, guild *model.Guild) {
+// InvalidateInvites invalidates all invites of the guild and adds the action to the audit log
+func (g *guildService) InvalidateInvites(ctx context.Context, guild *model.Guild, actorId string) {
 	g.RedisRepository.InvalidateInvites(ctx, guild)
+
+	writeAuditLog(g.AuditLogRepository, &model.AuditLogEntry{
+		GuildId:    guild.ID,
+		ActorId:    actorId,
+		ActionType: model.AuditInvitesDelete,
+		TargetId:   &guild.ID,
+		Changes:    appendChange(nil, "inviteLinks", len(guild.InviteLinks), 0),
+	})
 }
 
 func (g *guildService) RemoveMember(userId string, guildId string) error {
 	return g.GuildRepository.RemoveMember(userId, guildId)
 }
 
+// KickMember removes the member from the guild and adds the kick to the audit log
+func (g *guildService) KickMember(memberId string, guildId string, actorId string, reason *string) error {
+	if err := g.GuildRepository.RemoveMember(memberId, guildId); err != nil {
+		return err
+	}
+
+	writeAuditLog(g.AuditLogRepository, &model.AuditLogEntry{
+		GuildId:    guildId,
+		ActorId:    actorId,
+		ActionType: model.AuditMemberKick,
+		TargetId:   &memberId,
+		Reason:     reason,
+	})
+
+	return nil
+}
+
+// BanMember bans the member, removes them from the guild and adds the ban to the audit log
+func (g *guildService) BanMember(member *model.User, guild *model.Guild, actorId string, reason *string) error {
+	guild.Bans = append(guild.Bans, *member)
+
+	if err := g.GuildRepository.Save(guild); err != nil {
+		return err
+	}
+
+	if err := g.GuildRepository.RemoveMember(member.ID, guild.ID); err != nil {
+		return err
+	}
+
+	writeAuditLog(g.AuditLogRepository, &model.AuditLogEntry{
+		GuildId:    guild.ID,
+		ActorId:    actorId,
+		ActionType: model.AuditMemberBan,
+		TargetId:   &member.ID,
+		Reason:     reason,
+	})
+
+	return nil
+}
+
 func (g *guildService) DeleteGuild(guildId string) error {
 	return g.GuildRepository.Delete(guildId)
 }
 
-func (g *guildService) UnbanMember(userId string, guildId string) error {
-	return g.GuildRepository.UnbanMember(userId, guildId)
+// UnbanMember removes the ban of the user and adds the action to the audit log
+func (g *guildService) UnbanMember(userId string, guildId string, actorId string) error {
+	if err := g.GuildRepository.UnbanMember(userId, guildId); err != nil {
+		return err
+	}
+
+	writeAuditLog(g.AuditLogRepository, &model.AuditLogEntry{
+		GuildId:    guildId,
+		ActorId:    actorId,
+		ActionType: model.AuditMemberUnban,
+		TargetId:   &userId,
+	})
+
+	return nil
 }
 
 func (g *guildService) GetBanList(guildId string) (*[]model.BanResponse, error) {
@@ -116,8 +234,33 @@ func (g *guildService) GetMemberSettings(userId string, guildId string) (*model.
 	return g.GuildRepository.GetMemberSettings(userId, guildId)
 }
 
+// UpdateMemberSettings saves the member's guild settings and adds the changes to the audit log
 func (g *guildService) UpdateMemberSettings(settings *model.MemberSettings, userId string, guildId string) error {
-	return g.GuildRepository.UpdateMemberSettings(settings, userId, guildId)
+	previous, err := g.GuildRepository.GetMemberSettings(userId, guildId)
+
+	if err != nil {
+		return err
+	}
+
+	if err = g.GuildRepository.UpdateMemberSettings(settings, userId, guildId); err != nil {
+		return err
+	}
+
+	var changes model.AuditLogChanges
+	changes = appendChange(changes, "nickname", optionalValue(previous.Nickname), optionalValue(settings.Nickname))
+	changes = appendChange(changes, "color", optionalValue(previous.Color), optionalValue(settings.Color))
+
+	if len(changes) > 0 {
+		writeAuditLog(g.AuditLogRepository, &model.AuditLogEntry{
+			GuildId:    guildId,
+			ActorId:    userId,
+			ActionType: model.AuditMemberUpdate,
+			TargetId:   &userId,
+			Changes:    changes,
+		})
+	}
+
+	return nil
 }
 
 func (g *guildService) FindUsersByIds(ids []string, guildId string) (*[]model.User, error) {
@@ -127,3 +270,7 @@ func (g *guildService) FindUsersByIds(ids []string, guildId string) (*[]model.Us
 func (g *guildService) UpdateMemberLastSeen(userId, guildId string) error {
 	return g.GuildRepository.UpdateMemberLastSeen(userId, guildId)
 }
+
+func (g *guildService) GetAuditLogs(query *model.AuditLogQuery) (*[]model.AuditLogEntry, error) {
+	return g.AuditLogRepository.Find(query)
+}
@@ -69,6 +69,68 @@ func TestGuildService_CreateGuild(t *testing.T) {
 	})
 }
 
+func TestGuildService_GetGuildMembers(t *testing.T) {
+	uid, _ := GenerateId()
+	guildId := fixture.RandID()
+
+	t.Run("Adds the presence of the members", func(t *testing.T) {
+		online := fixture.RandID()
+		invisible := fixture.RandID()
+		members := []model.MemberResponse{{Id: uid}, {Id: online}, {Id: invisible}}
+		presences := map[string]model.Presence{
+			uid:       {UserId: uid, Status: model.StatusInvisible, CustomStatus: "Hidden"},
+			online:    {UserId: online, Status: model.StatusDND, CustomStatus: "Busy"},
+			invisible: {UserId: invisible, Status: model.StatusInvisible, CustomStatus: "Hidden"},
+		}
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		gs := NewGuildService(&GSConfig{
+			GuildRepository: mockGuildRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockGuildRepository.On("GuildMembers", uid, guildId).Return(&members, nil)
+		mockRedisRepository.On("GetPresences", mock.Anything, []string{uid, online, invisible}).Return(presences, nil)
+
+		result, err := gs.GetGuildMembers(uid, guildId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &[]model.MemberResponse{
+			// Users see their own status even if they are invisible
+			{Id: uid, Status: model.StatusInvisible, CustomStatus: "Hidden"},
+			{Id: online, Status: model.StatusDND, CustomStatus: "Busy"},
+			{Id: invisible, Status: model.StatusOffline},
+		}, result)
+
+		mockGuildRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		members := []model.MemberResponse{{Id: uid}}
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		gs := NewGuildService(&GSConfig{
+			GuildRepository: mockGuildRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockError := apperrors.NewInternal()
+		mockGuildRepository.On("GuildMembers", uid, guildId).Return(&members, nil)
+		mockRedisRepository.On("GetPresences", mock.Anything, []string{uid}).Return(nil, mockError)
+
+		result, err := gs.GetGuildMembers(uid, guildId)
+
+		assert.Nil(t, result)
+		assert.Equal(t, mockError, err)
+
+		mockGuildRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+	})
+}
+
 func TestGuildService_GenerateInviteLink(t *testing.T) {
 	guildId := fixture.RandID()
 	ctx := context.TODO()
@@ -129,3 +191,118 @@ func TestGuildService_GenerateInviteLink(t *testing.T) {
 		mockRedisRepository.AssertExpectations(t)
 	})
 }
+
+func TestGuildService_KickMember(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		actorId := fixture.RandID()
+		memberId := fixture.RandID()
+		reason := fixture.RandStr(20)
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockAuditLogRepository := new(mocks.AuditLogRepository)
+		gs := NewGuildService(&GSConfig{
+			GuildRepository:    mockGuildRepository,
+			AuditLogRepository: mockAuditLogRepository,
+		})
+
+		mockGuildRepository.On("RemoveMember", memberId, mockGuild.ID).Return(nil)
+		mockAuditLogRepository.
+			On("Create", mock.MatchedBy(func(entry *model.AuditLogEntry) bool {
+				return entry.ID != "" &&
+					entry.GuildId == mockGuild.ID &&
+					entry.ActorId == actorId &&
+					entry.ActionType == model.AuditMemberKick &&
+					*entry.TargetId == memberId &&
+					*entry.Reason == reason
+			})).
+			Return(nil)
+
+		err := gs.KickMember(memberId, mockGuild.ID, actorId, &reason)
+
+		assert.NoError(t, err)
+
+		mockGuildRepository.AssertExpectations(t)
+		mockAuditLogRepository.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		memberId := fixture.RandID()
+		mockErr := apperrors.NewInternal()
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockAuditLogRepository := new(mocks.AuditLogRepository)
+		gs := NewGuildService(&GSConfig{
+			GuildRepository:    mockGuildRepository,
+			AuditLogRepository: mockAuditLogRepository,
+		})
+
+		mockGuildRepository.On("RemoveMember", memberId, mockGuild.ID).Return(mockErr)
+
+		err := gs.KickMember(memberId, mockGuild.ID, fixture.RandID(), nil)
+
+		assert.EqualError(t, err, mockErr.Error())
+
+		mockAuditLogRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestGuildService_EditGuild(t *testing.T) {
+	t.Run("Logs the changed fields", func(t *testing.T) {
+		actorId := fixture.RandID()
+		previous := fixture.GetMockGuild("")
+		guild := *previous
+		guild.Name = fixture.RandStr(8)
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockAuditLogRepository := new(mocks.AuditLogRepository)
+		gs := NewGuildService(&GSConfig{
+			GuildRepository:    mockGuildRepository,
+			AuditLogRepository: mockAuditLogRepository,
+		})
+
+		changes := model.AuditLogChanges{
+			{Key: "name", Before: previous.Name, After: guild.Name},
+		}
+
+		mockGuildRepository.On("FindByID", guild.ID).Return(previous, nil)
+		mockGuildRepository.On("Save", &guild).Return(nil)
+		mockAuditLogRepository.
+			On("Create", mock.MatchedBy(func(entry *model.AuditLogEntry) bool {
+				return entry.ActionType == model.AuditGuildUpdate &&
+					entry.ActorId == actorId &&
+					assert.ObjectsAreEqual(changes, entry.Changes)
+			})).
+			Return(nil)
+
+		err := gs.EditGuild(&guild, actorId)
+
+		assert.NoError(t, err)
+
+		mockGuildRepository.AssertExpectations(t)
+		mockAuditLogRepository.AssertExpectations(t)
+	})
+
+	t.Run("Nothing changed", func(t *testing.T) {
+		previous := fixture.GetMockGuild("")
+		guild := *previous
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockAuditLogRepository := new(mocks.AuditLogRepository)
+		gs := NewGuildService(&GSConfig{
+			GuildRepository:    mockGuildRepository,
+			AuditLogRepository: mockAuditLogRepository,
+		})
+
+		mockGuildRepository.On("FindByID", guild.ID).Return(previous, nil)
+		mockGuildRepository.On("Save", &guild).Return(nil)
+
+		err := gs.EditGuild(&guild, fixture.RandID())
+
+		assert.NoError(t, err)
+
+		mockGuildRepository.AssertExpectations(t)
+		mockAuditLogRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"crypto/subtle"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+)
+
+// incomingWebhookService acts as a struct for injecting an implementation of IncomingWebhookRepository
+// for use in service methods
+type incomingWebhookService struct {
+	IncomingWebhookRepository model.IncomingWebhookRepository
+	UserRepository            model.UserRepository
+}
+
+// IWConfig will hold repositories that will eventually be injected into
+// this service layer
+type IWConfig struct {
+	IncomingWebhookRepository model.IncomingWebhookRepository
+	UserRepository            model.UserRepository
+}
+
+// NewIncomingWebhookService is a factory function for
+// initializing an IncomingWebhookService with its repository layer dependencies
+func NewIncomingWebhookService(c *IWConfig) model.IncomingWebhookService {
+	return &incomingWebhookService{
+		IncomingWebhookRepository: c.IncomingWebhookRepository,
+		UserRepository:            c.UserRepository,
+	}
+}
+
+func (w *incomingWebhookService) GetWebhooks(channelId string) (*[]model.IncomingWebhook, error) {
+	return w.IncomingWebhookRepository.FindByChannel(channelId)
+}
+
+func (w *incomingWebhookService) GetWebhook(webhookId string) (*model.IncomingWebhook, error) {
+	return w.IncomingWebhookRepository.FindByID(webhookId)
+}
+
+// CreateWebhook creates a webhook and its bot user for the given guild channel
+// and returns the plaintext token. The token can not be retrieved again afterwards.
+func (w *incomingWebhookService) CreateWebhook(channel *model.Channel, creatorId, name string) (*model.CreatedIncomingWebhook, error) {
+	if channel.GuildID == nil {
+		return nil, apperrors.NewBadRequest(apperrors.DMWebhookError)
+	}
+
+	count, err := w.IncomingWebhookRepository.CountByChannel(channel.ID)
+
+	if err != nil {
+		return nil, apperrors.NewInternal()
+	}
+
+	if count >= model.MaximumChannelWebhooks {
+		return nil, apperrors.NewBadRequest(apperrors.WebhookLimitError)
+	}
+
+	bot, err := newBotUser(name, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if bot, err = w.UserRepository.Create(bot); err != nil {
+		return nil, err
+	}
+
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateToken()
+
+	if err != nil {
+		log.Printf("Unable to generate a token for webhook: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	webhook := model.IncomingWebhook{
+		BaseModel: model.BaseModel{ID: id},
+		ChannelId: channel.ID,
+		GuildId:   *channel.GuildID,
+		UserId:    bot.ID,
+		CreatorId: creatorId,
+		Name:      name,
+		TokenHash: hashToken(secret),
+	}
+
+	if err = w.IncomingWebhookRepository.Create(&webhook); err != nil {
+		return nil, err
+	}
+
+	return &model.CreatedIncomingWebhook{
+		IncomingWebhook: webhook,
+		Token:           secret,
+	}, nil
+}
+
+func (w *incomingWebhookService) DeleteWebhook(webhook *model.IncomingWebhook) error {
+	return w.IncomingWebhookRepository.Delete(webhook)
+}
+
+// Authenticate returns the webhook if the token belongs to it.
+// Unknown webhooks and invalid tokens return the same error.
+func (w *incomingWebhookService) Authenticate(webhookId, token string) (*model.IncomingWebhook, error) {
+	webhook, err := w.IncomingWebhookRepository.FindByID(webhookId)
+
+	if err != nil {
+		return nil, apperrors.NewNotFound("webhook", webhookId)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(webhook.TokenHash), []byte(hashToken(token))) != 1 {
+		return nil, apperrors.NewNotFound("webhook", webhookId)
+	}
+
+	return webhook, nil
+}
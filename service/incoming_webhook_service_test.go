@@ -0,0 +1,115 @@
+package service
+
+import (
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestIncomingWebhookService_CreateWebhook(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel(fixture.RandID())
+		creatorId := fixture.RandID()
+
+		mockWebhookRepository := new(mocks.IncomingWebhookRepository)
+		mockWebhookRepository.On("CountByChannel", mockChannel.ID).Return(int64(0), nil)
+		mockWebhookRepository.On("Create", mock.AnythingOfType("*model.IncomingWebhook")).Return(nil)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("Create", mock.AnythingOfType("*model.User")).
+			Return(func(u *model.User) *model.User { return u }, nil)
+
+		iws := NewIncomingWebhookService(&IWConfig{
+			IncomingWebhookRepository: mockWebhookRepository,
+			UserRepository:            mockUserRepository,
+		})
+
+		webhook, err := iws.CreateWebhook(mockChannel, creatorId, "CI")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, webhook.Token)
+		assert.Equal(t, hashToken(webhook.Token), webhook.TokenHash)
+		assert.Equal(t, *mockChannel.GuildID, webhook.GuildId)
+		assert.Equal(t, creatorId, webhook.CreatorId)
+
+		bot := mockUserRepository.Calls[0].Arguments.Get(0).(*model.User)
+		assert.True(t, bot.IsBot)
+		assert.Nil(t, bot.OwnerId)
+		assert.Equal(t, bot.ID, webhook.UserId)
+		assert.Equal(t, "CI", bot.Username)
+	})
+
+	t.Run("DM channel", func(t *testing.T) {
+		mockWebhookRepository := new(mocks.IncomingWebhookRepository)
+
+		iws := NewIncomingWebhookService(&IWConfig{
+			IncomingWebhookRepository: mockWebhookRepository,
+		})
+
+		webhook, err := iws.CreateWebhook(fixture.GetMockDMChannel(), fixture.RandID(), "CI")
+
+		assert.Nil(t, webhook)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.DMWebhookError), err)
+		mockWebhookRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Webhook limit reached", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel(fixture.RandID())
+
+		mockWebhookRepository := new(mocks.IncomingWebhookRepository)
+		mockWebhookRepository.On("CountByChannel", mockChannel.ID).Return(int64(model.MaximumChannelWebhooks), nil)
+
+		mockUserRepository := new(mocks.UserRepository)
+
+		iws := NewIncomingWebhookService(&IWConfig{
+			IncomingWebhookRepository: mockWebhookRepository,
+			UserRepository:            mockUserRepository,
+		})
+
+		webhook, err := iws.CreateWebhook(mockChannel, fixture.RandID(), "CI")
+
+		assert.Nil(t, webhook)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.WebhookLimitError), err)
+		mockUserRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestIncomingWebhookService_Authenticate(t *testing.T) {
+	token := fixture.RandStr(43)
+	mockWebhook := &model.IncomingWebhook{
+		BaseModel: model.BaseModel{ID: fixture.RandID()},
+		TokenHash: hashToken(token),
+	}
+
+	t.Run("Valid token", func(t *testing.T) {
+		mockWebhookRepository := new(mocks.IncomingWebhookRepository)
+		mockWebhookRepository.On("FindByID", mockWebhook.ID).Return(mockWebhook, nil)
+
+		iws := NewIncomingWebhookService(&IWConfig{
+			IncomingWebhookRepository: mockWebhookRepository,
+		})
+
+		webhook, err := iws.Authenticate(mockWebhook.ID, token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockWebhook, webhook)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		mockWebhookRepository := new(mocks.IncomingWebhookRepository)
+		mockWebhookRepository.On("FindByID", mockWebhook.ID).Return(mockWebhook, nil)
+
+		iws := NewIncomingWebhookService(&IWConfig{
+			IncomingWebhookRepository: mockWebhookRepository,
+		})
+
+		webhook, err := iws.Authenticate(mockWebhook.ID, fixture.RandStr(43))
+
+		assert.Nil(t, webhook)
+		assert.Equal(t, apperrors.NewNotFound("webhook", mockWebhook.ID), err)
+	})
+}
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	gonanoid "github.com/matoous/go-nanoid"
 	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
 	"log"
 	"mime/multipart"
 	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // messageService acts as a struct for injecting an implementation of MessageRepository
@@ -17,6 +19,9 @@ import (
 type messageService struct {
 	MessageRepository model.MessageRepository
 	FileRepository    model.FileRepository
+	ChannelRepository model.ChannelRepository
+	GuildRepository   model.GuildRepository
+	RoleRepository    model.RoleRepository
 }
 
 // MSConfig will hold repositories that will eventually be injected into
@@ -24,6 +29,9 @@ type messageService struct {
 type MSConfig struct {
 	MessageRepository model.MessageRepository
 	FileRepository    model.FileRepository
+	ChannelRepository model.ChannelRepository
+	GuildRepository   model.GuildRepository
+	RoleRepository    model.RoleRepository
 }
 
 // NewMessageService is a factory function for
@@ -32,6 +40,9 @@ func NewMessageService(c *MSConfig) model.MessageService {
 	return &messageService{
 		MessageRepository: c.MessageRepository,
 		FileRepository:    c.FileRepository,
+		ChannelRepository: c.ChannelRepository,
+		GuildRepository:   c.GuildRepository,
+		RoleRepository:    c.RoleRepository,
 	}
 }
 
@@ -39,6 +50,17 @@ func (m *messageService) GetMessages(userId string, channel *model.Channel, curs
 	return m.MessageRepository.GetMessages(userId, channel, cursor)
 }
 
+// SearchMessages returns the messages that match the search.
+// Returns no messages if there are no channels to search in
+func (m *messageService) SearchMessages(userId string, search *model.MessageSearch) (*[]model.MessageResponse, error) {
+	if len(search.ChannelIds) == 0 {
+		return &[]model.MessageResponse{}, nil
+	}
+
+	return m.MessageRepository.SearchMessages(userId, search)
+}
+
+// CreateMessage stores the message and a mention for every user its text mentions
 func (m *messageService) CreateMessage(params *model.Message) (*model.Message, error) {
 	id, err := GenerateId()
 	if err != nil {
@@ -46,27 +68,178 @@ func (m *messageService) CreateMessage(params *model.Message) (*model.Message, e
 	}
 	params.ID = id
 
+	if params.Text != nil {
+		mentions, err := m.getMentions(params)
+
+		// A failed lookup should not prevent the message from being sent
+		if err != nil {
+			log.Printf("Failed to resolve the mentions of message: %v. Reason: %v\n", id, err)
+		}
+
+		params.Mentions = mentions
+	}
+
 	return m.MessageRepository.CreateMessage(params)
 }
 
-func (m *messageService) UpdateMessage(message *model.Message) error {
-	return m.MessageRepository.UpdateMessage(message)
+var mentionRegex = regexp.MustCompile(`<@(&?)(\d+)>`)
+
+// parseMentions returns the mentioned user and role ids of the text
+// and whether it mentions everyone
+func parseMentions(text string) (map[string]bool, []string, bool) {
+	userIds := make(map[string]bool)
+	roleIds := make([]string, 0)
+
+	for _, match := range mentionRegex.FindAllStringSubmatch(text, -1) {
+		if match[1] == "&" {
+			roleIds = append(roleIds, match[2])
+		} else {
+			userIds[match[2]] = true
+		}
+	}
+
+	return userIds, roleIds, strings.Contains(text, "@everyone")
 }
 
-func (m *messageService) DeleteMessage(message *model.Message) error {
-	if message.Attachment != nil {
-		if err := m.FileRepository.DeleteImage(message.Attachment.Filename); err != nil {
-			log.Printf("Error deleting file from S3: %s", err)
+// getMentions resolves the mentions of the message to the users that can see the message.
+// Role and everyone mentions require the MentionEveryone permission. The author never mentions themselves.
+func (m *messageService) getMentions(message *model.Message) ([]model.Mention, error) {
+	userIds, roleIds, everyone := parseMentions(*message.Text)
+
+	if len(userIds) == 0 && len(roleIds) == 0 && !everyone {
+		return nil, nil
+	}
+
+	channel, err := m.ChannelRepository.GetById(message.ChannelId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var mentioned []string
+
+	if channel.IsDM {
+		members, err := m.ChannelRepository.GetDMMemberIds(channel.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range *members {
+			if id != message.UserId && (everyone || userIds[id]) {
+				mentioned = append(mentioned, id)
+			}
+		}
+	} else {
+		mentioned, err = m.getGuildMentions(message, channel, userIds, roleIds, everyone)
+
+		if err != nil {
+			return nil, err
 		}
 	}
 
+	mentions := make([]model.Mention, 0)
+	for _, id := range mentioned {
+		mentions = append(mentions, model.Mention{
+			MessageId: message.ID,
+			UserId:    id,
+			ChannelId: channel.ID,
+			GuildId:   channel.GuildID,
+		})
+	}
+
+	return mentions, nil
+}
+
+// getGuildMentions returns the ids of the mentioned members that can view the channel
+func (m *messageService) getGuildMentions(
+	message *model.Message,
+	channel *model.Channel,
+	userIds map[string]bool,
+	roleIds []string,
+	everyone bool,
+) ([]string, error) {
+	guild, err := m.GuildRepository.FindByID(*channel.GuildID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Threads inherit the permissions of their parent channel
+	if channel.ParentId != nil {
+		if channel, err = m.ChannelRepository.GetById(*channel.ParentId); err != nil {
+			return nil, err
+		}
+	}
+
+	if everyone || len(roleIds) > 0 {
+		permissions, err := memberChannelPermissions(m.RoleRepository, guild, channel, message.UserId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !permissions.Has(model.MentionEveryone) {
+			everyone = false
+			roleIds = nil
+		}
+	}
+
+	if len(roleIds) > 0 {
+		members, err := m.RoleRepository.GetRoleMemberIds(guild.ID, roleIds)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range *members {
+			userIds[id] = true
+		}
+	}
+
+	// Only check the permissions of every member if not everyone can view the channel
+	restricted := !channel.IsPublic
+	for _, overwrite := range channel.Overwrites {
+		if overwrite.Deny&model.ViewChannel != 0 {
+			restricted = true
+		}
+	}
+
+	mentioned := make([]string, 0)
+	for _, member := range guild.Members {
+		if member.ID == message.UserId || (!everyone && !userIds[member.ID]) {
+			continue
+		}
+
+		if restricted {
+			permissions, err := memberChannelPermissions(m.RoleRepository, guild, channel, member.ID)
+
+			if err != nil || !permissions.Has(model.ViewChannel) {
+				continue
+			}
+		}
+
+		mentioned = append(mentioned, member.ID)
+	}
+
+	return mentioned, nil
+}
+
+func (m *messageService) UpdateMessage(message *model.Message) error {
+	return m.MessageRepository.UpdateMessage(message)
+}
+
+// DeleteMessage soft deletes the message. Its attachment is kept for the
+// message history until PurgeHistory removes the message.
+func (m *messageService) DeleteMessage(message *model.Message) error {
 	return m.MessageRepository.DeleteMessage(message)
 }
 
-func (m *messageService) UploadFile(header *multipart.FileHeader, channelId string) (*model.Attachment, error) {
+// UploadFile stores the attachment of the given type.
+// Metadata like the location gets removed from images first.
+func (m *messageService) UploadFile(header *multipart.FileHeader, channelId, mimetype string) (*model.Attachment, error) {
 
 	filename := formatName(header.Filename)
-	mimetype := header.Header.Get("Content-Type")
 
 	attachment := model.Attachment{
 		FileType: mimetype,
@@ -80,8 +253,23 @@ func (m *messageService) UploadFile(header *multipart.FileHeader, channelId stri
 
 	attachment.ID = id
 
+	file, err := header.Open()
+
+	if err != nil {
+		log.Printf("Failed to open header: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	defer file.Close()
+
+	content, err := stripMetadata(file, mimetype)
+
+	if err != nil {
+		return nil, err
+	}
+
 	directory := fmt.Sprintf("channels/%s", channelId)
-	url, err := m.FileRepository.UploadFile(header, directory, filename, mimetype)
+	url, err := m.FileRepository.UploadFile(content, directory, filename, mimetype)
 
 	if err != nil {
 		return nil, err
@@ -96,6 +284,88 @@ func (m *messageService) Get(messageId string) (*model.Message, error) {
 	return m.MessageRepository.GetById(messageId)
 }
 
+func (m *messageService) AddReaction(reaction *model.Reaction) error {
+	return m.MessageRepository.AddReaction(reaction)
+}
+
+func (m *messageService) RemoveReaction(reaction *model.Reaction) error {
+	return m.MessageRepository.RemoveReaction(reaction)
+}
+
+func (m *messageService) GetPinnedMessages(userId string, channel *model.Channel) (*[]model.MessageResponse, error) {
+	return m.MessageRepository.GetPinnedMessages(userId, channel)
+}
+
+// PinMessage pins the message in its channel unless the channel reached MaximumPins
+func (m *messageService) PinMessage(message *model.Message) error {
+	if message.PinnedAt != nil {
+		return nil
+	}
+
+	count, err := m.MessageRepository.CountPinnedMessages(message.ChannelId)
+
+	if err != nil {
+		return err
+	}
+
+	if count >= model.MaximumPins {
+		return apperrors.NewBadRequest(apperrors.PinLimitError)
+	}
+
+	now := time.Now()
+	message.PinnedAt = &now
+
+	return m.MessageRepository.SetPinned(message)
+}
+
+// UnpinMessage removes the message from the pins of its channel
+func (m *messageService) UnpinMessage(message *model.Message) error {
+	if message.PinnedAt == nil {
+		return nil
+	}
+
+	message.PinnedAt = nil
+
+	return m.MessageRepository.SetPinned(message)
+}
+
+func (m *messageService) GetHistory(messageId string) (*model.MessageHistory, error) {
+	return m.MessageRepository.GetHistory(messageId)
+}
+
+// PurgeHistory removes all message revisions and deleted messages that are older than the given retention.
+// The attachments of the removed messages get deleted from the storage as well.
+func (m *messageService) PurgeHistory(retention time.Duration) error {
+	date := time.Now().Add(-retention)
+	count, err := m.MessageRepository.DeleteRevisionsBefore(date)
+
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		log.Printf("Purged %d message revisions\n", count)
+	}
+
+	attachments, count, err := m.MessageRepository.DeleteMessagesBefore(date)
+
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range *attachments {
+		if err = m.FileRepository.DeleteImage(attachment.Url); err != nil {
+			log.Printf("Error deleting file: %s", err)
+		}
+	}
+
+	if count > 0 {
+		log.Printf("Purged %d deleted messages\n", count)
+	}
+
+	return nil
+}
+
 var re = regexp.MustCompile(`/[^a-z0-9]/g`)
 
 func formatName(filename string) string {
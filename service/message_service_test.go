@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"testing"
+	"time"
 )
 
 func TestGuildService_CreateMessage(t *testing.T) {
@@ -89,7 +90,7 @@ func TestGuildService_DeleteMessage(t *testing.T) {
 		mockMessageRepository.AssertExpectations(t)
 	})
 
-	t.Run("Success with attachment", func(t *testing.T) {
+	t.Run("Keeps the attachment for the history", func(t *testing.T) {
 		mockMessage := fixture.GetMockMessage("", "")
 		mockMessage.Attachment = &model.Attachment{
 			Filename: fixture.RandStr(12),
@@ -102,8 +103,6 @@ func TestGuildService_DeleteMessage(t *testing.T) {
 			FileRepository:    mockFileRepository,
 		})
 
-		mockFileRepository.On("DeleteImage", mockMessage.Attachment.Filename).Return(nil)
-
 		mockMessageRepository.
 			On("DeleteMessage", mockMessage).
 			Return(nil)
@@ -113,7 +112,7 @@ func TestGuildService_DeleteMessage(t *testing.T) {
 		assert.NoError(t, err)
 
 		mockMessageRepository.AssertExpectations(t)
-		mockFileRepository.AssertExpectations(t)
+		mockFileRepository.AssertNotCalled(t, "DeleteImage", mock.Anything)
 	})
 
 	t.Run("Error", func(t *testing.T) {
@@ -156,7 +155,7 @@ func TestMessageService_UploadFile(t *testing.T) {
 		}
 
 		uploadFileArgs := mock.Arguments{
-			imageFileHeader,
+			mock.Anything,
 			directory,
 			mock.AnythingOfType("string"),
 			attachment.FileType,
@@ -175,7 +174,7 @@ func TestMessageService_UploadFile(t *testing.T) {
 			FileRepository: mockFileRepository,
 		})
 
-		_, err = ms.UploadFile(imageFileHeader, channelId)
+		_, err = ms.UploadFile(imageFileHeader, channelId, attachment.FileType)
 		assert.NoError(t, err)
 
 		mockFileRepository.AssertExpectations(t)
@@ -195,7 +194,7 @@ func TestMessageService_UploadFile(t *testing.T) {
 		}
 
 		uploadFileArgs := mock.Arguments{
-			imageFileHeader,
+			mock.Anything,
 			directory,
 			mock.AnythingOfType("string"),
 			attachment.FileType,
@@ -212,7 +211,7 @@ func TestMessageService_UploadFile(t *testing.T) {
 			FileRepository: mockFileRepository,
 		})
 
-		att, err := ms.UploadFile(imageFileHeader, channelId)
+		att, err := ms.UploadFile(imageFileHeader, channelId, attachment.FileType)
 		assert.Error(t, err)
 		assert.Equal(t, err, apperrors.NewInternal())
 		assert.Nil(t, att)
@@ -220,3 +219,273 @@ func TestMessageService_UploadFile(t *testing.T) {
 		mockFileRepository.AssertExpectations(t)
 	})
 }
+
+func TestMessageService_SearchMessages(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		uid, _ := GenerateId()
+		mockMessage := fixture.GetMockMessageResponse("", "")
+
+		search := &model.MessageSearch{
+			Query:      fixture.RandStr(6),
+			ChannelIds: []string{fixture.RandID()},
+		}
+
+		mockMessageRepository := new(mocks.MessageRepository)
+		ms := NewMessageService(&MSConfig{
+			MessageRepository: mockMessageRepository,
+		})
+
+		mockMessageRepository.
+			On("SearchMessages", uid, search).
+			Return(&[]model.MessageResponse{*mockMessage}, nil)
+
+		messages, err := ms.SearchMessages(uid, search)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &[]model.MessageResponse{*mockMessage}, messages)
+
+		mockMessageRepository.AssertExpectations(t)
+	})
+
+	t.Run("No channels to search in", func(t *testing.T) {
+		uid, _ := GenerateId()
+
+		search := &model.MessageSearch{
+			Query:      fixture.RandStr(6),
+			ChannelIds: []string{},
+		}
+
+		mockMessageRepository := new(mocks.MessageRepository)
+		ms := NewMessageService(&MSConfig{
+			MessageRepository: mockMessageRepository,
+		})
+
+		messages, err := ms.SearchMessages(uid, search)
+
+		assert.NoError(t, err)
+		assert.Empty(t, *messages)
+
+		mockMessageRepository.AssertNotCalled(t, "SearchMessages", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMessageService_CreateMessage_Mentions(t *testing.T) {
+	author := fixture.GetMockUser()
+	member := fixture.GetMockUser()
+	other := fixture.GetMockUser()
+
+	t.Run("Mentions the user", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = []model.User{*author, *member, *other}
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		text := fmt.Sprintf("Hello <@%s> and <@%s>", member.ID, author.ID)
+		params := &model.Message{
+			UserId:    author.ID,
+			ChannelId: mockChannel.ID,
+			Text:      &text,
+		}
+
+		mockMessageRepository := new(mocks.MessageRepository)
+		mockChannelRepository := new(mocks.ChannelRepository)
+		mockGuildRepository := new(mocks.GuildRepository)
+		ms := NewMessageService(&MSConfig{
+			MessageRepository: mockMessageRepository,
+			ChannelRepository: mockChannelRepository,
+			GuildRepository:   mockGuildRepository,
+		})
+
+		mockChannelRepository.On("GetById", mockChannel.ID).Return(mockChannel, nil)
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockMessageRepository.On("CreateMessage", params).Return(params, nil)
+
+		message, err := ms.CreateMessage(params)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []model.Mention{{
+			MessageId: message.ID,
+			UserId:    member.ID,
+			ChannelId: mockChannel.ID,
+			GuildId:   mockChannel.GuildID,
+		}}, message.Mentions)
+
+		mockMessageRepository.AssertExpectations(t)
+	})
+
+	t.Run("Everyone requires the permission", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = []model.User{*author, *member, *other}
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		text := "Hello @everyone"
+		params := &model.Message{
+			UserId:    author.ID,
+			ChannelId: mockChannel.ID,
+			Text:      &text,
+		}
+
+		mockMessageRepository := new(mocks.MessageRepository)
+		mockChannelRepository := new(mocks.ChannelRepository)
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
+		ms := NewMessageService(&MSConfig{
+			MessageRepository: mockMessageRepository,
+			ChannelRepository: mockChannelRepository,
+			GuildRepository:   mockGuildRepository,
+			RoleRepository:    mockRoleRepository,
+		})
+
+		mockChannelRepository.On("GetById", mockChannel.ID).Return(mockChannel, nil)
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockRoleRepository.On("GetMemberRoles", author.ID, mockGuild.ID).Return(&[]model.Role{}, nil)
+		mockMessageRepository.On("CreateMessage", params).Return(params, nil)
+
+		message, err := ms.CreateMessage(params)
+
+		assert.NoError(t, err)
+		assert.Empty(t, message.Mentions)
+	})
+
+	t.Run("Everyone only reaches members that can view the channel", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(author.ID)
+		mockGuild.Members = []model.User{*author, *member, *other}
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockChannel.IsPublic = false
+		mockChannel.PCMembers = []model.User{*author, *member}
+
+		text := "Hello @everyone"
+		params := &model.Message{
+			UserId:    author.ID,
+			ChannelId: mockChannel.ID,
+			Text:      &text,
+		}
+
+		mockMessageRepository := new(mocks.MessageRepository)
+		mockChannelRepository := new(mocks.ChannelRepository)
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRoleRepository := new(mocks.RoleRepository)
+		ms := NewMessageService(&MSConfig{
+			MessageRepository: mockMessageRepository,
+			ChannelRepository: mockChannelRepository,
+			GuildRepository:   mockGuildRepository,
+			RoleRepository:    mockRoleRepository,
+		})
+
+		mockChannelRepository.On("GetById", mockChannel.ID).Return(mockChannel, nil)
+		mockGuildRepository.On("FindByID", mockGuild.ID).Return(mockGuild, nil)
+		mockRoleRepository.On("GetMemberRoles", mock.Anything, mockGuild.ID).Return(&[]model.Role{}, nil)
+		mockMessageRepository.On("CreateMessage", params).Return(params, nil)
+
+		message, err := ms.CreateMessage(params)
+
+		assert.NoError(t, err)
+		assert.Len(t, message.Mentions, 1)
+		assert.Equal(t, member.ID, message.Mentions[0].UserId)
+	})
+}
+
+func TestMessageService_PinMessage(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockMessage := fixture.GetMockMessage("", "")
+
+		mockMessageRepository := new(mocks.MessageRepository)
+		ms := NewMessageService(&MSConfig{
+			MessageRepository: mockMessageRepository,
+		})
+
+		mockMessageRepository.On("CountPinnedMessages", mockMessage.ChannelId).Return(int64(0), nil)
+		mockMessageRepository.On("SetPinned", mockMessage).Return(nil)
+
+		err := ms.PinMessage(mockMessage)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, mockMessage.PinnedAt)
+
+		mockMessageRepository.AssertExpectations(t)
+	})
+
+	t.Run("Pin limit reached", func(t *testing.T) {
+		mockMessage := fixture.GetMockMessage("", "")
+
+		mockMessageRepository := new(mocks.MessageRepository)
+		ms := NewMessageService(&MSConfig{
+			MessageRepository: mockMessageRepository,
+		})
+
+		mockMessageRepository.On("CountPinnedMessages", mockMessage.ChannelId).Return(int64(model.MaximumPins), nil)
+
+		err := ms.PinMessage(mockMessage)
+
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.PinLimitError), err)
+		assert.Nil(t, mockMessage.PinnedAt)
+
+		mockMessageRepository.AssertNotCalled(t, "SetPinned", mock.Anything)
+	})
+}
+
+func TestMessageService_PurgeHistory(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		retention := 30 * 24 * time.Hour
+		attachments := []model.Attachment{{Url: "https://cdn.example.com/file.png"}, {Url: "https://cdn.example.com/file.txt"}}
+		inRetention := mock.MatchedBy(func(date time.Time) bool {
+			return time.Since(date) >= retention && time.Since(date) < retention+time.Minute
+		})
+
+		mockMessageRepository := new(mocks.MessageRepository)
+		mockFileRepository := new(mocks.FileRepository)
+		ms := NewMessageService(&MSConfig{
+			MessageRepository: mockMessageRepository,
+			FileRepository:    mockFileRepository,
+		})
+
+		mockMessageRepository.On("DeleteRevisionsBefore", inRetention).Return(int64(3), nil)
+		mockMessageRepository.On("DeleteMessagesBefore", inRetention).Return(&attachments, int64(2), nil)
+		mockFileRepository.On("DeleteImage", attachments[0].Url).Return(nil)
+		mockFileRepository.On("DeleteImage", attachments[1].Url).Return(nil)
+
+		err := ms.PurgeHistory(retention)
+
+		assert.NoError(t, err)
+
+		mockMessageRepository.AssertExpectations(t)
+		mockFileRepository.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockErr := apperrors.NewInternal()
+
+		mockMessageRepository := new(mocks.MessageRepository)
+		ms := NewMessageService(&MSConfig{
+			MessageRepository: mockMessageRepository,
+		})
+
+		mockMessageRepository.On("DeleteRevisionsBefore", mock.AnythingOfType("time.Time")).Return(int64(0), mockErr)
+
+		err := ms.PurgeHistory(time.Hour)
+
+		assert.EqualError(t, err, mockErr.Error())
+
+		mockMessageRepository.AssertExpectations(t)
+		mockMessageRepository.AssertNotCalled(t, "DeleteMessagesBefore", mock.Anything)
+	})
+
+	t.Run("Keeps the files if the messages could not be removed", func(t *testing.T) {
+		mockErr := apperrors.NewInternal()
+
+		mockMessageRepository := new(mocks.MessageRepository)
+		mockFileRepository := new(mocks.FileRepository)
+		ms := NewMessageService(&MSConfig{
+			MessageRepository: mockMessageRepository,
+			FileRepository:    mockFileRepository,
+		})
+
+		mockMessageRepository.On("DeleteRevisionsBefore", mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+		mockMessageRepository.On("DeleteMessagesBefore", mock.AnythingOfType("time.Time")).Return(nil, int64(0), mockErr)
+
+		err := ms.PurgeHistory(time.Hour)
+
+		assert.EqualError(t, err, mockErr.Error())
+
+		mockFileRepository.AssertNotCalled(t, "DeleteImage", mock.Anything)
+	})
+}
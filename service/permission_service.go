@@ -0,0 +1,194 @@
+package service
+
+import (
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+)
+
+// permissionService acts as a struct for injecting an implementation of RoleRepository
+// for use in service methods
+type permissionService struct {
+	RoleRepository model.RoleRepository
+}
+
+// PSConfig will hold repositories that will eventually be injected into
+// this service layer
+type PSConfig struct {
+	RoleRepository model.RoleRepository
+}
+
+// NewPermissionService is a factory function for
+// initializing a PermissionService with its repository layer dependencies
+func NewPermissionService(c *PSConfig) model.PermissionService {
+	return &permissionService{
+		RoleRepository: c.RoleRepository,
+	}
+}
+
+func (p *permissionService) GetRoles(guildId string) (*[]model.RoleResponse, error) {
+	return p.RoleRepository.List(guildId)
+}
+
+func (p *permissionService) GetRole(roleId string) (*model.Role, error) {
+	return p.RoleRepository.GetById(roleId)
+}
+
+func (p *permissionService) CreateRole(role *model.Role) (*model.Role, error) {
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	role.ID = id
+
+	return p.RoleRepository.Create(role)
+}
+
+func (p *permissionService) UpdateRole(role *model.Role) error {
+	return p.RoleRepository.Save(role)
+}
+
+func (p *permissionService) DeleteRole(role *model.Role) error {
+	return p.RoleRepository.Delete(role)
+}
+
+func (p *permissionService) AddMemberRole(userId, guildId, roleId string) error {
+	return p.RoleRepository.AddMemberRole(userId, guildId, roleId)
+}
+
+func (p *permissionService) RemoveMemberRole(userId, guildId, roleId string) error {
+	return p.RoleRepository.RemoveMemberRole(userId, guildId, roleId)
+}
+
+// GetMemberPermissions returns the default permissions combined with the permissions
+// of the user's roles in the guild. The owner of the guild always has every permission.
+func (p *permissionService) GetMemberPermissions(userId string, guild *model.Guild) model.Permission {
+	if guild.OwnerId == userId {
+		return model.AllPermissions
+	}
+
+	permissions, err := p.RoleRepository.GetMemberPermissions(userId, guild.ID)
+
+	if err != nil {
+		log.Printf("Could not get the permissions of member %s: %v\n", userId, err)
+		return 0
+	}
+
+	return model.DefaultPermissions | permissions
+}
+
+// HasPermission checks if the given user has the permission in the guild
+func (p *permissionService) HasPermission(userId string, guild *model.Guild, permission model.Permission) bool {
+	return p.GetMemberPermissions(userId, guild).Has(permission)
+}
+
+// memberPermissions returns the guild wide permissions of the member and the ids of their roles.
+// Returns an error if the user is not a member of the guild
+func memberPermissions(roleRepository model.RoleRepository, guild *model.Guild, userId string) (model.Permission, []string, error) {
+	if guild.OwnerId == userId {
+		return model.AllPermissions, nil, nil
+	}
+
+	isMember := false
+	for _, member := range guild.Members {
+		if member.ID == userId {
+			isMember = true
+		}
+	}
+
+	if !isMember {
+		return 0, nil, apperrors.NewAuthorization(apperrors.NotAMember)
+	}
+
+	roles, err := roleRepository.GetMemberRoles(userId, guild.ID)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	permissions := model.DefaultPermissions
+	roleIds := make([]string, 0)
+	for _, role := range *roles {
+		permissions |= role.Permissions
+		roleIds = append(roleIds, role.ID)
+	}
+
+	return permissions, roleIds, nil
+}
+
+// memberChannelPermissions returns the permissions of the member in the given guild channel.
+// Returns an error if the user is not a member of the guild
+func memberChannelPermissions(roleRepository model.RoleRepository, guild *model.Guild, channel *model.Channel, userId string) (model.Permission, error) {
+	base, roleIds, err := memberPermissions(roleRepository, guild, userId)
+
+	if err != nil {
+		return 0, err
+	}
+
+	isPCMember := false
+	for _, member := range channel.PCMembers {
+		if member.ID == userId {
+			isPCMember = true
+		}
+	}
+
+	return computeChannelPermissions(base, userId, roleIds, channel, isPCMember), nil
+}
+
+// everyoneCanView checks if members without any roles or member overwrites can view the guild channel
+func everyoneCanView(channel *model.Channel) bool {
+	return computeChannelPermissions(model.DefaultPermissions, "", nil, channel, false).Has(model.ViewChannel)
+}
+
+// computeChannelPermissions applies the channel's overwrites to the base permissions of the member.
+// Private channels deny viewing to everyone except their members. Overwrites are applied
+// in the order @everyone, roles and member, so the more specific overwrite always wins.
+func computeChannelPermissions(base model.Permission, userId string, roleIds []string, channel *model.Channel, isPCMember bool) model.Permission {
+	if base.Has(model.Administrator) {
+		return model.AllPermissions
+	}
+
+	permissions := base
+	if !channel.IsPublic {
+		permissions &^= model.ViewChannel
+	}
+
+	roles := make(map[string]bool, len(roleIds))
+	for _, id := range roleIds {
+		roles[id] = true
+	}
+
+	var everyone, member *model.PermissionOverwrite
+	var roleAllow, roleDeny model.Permission
+	for i := range channel.Overwrites {
+		overwrite := &channel.Overwrites[i]
+		switch {
+		case overwrite.Type == model.RoleOverwrite && overwrite.TargetId == *channel.GuildID:
+			everyone = overwrite
+		case overwrite.Type == model.RoleOverwrite && roles[overwrite.TargetId]:
+			roleAllow |= overwrite.Allow
+			roleDeny |= overwrite.Deny
+		case overwrite.Type == model.MemberOverwrite && overwrite.TargetId == userId:
+			member = overwrite
+		}
+	}
+
+	if everyone != nil {
+		permissions = permissions&^everyone.Deny | everyone.Allow
+	}
+
+	permissions = permissions&^roleDeny | roleAllow
+
+	// Members of a private channel can always view it unless explicitly denied
+	if isPCMember {
+		permissions |= model.ViewChannel
+	}
+
+	if member != nil {
+		permissions = permissions&^member.Deny | member.Allow
+	}
+
+	return permissions
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"fmt"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestPermissionService_CreateRole(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRole := fixture.GetMockRole(fixture.RandID(), model.ManageMessages)
+
+		params := &model.Role{
+			GuildID:     mockRole.GuildID,
+			Name:        mockRole.Name,
+			Permissions: mockRole.Permissions,
+		}
+
+		mockRoleRepository := new(mocks.RoleRepository)
+		ps := NewPermissionService(&PSConfig{
+			RoleRepository: mockRoleRepository,
+		})
+
+		mockRoleRepository.
+			On("Create", params).
+			Run(func(args mock.Arguments) {
+				mockRole.ID = args.Get(0).(*model.Role).ID
+			}).Return(mockRole, nil)
+
+		role, err := ps.CreateRole(params)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, params.ID)
+		assert.Equal(t, params.ID, role.ID)
+
+		mockRoleRepository.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		params := &model.Role{
+			GuildID: fixture.RandID(),
+			Name:    fixture.RandStr(8),
+		}
+
+		mockRoleRepository := new(mocks.RoleRepository)
+		ps := NewPermissionService(&PSConfig{
+			RoleRepository: mockRoleRepository,
+		})
+
+		mockErr := apperrors.NewInternal()
+		mockRoleRepository.
+			On("Create", params).
+			Return(nil, mockErr)
+
+		role, err := ps.CreateRole(params)
+
+		assert.EqualError(t, err, mockErr.Error())
+		assert.Nil(t, role)
+	})
+}
+
+func TestPermissionService_GetMemberPermissions(t *testing.T) {
+	t.Run("Owner has all permissions", func(t *testing.T) {
+		uid, _ := GenerateId()
+		mockGuild := fixture.GetMockGuild(uid)
+
+		mockRoleRepository := new(mocks.RoleRepository)
+		ps := NewPermissionService(&PSConfig{
+			RoleRepository: mockRoleRepository,
+		})
+
+		permissions := ps.GetMemberPermissions(uid, mockGuild)
+
+		assert.Equal(t, model.AllPermissions, permissions)
+		mockRoleRepository.AssertNotCalled(t, "GetMemberPermissions", uid, mockGuild.ID)
+	})
+
+	t.Run("Member permissions", func(t *testing.T) {
+		uid, _ := GenerateId()
+		mockGuild := fixture.GetMockGuild("")
+
+		mockRoleRepository := new(mocks.RoleRepository)
+		ps := NewPermissionService(&PSConfig{
+			RoleRepository: mockRoleRepository,
+		})
+
+		expected := model.KickMembers | model.ManageMessages
+		mockRoleRepository.
+			On("GetMemberPermissions", uid, mockGuild.ID).
+			Return(expected, nil)
+
+		permissions := ps.GetMemberPermissions(uid, mockGuild)
+
+		assert.Equal(t, model.DefaultPermissions|expected, permissions)
+		mockRoleRepository.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		uid, _ := GenerateId()
+		mockGuild := fixture.GetMockGuild("")
+
+		mockRoleRepository := new(mocks.RoleRepository)
+		ps := NewPermissionService(&PSConfig{
+			RoleRepository: mockRoleRepository,
+		})
+
+		mockRoleRepository.
+			On("GetMemberPermissions", uid, mockGuild.ID).
+			Return(model.Permission(0), fmt.Errorf("some error"))
+
+		permissions := ps.GetMemberPermissions(uid, mockGuild)
+
+		assert.Equal(t, model.Permission(0), permissions)
+		mockRoleRepository.AssertExpectations(t)
+	})
+}
+
+func TestPermissionService_HasPermission(t *testing.T) {
+	uid, _ := GenerateId()
+	mockGuild := fixture.GetMockGuild("")
+
+	testCases := []struct {
+		name        string
+		permissions model.Permission
+		permission  model.Permission
+		expected    bool
+	}{
+		{
+			name:        "Has the permission",
+			permissions: model.KickMembers | model.BanMembers,
+			permission:  model.BanMembers,
+			expected:    true,
+		},
+		{
+			name:        "Missing the permission",
+			permissions: model.KickMembers,
+			permission:  model.BanMembers,
+			expected:    false,
+		},
+		{
+			name:        "Administrator has every permission",
+			permissions: model.Administrator,
+			permission:  model.ManageGuild,
+			expected:    true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			mockRoleRepository := new(mocks.RoleRepository)
+			ps := NewPermissionService(&PSConfig{
+				RoleRepository: mockRoleRepository,
+			})
+
+			mockRoleRepository.
+				On("GetMemberPermissions", uid, mockGuild.ID).
+				Return(tc.permissions, nil)
+
+			assert.Equal(t, tc.expected, ps.HasPermission(uid, mockGuild, tc.permission))
+		})
+	}
+}
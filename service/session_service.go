@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// sessionService acts as a struct for injecting an implementation of RedisRepository
+// for use in service methods
+type sessionService struct {
+	RedisRepository model.RedisRepository
+}
+
+// SNConfig will hold repositories that will eventually be injected into
+// this service layer
+type SNConfig struct {
+	RedisRepository model.RedisRepository
+}
+
+// NewSessionService is a factory function for
+// initializing a SessionService with its repository layer dependencies
+func NewSessionService(c *SNConfig) model.SessionService {
+	return &sessionService{
+		RedisRepository: c.RedisRepository,
+	}
+}
+
+// CreateSession starts a new session for the user on the device with the given user agent
+func (s *sessionService) CreateSession(ctx context.Context, userId, ip, userAgent string) (*model.Session, error) {
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &model.Session{
+		Id:        id,
+		Device:    parseDevice(userAgent),
+		IP:        ip,
+		UserAgent: userAgent,
+		CreatedAt: now,
+		LastUsed:  now,
+	}
+
+	if err = s.RedisRepository.SaveSession(ctx, userId, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// GetSessions returns the active sessions of the user, most recently used first
+func (s *sessionService) GetSessions(ctx context.Context, userId string) (*[]model.Session, error) {
+	sessions, err := s.RedisRepository.GetSessions(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(*sessions, func(i, j int) bool {
+		return (*sessions)[i].LastUsed.After((*sessions)[j].LastUsed)
+	})
+
+	return sessions, nil
+}
+
+// TouchSession checks that the session has not been revoked and
+// updates its last used time, which also extends its lifetime
+func (s *sessionService) TouchSession(ctx context.Context, userId, sessionId string) error {
+	session, err := s.RedisRepository.GetSession(ctx, userId, sessionId)
+
+	if err != nil {
+		if apperrors.Status(err) == http.StatusNotFound {
+			return apperrors.NewAuthorization(apperrors.InvalidSession)
+		}
+		return err
+	}
+
+	if time.Since(session.LastUsed) < model.SessionTouchInterval {
+		return nil
+	}
+
+	session.LastUsed = time.Now()
+
+	return s.RedisRepository.SaveSession(ctx, userId, session)
+}
+
+// RevokeSession ends the given session of the user
+func (s *sessionService) RevokeSession(ctx context.Context, userId, sessionId string) error {
+	if _, err := s.RedisRepository.GetSession(ctx, userId, sessionId); err != nil {
+		return err
+	}
+
+	return s.RedisRepository.DeleteSession(ctx, userId, sessionId)
+}
+
+// RevokeSessions ends all sessions of the user except the one with the exceptId
+// and returns the IDs of the revoked sessions
+func (s *sessionService) RevokeSessions(ctx context.Context, userId, exceptId string) ([]string, error) {
+	sessions, err := s.RedisRepository.GetSessions(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := make([]string, 0)
+	for _, session := range *sessions {
+		if session.Id == exceptId {
+			continue
+		}
+
+		if err = s.RedisRepository.DeleteSession(ctx, userId, session.Id); err != nil {
+			return revoked, err
+		}
+		revoked = append(revoked, session.Id)
+	}
+
+	return revoked, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestSessionService_CreateSession(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		userId := fixture.RandID()
+		userAgent := "Mozilla/5.0 (X11; Linux x86_64; rv:92.0) Gecko/20100101 Firefox/92.0"
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("SaveSession", mock.Anything, userId, mock.AnythingOfType("*model.Session")).Return(nil)
+
+		ss := NewSessionService(&SNConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		session, err := ss.CreateSession(context.Background(), userId, "127.0.0.1", userAgent)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, session.Id)
+		assert.Equal(t, "Firefox on Linux", session.Device)
+		assert.Equal(t, "127.0.0.1", session.IP)
+		assert.Equal(t, userAgent, session.UserAgent)
+		mockRedisRepository.AssertExpectations(t)
+	})
+}
+
+func TestSessionService_GetSessions(t *testing.T) {
+	t.Run("Most recently used first", func(t *testing.T) {
+		userId := fixture.RandID()
+		now := time.Now()
+		mockSessions := &[]model.Session{
+			{Id: "older", LastUsed: now.Add(-time.Hour)},
+			{Id: "newer", LastUsed: now},
+		}
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("GetSessions", mock.Anything, userId).Return(mockSessions, nil)
+
+		ss := NewSessionService(&SNConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		sessions, err := ss.GetSessions(context.Background(), userId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "newer", (*sessions)[0].Id)
+		assert.Equal(t, "older", (*sessions)[1].Id)
+	})
+}
+
+func TestSessionService_TouchSession(t *testing.T) {
+	userId := fixture.RandID()
+
+	t.Run("Updates the last used time", func(t *testing.T) {
+		session := &model.Session{Id: "session", LastUsed: time.Now().Add(-time.Hour)}
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("GetSession", mock.Anything, userId, session.Id).Return(session, nil)
+		mockRedisRepository.On("SaveSession", mock.Anything, userId, session).Return(nil)
+
+		ss := NewSessionService(&SNConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		err := ss.TouchSession(context.Background(), userId, session.Id)
+
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), session.LastUsed, time.Second)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Recently used sessions are not saved", func(t *testing.T) {
+		session := &model.Session{Id: "session", LastUsed: time.Now()}
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("GetSession", mock.Anything, userId, session.Id).Return(session, nil)
+
+		ss := NewSessionService(&SNConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		err := ss.TouchSession(context.Background(), userId, session.Id)
+
+		assert.NoError(t, err)
+		mockRedisRepository.AssertNotCalled(t, "SaveSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Revoked session", func(t *testing.T) {
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("GetSession", mock.Anything, userId, "revoked").
+			Return(nil, apperrors.NewNotFound("session", "revoked"))
+
+		ss := NewSessionService(&SNConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		err := ss.TouchSession(context.Background(), userId, "revoked")
+
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidSession), err)
+	})
+}
+
+func TestSessionService_RevokeSessions(t *testing.T) {
+	t.Run("Keeps the current session", func(t *testing.T) {
+		userId := fixture.RandID()
+		mockSessions := &[]model.Session{{Id: "current"}, {Id: "laptop"}, {Id: "phone"}}
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("GetSessions", mock.Anything, userId).Return(mockSessions, nil)
+		mockRedisRepository.On("DeleteSession", mock.Anything, userId, "laptop").Return(nil)
+		mockRedisRepository.On("DeleteSession", mock.Anything, userId, "phone").Return(nil)
+
+		ss := NewSessionService(&SNConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		revoked, err := ss.RevokeSessions(context.Background(), userId, "current")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"laptop", "phone"}, revoked)
+		mockRedisRepository.AssertExpectations(t)
+		mockRedisRepository.AssertNotCalled(t, "DeleteSession", mock.Anything, userId, "current")
+	})
+}
+
+func TestParseDevice(t *testing.T) {
+	agents := map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/94.0.4606.61 Safari/537.36":                      "Chrome on Windows",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/94.0.4606.61 Safari/537.36 Edg/94.0.992.31":      "Edge on Windows",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.0 Safari/605.1.15":                   "Safari on macOS",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 15_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.0 Mobile/15E148 Safari/604.1": "Safari on iOS",
+		"curl/7.79.1": "Unknown browser",
+	}
+
+	for agent, device := range agents {
+		assert.Equal(t, device, parseDevice(agent))
+	}
+}
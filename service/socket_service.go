@@ -11,6 +11,7 @@ type socketService struct {
 	Hub               ws.Hub
 	GuildRepository   model.GuildRepository
 	ChannelRepository model.ChannelRepository
+	WebhookService    model.WebhookService
 }
 
 // SSConfig will hold repositories that will eventually be injected into
@@ -19,6 +20,7 @@ type SSConfig struct {
 	Hub               ws.Hub
 	GuildRepository   model.GuildRepository
 	ChannelRepository model.ChannelRepository
+	WebhookService    model.WebhookService
 }
 
 // NewSocketService is a factory function for
@@ -28,6 +30,7 @@ func NewSocketService(c *SSConfig) model.SocketService {
 		Hub:               c.Hub,
 		GuildRepository:   c.GuildRepository,
 		ChannelRepository: c.ChannelRepository,
+		WebhookService:    c.WebhookService,
 	}
 }
 
@@ -42,6 +45,8 @@ func (s *socketService) EmitNewMessage(room string, message *model.MessageRespon
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchChannelEvent(room, ws.NewMessageAction, message)
 }
 
 func (s *socketService) EmitEditMessage(room string, message *model.MessageResponse) {
@@ -55,6 +60,8 @@ func (s *socketService) EmitEditMessage(room string, message *model.MessageRespo
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchChannelEvent(room, ws.EditMessageAction, message)
 }
 
 func (s *socketService) EmitDeleteMessage(room, messageId string) {
@@ -68,6 +75,53 @@ func (s *socketService) EmitDeleteMessage(room, messageId string) {
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchChannelEvent(room, ws.DeleteMessageAction, messageId)
+}
+
+func (s *socketService) EmitAddReaction(room string, reaction *model.Reaction) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.AddReactionAction,
+		Data:   reaction,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchChannelEvent(room, ws.AddReactionAction, reaction)
+}
+
+func (s *socketService) EmitRemoveReaction(room string, reaction *model.Reaction) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.RemoveReactionAction,
+		Data:   reaction,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchChannelEvent(room, ws.RemoveReactionAction, reaction)
+}
+
+func (s *socketService) EmitPinsUpdate(room string, update *model.PinsUpdate) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.PinsUpdateAction,
+		Data:   update,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchChannelEvent(room, ws.PinsUpdateAction, update)
 }
 
 func (s *socketService) EmitNewChannel(room string, channel *model.ChannelResponse) {
@@ -81,6 +135,8 @@ func (s *socketService) EmitNewChannel(room string, channel *model.ChannelRespon
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchGuildEvent(room, ws.AddChannelAction, channel)
 }
 
 func (s *socketService) EmitNewPrivateChannel(members []string, channel *model.ChannelResponse) {
@@ -96,6 +152,8 @@ func (s *socketService) EmitNewPrivateChannel(members []string, channel *model.C
 	for _, id := range members {
 		s.Hub.BroadcastToRoom(data, id)
 	}
+
+	s.dispatchChannelEvent(channel.Id, ws.AddPrivateChannelAction, channel)
 }
 
 func (s *socketService) EmitEditChannel(room string, channel *model.ChannelResponse) {
@@ -109,6 +167,11 @@ func (s *socketService) EmitEditChannel(room string, channel *model.ChannelRespo
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	// Members or overwrites might have changed, so not everyone may view the channel anymore
+	s.Hub.RecheckChannelSubscriptions(channel.Id)
+
+	s.dispatchGuildEvent(room, ws.EditChannelAction, channel)
 }
 
 func (s *socketService) EmitDeleteChannel(channel *model.Channel) {
@@ -122,6 +185,23 @@ func (s *socketService) EmitDeleteChannel(channel *model.Channel) {
 	}
 
 	s.Hub.BroadcastToRoom(data, *channel.GuildID)
+
+	s.dispatchGuildEvent(*channel.GuildID, ws.DeleteChannelAction, channel.ID)
+}
+
+func (s *socketService) EmitAddThread(room string, thread *model.ChannelResponse) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.AddThreadAction,
+		Data:   thread,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchChannelEvent(room, ws.AddThreadAction, thread)
 }
 
 func (s *socketService) EmitEditGuild(guild *model.Guild) {
@@ -146,6 +226,8 @@ func (s *socketService) EmitEditGuild(guild *model.Guild) {
 	for _, id := range *members {
 		s.Hub.BroadcastToRoom(data, id)
 	}
+
+	s.dispatchGuildEvent(guild.ID, ws.EditGuildAction, response)
 }
 
 func (s *socketService) EmitDeleteGuild(guildId string, members []string) {
@@ -183,6 +265,7 @@ func (s *socketService) EmitAddMember(room string, member *model.User) {
 		Username:  member.Username,
 		Image:     member.Image,
 		IsOnline:  member.IsOnline,
+		IsBot:     member.IsBot,
 		CreatedAt: member.CreatedAt,
 		UpdatedAt: member.UpdatedAt,
 		IsFriend:  false,
@@ -198,6 +281,8 @@ func (s *socketService) EmitAddMember(room string, member *model.User) {
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchGuildEvent(room, ws.AddMemberAction, response)
 }
 
 func (s *socketService) EmitRemoveMember(room, memberId string) {
@@ -211,6 +296,60 @@ func (s *socketService) EmitRemoveMember(room, memberId string) {
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	// The member's connections must not receive the guild's events anymore
+	s.Hub.RevokeGuildSubscriptions(room, memberId)
+
+	s.dispatchGuildEvent(room, ws.RemoveMemberAction, memberId)
+}
+
+func (s *socketService) EmitAddRole(guildId string, role *model.RoleResponse) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.AddRoleAction,
+		Data:   role,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, guildId)
+
+	s.dispatchGuildEvent(guildId, ws.AddRoleAction, role)
+}
+
+func (s *socketService) EmitEditRole(guildId string, role *model.RoleResponse) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.EditRoleAction,
+		Data:   role,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, guildId)
+
+	s.Hub.RecheckGuildSubscriptions(guildId)
+
+	s.dispatchGuildEvent(guildId, ws.EditRoleAction, role)
+}
+
+func (s *socketService) EmitDeleteRole(guildId, roleId string) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.DeleteRoleAction,
+		Data:   roleId,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, guildId)
+
+	s.Hub.RecheckGuildSubscriptions(guildId)
+
+	s.dispatchGuildEvent(guildId, ws.DeleteRoleAction, roleId)
 }
 
 func (s *socketService) EmitNewDMNotification(channelId string, user *model.User) {
@@ -290,6 +429,58 @@ func (s *socketService) EmitNewNotification(guildId, channelId string) {
 	s.Hub.BroadcastToRoom(notification, guildId)
 }
 
+func (s *socketService) EmitNewMention(userId string, mention *model.Mention) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.NewMentionAction,
+		Data:   mention,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, userId)
+}
+
+func (s *socketService) EmitAck(userId string, state *model.ReadState) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.AckAction,
+		Data:   state,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, userId)
+}
+
+func (s *socketService) EmitInteraction(botId string, interaction *model.Interaction) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.InteractionAction,
+		Data:   interaction,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, botId)
+}
+
+func (s *socketService) EmitEphemeralMessage(userId string, message *model.EphemeralMessage) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.EphemeralMessageAction,
+		Data:   message,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, userId)
+}
+
 func (s *socketService) EmitSendRequest(room string) {
 	data, err := json.Marshal(model.WebsocketMessage{
 		Action: ws.SendRequestAction,
@@ -379,3 +570,67 @@ func (s *socketService) EmitRemoveFriend(userId, memberId string) {
 
 	s.Hub.BroadcastToRoom(data, memberId)
 }
+
+// StopTyping removes the typing indicator of the user in the channel
+func (s *socketService) StopTyping(channelId, userId string) {
+	s.Hub.StopTyping(channelId, userId)
+}
+
+// RecheckSubscriptions removes the connections from the channels of the guild
+// they cannot view anymore, e.g. after the roles of a member changed
+func (s *socketService) RecheckSubscriptions(guildId string) {
+	s.Hub.RecheckGuildSubscriptions(guildId)
+}
+
+// CloseSessions closes all websocket connections opened with the given sessions
+func (s *socketService) CloseSessions(sessionIds []string) {
+	for _, id := range sessionIds {
+		s.Hub.CloseSession(id)
+	}
+}
+
+// dispatchGuildEvent forwards the event to the webhooks of the guild.
+// Deliveries are queued in the background so emitting never blocks the request.
+func (s *socketService) dispatchGuildEvent(guildId, event string, data interface{}) {
+	if s.WebhookService == nil {
+		return
+	}
+
+	go s.WebhookService.Dispatch(guildId, event, data)
+}
+
+// dispatchChannelEvent forwards the event to the webhooks of the guild the channel belongs to.
+// Events of DM channels are not forwarded.
+func (s *socketService) dispatchChannelEvent(channelId, event string, data interface{}) {
+	if s.WebhookService == nil {
+		return
+	}
+
+	go s.forwardChannelEvent(channelId, event, data)
+}
+
+// forwardChannelEvent sends the event to the webhooks of the channel's guild.
+// Managing webhooks does not require access to every channel, so only events
+// of channels that every member can view are forwarded.
+func (s *socketService) forwardChannelEvent(channelId, event string, data interface{}) {
+	channel, err := s.ChannelRepository.GetById(channelId)
+
+	if err != nil || channel.GuildID == nil {
+		return
+	}
+
+	guildId := *channel.GuildID
+
+	// Threads inherit the permissions of their parent channel
+	if channel.ParentId != nil {
+		if channel, err = s.ChannelRepository.GetById(*channel.ParentId); err != nil {
+			return
+		}
+	}
+
+	if !everyoneCanView(channel) {
+		return
+	}
+
+	s.WebhookService.Dispatch(guildId, event, data)
+}
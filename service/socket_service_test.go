@@ -0,0 +1,89 @@
+package service
+
+import (
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestSocketService_ForwardChannelEvent(t *testing.T) {
+	guildId := fixture.RandID()
+	roleId := fixture.RandID()
+	event := "new_message"
+	data := "message"
+
+	private := fixture.GetMockChannel(guildId)
+	private.IsPublic = false
+
+	denied := fixture.GetMockChannel(guildId)
+	denied.Overwrites = []model.PermissionOverwrite{
+		{ChannelId: denied.ID, TargetId: guildId, Type: model.RoleOverwrite, Deny: model.ViewChannel},
+		{ChannelId: denied.ID, TargetId: roleId, Type: model.RoleOverwrite, Allow: model.ViewChannel},
+	}
+
+	testCases := []struct {
+		name    string
+		channel *model.Channel
+		// The parent channel if the channel is a thread
+		parent     *model.Channel
+		dispatched bool
+	}{
+		{
+			name:       "Public channel",
+			channel:    fixture.GetMockChannel(guildId),
+			dispatched: true,
+		},
+		{
+			name:    "Private channel",
+			channel: private,
+		},
+		{
+			name:    "Channel that @everyone cannot view",
+			channel: denied,
+		},
+		{
+			name:       "Thread of a public channel",
+			channel:    fixture.GetMockChannel(guildId),
+			parent:     fixture.GetMockChannel(guildId),
+			dispatched: true,
+		},
+		{
+			name:    "Thread of a private channel",
+			channel: fixture.GetMockChannel(guildId),
+			parent:  private,
+		},
+		{
+			name:    "DM channel",
+			channel: fixture.GetMockDMChannel(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockChannelRepository := new(mocks.ChannelRepository)
+			mockWebhookService := new(mocks.WebhookService)
+			ss := &socketService{
+				ChannelRepository: mockChannelRepository,
+				WebhookService:    mockWebhookService,
+			}
+
+			channel := *tc.channel
+			if tc.parent != nil {
+				channel.ParentId = &tc.parent.ID
+				mockChannelRepository.On("GetById", tc.parent.ID).Return(tc.parent, nil)
+			}
+			mockChannelRepository.On("GetById", channel.ID).Return(&channel, nil)
+			mockWebhookService.On("Dispatch", guildId, event, data).Return()
+
+			ss.forwardChannelEvent(channel.ID, event, data)
+
+			if tc.dispatched {
+				mockWebhookService.AssertCalled(t, "Dispatch", guildId, event, data)
+			} else {
+				mockWebhookService.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
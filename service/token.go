@@ -0,0 +1,65 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+)
+
+// generateToken returns a random URL-safe token with 256 bits of entropy
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hex-encoded SHA-256 hash of the token.
+// Tokens are random, so they do not need a salted and slow hash like passwords.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// newBotUser returns a bot account with the given username.
+// Bots have no usable email or password and have to authenticate using a token.
+func newBotUser(username string, ownerId *string) (*model.User, error) {
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateToken()
+
+	if err != nil {
+		log.Printf("Unable to generate a password for bot: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	hashedPassword, err := hashPassword(secret)
+
+	if err != nil {
+		log.Printf("Unable to hash the password for bot: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	bot := &model.User{
+		BaseModel: model.BaseModel{ID: id},
+		Username:  username,
+		Email:     fmt.Sprintf("%s@bots.invalid", id),
+		Password:  hashedPassword,
+		IsBot:     true,
+		OwnerId:   ownerId,
+	}
+	bot.Image = generateAvatar(bot.Email)
+
+	return bot, nil
+}
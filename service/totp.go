@@ -0,0 +1,122 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters (RFC 6238). These are the defaults of all common authenticator apps.
+const (
+	totpPeriod = 30
+	totpDigits = 6
+	// totpSkew is the amount of time steps before and after the current one that are accepted
+	totpSkew = 1
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateTotpSecret returns a random base32 encoded secret with 160 bits of entropy
+func generateTotpSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base32NoPadding.EncodeToString(b), nil
+}
+
+// totpUri returns the otpauth:// provisioning URI for the given account
+func totpUri(account, secret string) string {
+	label := url.PathEscape(fmt.Sprintf("%s:%s", model.MfaIssuer, account))
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", model.MfaIssuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// totpCode returns the code of the secret for the given time step (RFC 4226)
+func totpCode(secret string, step int64) (string, error) {
+	key, err := base32NoPadding.DecodeString(strings.ToUpper(secret))
+
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000), nil
+}
+
+// validateTotp checks the code against the current time step and the steps within the skew.
+// It returns the matching time step.
+func validateTotp(secret, code string, now time.Time) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := totpCode(secret, step)
+
+		if err != nil {
+			return 0, false
+		}
+
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// recoveryCodeAlphabet omits characters that are easily confused
+const recoveryCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// generateRecoveryCodes returns model.RecoveryCodeCount codes of the form XXXXX-XXXXX and their hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, model.RecoveryCodeCount)
+	hashes := make([]string, model.RecoveryCodeCount)
+
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+
+		codes[i] = fmt.Sprintf("%s-%s", b[:5], b[5:])
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode normalizes the code and returns its hash
+func hashRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	return hashToken(code)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the base32 encoded SHA1 secret of the RFC 6238 test vectors
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTotpCode(t *testing.T) {
+	// The RFC uses 8 digits, so the expected codes are the last 6 digits
+	testCases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1234567890:  "005924",
+		20000000000: "353130",
+	}
+
+	for timestamp, expected := range testCases {
+		code, err := totpCode(rfcSecret, timestamp/totpPeriod)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code)
+	}
+}
+
+func TestValidateTotp(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	current := now.Unix() / totpPeriod
+
+	code, _ := totpCode(rfcSecret, current)
+	step, valid := validateTotp(rfcSecret, code, now)
+	assert.True(t, valid)
+	assert.Equal(t, current, step)
+
+	// Codes of the previous step are accepted to allow for clock drift
+	previous, _ := totpCode(rfcSecret, current-1)
+	step, valid = validateTotp(rfcSecret, previous, now)
+	assert.True(t, valid)
+	assert.Equal(t, current-1, step)
+
+	expired, _ := totpCode(rfcSecret, current-2)
+	_, valid = validateTotp(rfcSecret, expired, now)
+	assert.False(t, valid)
+
+	_, valid = validateTotp(rfcSecret, "12345", now)
+	assert.False(t, valid)
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes()
+
+	assert.NoError(t, err)
+	assert.Len(t, codes, len(hashes))
+
+	for i, code := range codes {
+		assert.Len(t, code, 11)
+		assert.Equal(t, hashes[i], hashRecoveryCode(code))
+		// Codes are accepted without the dash and in lowercase
+		assert.Equal(t, hashes[i], hashRecoveryCode(strings.ToLower(strings.ReplaceAll(code, "-", ""))))
+	}
+}
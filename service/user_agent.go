@@ -0,0 +1,46 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Checked in order, as e.g. Edge and Chrome also contain "Safari" in their user agents
+var browsers = []struct{ token, name string }{
+	{"Edg/", "Edge"},
+	{"OPR/", "Opera"},
+	{"Firefox/", "Firefox"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+	{"okhttp", "Android App"},
+	{"CFNetwork", "iOS App"},
+}
+
+var systems = []struct{ token, name string }{
+	{"Android", "Android"},
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"CrOS", "ChromeOS"},
+	{"Linux", "Linux"},
+}
+
+// parseDevice returns a readable description like "Firefox on Linux" for the user agent
+func parseDevice(userAgent string) string {
+	browser := "Unknown browser"
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+
+	for _, s := range systems {
+		if strings.Contains(userAgent, s.token) {
+			return fmt.Sprintf("%s on %s", browser, s.name)
+		}
+	}
+
+	return browser
+}
@@ -10,6 +10,7 @@ import (
 	"log"
 	"mime/multipart"
 	"strings"
+	"time"
 )
 
 // UserService acts as a struct for injecting an implementation of UserRepository
@@ -19,6 +20,7 @@ type userService struct {
 	FileRepository  model.FileRepository
 	RedisRepository model.RedisRepository
 	MailRepository  model.MailRepository
+	TokenRepository model.TokenRepository
 }
 
 // USConfig will hold repositories that will eventually be injected into
@@ -28,6 +30,7 @@ type USConfig struct {
 	FileRepository  model.FileRepository
 	RedisRepository model.RedisRepository
 	MailRepository  model.MailRepository
+	TokenRepository model.TokenRepository
 }
 
 // NewUserService is a factory function for
@@ -38,6 +41,7 @@ func NewUserService(c *USConfig) model.UserService {
 		FileRepository:  c.FileRepository,
 		RedisRepository: c.RedisRepository,
 		MailRepository:  c.MailRepository,
+		TokenRepository: c.TokenRepository,
 	}
 }
 
@@ -90,6 +94,11 @@ func (s *userService) Login(email, password string) (*model.User, error) {
 		return nil, apperrors.NewAuthorization(apperrors.InvalidCredentials)
 	}
 
+	// Bots can only authenticate using their tokens
+	if user.IsBot {
+		return nil, apperrors.NewAuthorization(apperrors.InvalidCredentials)
+	}
+
 	// verify
 	match, err := comparePasswords(user.Password, password)
 
@@ -157,7 +166,7 @@ func (s *userService) ForgotPassword(ctx context.Context, user *model.User) erro
 		return err
 	}
 
-	return s.MailRepository.SendResetMail(user.Email, token)
+	return s.MailRepository.SendResetMail(user.Email, token, user.Locale)
 }
 
 func (s *userService) ResetPassword(ctx context.Context, password string, token string) (*model.User, error) {
@@ -181,6 +190,76 @@ func (s *userService) ResetPassword(ctx context.Context, password string, token
 	}
 
 	user.Password = hashedPassword
+	// The reset link proves the ownership of the email
+	user.EmailVerified = true
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SendVerificationMail sends a link to verify the user's current email
+func (s *userService) SendVerificationMail(ctx context.Context, user *model.User) error {
+	token, err := s.RedisRepository.SetVerificationToken(ctx, user.ID, user.Email)
+
+	if err != nil {
+		return err
+	}
+
+	if err = s.MailRepository.SendVerificationMail(user.Email, token, user.Locale); err != nil {
+		log.Printf("Unable to send the verification mail: %v\n", err)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// ResendVerificationMail sends another verification mail
+// if the user did not request one within model.VerificationMailInterval
+func (s *userService) ResendVerificationMail(ctx context.Context, user *model.User) error {
+	if user.EmailVerified {
+		return apperrors.NewBadRequest(apperrors.EmailVerified)
+	}
+
+	ok, err := s.RedisRepository.ThrottleVerificationMail(ctx, user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return apperrors.NewTooManyRequests(apperrors.VerifyMailThrottled)
+	}
+
+	return s.SendVerificationMail(ctx, user)
+}
+
+// VerifyEmail marks the email of the token's user as verified.
+// Tokens become invalid if the user changed their email in the meantime.
+func (s *userService) VerifyEmail(ctx context.Context, token string) (*model.User, error) {
+	id, email, err := s.RedisRepository.UseVerificationToken(ctx, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepository.FindByID(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Email != email {
+		return nil, apperrors.NewBadRequest(apperrors.InvalidVerifyToken)
+	}
+
+	if user.EmailVerified {
+		return user, nil
+	}
+
+	user.EmailVerified = true
 
 	if err = s.UserRepository.Update(user); err != nil {
 		return nil, err
@@ -197,6 +276,333 @@ func (s *userService) GetRequestCount(userId string) (*int64, error) {
 	return s.UserRepository.GetRequestCount(userId)
 }
 
+func (s *userService) GetBots(ownerId string) (*[]model.User, error) {
+	return s.UserRepository.FindBots(ownerId)
+}
+
+// CreateBot creates a bot account owned by the given user
+func (s *userService) CreateBot(ownerId, username string) (*model.User, error) {
+	count, err := s.UserRepository.CountBots(ownerId)
+
+	if err != nil {
+		return nil, apperrors.NewInternal()
+	}
+
+	if count >= model.MaximumBots {
+		return nil, apperrors.NewBadRequest(apperrors.BotLimitError)
+	}
+
+	bot, err := newBotUser(username, &ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.UserRepository.Create(bot)
+}
+
+func (s *userService) GetTokens(ownerId string) (*[]model.APIToken, error) {
+	return s.TokenRepository.FindByOwner(ownerId)
+}
+
+// CreateToken creates a new token for the given user and returns the plaintext token.
+// The token can not be retrieved again afterwards.
+func (s *userService) CreateToken(user *model.User, name string) (*model.CreatedToken, error) {
+	ownerId := user.ID
+	if user.IsBot && user.OwnerId != nil {
+		ownerId = *user.OwnerId
+	}
+
+	tokens, err := s.TokenRepository.FindByOwner(ownerId)
+
+	if err != nil {
+		return nil, apperrors.NewInternal()
+	}
+
+	if len(*tokens) >= model.MaximumTokens {
+		return nil, apperrors.NewBadRequest(apperrors.TokenLimitError)
+	}
+
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateToken()
+
+	if err != nil {
+		log.Printf("Unable to generate a token for user: %v\n", user.ID)
+		return nil, apperrors.NewInternal()
+	}
+
+	token := model.APIToken{
+		BaseModel: model.BaseModel{ID: id},
+		UserId:    user.ID,
+		Name:      name,
+		TokenHash: hashToken(secret),
+	}
+
+	if err = s.TokenRepository.Create(&token); err != nil {
+		return nil, err
+	}
+
+	return &model.CreatedToken{
+		APIToken: token,
+		Token:    secret,
+	}, nil
+}
+
+// RevokeToken deletes the token if it belongs to the given user or one of their bots
+func (s *userService) RevokeToken(ownerId, tokenId string) error {
+	token, err := s.TokenRepository.FindByID(tokenId)
+
+	if err != nil {
+		return err
+	}
+
+	if token.UserId != ownerId {
+		user, err := s.UserRepository.FindByID(token.UserId)
+
+		if err != nil || user.OwnerId == nil || *user.OwnerId != ownerId {
+			return apperrors.NewNotFound("token", tokenId)
+		}
+	}
+
+	return s.TokenRepository.Delete(token)
+}
+
+// Authenticate returns the user the token belongs to.
+// The scheme has to match the account type, so bot tokens can't be used as bearer tokens and vice versa.
+func (s *userService) Authenticate(scheme, token string) (*model.User, error) {
+	apiToken, err := s.TokenRepository.FindByHash(hashToken(token))
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepository.FindByID(apiToken.UserId)
+
+	if err != nil {
+		return nil, apperrors.NewAuthorization(apperrors.InvalidToken)
+	}
+
+	if user.IsBot != (scheme == model.BotScheme) {
+		return nil, apperrors.NewAuthorization(apperrors.InvalidToken)
+	}
+
+	if err = s.TokenRepository.SetLastUsed(apiToken.ID, time.Now()); err != nil {
+		log.Printf("Unable to update the token usage: %v\n", err)
+	}
+
+	return user, nil
+}
+
+// SetupMfa generates a new TOTP secret for the user. The secret is pending
+// until it gets verified using EnableMfa.
+func (s *userService) SetupMfa(user *model.User) (*model.MfaSetup, error) {
+	if user.MfaEnabled {
+		return nil, apperrors.NewBadRequest(apperrors.MfaAlreadyEnabled)
+	}
+
+	secret, err := generateTotpSecret()
+
+	if err != nil {
+		log.Printf("Unable to generate a totp secret: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	user.TotpSecret = &secret
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &model.MfaSetup{
+		Secret: secret,
+		Uri:    totpUri(user.Email, secret),
+	}, nil
+}
+
+// EnableMfa enables two-factor authentication if the code matches the pending secret
+// and returns the recovery codes
+func (s *userService) EnableMfa(user *model.User, code string) (*model.RecoveryCodes, error) {
+	if user.MfaEnabled {
+		return nil, apperrors.NewBadRequest(apperrors.MfaAlreadyEnabled)
+	}
+
+	if user.TotpSecret == nil {
+		return nil, apperrors.NewBadRequest(apperrors.MfaSetupRequired)
+	}
+
+	if err := s.verifyTotp(user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+
+	if err != nil {
+		log.Printf("Unable to generate recovery codes: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	user.MfaEnabled = true
+	user.RecoveryCodes = hashes
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &model.RecoveryCodes{Codes: codes}, nil
+}
+
+// DisableMfa removes the secret and the recovery codes of the user
+func (s *userService) DisableMfa(user *model.User, code string) error {
+	if !user.MfaEnabled {
+		return apperrors.NewBadRequest(apperrors.MfaNotEnabled)
+	}
+
+	if err := s.VerifyMfa(user, code); err != nil {
+		return err
+	}
+
+	user.MfaEnabled = false
+	user.TotpSecret = nil
+	user.TotpLastStep = 0
+	user.RecoveryCodes = nil
+
+	return s.UserRepository.Update(user)
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user
+func (s *userService) RegenerateRecoveryCodes(user *model.User, code string) (*model.RecoveryCodes, error) {
+	if !user.MfaEnabled {
+		return nil, apperrors.NewBadRequest(apperrors.MfaNotEnabled)
+	}
+
+	if err := s.verifyTotp(user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+
+	if err != nil {
+		log.Printf("Unable to generate recovery codes: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	user.RecoveryCodes = hashes
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &model.RecoveryCodes{Codes: codes}, nil
+}
+
+// VerifyMfa checks the TOTP or recovery code if the user enabled two-factor authentication.
+// Used recovery codes get removed.
+func (s *userService) VerifyMfa(user *model.User, code string) error {
+	if !user.MfaEnabled {
+		return nil
+	}
+
+	code = strings.TrimSpace(code)
+
+	if code == "" {
+		return apperrors.NewAuthorization(apperrors.MfaCodeRequired)
+	}
+
+	if len(code) == totpDigits {
+		return s.verifyTotp(user, code)
+	}
+
+	used, err := s.UserRepository.UseRecoveryCode(user.ID, hashRecoveryCode(code))
+
+	if err != nil {
+		log.Printf("Unable to use recovery code: %v\n", err)
+		return apperrors.NewInternal()
+	}
+
+	if !used {
+		return apperrors.NewAuthorization(apperrors.InvalidMfaCode)
+	}
+
+	return nil
+}
+
+// CreateMfaChallenge returns the ticket the user has to complete with a code to log in
+func (s *userService) CreateMfaChallenge(ctx context.Context, user *model.User) (*model.MfaChallenge, error) {
+	ticket, err := s.RedisRepository.SetMfaTicket(ctx, user.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.MfaChallenge{
+		MfaRequired: true,
+		Ticket:      ticket,
+	}, nil
+}
+
+// LoginWithMfa completes the login of the ticket. The ticket is invalidated after
+// a successful login or model.MfaMaxAttempts failed attempts.
+func (s *userService) LoginWithMfa(ctx context.Context, ticket, code string) (*model.User, error) {
+	userId, attempts, err := s.RedisRepository.UseMfaTicket(ctx, ticket)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if attempts > model.MfaMaxAttempts {
+		s.RedisRepository.DeleteMfaTicket(ctx, ticket)
+		return nil, apperrors.NewAuthorization(apperrors.InvalidMfaTicket)
+	}
+
+	user, err := s.UserRepository.FindByID(userId)
+
+	if err != nil {
+		return nil, apperrors.NewAuthorization(apperrors.InvalidMfaTicket)
+	}
+
+	if err = s.VerifyMfa(user, code); err != nil {
+		return nil, err
+	}
+
+	s.RedisRepository.DeleteMfaTicket(ctx, ticket)
+
+	return user, nil
+}
+
+// verifyTotp checks the code against the user's secret and marks it as used
+func (s *userService) verifyTotp(user *model.User, code string) error {
+	if user.TotpSecret == nil {
+		return apperrors.NewAuthorization(apperrors.InvalidMfaCode)
+	}
+
+	step, valid := validateTotp(*user.TotpSecret, code, time.Now())
+
+	if !valid {
+		return apperrors.NewAuthorization(apperrors.InvalidMfaCode)
+	}
+
+	// Reject codes that have already been used
+	fresh, err := s.UserRepository.SetTotpStep(user.ID, step)
+
+	if err != nil {
+		log.Printf("Unable to store the totp step: %v\n", err)
+		return apperrors.NewInternal()
+	}
+
+	if !fresh {
+		return apperrors.NewAuthorization(apperrors.InvalidMfaCode)
+	}
+
+	user.TotpLastStep = step
+
+	return nil
+}
+
 // generateAvatar returns a gravatar using the md5 hash of the email
 func generateAvatar(email string) string {
 	hash := md5.Sum([]byte(email))
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"testing"
+	"time"
 )
 
 func TestGet(t *testing.T) {
@@ -294,10 +295,12 @@ func TestUserService_ChangeAvatar(t *testing.T) {
 				CreatedAt: mockUser.CreatedAt,
 				UpdatedAt: mockUser.UpdatedAt,
 			},
-			Email:    mockUser.Email,
-			Username: mockUser.Username,
-			Image:    imageURL,
-			Password: mockUser.Password,
+			Email:         mockUser.Email,
+			Username:      mockUser.Username,
+			Image:         imageURL,
+			Password:      mockUser.Password,
+			EmailVerified: mockUser.EmailVerified,
+			Locale:        mockUser.Locale,
 		}
 
 		mockUserRepository.
@@ -557,7 +560,7 @@ func TestUserService_ForgotPassword(t *testing.T) {
 		})
 
 		mockRedisRepository.On("SetResetToken", mock.Anything, mockUser.ID).Return(token, nil)
-		mockMailRepository.On("SendResetMail", mockUser.Email, token).Return(nil)
+		mockMailRepository.On("SendResetMail", mockUser.Email, token, mockUser.Locale).Return(nil)
 
 		err := us.ForgotPassword(context.TODO(), mockUser)
 		assert.NoError(t, err)
@@ -678,3 +681,422 @@ func TestUserService_ResetPassword(t *testing.T) {
 		mockRedisRepository.AssertExpectations(t)
 	})
 }
+
+func TestUserService_CreateToken(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+
+		mockTokenRepository := new(mocks.TokenRepository)
+		us := NewUserService(&USConfig{
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByOwner", mockUser.ID).Return(&[]model.APIToken{}, nil)
+		mockTokenRepository.On("Create", mock.AnythingOfType("*model.APIToken")).Return(nil)
+
+		token, err := us.CreateToken(mockUser, "CI")
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockUser.ID, token.UserId)
+		assert.Equal(t, "CI", token.Name)
+		assert.NotEmpty(t, token.Token)
+		assert.Equal(t, hashToken(token.Token), token.TokenHash)
+		mockTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Bot tokens count towards the owner's limit", func(t *testing.T) {
+		ownerId := fixture.RandID()
+		mockBot := fixture.GetMockUser()
+		mockBot.IsBot = true
+		mockBot.OwnerId = &ownerId
+
+		tokens := make([]model.APIToken, model.MaximumTokens)
+
+		mockTokenRepository := new(mocks.TokenRepository)
+		us := NewUserService(&USConfig{
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByOwner", ownerId).Return(&tokens, nil)
+
+		token, err := us.CreateToken(mockBot, "CI")
+
+		assert.Nil(t, token)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.TokenLimitError), err)
+		mockTokenRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestUserService_Authenticate(t *testing.T) {
+	secret := "secret-token"
+
+	t.Run("Success", func(t *testing.T) {
+		mockBot := fixture.GetMockUser()
+		mockBot.IsBot = true
+		mockToken := &model.APIToken{
+			BaseModel: model.BaseModel{ID: fixture.RandID()},
+			UserId:    mockBot.ID,
+		}
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockTokenRepository := new(mocks.TokenRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByHash", hashToken(secret)).Return(mockToken, nil)
+		mockTokenRepository.On("SetLastUsed", mockToken.ID, mock.AnythingOfType("time.Time")).Return(nil)
+		mockUserRepository.On("FindByID", mockBot.ID).Return(mockBot, nil)
+
+		user, err := us.Authenticate(model.BotScheme, secret)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockBot, user)
+		mockTokenRepository.AssertExpectations(t)
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("Scheme does not match the account type", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockToken := &model.APIToken{
+			BaseModel: model.BaseModel{ID: fixture.RandID()},
+			UserId:    mockUser.ID,
+		}
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockTokenRepository := new(mocks.TokenRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByHash", hashToken(secret)).Return(mockToken, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+
+		user, err := us.Authenticate(model.BotScheme, secret)
+
+		assert.Nil(t, user)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidToken), err)
+		mockTokenRepository.AssertNotCalled(t, "SetLastUsed", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_RevokeToken(t *testing.T) {
+	t.Run("Token of another user", func(t *testing.T) {
+		ownerId := fixture.RandID()
+		mockUser := fixture.GetMockUser()
+		mockToken := &model.APIToken{
+			BaseModel: model.BaseModel{ID: fixture.RandID()},
+			UserId:    mockUser.ID,
+		}
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockTokenRepository := new(mocks.TokenRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByID", mockToken.ID).Return(mockToken, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+
+		err := us.RevokeToken(ownerId, mockToken.ID)
+
+		assert.Equal(t, apperrors.NewNotFound("token", mockToken.ID), err)
+		mockTokenRepository.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
+
+func TestUserService_EnableMfa(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		secret, _ := generateTotpSecret()
+		mockUser.TotpSecret = &secret
+		code, _ := totpCode(secret, time.Now().Unix()/totpPeriod)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("SetTotpStep", mockUser.ID, mock.AnythingOfType("int64")).Return(true, nil)
+		mockUserRepository.On("Update", mockUser).Return(nil)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		codes, err := us.EnableMfa(mockUser, code)
+
+		assert.NoError(t, err)
+		assert.True(t, mockUser.MfaEnabled)
+		assert.Len(t, codes.Codes, model.RecoveryCodeCount)
+		assert.Len(t, mockUser.RecoveryCodes, model.RecoveryCodeCount)
+		assert.NotContains(t, mockUser.RecoveryCodes, codes.Codes[0])
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		secret, _ := generateTotpSecret()
+		mockUser.TotpSecret = &secret
+
+		mockUserRepository := new(mocks.UserRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		codes, err := us.EnableMfa(mockUser, "abcdef")
+
+		assert.Nil(t, codes)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidMfaCode), err)
+		assert.False(t, mockUser.MfaEnabled)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Setup required", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+
+		us := NewUserService(&USConfig{})
+
+		codes, err := us.EnableMfa(mockUser, "123456")
+
+		assert.Nil(t, codes)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.MfaSetupRequired), err)
+	})
+}
+
+func TestUserService_VerifyMfa(t *testing.T) {
+	secret, _ := generateTotpSecret()
+
+	getMfaUser := func() *model.User {
+		mockUser := fixture.GetMockUser()
+		mockUser.MfaEnabled = true
+		mockUser.TotpSecret = &secret
+		return mockUser
+	}
+
+	t.Run("Not enabled", func(t *testing.T) {
+		us := NewUserService(&USConfig{})
+
+		err := us.VerifyMfa(fixture.GetMockUser(), "")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Code required", func(t *testing.T) {
+		us := NewUserService(&USConfig{})
+
+		err := us.VerifyMfa(getMfaUser(), " ")
+
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.MfaCodeRequired), err)
+	})
+
+	t.Run("Used TOTP code", func(t *testing.T) {
+		mockUser := getMfaUser()
+		code, _ := totpCode(secret, time.Now().Unix()/totpPeriod)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("SetTotpStep", mockUser.ID, mock.AnythingOfType("int64")).Return(false, nil)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		err := us.VerifyMfa(mockUser, code)
+
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidMfaCode), err)
+	})
+
+	t.Run("Recovery code", func(t *testing.T) {
+		mockUser := getMfaUser()
+		code := "ABCDE-FGHJK"
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("UseRecoveryCode", mockUser.ID, hashRecoveryCode(code)).Return(true, nil)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		err := us.VerifyMfa(mockUser, "abcdefghjk")
+
+		assert.NoError(t, err)
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("Invalid recovery code", func(t *testing.T) {
+		mockUser := getMfaUser()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("UseRecoveryCode", mockUser.ID, mock.Anything).Return(false, nil)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		err := us.VerifyMfa(mockUser, "ABCDE-FGHJK")
+
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidMfaCode), err)
+	})
+}
+
+func TestUserService_LoginWithMfa(t *testing.T) {
+	secret, _ := generateTotpSecret()
+	ticket := fixture.RandStr(32)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.MfaEnabled = true
+		mockUser.TotpSecret = &secret
+		code, _ := totpCode(secret, time.Now().Unix()/totpPeriod)
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("UseMfaTicket", mock.Anything, ticket).Return(mockUser.ID, int64(1), nil)
+		mockRedisRepository.On("DeleteMfaTicket", mock.Anything, ticket).Return()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockUserRepository.On("SetTotpStep", mockUser.ID, mock.AnythingOfType("int64")).Return(true, nil)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		user, err := us.LoginWithMfa(context.TODO(), ticket, code)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockUser, user)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Too many attempts", func(t *testing.T) {
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("UseMfaTicket", mock.Anything, ticket).Return(fixture.RandID(), int64(model.MfaMaxAttempts+1), nil)
+		mockRedisRepository.On("DeleteMfaTicket", mock.Anything, ticket).Return()
+
+		mockUserRepository := new(mocks.UserRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		user, err := us.LoginWithMfa(context.TODO(), ticket, "123456")
+
+		assert.Nil(t, user)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidMfaTicket), err)
+		mockRedisRepository.AssertExpectations(t)
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+}
+
+func TestUserService_ResendVerificationMail(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+		token := fixture.RandStringRunes(32)
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("ThrottleVerificationMail", mock.Anything, mockUser.ID).Return(true, nil)
+		mockRedisRepository.On("SetVerificationToken", mock.Anything, mockUser.ID, mockUser.Email).Return(token, nil)
+
+		mockMailRepository := new(mocks.MailRepository)
+		mockMailRepository.On("SendVerificationMail", mockUser.Email, token, mockUser.Locale).Return(nil)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+			MailRepository:  mockMailRepository,
+		})
+
+		err := us.ResendVerificationMail(context.Background(), mockUser)
+
+		assert.NoError(t, err)
+		mockRedisRepository.AssertExpectations(t)
+		mockMailRepository.AssertExpectations(t)
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("ThrottleVerificationMail", mock.Anything, mockUser.ID).Return(false, nil)
+
+		mockMailRepository := new(mocks.MailRepository)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+			MailRepository:  mockMailRepository,
+		})
+
+		err := us.ResendVerificationMail(context.Background(), mockUser)
+
+		assert.Equal(t, apperrors.NewTooManyRequests(apperrors.VerifyMailThrottled), err)
+		mockMailRepository.AssertNotCalled(t, "SendVerificationMail", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Already verified", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		err := us.ResendVerificationMail(context.Background(), mockUser)
+
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.EmailVerified), err)
+		mockRedisRepository.AssertNotCalled(t, "ThrottleVerificationMail", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_VerifyEmail(t *testing.T) {
+	token := fixture.RandStringRunes(32)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("UseVerificationToken", mock.Anything, token).Return(mockUser.ID, mockUser.Email, nil)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockUserRepository.On("Update", mockUser).Return(nil)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		user, err := us.VerifyEmail(context.Background(), token)
+
+		assert.NoError(t, err)
+		assert.True(t, user.EmailVerified)
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("Email changed after the token was sent", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockRedisRepository.On("UseVerificationToken", mock.Anything, token).Return(mockUser.ID, "old@example.com", nil)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		user, err := us.VerifyEmail(context.Background(), token)
+
+		assert.Nil(t, user)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.InvalidVerifyToken), err)
+		assert.False(t, mockUser.EmailVerified)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
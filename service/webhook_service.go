@@ -0,0 +1,249 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// webhookService acts as a struct for injecting an implementation of WebhookRepository
+// for use in service methods
+type webhookService struct {
+	WebhookRepository model.WebhookRepository
+	WebhookClient     model.WebhookClient
+	RetryDelay        time.Duration
+}
+
+// WHConfig will hold repositories that will eventually be injected into
+// this service layer
+type WHConfig struct {
+	WebhookRepository model.WebhookRepository
+	WebhookClient     model.WebhookClient
+	// RetryDelay is the delay before the first retry and doubles after every failed attempt
+	RetryDelay time.Duration
+}
+
+// NewWebhookService is a factory function for
+// initializing a WebhookService with its repository layer dependencies
+func NewWebhookService(c *WHConfig) model.WebhookService {
+	return &webhookService{
+		WebhookRepository: c.WebhookRepository,
+		WebhookClient:     c.WebhookClient,
+		RetryDelay:        c.RetryDelay,
+	}
+}
+
+// deliveryBatchSize is the maximum amount of deliveries attempted per run
+const deliveryBatchSize = 100
+
+// deliveryLease is how long claimed deliveries are hidden from other instances.
+// It has to be longer than sending a whole batch takes.
+const deliveryLease = 2 * time.Minute
+
+func (w *webhookService) GetWebhooks(guildId string) (*[]model.Webhook, error) {
+	return w.WebhookRepository.FindByGuild(guildId)
+}
+
+func (w *webhookService) GetWebhook(webhookId string) (*model.Webhook, error) {
+	return w.WebhookRepository.FindByID(webhookId)
+}
+
+func (w *webhookService) CreateWebhook(webhook *model.Webhook) (*model.Webhook, error) {
+	count, err := w.WebhookRepository.CountByGuild(webhook.GuildId)
+
+	if err != nil {
+		return nil, apperrors.NewInternal()
+	}
+
+	if count >= model.MaximumWebhooks {
+		return nil, apperrors.NewBadRequest(apperrors.WebhookLimitError)
+	}
+
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.ID = id
+	webhook.Enabled = true
+
+	if err = w.WebhookRepository.Create(webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (w *webhookService) UpdateWebhook(webhook *model.Webhook) error {
+	return w.WebhookRepository.Update(webhook)
+}
+
+func (w *webhookService) DeleteWebhook(webhook *model.Webhook) error {
+	return w.WebhookRepository.Delete(webhook)
+}
+
+func (w *webhookService) GetDeliveries(webhookId string, limit int) (*[]model.WebhookDelivery, error) {
+	return w.WebhookRepository.FindDeliveries(webhookId, limit)
+}
+
+// Dispatch queues a delivery of the event for every webhook of the guild subscribed to it.
+// Errors are only logged so they never affect the action that emitted the event.
+func (w *webhookService) Dispatch(guildId, event string, data interface{}) {
+	webhooks, err := w.WebhookRepository.FindSubscribed(guildId, event)
+
+	if err != nil {
+		log.Printf("could not find webhooks for guild %s: %v\n", guildId, err)
+		return
+	}
+
+	if len(*webhooks) == 0 {
+		return
+	}
+
+	now := time.Now()
+	deliveries := make([]model.WebhookDelivery, 0, len(*webhooks))
+
+	for _, webhook := range *webhooks {
+		id, err := GenerateId()
+
+		if err != nil {
+			return
+		}
+
+		payload, err := json.Marshal(model.WebhookPayload{
+			Id:        id,
+			Event:     event,
+			GuildId:   guildId,
+			Data:      data,
+			CreatedAt: now,
+		})
+
+		if err != nil {
+			log.Printf("error marshalling webhook payload: %v\n", err)
+			return
+		}
+
+		deliveries = append(deliveries, model.WebhookDelivery{
+			ID:            id,
+			WebhookId:     webhook.ID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        model.DeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+
+	if err = w.WebhookRepository.CreateDeliveries(deliveries); err != nil {
+		log.Printf("could not queue webhook deliveries for guild %s: %v\n", guildId, err)
+	}
+}
+
+// ProcessDeliveries claims and attempts all pending deliveries that are due.
+// Failed deliveries are retried with an exponential backoff and the webhook
+// gets disabled after too many consecutive failed deliveries.
+func (w *webhookService) ProcessDeliveries() error {
+	deliveries, err := w.WebhookRepository.ClaimDueDeliveries(time.Now(), deliveryLease, deliveryBatchSize)
+
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for i := range *deliveries {
+		wg.Add(1)
+		go func(delivery *model.WebhookDelivery) {
+			defer wg.Done()
+			w.deliver(delivery)
+		}(&(*deliveries)[i])
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// deliver sends the delivery to its webhook and updates its status
+func (w *webhookService) deliver(delivery *model.WebhookDelivery) {
+	webhook := delivery.Webhook
+
+	if webhook == nil || !webhook.Enabled {
+		disabled := "webhook is disabled"
+		delivery.Status = model.DeliveryFailed
+		delivery.LastError = &disabled
+		delivery.NextAttemptAt = nil
+		w.updateDelivery(delivery)
+		return
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		"X-Valkyrie-Event":     delivery.Event,
+		"X-Valkyrie-Delivery":  delivery.ID,
+		"X-Valkyrie-Timestamp": timestamp,
+		"X-Valkyrie-Signature": signPayload(webhook.Secret, timestamp, delivery.Payload),
+	}
+
+	status, err := w.WebhookClient.Post(webhook.Url, []byte(delivery.Payload), headers)
+	delivery.Attempts++
+
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	if err == nil && status >= 200 && status < 300 {
+		delivery.Status = model.DeliverySucceeded
+		delivery.LastError = nil
+		delivery.NextAttemptAt = nil
+		w.updateDelivery(delivery)
+
+		if webhook.FailureCount > 0 {
+			if err := w.WebhookRepository.ResetFailures(webhook.ID); err != nil {
+				log.Printf("could not reset webhook failures: %v\n", err)
+			}
+		}
+		return
+	}
+
+	reason := fmt.Sprintf("unexpected response status %d", status)
+	if err != nil {
+		reason = err.Error()
+	}
+	delivery.LastError = &reason
+
+	if delivery.Attempts >= model.WebhookMaxAttempts {
+		delivery.Status = model.DeliveryFailed
+		delivery.NextAttemptAt = nil
+		w.updateDelivery(delivery)
+
+		if err := w.WebhookRepository.RecordFailure(webhook.ID, model.WebhookFailureLimit); err != nil {
+			log.Printf("could not record webhook failure: %v\n", err)
+		}
+		return
+	}
+
+	next := time.Now().Add(w.RetryDelay << (delivery.Attempts - 1))
+	delivery.NextAttemptAt = &next
+	w.updateDelivery(delivery)
+}
+
+func (w *webhookService) updateDelivery(delivery *model.WebhookDelivery) {
+	if err := w.WebhookRepository.UpdateDelivery(delivery); err != nil {
+		log.Printf("could not update webhook delivery %s: %v\n", delivery.ID, err)
+	}
+}
+
+// signPayload returns the hex-encoded HMAC-SHA256 of "<timestamp>.<payload>" using the webhook secret.
+// Receivers should recompute it and reject old timestamps to prevent replays.
+func signPayload(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"errors"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func getMockWebhook(guildId string) *model.Webhook {
+	id := fixture.RandID()
+	return &model.Webhook{
+		BaseModel: model.BaseModel{ID: id},
+		GuildId:   guildId,
+		Url:       "https://example.com/hooks/" + id,
+		Secret:    fixture.RandStr(32),
+		Enabled:   true,
+	}
+}
+
+func getMockDelivery(webhook *model.Webhook) *model.WebhookDelivery {
+	now := time.Now()
+	return &model.WebhookDelivery{
+		ID:            fixture.RandID(),
+		WebhookId:     webhook.ID,
+		Webhook:       webhook,
+		Event:         "new_message",
+		Payload:       `{"event":"new_message"}`,
+		Status:        model.DeliveryPending,
+		NextAttemptAt: &now,
+	}
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	t.Run("Webhook limit reached", func(t *testing.T) {
+		mockWebhook := getMockWebhook(fixture.RandID())
+
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		mockWebhookRepository.On("CountByGuild", mockWebhook.GuildId).Return(int64(model.MaximumWebhooks), nil)
+
+		whs := NewWebhookService(&WHConfig{
+			WebhookRepository: mockWebhookRepository,
+		})
+
+		webhook, err := whs.CreateWebhook(mockWebhook)
+
+		assert.Nil(t, webhook)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.WebhookLimitError), err)
+		mockWebhookRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestWebhookService_Dispatch(t *testing.T) {
+	t.Run("Queues a delivery for every subscribed webhook", func(t *testing.T) {
+		guildId := fixture.RandID()
+		webhooks := []model.Webhook{*getMockWebhook(guildId), *getMockWebhook(guildId)}
+
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		mockWebhookRepository.On("FindSubscribed", guildId, "add_role").Return(&webhooks, nil)
+		mockWebhookRepository.On("CreateDeliveries", mock.MatchedBy(func(deliveries []model.WebhookDelivery) bool {
+			return len(deliveries) == 2 &&
+				deliveries[0].WebhookId == webhooks[0].ID &&
+				deliveries[1].WebhookId == webhooks[1].ID &&
+				deliveries[0].Status == model.DeliveryPending &&
+				deliveries[0].Event == "add_role"
+		})).Return(nil)
+
+		whs := NewWebhookService(&WHConfig{
+			WebhookRepository: mockWebhookRepository,
+		})
+
+		whs.Dispatch(guildId, "add_role", map[string]string{"id": "1"})
+
+		mockWebhookRepository.AssertExpectations(t)
+	})
+
+	t.Run("No subscribed webhooks", func(t *testing.T) {
+		guildId := fixture.RandID()
+
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		mockWebhookRepository.On("FindSubscribed", guildId, "add_role").Return(&[]model.Webhook{}, nil)
+
+		whs := NewWebhookService(&WHConfig{
+			WebhookRepository: mockWebhookRepository,
+		})
+
+		whs.Dispatch(guildId, "add_role", nil)
+
+		mockWebhookRepository.AssertNotCalled(t, "CreateDeliveries", mock.Anything)
+	})
+}
+
+func TestWebhookService_ProcessDeliveries(t *testing.T) {
+	t.Run("Successful delivery resets the failure count", func(t *testing.T) {
+		mockWebhook := getMockWebhook(fixture.RandID())
+		mockWebhook.FailureCount = 2
+		mockDelivery := getMockDelivery(mockWebhook)
+
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		mockWebhookRepository.On("ClaimDueDeliveries", mock.AnythingOfType("time.Time"), deliveryLease, deliveryBatchSize).
+			Return(&[]model.WebhookDelivery{*mockDelivery}, nil)
+		mockWebhookRepository.On("UpdateDelivery", mock.MatchedBy(func(d *model.WebhookDelivery) bool {
+			return d.Status == model.DeliverySucceeded && d.Attempts == 1 && *d.ResponseStatus == http.StatusNoContent
+		})).Return(nil)
+		mockWebhookRepository.On("ResetFailures", mockWebhook.ID).Return(nil)
+
+		mockWebhookClient := new(mocks.WebhookClient)
+		mockWebhookClient.On("Post", mockWebhook.Url, []byte(mockDelivery.Payload), mock.MatchedBy(func(headers map[string]string) bool {
+			expected := signPayload(mockWebhook.Secret, headers["X-Valkyrie-Timestamp"], mockDelivery.Payload)
+			return headers["X-Valkyrie-Signature"] == expected &&
+				headers["X-Valkyrie-Event"] == mockDelivery.Event &&
+				headers["X-Valkyrie-Delivery"] == mockDelivery.ID
+		})).Return(http.StatusNoContent, nil)
+
+		whs := NewWebhookService(&WHConfig{
+			WebhookRepository: mockWebhookRepository,
+			WebhookClient:     mockWebhookClient,
+			RetryDelay:        time.Second,
+		})
+
+		err := whs.ProcessDeliveries()
+
+		assert.NoError(t, err)
+		mockWebhookRepository.AssertExpectations(t)
+		mockWebhookClient.AssertExpectations(t)
+	})
+
+	t.Run("Failed attempt schedules a retry with backoff", func(t *testing.T) {
+		mockWebhook := getMockWebhook(fixture.RandID())
+		mockDelivery := getMockDelivery(mockWebhook)
+		mockDelivery.Attempts = 2
+
+		start := time.Now()
+
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		mockWebhookRepository.On("ClaimDueDeliveries", mock.AnythingOfType("time.Time"), deliveryLease, deliveryBatchSize).
+			Return(&[]model.WebhookDelivery{*mockDelivery}, nil)
+		mockWebhookRepository.On("UpdateDelivery", mock.MatchedBy(func(d *model.WebhookDelivery) bool {
+			// Third attempt failed, so the next one is delayed by 4 times the retry delay
+			return d.Status == model.DeliveryPending &&
+				d.Attempts == 3 &&
+				*d.LastError == "unexpected response status 500" &&
+				!d.NextAttemptAt.Before(start.Add(4*time.Minute))
+		})).Return(nil)
+
+		mockWebhookClient := new(mocks.WebhookClient)
+		mockWebhookClient.On("Post", mockWebhook.Url, mock.Anything, mock.Anything).Return(http.StatusInternalServerError, nil)
+
+		whs := NewWebhookService(&WHConfig{
+			WebhookRepository: mockWebhookRepository,
+			WebhookClient:     mockWebhookClient,
+			RetryDelay:        time.Minute,
+		})
+
+		err := whs.ProcessDeliveries()
+
+		assert.NoError(t, err)
+		mockWebhookRepository.AssertExpectations(t)
+		mockWebhookRepository.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Last attempt failed records a webhook failure", func(t *testing.T) {
+		mockWebhook := getMockWebhook(fixture.RandID())
+		mockDelivery := getMockDelivery(mockWebhook)
+		mockDelivery.Attempts = model.WebhookMaxAttempts - 1
+
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		mockWebhookRepository.On("ClaimDueDeliveries", mock.AnythingOfType("time.Time"), deliveryLease, deliveryBatchSize).
+			Return(&[]model.WebhookDelivery{*mockDelivery}, nil)
+		mockWebhookRepository.On("UpdateDelivery", mock.MatchedBy(func(d *model.WebhookDelivery) bool {
+			return d.Status == model.DeliveryFailed &&
+				d.NextAttemptAt == nil &&
+				d.ResponseStatus == nil &&
+				*d.LastError == "connection refused"
+		})).Return(nil)
+		mockWebhookRepository.On("RecordFailure", mockWebhook.ID, model.WebhookFailureLimit).Return(nil)
+
+		mockWebhookClient := new(mocks.WebhookClient)
+		mockWebhookClient.On("Post", mockWebhook.Url, mock.Anything, mock.Anything).Return(0, errors.New("connection refused"))
+
+		whs := NewWebhookService(&WHConfig{
+			WebhookRepository: mockWebhookRepository,
+			WebhookClient:     mockWebhookClient,
+			RetryDelay:        time.Second,
+		})
+
+		err := whs.ProcessDeliveries()
+
+		assert.NoError(t, err)
+		mockWebhookRepository.AssertExpectations(t)
+	})
+
+	t.Run("Deliveries of disabled webhooks fail", func(t *testing.T) {
+		mockWebhook := getMockWebhook(fixture.RandID())
+		mockWebhook.Enabled = false
+		mockDelivery := getMockDelivery(mockWebhook)
+
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		mockWebhookRepository.On("ClaimDueDeliveries", mock.AnythingOfType("time.Time"), deliveryLease, deliveryBatchSize).
+			Return(&[]model.WebhookDelivery{*mockDelivery}, nil)
+		mockWebhookRepository.On("UpdateDelivery", mock.MatchedBy(func(d *model.WebhookDelivery) bool {
+			return d.Status == model.DeliveryFailed && d.Attempts == 0
+		})).Return(nil)
+
+		mockWebhookClient := new(mocks.WebhookClient)
+
+		whs := NewWebhookService(&WHConfig{
+			WebhookRepository: mockWebhookRepository,
+			WebhookClient:     mockWebhookClient,
+			RetryDelay:        time.Second,
+		})
+
+		err := whs.ProcessDeliveries()
+
+		assert.NoError(t, err)
+		mockWebhookRepository.AssertExpectations(t)
+		mockWebhookClient.AssertNotCalled(t, "Post", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -9,9 +9,10 @@ const (
 	LeaveRoomAction       = "leaveRoom"
 	StartTypingAction     = "startTyping"
 	StopTypingAction      = "stopTyping"
-	ToggleOnlineAction    = "toggleOnline"
-	ToggleOfflineAction   = "toggleOffline"
+	UpdatePresenceAction  = "updatePresence"
 	GetRequestCountAction = "getRequestCount"
+	AckChannelAction      = "ackChannel"
+	ResumeAction          = "resume"
 )
 
 // Emitted Messages
@@ -19,19 +20,27 @@ const (
 	NewMessageAction        = "new_message"
 	EditMessageAction       = "edit_message"
 	DeleteMessageAction     = "delete_message"
+	AddReactionAction       = "add_reaction"
+	RemoveReactionAction    = "remove_reaction"
+	PinsUpdateAction        = "pins_update"
 	AddChannelAction        = "add_channel"
 	AddPrivateChannelAction = "add_private_channel"
 	EditChannelAction       = "edit_channel"
 	DeleteChannelAction     = "delete_channel"
+	AddThreadAction         = "add_thread"
 	EditGuildAction         = "edit_guild"
 	DeleteGuildAction       = "delete_guild"
 	RemoveFromGuildAction   = "remove_from_guild"
 	AddMemberAction         = "add_member"
 	RemoveMemberAction      = "remove_member"
+	AddRoleAction           = "add_role"
+	EditRoleAction          = "edit_role"
+	DeleteRoleAction        = "delete_role"
 	NewDMNotificationAction = "new_dm_notification"
 	NewNotificationAction   = "new_notification"
-	ToggleOnlineEmission    = "toggle_online"
-	ToggleOfflineEmission   = "toggle_offline"
+	NewMentionAction        = "new_mention"
+	AckAction               = "ack"
+	PresenceUpdateEmission  = "presence_update"
 	AddToTypingAction       = "addToTyping"
 	RemoveFromTypingAction  = "removeFromTyping"
 	SendRequestAction       = "send_request"
@@ -40,4 +49,34 @@ const (
 	RemoveFriendAction      = "remove_friend"
 	PushToTopAction         = "push_to_top"
 	RequestCountEmission    = "requestCount"
+	InteractionAction       = "interaction"
+	EphemeralMessageAction  = "ephemeral_message"
+	HelloAction             = "hello"
+	ResumedAction           = "resumed"
+	InvalidSessionAction    = "invalid_session"
+	ActionAckEmission       = "action_ack"
+	ActionErrorEmission     = "action_error"
 )
+
+// WebhookEvents are the emitted messages that get forwarded to the webhooks of a guild.
+// delete_guild is not forwarded as the webhooks get deleted with the guild and
+// user specific messages like notifications or friend events are never forwarded.
+var WebhookEvents = []string{
+	NewMessageAction,
+	EditMessageAction,
+	DeleteMessageAction,
+	AddReactionAction,
+	RemoveReactionAction,
+	PinsUpdateAction,
+	AddChannelAction,
+	AddPrivateChannelAction,
+	EditChannelAction,
+	DeleteChannelAction,
+	AddThreadAction,
+	EditGuildAction,
+	AddMemberAction,
+	RemoveMemberAction,
+	AddRoleAction,
+	EditRoleAction,
+	DeleteRoleAction,
+}
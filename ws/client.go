@@ -2,10 +2,14 @@ package ws
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/gin-gonic/gin"
+	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -38,20 +42,42 @@ var upgrader = websocket.Upgrader{
 // Client represents the websockets client at the server
 type Client struct {
 	// The actual websockets connection.
-	ID    string
-	conn  *websocket.Conn
-	hub   *Hub
-	send  chan []byte
-	rooms map[*Room]bool
+	ID string
+	// The session the connection was opened with. Empty for token authentication.
+	SessionId string
+	conn      *websocket.Conn
+	hub       *Hub
+	send      chan []byte
+	resume    chan *resumeRequest
+	// Acknowledgements and errors of received messages, which are not part of the session
+	replies chan []byte
+	// Identifies the connection as the owner of its resumable session
+	connId string
+	// The rooms the client subscribed to, guarded by mu
+	rooms map[*Room]*subscription
+	// The resumable session the events get recorded in, guarded by mu
+	sessionId string
+	// Whether the connection is still open, guarded by mu
+	attached bool
+	// Whether the session got revoked, guarded by mu
+	revoked  bool
+	mu       sync.Mutex
+	released sync.Once
 }
 
-func newClient(conn *websocket.Conn, hub *Hub, id string) *Client {
+func newClient(conn *websocket.Conn, hub *Hub, id, sessionId string) *Client {
+	connId, _ := gonanoid.New()
 	return &Client{
-		ID:    id,
-		conn:  conn,
-		hub:   hub,
-		send:  make(chan []byte, 256),
-		rooms: make(map[*Room]bool),
+		ID:        id,
+		SessionId: sessionId,
+		conn:      conn,
+		hub:       hub,
+		send:      make(chan []byte, 256),
+		resume:    make(chan *resumeRequest, 1),
+		replies:   make(chan []byte, 64),
+		connId:    connId,
+		rooms:     make(map[*Room]*subscription),
+		attached:  true,
 	}
 }
 
@@ -86,246 +112,365 @@ func (client *Client) writePump() {
 		ticker.Stop()
 		_ = client.conn.Close()
 	}()
+
+	client.writeFrame(client.hello())
+
 	for {
 		select {
 		case message, ok := <-client.send:
-			_ = client.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
 				// The hub closed the channel.
+				_ = client.conn.SetWriteDeadline(time.Now().Add(writeWait))
 				_ = client.conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
 
-			w, err := client.conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return
-			}
-			_, _ = w.Write(message)
-
 			// Attach queued chat messages to the current websockets message.
+			events := [][]byte{message}
 			n := len(client.send)
 			for i := 0; i < n; i++ {
-				_, _ = w.Write(newline)
-				_, _ = w.Write(<-client.send)
+				events = append(events, <-client.send)
 			}
 
-			if err := w.Close(); err != nil {
-				return
+			// Detached clients keep recording the events until they get resumed or released
+			events = client.record(events)
+			if len(events) == 0 || !client.isAttached() {
+				continue
+			}
+
+			client.writeFrame(joinEvents(events))
+		case req := <-client.resume:
+			client.writeFrame(client.resumeSession(req))
+		case reply := <-client.replies:
+			if client.isAttached() {
+				client.writeFrame(reply)
 			}
 		case <-ticker.C:
+			if !client.isAttached() {
+				continue
+			}
+
+			client.refreshSession()
+			client.hub.heartbeat(client)
 			_ = client.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return
+				_ = client.conn.Close()
 			}
 		}
 	}
 }
 
+// writeFrame writes the message to the connection.
+// On failure the connection gets closed, so that the read pump detaches the client.
+func (client *Client) writeFrame(message []byte) {
+	_ = client.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	if err := client.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+		_ = client.conn.Close()
+	}
+}
+
+// hello tells the client the session to resume after the connection dropped
+func (client *Client) hello() []byte {
+	msg := model.WebsocketMessage{
+		Action: HelloAction,
+		Data:   sessionFrame{SessionId: client.resumableSession()},
+	}
+	return msg.Encode()
+}
+
+// isAttached returns whether the connection is still open
+func (client *Client) isAttached() bool {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.attached
+}
+
+// disconnect detaches the client after the connection dropped. The client stays in its rooms
+// and keeps recording events for the resume window, unless its session got revoked.
 func (client *Client) disconnect() {
-	client.hub.unregister <- client
-	for room := range client.rooms {
-		room.unregister <- client
+	_ = client.conn.Close()
+
+	client.mu.Lock()
+	client.attached = false
+	resumable := !client.revoked && client.sessionId != ""
+	client.mu.Unlock()
+
+	client.hub.disconnectPresence(client)
+
+	if !resumable {
+		client.release()
+		return
 	}
-	close(client.send)
+
+	time.AfterFunc(resumeWindow, client.release)
+}
+
+// release removes the client from the hub and all rooms
+func (client *Client) release() {
+	client.released.Do(func() {
+		client.hub.unregister <- client
+		for room := range client.subscriptions() {
+			room.unregister <- client
+		}
+		close(client.send)
+
+		client.mu.Lock()
+		revoked, id := client.revoked, client.sessionId
+		client.mu.Unlock()
+
+		// Revoked sessions cannot be resumed
+		if revoked && id != "" {
+			client.hub.redisClient.Del(ctx, sessionKey(id), eventsKey(id))
+		}
+	})
+}
+
+// close tells the peer that its session got revoked and closes the connection.
+// The read pump then fails and releases the client.
+func (client *Client) close() {
+	client.mu.Lock()
+	client.revoked = true
+	attached := client.attached
+	client.mu.Unlock()
+
+	if !attached {
+		go client.release()
+		return
+	}
+
+	message := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "session revoked")
+	_ = client.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeWait))
 	_ = client.conn.Close()
 }
 
 // ServeWs handles websockets requests from clients requests.
+// The connection is authenticated by the AuthUser middleware using either the session or a token.
 func ServeWs(hub *Hub, ctx *gin.Context) {
 
 	userId := ctx.MustGet("userId").(string)
+	sessionId := ctx.GetString("sessionId")
 	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
 	if err != nil {
 		log.Println(err)
 		return
 	}
 
-	client := newClient(conn, hub, userId)
+	client := newClient(conn, hub, userId, sessionId)
+
+	// Without a resumable session the client has to fetch its state again after reconnecting
+	if err = client.openSession(); err != nil {
+		log.Printf("error opening session: %v\n", err)
+	}
 
 	go client.writePump()
 	go client.readPump()
 
 	hub.register <- client
+	hub.connectPresence(client)
 }
 
 func (client *Client) handleNewMessage(jsonMessage []byte) {
 
 	var message model.ReceivedMessage
 	if err := json.Unmarshal(jsonMessage, &message); err != nil {
-		log.Printf("Error on unmarshal JSON message %s", err)
+		client.reply(message, apperrors.NewBadRequest(apperrors.InvalidFrame))
+		return
 	}
 
+	client.reply(message, client.handleAction(message))
+}
+
+// handleAction executes the received action
+func (client *Client) handleAction(message model.ReceivedMessage) error {
 	switch message.Action {
 	// Join Room Actions
 	case JoinChannelAction:
-		client.handleJoinChannelMessage(message)
+		return client.handleJoinChannelMessage(message)
 	case JoinGuildAction:
-		client.handleJoinGuildMessage(message)
+		return client.handleJoinGuildMessage(message)
 	case JoinUserAction:
-		client.handleJoinRoomMessage(message)
+		return client.handleJoinUserMessage(message)
 
 	// Leave Room Actions
 	case LeaveRoomAction:
-		client.handleLeaveRoomMessage(message)
+		return client.handleLeaveRoomMessage(message)
 	case LeaveGuildAction:
-		client.handleLeaveGuildMessage(message)
+		return client.handleLeaveGuildMessage(message)
 
 	// Chat Typing Actions
 	case StartTypingAction:
-		client.handleTypingEvent(message, AddToTypingAction)
+		return client.handleStartTyping(message)
 	case StopTypingAction:
-		client.handleTypingEvent(message, RemoveFromTypingAction)
+		return client.handleStopTyping(message)
+
+	// Presence Actions
+	case UpdatePresenceAction:
+		return client.handleUpdatePresenceMessage(message)
 
-	// Online Status Actions
-	case ToggleOnlineAction:
-		client.toggleOnlineStatus(true)
-	case ToggleOfflineAction:
-		client.toggleOnlineStatus(false)
+	// Read State Actions
+	case AckChannelAction:
+		return client.handleAckChannelMessage(message)
+
+	// Session Actions
+	case ResumeAction:
+		return client.handleResumeMessage(message)
 
 	// Other
 	case GetRequestCountAction:
-		client.handleGetRequestCount()
+		return client.handleGetRequestCount()
 	}
-}
 
-// handleJoinChannelMessage joins the given room if the user is a member in it
-func (client *Client) handleJoinChannelMessage(message model.ReceivedMessage) {
-	roomName := message.Room
+	return apperrors.NewBadRequest(apperrors.UnknownAction)
+}
 
-	cs := client.hub.channelService
-	channel, err := cs.Get(roomName)
+// replyFrame is the data of the acknowledgement or error of a received message
+type replyFrame struct {
+	Nonce   string         `json:"nonce,omitempty"`
+	Action  string         `json:"action"`
+	Code    apperrors.Type `json:"code,omitempty"`
+	Message string         `json:"message,omitempty"`
+}
 
-	if err != nil {
+// reply acknowledges the received message if the client supplied a nonce.
+// Errors are always reported, using the type of the app error as their code.
+func (client *Client) reply(message model.ReceivedMessage, err error) {
+	if err == nil && message.Nonce == "" {
 		return
 	}
 
-	// Check if the user has access to the given channel
-	if err = cs.IsChannelMember(channel, client.ID); err != nil {
-		return
+	msg := model.WebsocketMessage{
+		Action: ActionAckEmission,
+		Data:   replyFrame{Nonce: message.Nonce, Action: message.Action},
 	}
 
-	client.handleJoinRoomMessage(message)
-}
-
-// handleJoinGuildMessage joins the given guild if the user is member in it
-func (client *Client) handleJoinGuildMessage(message model.ReceivedMessage) {
-	roomName := message.Room
+	if err != nil {
+		var e *apperrors.Error
+		if !errors.As(err, &e) {
+			log.Printf("error handling %s: %v\n", message.Action, err)
+			e = apperrors.NewInternal()
+		}
 
-	gs := client.hub.guildService
-	guild, err := gs.GetGuild(roomName)
+		msg.Action = ActionErrorEmission
+		msg.Data = replyFrame{Nonce: message.Nonce, Action: message.Action, Code: e.Type, Message: e.Message}
+	}
 
-	if err != nil {
-		return
+	select {
+	case client.replies <- msg.Encode():
+	default:
+		log.Printf("dropped reply to %s, the client is not reading\n", message.Action)
 	}
+}
 
-	// Check if the user is member of the given guild
-	if !isMember(guild, client.ID) {
-		return
+// handleJoinChannelMessage joins the given room if the user is a member in it
+func (client *Client) handleJoinChannelMessage(message model.ReceivedMessage) error {
+	sub, err := client.hub.authorizeChannel(message.Room, client.ID)
+
+	if err != nil {
+		return err
 	}
 
-	client.handleJoinRoomMessage(message)
+	client.subscribe(message.Room, sub)
+	return nil
 }
 
-// handleJoinRoomMessage joins the given room
-func (client *Client) handleJoinRoomMessage(message model.ReceivedMessage) {
-	roomName := message.Room
+// handleJoinGuildMessage joins the given guild if the user is member in it
+func (client *Client) handleJoinGuildMessage(message model.ReceivedMessage) error {
+	sub, err := client.hub.authorizeGuild(message.Room, client.ID)
 
-	room := client.hub.findRoomById(roomName)
-	if room == nil {
-		room = client.hub.createRoom(roomName)
+	if err != nil {
+		return err
 	}
 
-	client.rooms[room] = true
+	client.subscribe(message.Room, sub)
+	return nil
+}
 
-	room.register <- client
+// handleJoinUserMessage joins the room of the user.
+// Users can only join their own room.
+func (client *Client) handleJoinUserMessage(message model.ReceivedMessage) error {
+	if message.Room != client.ID {
+		return apperrors.NewAuthorization(apperrors.JoinUserError)
+	}
+
+	client.subscribe(message.Room, &subscription{kind: userRoom})
+	return nil
 }
 
 // handleLeaveGuildMessage leaves the room and updates the members last seen date
-func (client *Client) handleLeaveGuildMessage(message model.ReceivedMessage) {
+func (client *Client) handleLeaveGuildMessage(message model.ReceivedMessage) error {
 	_ = client.hub.guildService.UpdateMemberLastSeen(client.ID, message.Room)
-	client.handleLeaveRoomMessage(message)
+	return client.handleLeaveRoomMessage(message)
 }
 
 // handleLeaveRoomMessage leaves the room
-func (client *Client) handleLeaveRoomMessage(message model.ReceivedMessage) {
+func (client *Client) handleLeaveRoomMessage(message model.ReceivedMessage) error {
 	room := client.hub.findRoomById(message.Room)
-	delete(client.rooms, room)
 
-	if room != nil {
-		room.unregister <- client
+	if room == nil {
+		return apperrors.NewNotFound("room", message.Room)
 	}
+
+	client.unsubscribe(room)
+	return nil
 }
 
-// handleGetRequestCount returns the users incoming friend request count
-func (client *Client) handleGetRequestCount() {
-	if room := client.hub.findRoomById(client.ID); room != nil {
-		count, err := client.hub.userService.GetRequestCount(client.ID)
+// handleAckChannelMessage marks the channel as read up to the given message
+// and syncs the read state to all sessions of the user
+func (client *Client) handleAckChannelMessage(message model.ReceivedMessage) error {
+	if message.Message == nil {
+		return apperrors.NewBadRequest(apperrors.MessageRequired)
+	}
 
-		if err != nil {
-			return
-		}
+	cs := client.hub.channelService
+	channel, err := cs.Get(message.Room)
 
-		msg := model.WebsocketMessage{
-			Action: RequestCountEmission,
-			Data:   count,
-		}
-		room.broadcast <- &msg
+	if err != nil {
+		return err
 	}
-}
 
-// handleTypingEvent emits the username of the currently typing user to the room
-func (client *Client) handleTypingEvent(message model.ReceivedMessage, action string) {
-	roomID := message.Room
-	if room := client.hub.findRoomById(roomID); room != nil {
-		msg := model.WebsocketMessage{
-			Action: action,
-			Data:   message.Message,
-		}
-		room.broadcast <- &msg
+	// Check if the user has access to the given channel
+	if _, err = cs.GetPermissions(channel, client.ID); err != nil {
+		return err
 	}
-}
-
-// toggleOnlineStatus updates the users online status and emits it to all
-// guilds the user is a member of and all of their friends
-func (client *Client) toggleOnlineStatus(isOnline bool) {
-	uid := client.ID
-	us := client.hub.userService
 
-	user, err := us.Get(uid)
+	state, err := cs.AckChannel(channel, client.ID, *message.Message)
 
 	if err != nil {
-		log.Printf("could not find user: %v", err)
-		return
+		return err
 	}
 
-	user.IsOnline = isOnline
+	if room := client.hub.findRoomById(client.ID); room != nil {
+		msg := model.WebsocketMessage{
+			Action: AckAction,
+			Data:   state,
+		}
+		room.broadcast <- &msg
+	}
 
-	if err := us.UpdateAccount(user); err != nil {
-		log.Printf("could not update user: %v", err)
-		return
+	return nil
+}
+
+// handleGetRequestCount returns the users incoming friend request count
+func (client *Client) handleGetRequestCount() error {
+	room := client.hub.findRoomById(client.ID)
+
+	if room == nil {
+		return apperrors.NewBadRequest(apperrors.JoinRoomFirst)
 	}
 
-	ids, err := us.GetFriendAndGuildIds(uid)
+	count, err := client.hub.userService.GetRequestCount(client.ID)
 
 	if err != nil {
-		log.Printf("could not find ids: %v", err)
-		return
+		return err
 	}
 
-	action := ToggleOfflineEmission
-	if isOnline {
-		action = ToggleOnlineEmission
+	msg := model.WebsocketMessage{
+		Action: RequestCountEmission,
+		Data:   count,
 	}
+	room.broadcast <- &msg
 
-	for _, id := range *ids {
-		if room := client.hub.findRoomById(id); room != nil {
-			msg := model.WebsocketMessage{
-				Action: action,
-				Data:   uid,
-			}
-			room.broadcast <- &msg
-		}
-	}
+	return nil
 }
 
 // isMember checks if the user is member of the given guild
@@ -0,0 +1,162 @@
+package ws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+// nextReply returns the acknowledgement or error sent to the client, nil if there is none
+func nextReply(client *Client) []byte {
+	select {
+	case reply := <-client.replies:
+		return reply
+	default:
+		return nil
+	}
+}
+
+func TestClient_Reply(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nonce    string
+		err      error
+		expected *replyFrame
+		action   string
+	}{
+		{
+			name: "Successful action without nonce",
+		},
+		{
+			name:     "Successful action with nonce",
+			nonce:    "1",
+			action:   ActionAckEmission,
+			expected: &replyFrame{Nonce: "1", Action: JoinGuildAction},
+		},
+		{
+			name:     "App error",
+			nonce:    "2",
+			err:      apperrors.NewAuthorization(apperrors.NotAMember),
+			action:   ActionErrorEmission,
+			expected: &replyFrame{Nonce: "2", Action: JoinGuildAction, Code: apperrors.Authorization, Message: apperrors.NotAMember},
+		},
+		{
+			name:     "App error without nonce",
+			err:      apperrors.NewNotFound("guild", "1"),
+			action:   ActionErrorEmission,
+			expected: &replyFrame{Action: JoinGuildAction, Code: apperrors.NotFound, Message: "resource: guild with value: 1 not found"},
+		},
+		{
+			name:     "Other errors are internal errors",
+			nonce:    "3",
+			err:      errors.New("connection refused"),
+			action:   ActionErrorEmission,
+			expected: &replyFrame{Nonce: "3", Action: JoinGuildAction, Code: apperrors.Internal, Message: apperrors.NewInternal().Message},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := newTestHub(t)
+			client := newTestClient(hub, fixture.RandID())
+
+			client.reply(model.ReceivedMessage{Action: JoinGuildAction, Room: fixture.RandID(), Nonce: tc.nonce}, tc.err)
+			reply := nextReply(client)
+
+			if tc.expected == nil {
+				assert.Nil(t, reply)
+				return
+			}
+
+			var frame replyFrame
+			assert.NotNil(t, reply)
+			assert.Equal(t, tc.action, decodeEvent(t, reply, &frame).Action)
+			assert.Equal(t, *tc.expected, frame)
+		})
+	}
+
+	t.Run("Drops replies if the client is not reading", func(t *testing.T) {
+		hub := newTestHub(t)
+		client := newTestClient(hub, fixture.RandID())
+
+		for i := 0; i < cap(client.replies)+1; i++ {
+			client.reply(model.ReceivedMessage{Action: JoinGuildAction, Nonce: "1"}, nil)
+		}
+
+		assert.Len(t, client.replies, cap(client.replies))
+	})
+}
+
+func TestClient_HandleNewMessage(t *testing.T) {
+	user := fixture.GetMockUser()
+
+	testCases := []struct {
+		name    string
+		message string
+		err     *apperrors.Error
+	}{
+		{
+			name:    "Invalid frame",
+			message: `{"action":`,
+			err:     apperrors.NewBadRequest(apperrors.InvalidFrame),
+		},
+		{
+			name:    "Unknown action",
+			message: `{"action":"toggleOnline","nonce":"1"}`,
+			err:     apperrors.NewBadRequest(apperrors.UnknownAction),
+		},
+		{
+			name:    "Joining the room of another user",
+			message: `{"action":"joinUser","room":"` + fixture.RandID() + `","nonce":"1"}`,
+			err:     apperrors.NewAuthorization(apperrors.JoinUserError),
+		},
+		{
+			name:    "Resuming without a sequence number",
+			message: `{"action":"resume","room":"` + fixture.RandID() + `","nonce":"1"}`,
+			err:     apperrors.NewBadRequest(apperrors.MessageRequired),
+		},
+		{
+			name:    "Typing in a channel that was not joined",
+			message: `{"action":"startTyping","room":"` + fixture.RandID() + `","nonce":"1"}`,
+			err:     apperrors.NewBadRequest(apperrors.JoinRoomFirst),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := newTestHub(t)
+			client := newTestClient(hub, user.ID)
+
+			client.handleNewMessage([]byte(tc.message))
+			reply := nextReply(client)
+
+			var frame replyFrame
+			assert.NotNil(t, reply)
+			assert.Equal(t, ActionErrorEmission, decodeEvent(t, reply, &frame).Action)
+			assert.Equal(t, tc.err.Type, frame.Code)
+			assert.Equal(t, tc.err.Message, frame.Message)
+			assert.Len(t, client.subscriptions(), 0)
+		})
+	}
+
+	t.Run("Acknowledges joining a guild", func(t *testing.T) {
+		hub := newTestHub(t)
+		guild := fixture.GetMockGuild("")
+		guild.Members = []model.User{*user}
+		hub.guildService.On("GetGuild", guild.ID).Return(guild, nil)
+
+		client := newTestClient(hub, user.ID)
+		client.handleNewMessage([]byte(`{"action":"joinGuild","room":"` + guild.ID + `","nonce":"abc"}`))
+		reply := nextReply(client)
+
+		var frame replyFrame
+		assert.NotNil(t, reply)
+		assert.Equal(t, ActionAckEmission, decodeEvent(t, reply, &frame).Action)
+		assert.Equal(t, replyFrame{Nonce: "abc", Action: JoinGuildAction}, frame)
+		assert.Equal(t, []string{guild.ID}, roomIds(client))
+	})
+}
@@ -0,0 +1,136 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// discardLogger silences redis, which logs the closed subscriptions of the rooms after every test
+type discardLogger struct{}
+
+func (discardLogger) Printf(context.Context, string, ...interface{}) {}
+
+func TestMain(m *testing.M) {
+	redis.SetLogger(discardLogger{})
+	os.Exit(m.Run())
+}
+
+// testHub is a hub using an in-memory redis and mocked services
+type testHub struct {
+	*Hub
+	mr             *miniredis.Miniredis
+	userService    *mocks.UserService
+	guildService   *mocks.GuildService
+	channelService *mocks.ChannelService
+}
+
+func newTestHub(t *testing.T) *testHub {
+	mr := miniredis.RunT(t)
+	rds := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rds.Close() })
+
+	userService := new(mocks.UserService)
+	guildService := new(mocks.GuildService)
+	channelService := new(mocks.ChannelService)
+
+	hub := NewWebsocketHub(&Config{
+		UserService:    userService,
+		GuildService:   guildService,
+		ChannelService: channelService,
+		Redis:          rds,
+	})
+
+	return &testHub{
+		Hub:            hub,
+		mr:             mr,
+		userService:    userService,
+		guildService:   guildService,
+		channelService: channelService,
+	}
+}
+
+// newTestClient returns a client of the user without a connection
+func newTestClient(hub *testHub, userId string) *Client {
+	return newClient(nil, hub.Hub, userId, "")
+}
+
+// roomIds returns the sorted IDs of the rooms the client subscribed to
+func roomIds(client *Client) []string {
+	ids := make([]string, 0)
+	for room := range client.subscriptions() {
+		ids = append(ids, room.GetId())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// sortedIds returns the IDs in the order of roomIds
+func sortedIds(ids ...string) []string {
+	sorted := append([]string{}, ids...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// event is an emitted message with its data left encoded
+type event struct {
+	Seq    int64           `json:"seq"`
+	Action string          `json:"action"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// decodeEvent decodes the emitted message and its data
+func decodeEvent(t *testing.T, payload []byte, data interface{}) event {
+	var e event
+	assert.NoError(t, json.Unmarshal(payload, &e))
+
+	if data != nil {
+		assert.NoError(t, json.Unmarshal(e.Data, data))
+	}
+
+	return e
+}
+
+// listen returns the messages published to the room
+func listen(t *testing.T, hub *testHub, roomId string) <-chan *redis.Message {
+	pubsub := hub.redisClient.Subscribe(ctx, roomId)
+	t.Cleanup(func() { _ = pubsub.Close() })
+
+	// Wait until the subscription is active
+	_, err := pubsub.Receive(ctx)
+	assert.NoError(t, err)
+
+	return pubsub.Channel()
+}
+
+// nextEvent waits for the next message published to the room and decodes its data
+func nextEvent(t *testing.T, ch <-chan *redis.Message, data interface{}) event {
+	select {
+	case msg := <-ch:
+		return decodeEvent(t, []byte(msg.Payload), data)
+	case <-time.After(time.Second):
+		t.Fatal("no event got published")
+		return event{}
+	}
+}
+
+// noEvent checks that nothing got published to the room
+func noEvent(t *testing.T, ch <-chan *redis.Message) {
+	select {
+	case msg := <-ch:
+		t.Fatalf("unexpected event: %s", msg.Payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -3,14 +3,21 @@ package ws
 import (
 	"github.com/go-redis/redis/v8"
 	"github.com/sentrionic/valkyrie/model"
+	"log"
 )
 
+// revokedSessionsChannel is the redis channel revoked session IDs get published to,
+// so that every instance closes the connections opened with them
+const revokedSessionsChannel = "revoked-sessions"
+
 // Hub contains all rooms and clients
 type Hub struct {
 	clients        map[*Client]bool
 	register       chan *Client
 	unregister     chan *Client
 	broadcast      chan []byte
+	revoke         chan string
+	checks         chan *subscriptionCheck
 	rooms          map[*Room]bool
 	channelService model.ChannelService
 	guildService   model.GuildService
@@ -34,6 +41,8 @@ func NewWebsocketHub(c *Config) *Hub {
 		register:       make(chan *Client),
 		unregister:     make(chan *Client),
 		broadcast:      make(chan []byte),
+		revoke:         make(chan string),
+		checks:         make(chan *subscriptionCheck),
 		rooms:          make(map[*Room]bool),
 		channelService: c.ChannelService,
 		guildService:   c.GuildService,
@@ -44,6 +53,12 @@ func NewWebsocketHub(c *Config) *Hub {
 
 // Run our websocket server, accepting various requests
 func (hub *Hub) Run() {
+
+	go hub.subscribeToRevokedSessions()
+	go hub.subscribeToSubscriptionChecks()
+	go hub.sweepPresences()
+	go hub.sweepTyping()
+
 	for {
 		select {
 
@@ -55,6 +70,12 @@ func (hub *Hub) Run() {
 
 		case message := <-hub.broadcast:
 			hub.broadcastToClients(message)
+
+		case sessionId := <-hub.revoke:
+			hub.closeSessionClients(sessionId)
+
+		case check := <-hub.checks:
+			hub.checkSubscriptions(check)
 		}
 	}
 }
@@ -73,6 +94,33 @@ func (hub *Hub) broadcastToClients(message []byte) {
 	}
 }
 
+// closeSessionClients closes all connections opened with the given session
+func (hub *Hub) closeSessionClients(sessionId string) {
+	for client := range hub.clients {
+		if client.SessionId != "" && client.SessionId == sessionId {
+			client.close()
+		}
+	}
+}
+
+// CloseSession closes the connections of the given session on all instances
+func (hub *Hub) CloseSession(sessionId string) {
+	if err := hub.redisClient.Publish(ctx, revokedSessionsChannel, sessionId).Err(); err != nil {
+		log.Println(err)
+	}
+}
+
+// subscribeToRevokedSessions listens for revoked sessions
+func (hub *Hub) subscribeToRevokedSessions() {
+	pubsub := hub.redisClient.Subscribe(ctx, revokedSessionsChannel)
+
+	ch := pubsub.Channel()
+
+	for msg := range ch {
+		hub.revoke <- msg.Payload
+	}
+}
+
 // BroadcastToRoom sends the given message to all clients connected to the given room
 func (hub *Hub) BroadcastToRoom(message []byte, roomId string) {
 	if room := hub.findRoomById(roomId); room != nil {
@@ -80,6 +128,14 @@ func (hub *Hub) BroadcastToRoom(message []byte, roomId string) {
 	}
 }
 
+// publish sends the message to the clients of the room on all instances,
+// even if no client of this instance joined the room
+func (hub *Hub) publish(roomId string, message *model.WebsocketMessage) {
+	if err := hub.redisClient.Publish(ctx, roomId, message.Encode()).Err(); err != nil {
+		log.Println(err)
+	}
+}
+
 func (hub *Hub) findRoomById(id string) *Room {
 	var foundRoom *Room
 	for room := range hub.rooms {
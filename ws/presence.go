@@ -0,0 +1,244 @@
+package ws
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+)
+
+const (
+	// How long a connection counts as online without a heartbeat
+	presenceTTL = 2 * pingPeriod
+
+	// How often expired connections and custom statuses are looked for
+	presenceSweepInterval = 15 * time.Second
+
+	// Maximum length of a custom status
+	maxCustomStatusLength = 128
+
+	// Sorted sets of user IDs, scored by when their presence has to be computed again
+	presenceDueKey     = "presence-due"
+	customStatusDueKey = "presence-custom-status-due"
+)
+
+// presenceScript computes the presence of the user from their live connections and chosen status.
+// Returns nil if the presence did not change since it got emitted the last time.
+var presenceScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+local status = 'offline'
+if redis.call('ZCARD', KEYS[1]) > 0 then
+	status = redis.call('HGET', KEYS[2], 'status') or 'online'
+	if status == 'invisible' then
+		status = 'offline'
+	end
+end
+local text = ''
+local expiresAt = tonumber(redis.call('HGET', KEYS[2], 'expiresAt') or '0')
+if status ~= 'offline' and (expiresAt == 0 or expiresAt > tonumber(ARGV[1])) then
+	text = redis.call('HGET', KEYS[2], 'customStatus') or ''
+end
+if text == '' then
+	expiresAt = 0
+end
+local current = status .. ':' .. expiresAt .. ':' .. text
+if redis.call('HGET', KEYS[2], 'last') == current then
+	return false
+end
+redis.call('HSET', KEYS[2], 'last', current)
+return {status, text, tostring(expiresAt)}
+`)
+
+// presenceUpdate is the message of the updatePresence action
+type presenceUpdate struct {
+	Status       string     `json:"status"`
+	CustomStatus string     `json:"customStatus"`
+	ExpiresAt    *time.Time `json:"expiresAt"`
+}
+
+// heartbeat marks the connection as online for another presenceTTL
+func (hub *Hub) heartbeat(client *Client) {
+	expiry := float64(time.Now().Add(presenceTTL).Unix())
+	key := model.PresenceConnectionsKey(client.ID)
+
+	_, err := hub.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, &redis.Z{Score: expiry, Member: client.connId})
+		pipe.Expire(ctx, key, presenceTTL)
+		pipe.ZAdd(ctx, presenceDueKey, &redis.Z{Score: expiry, Member: client.ID})
+		return nil
+	})
+
+	if err != nil {
+		log.Printf("error updating heartbeat: %v\n", err)
+	}
+}
+
+// connectPresence adds the connection to the user's presence
+func (hub *Hub) connectPresence(client *Client) {
+	hub.heartbeat(client)
+	hub.updatePresence(client.ID)
+}
+
+// disconnectPresence removes the connection from the user's presence
+func (hub *Hub) disconnectPresence(client *Client) {
+	if err := hub.redisClient.ZRem(ctx, model.PresenceConnectionsKey(client.ID), client.connId).Err(); err != nil {
+		log.Printf("error removing connection: %v\n", err)
+	}
+	hub.updatePresence(client.ID)
+}
+
+// updatePresence computes the presence of the user and emits it to their friends and guilds if it changed.
+// The IsOnline flag of the user gets kept in sync, so that lists of users show who is online.
+func (hub *Hub) updatePresence(userId string) {
+	keys := []string{model.PresenceConnectionsKey(userId), model.PresenceKey(userId)}
+	value, err := presenceScript.Run(ctx, hub.redisClient, keys, time.Now().Unix()).Result()
+
+	if err == redis.Nil {
+		return
+	}
+
+	result, _ := value.([]interface{})
+	if err != nil || len(result) != 3 {
+		log.Printf("error computing presence: %v\n", err)
+		return
+	}
+
+	status, _ := result[0].(string)
+	customStatus, _ := result[1].(string)
+	presence := model.Presence{
+		UserId:       userId,
+		Status:       status,
+		CustomStatus: customStatus,
+	}
+
+	expiry, _ := result[2].(string)
+	if expiresAt, _ := strconv.ParseInt(expiry, 10, 64); expiresAt > 0 {
+		t := time.Unix(expiresAt, 0).UTC()
+		presence.CustomStatusExpiresAt = &t
+	}
+
+	us := hub.userService
+	user, err := us.Get(userId)
+
+	if err != nil {
+		log.Printf("could not find user: %v", err)
+		return
+	}
+
+	if isOnline := presence.Status != model.StatusOffline; user.IsOnline != isOnline {
+		user.IsOnline = isOnline
+		if err = us.UpdateAccount(user); err != nil {
+			log.Printf("could not update user: %v", err)
+		}
+	}
+
+	ids, err := us.GetFriendAndGuildIds(userId)
+
+	if err != nil {
+		log.Printf("could not find ids: %v", err)
+		return
+	}
+
+	msg := model.WebsocketMessage{
+		Action: PresenceUpdateEmission,
+		Data:   presence,
+	}
+
+	for _, id := range *ids {
+		hub.publish(id, &msg)
+	}
+}
+
+// sweepPresences updates the presence of users whose connections or custom status expired,
+// e.g. because their client or the instance they were connected to crashed
+func (hub *Hub) sweepPresences() {
+	ticker := time.NewTicker(presenceSweepInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		hub.expirePresences(now)
+	}
+}
+
+// expirePresences updates the presence of the users that are due at the given time
+func (hub *Hub) expirePresences(now time.Time) {
+	max := strconv.FormatInt(now.Unix(), 10)
+
+	for _, key := range []string{presenceDueKey, customStatusDueKey} {
+		ids, err := hub.redisClient.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: "-inf", Max: max}).Result()
+
+		if err != nil {
+			log.Printf("error sweeping presences: %v\n", err)
+			continue
+		}
+
+		for _, id := range ids {
+			// Only the instance removing the entry updates the presence
+			if removed, _ := hub.redisClient.ZRem(ctx, key, id).Result(); removed == 1 {
+				hub.updatePresence(id)
+			}
+		}
+	}
+}
+
+// handleUpdatePresenceMessage sets the chosen status and custom status of the user.
+// The message contains the presenceUpdate as JSON.
+func (client *Client) handleUpdatePresenceMessage(message model.ReceivedMessage) error {
+	if message.Message == nil {
+		return apperrors.NewBadRequest(apperrors.MessageRequired)
+	}
+
+	var update presenceUpdate
+	if err := json.Unmarshal([]byte(*message.Message), &update); err != nil {
+		return apperrors.NewBadRequest(apperrors.InvalidFrame)
+	}
+
+	if !isPresenceStatus(update.Status) {
+		return apperrors.NewBadRequest(apperrors.InvalidStatus)
+	}
+
+	if utf8.RuneCountInString(update.CustomStatus) > maxCustomStatusLength {
+		return apperrors.NewBadRequest(apperrors.CustomStatusLen)
+	}
+
+	var expiresAt int64
+	if update.ExpiresAt != nil && update.CustomStatus != "" {
+		if !update.ExpiresAt.After(time.Now()) {
+			return apperrors.NewBadRequest(apperrors.ExpiryInPast)
+		}
+		expiresAt = update.ExpiresAt.Unix()
+	}
+
+	rds := client.hub.redisClient
+	_, err := rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, model.PresenceKey(client.ID), "status", update.Status, "customStatus", update.CustomStatus, "expiresAt", expiresAt)
+		if expiresAt > 0 {
+			pipe.ZAdd(ctx, customStatusDueKey, &redis.Z{Score: float64(expiresAt), Member: client.ID})
+		} else {
+			pipe.ZRem(ctx, customStatusDueKey, client.ID)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	client.hub.updatePresence(client.ID)
+	return nil
+}
+
+// isPresenceStatus checks if the status can be chosen by users
+func isPresenceStatus(status string) bool {
+	for _, s := range model.PresenceStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
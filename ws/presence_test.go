@@ -0,0 +1,192 @@
+package ws
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// updatePresenceMessage returns the updatePresence action with the given JSON message
+func updatePresenceMessage(message string) model.ReceivedMessage {
+	return model.ReceivedMessage{Action: UpdatePresenceAction, Message: &message}
+}
+
+func TestHub_UpdatePresence(t *testing.T) {
+	hub := newTestHub(t)
+	user := fixture.GetMockUser()
+	friendId := fixture.RandID()
+	client := newTestClient(hub, user.ID)
+
+	hub.userService.On("Get", user.ID).Return(user, nil)
+	hub.userService.On("UpdateAccount", user).Return(nil)
+	hub.userService.On("GetFriendAndGuildIds", user.ID).Return(&[]string{friendId}, nil)
+
+	friend := listen(t, hub, friendId)
+
+	// expireConnection lets the heartbeat of the connection run out
+	expireConnection := func() {
+		past := float64(time.Now().Add(-time.Second).Unix())
+		hub.mr.ZAdd(model.PresenceConnectionsKey(user.ID), past, client.connId)
+		hub.mr.ZAdd(presenceDueKey, past, user.ID)
+	}
+
+	steps := []struct {
+		name   string
+		action func()
+		// The emitted presence, nil if nothing should be emitted
+		expected *model.Presence
+		isOnline bool
+	}{
+		{
+			name:     "Connecting makes the user online",
+			action:   func() { hub.connectPresence(client) },
+			expected: &model.Presence{Status: model.StatusOnline},
+			isOnline: true,
+		},
+		{
+			name:     "Unchanged presences are not emitted",
+			action:   func() { hub.updatePresence(user.ID) },
+			isOnline: true,
+		},
+		{
+			name: "Chosen status and custom status",
+			action: func() {
+				assert.NoError(t, client.handleUpdatePresenceMessage(updatePresenceMessage(`{"status":"dnd","customStatus":"Busy"}`)))
+			},
+			expected: &model.Presence{Status: model.StatusDND, CustomStatus: "Busy"},
+			isOnline: true,
+		},
+		{
+			name: "Invisible users appear offline without their custom status",
+			action: func() {
+				assert.NoError(t, client.handleUpdatePresenceMessage(updatePresenceMessage(`{"status":"invisible","customStatus":"Busy"}`)))
+			},
+			expected: &model.Presence{Status: model.StatusOffline},
+		},
+		{
+			name: "Back online",
+			action: func() {
+				assert.NoError(t, client.handleUpdatePresenceMessage(updatePresenceMessage(`{"status":"online"}`)))
+			},
+			expected: &model.Presence{Status: model.StatusOnline},
+			isOnline: true,
+		},
+		{
+			name: "Heartbeats that are not due yet are not swept",
+			action: func() {
+				hub.heartbeat(client)
+				hub.expirePresences(time.Now())
+			},
+			isOnline: true,
+		},
+		{
+			name: "Missing heartbeats make the user offline",
+			action: func() {
+				expireConnection()
+				hub.expirePresences(time.Now())
+			},
+			expected: &model.Presence{Status: model.StatusOffline},
+		},
+		{
+			name: "A heartbeat makes the user online again",
+			action: func() {
+				hub.connectPresence(client)
+			},
+			expected: &model.Presence{Status: model.StatusOnline},
+			isOnline: true,
+		},
+		{
+			name: "Expired custom statuses are removed",
+			action: func() {
+				expiresAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+				assert.NoError(t, client.handleUpdatePresenceMessage(updatePresenceMessage(`{"status":"idle","customStatus":"Lunch","expiresAt":"`+expiresAt+`"}`)))
+				nextEvent(t, friend, nil)
+
+				past := time.Now().Add(-time.Second).Unix()
+				hub.mr.HSet(model.PresenceKey(user.ID), "expiresAt", strconv.FormatInt(past, 10))
+				hub.mr.ZAdd(customStatusDueKey, float64(past), user.ID)
+				hub.expirePresences(time.Now())
+			},
+			expected: &model.Presence{Status: model.StatusIdle},
+			isOnline: true,
+		},
+		{
+			name:     "Disconnecting makes the user offline",
+			action:   func() { hub.disconnectPresence(client) },
+			expected: &model.Presence{Status: model.StatusOffline},
+		},
+	}
+
+	for _, step := range steps {
+		step.action()
+
+		if step.expected == nil {
+			noEvent(t, friend)
+		} else {
+			var presence model.Presence
+			e := nextEvent(t, friend, &presence)
+			step.expected.UserId = user.ID
+
+			assert.Equal(t, PresenceUpdateEmission, e.Action, step.name)
+			assert.Equal(t, *step.expected, presence, step.name)
+		}
+
+		assert.Equal(t, step.isOnline, user.IsOnline, step.name)
+	}
+
+	hub.userService.AssertCalled(t, "UpdateAccount", mock.Anything)
+}
+
+func TestClient_HandleUpdatePresenceMessage(t *testing.T) {
+	past := time.Now().Add(-time.Minute).Format(time.RFC3339)
+
+	testCases := []struct {
+		name    string
+		message *string
+		reason  string
+	}{
+		{
+			name:   "Message required",
+			reason: apperrors.MessageRequired,
+		},
+		{
+			name:    "Invalid JSON",
+			message: stringPtr(`{"status":`),
+			reason:  apperrors.InvalidFrame,
+		},
+		{
+			name:    "Unknown status",
+			message: stringPtr(`{"status":"offline"}`),
+			reason:  apperrors.InvalidStatus,
+		},
+		{
+			name:    "Custom status too long",
+			message: stringPtr(`{"status":"online","customStatus":"` + strings.Repeat("a", maxCustomStatusLength+1) + `"}`),
+			reason:  apperrors.CustomStatusLen,
+		},
+		{
+			name:    "Expiry in the past",
+			message: stringPtr(`{"status":"online","customStatus":"Lunch","expiresAt":"` + past + `"}`),
+			reason:  apperrors.ExpiryInPast,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := newTestHub(t)
+			client := newTestClient(hub, fixture.RandID())
+
+			err := client.handleUpdatePresenceMessage(model.ReceivedMessage{Action: UpdatePresenceAction, Message: tc.message})
+
+			assert.Equal(t, apperrors.NewBadRequest(tc.reason), err)
+			assert.False(t, hub.mr.Exists(model.PresenceKey(client.ID)))
+		})
+	}
+}
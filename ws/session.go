@@ -0,0 +1,338 @@
+package ws
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+)
+
+const (
+	// How long a dropped connection keeps recording events so that the client can resume its session
+	resumeWindow = 2 * time.Minute
+
+	// Maximum number of events kept per session for replay
+	replayBufferSize = 500
+)
+
+// sessionKey is the redis hash containing the owner, sequence and rooms of the session
+func sessionKey(id string) string {
+	return "ws-session:" + id
+}
+
+// eventsKey is the redis stream containing the last events of the session.
+// The ID of every entry is the sequence number of the event.
+func eventsKey(id string) string {
+	return "ws-events:" + id
+}
+
+// recordScript stamps the event with the next sequence number of the session and appends it
+// to the replay buffer. Only the connection owning the session may record, -1 is returned otherwise.
+var recordScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'owner') ~= ARGV[1] then
+	return -1
+end
+local seq = redis.call('HINCRBY', KEYS[1], 'seq', 1)
+redis.call('XADD', KEYS[2], 'MAXLEN', '~', ARGV[3], seq .. '-0', 'data', ARGV[2])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+redis.call('EXPIRE', KEYS[2], ARGV[4])
+return seq
+`)
+
+// saveRoomsScript stores the rooms of the session if the connection still owns it
+var saveRoomsScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'owner') == ARGV[1] then
+	redis.call('HSET', KEYS[1], 'rooms', ARGV[2])
+end
+return 0
+`)
+
+// sessionFrame is sent when a session got opened or resumed
+type sessionFrame struct {
+	SessionId string `json:"sessionId"`
+	Seq       int64  `json:"seq"`
+}
+
+// storedSubscription is a subscription as saved in the session, so that it can be restored on resume
+type storedSubscription struct {
+	Room    string `json:"room"`
+	Kind    string `json:"kind"`
+	GuildId string `json:"guildId,omitempty"`
+}
+
+// resumeRequest asks the write pump to take over the given session
+type resumeRequest struct {
+	sessionId string
+	seq       int64
+}
+
+// openSession creates a new resumable session owned by the client
+func (client *Client) openSession() error {
+	id, err := gonanoid.New()
+
+	if err != nil {
+		return err
+	}
+
+	key := sessionKey(id)
+	_, err = client.hub.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "userId", client.ID, "owner", client.connId, "seq", 0, "rooms", "[]")
+		pipe.Expire(ctx, key, resumeWindow)
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	client.mu.Lock()
+	client.sessionId = id
+	client.mu.Unlock()
+
+	return nil
+}
+
+// resumableSession returns the ID of the session the client records its events in
+func (client *Client) resumableSession() string {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.sessionId
+}
+
+// refreshSession keeps the session alive while the connection is idle
+func (client *Client) refreshSession() {
+	id := client.resumableSession()
+	if id == "" {
+		return
+	}
+
+	_, err := client.hub.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Expire(ctx, sessionKey(id), resumeWindow)
+		pipe.Expire(ctx, eventsKey(id), resumeWindow)
+		return nil
+	})
+
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// record stamps the events with their sequence numbers and saves them for replay.
+// If another connection took over the session, the client gets released and nil is returned.
+// Events that could not be recorded are delivered without a sequence number.
+func (client *Client) record(events [][]byte) [][]byte {
+	id := client.resumableSession()
+	if id == "" {
+		return events
+	}
+
+	keys := []string{sessionKey(id), eventsKey(id)}
+	ttl := int(resumeWindow.Seconds())
+	stamped := make([][]byte, 0, len(events))
+
+	for _, event := range events {
+		seq, err := recordScript.Run(ctx, client.hub.redisClient, keys, client.connId, event, replayBufferSize, ttl).Int64()
+
+		if err != nil {
+			log.Printf("error recording event: %v\n", err)
+			stamped = append(stamped, event)
+			continue
+		}
+
+		if seq < 0 {
+			go client.release()
+			return nil
+		}
+
+		stamped = append(stamped, stamp(event, seq))
+	}
+
+	return stamped
+}
+
+// stamp adds the sequence number to the encoded event
+func stamp(event []byte, seq int64) []byte {
+	if len(event) < 2 || event[0] != '{' {
+		return event
+	}
+
+	stamped := make([]byte, 0, len(event)+24)
+	stamped = append(stamped, `{"seq":`...)
+	stamped = strconv.AppendInt(stamped, seq, 10)
+	if event[1] != '}' {
+		stamped = append(stamped, ',')
+	}
+
+	return append(stamped, event[1:]...)
+}
+
+// saveSubscriptions stores the rooms of the client in the session
+func (client *Client) saveSubscriptions() {
+	id := client.resumableSession()
+	if id == "" {
+		return
+	}
+
+	var subs []storedSubscription
+	for room, sub := range client.subscriptions() {
+		subs = append(subs, storedSubscription{Room: room.GetId(), Kind: sub.kind, GuildId: sub.guildId})
+	}
+
+	data, err := json.Marshal(subs)
+
+	if err != nil {
+		log.Printf("error marshalling subscriptions: %v\n", err)
+		return
+	}
+
+	keys := []string{sessionKey(id)}
+	if err = saveRoomsScript.Run(ctx, client.hub.redisClient, keys, client.connId, data).Err(); err != nil {
+		log.Println(err)
+	}
+}
+
+// restoreSubscriptions joins the stored rooms again. Every room gets authorized again,
+// as the permissions could have changed while the client was disconnected.
+func (client *Client) restoreSubscriptions(data string) {
+	var subs []storedSubscription
+	if err := json.Unmarshal([]byte(data), &subs); err != nil {
+		return
+	}
+
+	for _, stored := range subs {
+		switch stored.Kind {
+		case userRoom:
+			if stored.Room == client.ID {
+				client.subscribe(stored.Room, &subscription{kind: userRoom})
+			}
+		case guildRoom:
+			if sub, err := client.hub.authorizeGuild(stored.Room, client.ID); err == nil {
+				client.subscribe(stored.Room, sub)
+			}
+		case channelRoom:
+			if sub, err := client.hub.authorizeChannel(stored.Room, client.ID); err == nil {
+				client.subscribe(stored.Room, sub)
+			}
+		}
+	}
+}
+
+// handleResumeMessage asks the write pump to resume the given session.
+// The message contains the sequence number of the last event the client received.
+func (client *Client) handleResumeMessage(message model.ReceivedMessage) error {
+	if message.Message == nil {
+		return apperrors.NewBadRequest(apperrors.MessageRequired)
+	}
+
+	seq, err := strconv.ParseInt(*message.Message, 10, 64)
+	if err != nil || seq < 0 {
+		return apperrors.NewBadRequest(apperrors.InvalidSequence)
+	}
+
+	// Only one resume can be pending
+	select {
+	case client.resume <- &resumeRequest{sessionId: message.Room, seq: seq}:
+		return nil
+	default:
+		return apperrors.NewTooManyRequests(apperrors.ResumePending)
+	}
+}
+
+// resumeSession takes over the requested session and replays the events the client missed.
+// If the session cannot be resumed, the client has to fetch its state again.
+// Events emitted while the connection takes over the session may be delivered twice.
+func (client *Client) resumeSession(req *resumeRequest) []byte {
+	invalid := (&model.WebsocketMessage{Action: InvalidSessionAction}).Encode()
+
+	if req.sessionId == client.resumableSession() {
+		return invalid
+	}
+
+	rds := client.hub.redisClient
+	state, err := rds.HGetAll(ctx, sessionKey(req.sessionId)).Result()
+
+	if err != nil || state["userId"] != client.ID {
+		return invalid
+	}
+
+	seq, err := strconv.ParseInt(state["seq"], 10, 64)
+
+	if err != nil || req.seq > seq {
+		return invalid
+	}
+
+	// Check that the buffer still contains all missed events
+	if req.seq < seq {
+		first, err := rds.XRangeN(ctx, eventsKey(req.sessionId), "-", "+", 1).Result()
+
+		if err != nil || len(first) == 0 || sequenceOf(first[0]) > req.seq+1 {
+			return invalid
+		}
+	}
+
+	client.restoreSubscriptions(state["rooms"])
+
+	// The previous connection stops recording with its next event
+	if err = rds.HSet(ctx, sessionKey(req.sessionId), "owner", client.connId).Err(); err != nil {
+		return invalid
+	}
+
+	entries, err := rds.XRange(ctx, eventsKey(req.sessionId), strconv.FormatInt(req.seq+1, 10), "+").Result()
+
+	if err != nil {
+		return invalid
+	}
+
+	previous := client.resumableSession()
+	client.mu.Lock()
+	client.sessionId = req.sessionId
+	client.mu.Unlock()
+	rds.Del(ctx, sessionKey(previous), eventsKey(previous))
+	client.saveSubscriptions()
+
+	replay := make([][]byte, 0, len(entries)+1)
+	for _, entry := range entries {
+		if data, ok := entry.Values["data"].(string); ok {
+			seq = sequenceOf(entry)
+			replay = append(replay, stamp([]byte(data), seq))
+		}
+	}
+
+	resumed := model.WebsocketMessage{
+		Action: ResumedAction,
+		Data:   sessionFrame{SessionId: req.sessionId, Seq: seq},
+	}
+	replay = append(replay, resumed.Encode())
+
+	return joinEvents(replay)
+}
+
+// sequenceOf returns the sequence number of the stream entry
+func sequenceOf(entry redis.XMessage) int64 {
+	for i, c := range entry.ID {
+		if c == '-' {
+			seq, _ := strconv.ParseInt(entry.ID[:i], 10, 64)
+			return seq
+		}
+	}
+
+	seq, _ := strconv.ParseInt(entry.ID, 10, 64)
+	return seq
+}
+
+// joinEvents puts the events into a single websocket message, separated by newlines
+func joinEvents(events [][]byte) []byte {
+	var joined []byte
+	for i, event := range events {
+		if i > 0 {
+			joined = append(joined, newline...)
+		}
+		joined = append(joined, event...)
+	}
+	return joined
+}
@@ -0,0 +1,183 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordEvents records the given amount of events in the client's session
+func recordEvents(t *testing.T, client *Client, n int) {
+	for i := 1; i <= n; i++ {
+		msg := model.WebsocketMessage{Action: fmt.Sprintf("event-%d", i)}
+		stamped := client.record([][]byte{msg.Encode()})
+		assert.Len(t, stamped, 1)
+	}
+}
+
+// decodeFrame decodes the events of a websocket message
+func decodeFrame(t *testing.T, frame []byte) []event {
+	var events []event
+	for _, line := range strings.Split(string(frame), "\n") {
+		events = append(events, decodeEvent(t, []byte(line), nil))
+	}
+	return events
+}
+
+func TestClient_Record(t *testing.T) {
+	t.Run("Stamps the events with increasing sequence numbers", func(t *testing.T) {
+		hub := newTestHub(t)
+		client := newTestClient(hub, fixture.RandID())
+		assert.NoError(t, client.openSession())
+
+		first := (&model.WebsocketMessage{Action: "first"}).Encode()
+		second := (&model.WebsocketMessage{Action: "second"}).Encode()
+		stamped := client.record([][]byte{first, second})
+
+		assert.Len(t, stamped, 2)
+		assert.Equal(t, event{Seq: 1, Action: "first", Data: []byte("null")}, decodeEvent(t, stamped[0], nil))
+		assert.Equal(t, event{Seq: 2, Action: "second", Data: []byte("null")}, decodeEvent(t, stamped[1], nil))
+	})
+
+	t.Run("Releases the client after another connection took over the session", func(t *testing.T) {
+		hub := newTestHub(t)
+		client := newTestClient(hub, fixture.RandID())
+		assert.NoError(t, client.openSession())
+
+		unregistered := make(chan *Client, 1)
+		go func() { unregistered <- <-hub.unregister }()
+
+		hub.mr.HSet(sessionKey(client.resumableSession()), "owner", fixture.RandID())
+		stamped := client.record([][]byte{(&model.WebsocketMessage{Action: "event"}).Encode()})
+
+		assert.Nil(t, stamped)
+		assert.Equal(t, client, <-unregistered)
+	})
+}
+
+func TestClient_ResumeSession(t *testing.T) {
+	userId := fixture.RandID()
+
+	testCases := []struct {
+		name string
+		// Prepares the session that gets resumed and returns the request
+		setup func(hub *testHub, session string) *resumeRequest
+		// Sequence numbers of the replayed events, nil if the session cannot be resumed
+		replayed []int64
+	}{
+		{
+			name: "Replays the events after the sequence number",
+			setup: func(hub *testHub, session string) *resumeRequest {
+				return &resumeRequest{sessionId: session, seq: 1}
+			},
+			replayed: []int64{2, 3},
+		},
+		{
+			name: "Replays nothing if the client did not miss any events",
+			setup: func(hub *testHub, session string) *resumeRequest {
+				return &resumeRequest{sessionId: session, seq: 3}
+			},
+			replayed: []int64{},
+		},
+		{
+			name: "Session expired",
+			setup: func(hub *testHub, session string) *resumeRequest {
+				hub.mr.FastForward(resumeWindow)
+				return &resumeRequest{sessionId: session, seq: 1}
+			},
+		},
+		{
+			name: "Missed events are not buffered anymore",
+			setup: func(hub *testHub, session string) *resumeRequest {
+				hub.redisClient.XDel(ctx, eventsKey(session), "1-0")
+				return &resumeRequest{sessionId: session, seq: 0}
+			},
+		},
+		{
+			name: "Sequence number is ahead of the session",
+			setup: func(hub *testHub, session string) *resumeRequest {
+				return &resumeRequest{sessionId: session, seq: 4}
+			},
+		},
+		{
+			name: "Session of another user",
+			setup: func(hub *testHub, session string) *resumeRequest {
+				hub.mr.HSet(sessionKey(session), "userId", fixture.RandID())
+				return &resumeRequest{sessionId: session, seq: 1}
+			},
+		},
+		{
+			name: "Unknown session",
+			setup: func(hub *testHub, session string) *resumeRequest {
+				return &resumeRequest{sessionId: fixture.RandID(), seq: 0}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := newTestHub(t)
+
+			previous := newTestClient(hub, userId)
+			assert.NoError(t, previous.openSession())
+			session := previous.resumableSession()
+			recordEvents(t, previous, 3)
+
+			client := newTestClient(hub, userId)
+			assert.NoError(t, client.openSession())
+			own := client.resumableSession()
+
+			events := decodeFrame(t, client.resumeSession(tc.setup(hub, session)))
+
+			if tc.replayed == nil {
+				assert.Equal(t, []event{{Action: InvalidSessionAction, Data: []byte("null")}}, events)
+				assert.Equal(t, own, client.resumableSession())
+				return
+			}
+
+			assert.Len(t, events, len(tc.replayed)+1)
+			for i, seq := range tc.replayed {
+				assert.Equal(t, seq, events[i].Seq)
+				assert.Equal(t, fmt.Sprintf("event-%d", seq), events[i].Action)
+			}
+
+			var frame sessionFrame
+			resumed := events[len(events)-1]
+			assert.Equal(t, ResumedAction, resumed.Action)
+			assert.NoError(t, json.Unmarshal(resumed.Data, &frame))
+			assert.Equal(t, sessionFrame{SessionId: session, Seq: 3}, frame)
+
+			// The client records in the resumed session and the previous connection stops
+			assert.Equal(t, session, client.resumableSession())
+			assert.Equal(t, client.connId, hub.mr.HGet(sessionKey(session), "owner"))
+			assert.False(t, hub.mr.Exists(sessionKey(own)))
+		})
+	}
+
+	t.Run("Restores the subscriptions that are still authorized", func(t *testing.T) {
+		hub := newTestHub(t)
+		user := fixture.GetMockUser()
+		left := fixture.GetMockGuild("")
+		joined := fixture.GetMockGuild("")
+		joined.Members = []model.User{*user}
+		hub.guildService.On("GetGuild", left.ID).Return(left, nil)
+		hub.guildService.On("GetGuild", joined.ID).Return(joined, nil)
+
+		previous := newTestClient(hub, user.ID)
+		assert.NoError(t, previous.openSession())
+		previous.subscribe(user.ID, &subscription{kind: userRoom})
+		previous.subscribe(left.ID, &subscription{kind: guildRoom, guildId: left.ID})
+		previous.subscribe(joined.ID, &subscription{kind: guildRoom, guildId: joined.ID})
+
+		client := newTestClient(hub, user.ID)
+		assert.NoError(t, client.openSession())
+		client.resumeSession(&resumeRequest{sessionId: previous.resumableSession(), seq: 0})
+
+		assert.Equal(t, sortedIds(user.ID, joined.ID), roomIds(client))
+	})
+}
@@ -0,0 +1,208 @@
+package ws
+
+import (
+	"encoding/json"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+)
+
+// subscriptionChecksChannel is the redis channel subscription checks get published to,
+// so that every instance re-evaluates the subscriptions of its clients
+const subscriptionChecksChannel = "subscription-checks"
+
+// Kinds of rooms a client can subscribe to
+const (
+	userRoom    = "user"
+	guildRoom   = "guild"
+	channelRoom = "channel"
+)
+
+// subscription contains why the client was allowed to join a room
+type subscription struct {
+	kind string
+	// The guild of guild rooms and guild channel rooms. Empty for DMs.
+	guildId string
+}
+
+// subscriptionCheck describes which subscriptions have to be re-evaluated.
+// If UserId is set, the user loses all subscriptions of the guild.
+// Otherwise the channel room or all channel rooms of the guild get checked again.
+type subscriptionCheck struct {
+	GuildId   string `json:"guildId,omitempty"`
+	ChannelId string `json:"channelId,omitempty"`
+	UserId    string `json:"userId,omitempty"`
+}
+
+// authorizeGuild returns the subscription to the guild room if the user is a member
+func (hub *Hub) authorizeGuild(guildId, userId string) (*subscription, error) {
+	guild, err := hub.guildService.GetGuild(guildId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMember(guild, userId) {
+		return nil, apperrors.NewAuthorization(apperrors.NotAMember)
+	}
+
+	return &subscription{kind: guildRoom, guildId: guild.ID}, nil
+}
+
+// authorizeChannel returns the subscription to the channel room if the user can view the channel
+func (hub *Hub) authorizeChannel(channelId, userId string) (*subscription, error) {
+	cs := hub.channelService
+	channel, err := cs.Get(channelId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cs.IsChannelMember(channel, userId); err != nil {
+		return nil, err
+	}
+
+	sub := &subscription{kind: channelRoom}
+	if channel.GuildID != nil {
+		sub.guildId = *channel.GuildID
+	}
+
+	return sub, nil
+}
+
+// RevokeGuildSubscriptions removes the user's connections on all instances from the rooms
+// of the guild, e.g. after they got kicked or banned
+func (hub *Hub) RevokeGuildSubscriptions(guildId, userId string) {
+	hub.publishSubscriptionCheck(&subscriptionCheck{GuildId: guildId, UserId: userId})
+}
+
+// RecheckChannelSubscriptions removes the connections on all instances from the channel room
+// that cannot view the channel anymore
+func (hub *Hub) RecheckChannelSubscriptions(channelId string) {
+	hub.publishSubscriptionCheck(&subscriptionCheck{ChannelId: channelId})
+}
+
+// RecheckGuildSubscriptions re-evaluates the subscriptions to all channel rooms of the guild
+// on all instances, e.g. after roles or overwrites changed
+func (hub *Hub) RecheckGuildSubscriptions(guildId string) {
+	hub.publishSubscriptionCheck(&subscriptionCheck{GuildId: guildId})
+}
+
+func (hub *Hub) publishSubscriptionCheck(check *subscriptionCheck) {
+	data, err := json.Marshal(check)
+
+	if err != nil {
+		log.Printf("error marshalling subscription check: %v\n", err)
+		return
+	}
+
+	if err = hub.redisClient.Publish(ctx, subscriptionChecksChannel, data).Err(); err != nil {
+		log.Println(err)
+	}
+}
+
+// subscribeToSubscriptionChecks listens for subscription checks
+func (hub *Hub) subscribeToSubscriptionChecks() {
+	pubsub := hub.redisClient.Subscribe(ctx, subscriptionChecksChannel)
+
+	ch := pubsub.Channel()
+
+	for msg := range ch {
+		var check subscriptionCheck
+		if err := json.Unmarshal([]byte(msg.Payload), &check); err != nil {
+			log.Printf("error unmarshalling subscription check: %v\n", err)
+			continue
+		}
+		hub.checks <- &check
+	}
+}
+
+// checkSubscriptions re-evaluates the subscriptions of the clients connected to this instance.
+// The permissions are checked in the background so the hub is not blocked by the queries.
+func (hub *Hub) checkSubscriptions(check *subscriptionCheck) {
+	for client := range hub.clients {
+		if check.UserId != "" {
+			if client.ID == check.UserId {
+				client.unsubscribeFromGuild(check.GuildId)
+			}
+			continue
+		}
+
+		for room, sub := range client.subscriptions() {
+			if sub.kind != channelRoom {
+				continue
+			}
+
+			if room.GetId() == check.ChannelId || (check.ChannelId == "" && sub.guildId == check.GuildId) {
+				go client.recheckChannel(room)
+			}
+		}
+	}
+}
+
+// recheckChannel leaves the channel room if the client cannot view the channel anymore
+func (client *Client) recheckChannel(room *Room) {
+	if _, err := client.hub.authorizeChannel(room.GetId(), client.ID); err != nil {
+		client.unsubscribe(room)
+	}
+}
+
+// unsubscribeFromGuild leaves the guild room and all channel rooms of the guild
+func (client *Client) unsubscribeFromGuild(guildId string) {
+	for room, sub := range client.subscriptions() {
+		if sub.guildId == guildId {
+			client.unsubscribe(room)
+		}
+	}
+}
+
+// subscribe joins the room
+func (client *Client) subscribe(roomId string, sub *subscription) {
+	room := client.hub.findRoomById(roomId)
+	if room == nil {
+		room = client.hub.createRoom(roomId)
+	}
+
+	client.mu.Lock()
+	client.rooms[room] = sub
+	client.mu.Unlock()
+
+	room.register <- client
+	client.saveSubscriptions()
+}
+
+// unsubscribe leaves the room
+func (client *Client) unsubscribe(room *Room) {
+	client.mu.Lock()
+	_, subscribed := client.rooms[room]
+	delete(client.rooms, room)
+	client.mu.Unlock()
+
+	if subscribed {
+		room.unregister <- client
+		client.saveSubscriptions()
+	}
+}
+
+// subscriptions returns a copy of the client's subscriptions
+func (client *Client) subscriptions() map[*Room]*subscription {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	rooms := make(map[*Room]*subscription, len(client.rooms))
+	for room, sub := range client.rooms {
+		rooms[room] = sub
+	}
+
+	return rooms
+}
+
+// subscription returns the subscription to the room with the given ID
+func (client *Client) subscription(roomId string) (*subscription, bool) {
+	for room, sub := range client.subscriptions() {
+		if room.GetId() == roomId {
+			return sub, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,197 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_AuthorizeGuild(t *testing.T) {
+	user := fixture.GetMockUser()
+
+	testCases := []struct {
+		name    string
+		members []model.User
+		err     error
+	}{
+		{
+			name:    "Member",
+			members: []model.User{*fixture.GetMockUser(), *user},
+		},
+		{
+			name:    "Not a member",
+			members: []model.User{*fixture.GetMockUser()},
+			err:     apperrors.NewAuthorization(apperrors.NotAMember),
+		},
+		{
+			name: "Unknown guild",
+			err:  apperrors.NewNotFound("guild", "id"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := newTestHub(t)
+			guild := fixture.GetMockGuild("")
+			guild.Members = tc.members
+
+			if tc.members == nil {
+				hub.guildService.On("GetGuild", guild.ID).Return(nil, tc.err)
+			} else {
+				hub.guildService.On("GetGuild", guild.ID).Return(guild, nil)
+			}
+
+			sub, err := hub.authorizeGuild(guild.ID, user.ID)
+
+			if tc.err != nil {
+				assert.Equal(t, tc.err, err)
+				assert.Nil(t, sub)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, &subscription{kind: guildRoom, guildId: guild.ID}, sub)
+		})
+	}
+}
+
+func TestHub_AuthorizeChannel(t *testing.T) {
+	user := fixture.GetMockUser()
+	guildId := fixture.RandID()
+
+	testCases := []struct {
+		name     string
+		channel  *model.Channel
+		err      error
+		expected *subscription
+	}{
+		{
+			name:     "Member of the guild",
+			channel:  fixture.GetMockChannel(guildId),
+			expected: &subscription{kind: channelRoom, guildId: guildId},
+		},
+		{
+			name:    "Missing the ViewChannel permission",
+			channel: fixture.GetMockChannel(guildId),
+			err:     apperrors.NewAuthorization(apperrors.MissingPermissions),
+		},
+		{
+			name:     "DM participant",
+			channel:  fixture.GetMockDMChannel(),
+			expected: &subscription{kind: channelRoom},
+		},
+		{
+			name:    "Not a DM participant",
+			channel: fixture.GetMockDMChannel(),
+			err:     apperrors.NewAuthorization(apperrors.Unauthorized),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := newTestHub(t)
+			hub.channelService.On("Get", tc.channel.ID).Return(tc.channel, nil)
+			hub.channelService.On("IsChannelMember", tc.channel, user.ID).Return(tc.err)
+
+			sub, err := hub.authorizeChannel(tc.channel.ID, user.ID)
+
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, sub)
+		})
+	}
+
+	t.Run("Unknown channel", func(t *testing.T) {
+		hub := newTestHub(t)
+		id := fixture.RandID()
+		mockError := apperrors.NewNotFound("channel", id)
+		hub.channelService.On("Get", id).Return(nil, mockError)
+
+		sub, err := hub.authorizeChannel(id, user.ID)
+
+		assert.Equal(t, mockError, err)
+		assert.Nil(t, sub)
+		hub.channelService.AssertNotCalled(t, "IsChannelMember")
+	})
+}
+
+func TestHub_CheckSubscriptions(t *testing.T) {
+	user := fixture.GetMockUser()
+	guildId := fixture.RandID()
+	otherGuildId := fixture.RandID()
+	first := fixture.GetMockChannel(guildId)
+	second := fixture.GetMockChannel(guildId)
+	other := fixture.GetMockChannel(otherGuildId)
+	denied := apperrors.NewAuthorization(apperrors.MissingPermissions)
+
+	testCases := []struct {
+		name  string
+		check *subscriptionCheck
+		// Channels the user cannot view anymore
+		denied    []*model.Channel
+		remaining []string
+	}{
+		{
+			name:      "Revokes the guild subscriptions of the user",
+			check:     &subscriptionCheck{GuildId: guildId, UserId: user.ID},
+			remaining: []string{user.ID, other.ID},
+		},
+		{
+			name:      "Keeps the subscriptions if another user got removed",
+			check:     &subscriptionCheck{GuildId: guildId, UserId: fixture.RandID()},
+			remaining: []string{user.ID, guildId, first.ID, second.ID, other.ID},
+		},
+		{
+			name:      "Leaves the channels of the guild that cannot be viewed anymore",
+			check:     &subscriptionCheck{GuildId: guildId},
+			denied:    []*model.Channel{first},
+			remaining: []string{user.ID, guildId, second.ID, other.ID},
+		},
+		{
+			name:      "Leaves the rechecked channel",
+			check:     &subscriptionCheck{ChannelId: second.ID},
+			denied:    []*model.Channel{second},
+			remaining: []string{user.ID, guildId, first.ID, other.ID},
+		},
+		{
+			name:      "Keeps the rechecked channel if it can still be viewed",
+			check:     &subscriptionCheck{ChannelId: second.ID},
+			remaining: []string{user.ID, guildId, first.ID, second.ID, other.ID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := newTestHub(t)
+
+			for _, channel := range []*model.Channel{first, second} {
+				var err error
+				for _, d := range tc.denied {
+					if d == channel {
+						err = denied
+					}
+				}
+				hub.channelService.On("Get", channel.ID).Return(channel, nil)
+				hub.channelService.On("IsChannelMember", channel, user.ID).Return(err)
+			}
+
+			client := newTestClient(hub, user.ID)
+			hub.registerClient(client)
+			client.subscribe(user.ID, &subscription{kind: userRoom})
+			client.subscribe(guildId, &subscription{kind: guildRoom, guildId: guildId})
+			client.subscribe(first.ID, &subscription{kind: channelRoom, guildId: guildId})
+			client.subscribe(second.ID, &subscription{kind: channelRoom, guildId: guildId})
+			client.subscribe(other.ID, &subscription{kind: channelRoom, guildId: otherGuildId})
+
+			hub.checkSubscriptions(tc.check)
+
+			assert.Eventually(t, func() bool {
+				return assert.ObjectsAreEqual(sortedIds(tc.remaining...), roomIds(client))
+			}, time.Second, 10*time.Millisecond)
+			hub.channelService.AssertNotCalled(t, "Get", other.ID)
+		})
+	}
+}
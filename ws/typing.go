@@ -0,0 +1,167 @@
+package ws
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+)
+
+const (
+	// How long a typing indicator lasts without another startTyping
+	typingTimeout = 10 * time.Second
+
+	// Minimum time between two handled startTyping actions of a user in a channel
+	typingThrottle = 3 * time.Second
+
+	// How often expired typing indicators are looked for
+	typingSweepInterval = time.Second
+
+	// Sorted set of the typing users, scored by the expiry of their indicator in milliseconds
+	typingDueKey = "typing-due"
+)
+
+// typingMember is the member of the typing user in typingDueKey
+func typingMember(channelId, userId string) string {
+	return channelId + ":" + userId
+}
+
+// typingThrottleKey is set while further startTyping actions of the user in the channel are ignored
+func typingThrottleKey(channelId, userId string) string {
+	return "typing-throttle:" + typingMember(channelId, userId)
+}
+
+// toMillis returns the time in milliseconds since the unix epoch
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// handleStartTyping emits the display name of the user to the channel they are typing in.
+// Only channels the client subscribed to can be typed in. While the user is typing,
+// startTyping only extends the indicator, which expires after typingTimeout.
+func (client *Client) handleStartTyping(message model.ReceivedMessage) error {
+	channelId := message.Room
+	sub, ok := client.subscription(channelId)
+
+	if !ok || sub.kind != channelRoom {
+		return apperrors.NewBadRequest(apperrors.JoinRoomFirst)
+	}
+
+	rds := client.hub.redisClient
+
+	// Throttled actions are ignored as the indicator was extended recently
+	allowed, err := rds.SetNX(ctx, typingThrottleKey(channelId, client.ID), 1, typingThrottle).Result()
+
+	if err != nil || !allowed {
+		return err
+	}
+
+	expiry := float64(toMillis(time.Now().Add(typingTimeout)))
+	added, err := rds.ZAdd(ctx, typingDueKey, &redis.Z{Score: expiry, Member: typingMember(channelId, client.ID)}).Result()
+
+	if err != nil || added == 0 {
+		return err
+	}
+
+	client.hub.publish(channelId, &model.WebsocketMessage{
+		Action: AddToTypingAction,
+		Data: model.TypingEvent{
+			ChannelId: channelId,
+			UserId:    client.ID,
+			Username:  client.displayName(sub.guildId),
+		},
+	})
+
+	return nil
+}
+
+// handleStopTyping removes the typing indicator of the user
+func (client *Client) handleStopTyping(message model.ReceivedMessage) error {
+	if sub, ok := client.subscription(message.Room); !ok || sub.kind != channelRoom {
+		return apperrors.NewBadRequest(apperrors.JoinRoomFirst)
+	}
+
+	client.hub.StopTyping(message.Room, client.ID)
+	return nil
+}
+
+// displayName returns the nickname of the member in the guild or their username
+func (client *Client) displayName(guildId string) string {
+	if guildId != "" {
+		settings, err := client.hub.guildService.GetMemberSettings(client.ID, guildId)
+		if err == nil && settings.Nickname != nil && *settings.Nickname != "" {
+			return *settings.Nickname
+		}
+	}
+
+	user, err := client.hub.userService.Get(client.ID)
+
+	if err != nil {
+		return ""
+	}
+
+	return user.Username
+}
+
+// StopTyping removes the typing indicator of the user in the channel, e.g. after they sent a message
+func (hub *Hub) StopTyping(channelId, userId string) {
+	rds := hub.redisClient
+	removed, err := rds.ZRem(ctx, typingDueKey, typingMember(channelId, userId)).Result()
+
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	rds.Del(ctx, typingThrottleKey(channelId, userId))
+
+	if removed == 1 {
+		hub.emitStopTyping(channelId, userId)
+	}
+}
+
+func (hub *Hub) emitStopTyping(channelId, userId string) {
+	hub.publish(channelId, &model.WebsocketMessage{
+		Action: RemoveFromTypingAction,
+		Data: model.TypingEvent{
+			ChannelId: channelId,
+			UserId:    userId,
+		},
+	})
+}
+
+// sweepTyping emits the stop of typing indicators that expired, e.g. because stopTyping never arrived
+func (hub *Hub) sweepTyping() {
+	ticker := time.NewTicker(typingSweepInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		hub.expireTyping(now)
+	}
+}
+
+// expireTyping emits the stop of the typing indicators that expired at the given time
+func (hub *Hub) expireTyping(now time.Time) {
+	max := strconv.FormatInt(toMillis(now), 10)
+	members, err := hub.redisClient.ZRangeByScore(ctx, typingDueKey, &redis.ZRangeBy{Min: "-inf", Max: max}).Result()
+
+	if err != nil {
+		log.Printf("error sweeping typing indicators: %v\n", err)
+		return
+	}
+
+	for _, member := range members {
+		// Only the instance removing the indicator emits the stop
+		if removed, _ := hub.redisClient.ZRem(ctx, typingDueKey, member).Result(); removed != 1 {
+			continue
+		}
+
+		if i := strings.Index(member, ":"); i > 0 {
+			hub.emitStopTyping(member[:i], member[i+1:])
+		}
+	}
+}
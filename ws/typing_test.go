@@ -0,0 +1,149 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_HandleStartTyping(t *testing.T) {
+	hub := newTestHub(t)
+	user := fixture.GetMockUser()
+	guildId := fixture.RandID()
+	channel := fixture.GetMockChannel(guildId)
+	nickname := "Nick"
+
+	hub.guildService.On("GetMemberSettings", user.ID, guildId).Return(&model.MemberSettings{Nickname: &nickname}, nil)
+
+	client := newTestClient(hub, user.ID)
+	typing := listen(t, hub, channel.ID)
+	start := model.ReceivedMessage{Action: StartTypingAction, Room: channel.ID}
+	started := &model.TypingEvent{ChannelId: channel.ID, UserId: user.ID, Username: nickname}
+	stopped := &model.TypingEvent{ChannelId: channel.ID, UserId: user.ID}
+
+	steps := []struct {
+		name   string
+		action func() error
+		err    error
+		// The emitted action and event, nothing should be emitted if empty
+		emitted  string
+		expected *model.TypingEvent
+	}{
+		{
+			name:   "The channel has to be joined first",
+			action: func() error { return client.handleStartTyping(start) },
+			err:    apperrors.NewBadRequest(apperrors.JoinRoomFirst),
+		},
+		{
+			name: "Emits the nickname of the member",
+			action: func() error {
+				client.subscribe(channel.ID, &subscription{kind: channelRoom, guildId: guildId})
+				return client.handleStartTyping(start)
+			},
+			emitted:  AddToTypingAction,
+			expected: started,
+		},
+		{
+			name:   "Repeated actions are throttled",
+			action: func() error { return client.handleStartTyping(start) },
+		},
+		{
+			name: "Actions after the throttle only extend the indicator",
+			action: func() error {
+				hub.mr.FastForward(typingThrottle)
+				return client.handleStartTyping(start)
+			},
+		},
+		{
+			name: "Indicators that did not expire are kept",
+			action: func() error {
+				hub.expireTyping(time.Now())
+				return nil
+			},
+		},
+		{
+			name: "Expired indicators are removed",
+			action: func() error {
+				hub.expireTyping(time.Now().Add(typingTimeout + time.Second))
+				return nil
+			},
+			emitted:  RemoveFromTypingAction,
+			expected: stopped,
+		},
+		{
+			name: "Expired indicators are only removed once",
+			action: func() error {
+				hub.expireTyping(time.Now().Add(typingTimeout + time.Second))
+				return nil
+			},
+		},
+		{
+			name: "Typing again after the indicator expired",
+			action: func() error {
+				hub.mr.FastForward(typingThrottle)
+				return client.handleStartTyping(start)
+			},
+			emitted:  AddToTypingAction,
+			expected: started,
+		},
+		{
+			name: "Stopping removes the indicator",
+			action: func() error {
+				return client.handleStopTyping(model.ReceivedMessage{Action: StopTypingAction, Room: channel.ID})
+			},
+			emitted:  RemoveFromTypingAction,
+			expected: stopped,
+		},
+		{
+			name: "Stopping without an indicator",
+			action: func() error {
+				hub.StopTyping(channel.ID, user.ID)
+				return nil
+			},
+		},
+		{
+			name:     "Stopping resets the throttle",
+			action:   func() error { return client.handleStartTyping(start) },
+			emitted:  AddToTypingAction,
+			expected: started,
+		},
+	}
+
+	for _, step := range steps {
+		err := step.action()
+		assert.Equal(t, step.err, err, step.name)
+
+		if step.emitted == "" {
+			noEvent(t, typing)
+			continue
+		}
+
+		var e model.TypingEvent
+		assert.Equal(t, step.emitted, nextEvent(t, typing, &e).Action, step.name)
+		assert.Equal(t, *step.expected, e, step.name)
+	}
+}
+
+func TestClient_HandleStartTyping_DM(t *testing.T) {
+	hub := newTestHub(t)
+	user := fixture.GetMockUser()
+	channel := fixture.GetMockDMChannel()
+
+	hub.userService.On("Get", user.ID).Return(user, nil)
+
+	client := newTestClient(hub, user.ID)
+	client.subscribe(channel.ID, &subscription{kind: channelRoom})
+	typing := listen(t, hub, channel.ID)
+
+	err := client.handleStartTyping(model.ReceivedMessage{Action: StartTypingAction, Room: channel.ID})
+
+	var e model.TypingEvent
+	assert.NoError(t, err)
+	assert.Equal(t, AddToTypingAction, nextEvent(t, typing, &e).Action)
+	assert.Equal(t, model.TypingEvent{ChannelId: channel.ID, UserId: user.ID, Username: user.Username}, e)
+	hub.guildService.AssertNotCalled(t, "GetMemberSettings")
+}